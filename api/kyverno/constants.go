@@ -14,12 +14,30 @@ const (
 	AnnotationAutogenControllers       = "pod-policies.kyverno.io/autogen-controllers"
 	AnnotationImageVerify              = "kyverno.io/verify-images"
 	AnnotationImageVerifyOutcomes      = "kyverno.io/image-verification-outcomes"
+	AnnotationImageVerifyFailed        = "kyverno.io/image-verification-failed"
 	AnnotationPolicyCategory           = "policies.kyverno.io/category"
+	AnnotationPolicyRemediation        = "policies.kyverno.io/remediation"
+	AnnotationPolicyRemediationLinks   = "policies.kyverno.io/remediation-links"
 	AnnotationPolicyScored             = "policies.kyverno.io/scored"
 	AnnotationPolicySeverity           = "policies.kyverno.io/severity"
 	AnnotationCleanupPropagationPolicy = "cleanup.kyverno.io/propagation-policy"
+	AnnotationCleanupTtlAnchor         = "cleanup.kyverno.io/ttl-anchor"
+	AnnotationCleanupLastUpdate        = "cleanup.kyverno.io/last-update"
+	AnnotationCleanupIdleSince         = "cleanup.kyverno.io/idle-since"
+	AnnotationUpdateRequestPriority    = "kyverno.io/update-request-priority"
+	// Well known annotation prefixes, the rule name is appended to the prefix
+	AnnotationPrefixRuleRemediation      = "remediation.policies.kyverno.io/"
+	AnnotationPrefixRuleRemediationLinks = "remediation-links.policies.kyverno.io/"
+	// Well known cleanup annotation prefixes, the cleanup policy UID is appended to the prefix
+	AnnotationPrefixCleanupMatchedAt    = "matched-at.cleanup.kyverno.io/"
+	AnnotationPrefixCleanupDeletionTime = "deletion-time.cleanup.kyverno.io/"
 	// Well known values
 	ValueKyvernoApp        = "kyverno"
 	ValueTtlDateTimeLayout = "2006-01-02T150405Z"
 	ValueTtlDateLayout     = "2006-01-02"
+	// Well known TTL anchors, the TTL duration is relative to the anchor time
+	ValueTtlAnchorCreation      = "creation"
+	ValueTtlAnchorLastUpdate    = "last-update"
+	ValueTtlAnchorJobCompletion = "job-completion"
+	ValueTtlAnchorIdle          = "idle"
 )
@@ -2,6 +2,10 @@ package v1
 
 import (
 	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 func TestResourceFilter_IsEmpty(t *testing.T) {
@@ -43,3 +47,87 @@ func TestResourceFilter_IsEmpty(t *testing.T) {
 		})
 	}
 }
+
+func TestLatencyBudget_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		budget  LatencyBudget
+		wantErr int
+	}{
+		{
+			name:   "valid budget",
+			budget: LatencyBudget{Duration: metav1.Duration{Duration: time.Second}},
+		},
+		{
+			name: "valid budget with degradation",
+			budget: LatencyBudget{
+				Duration:   metav1.Duration{Duration: time.Second},
+				DegradeFor: &metav1.Duration{Duration: time.Minute},
+			},
+		},
+		{
+			name:    "zero budget",
+			budget:  LatencyBudget{},
+			wantErr: 1,
+		},
+		{
+			name: "negative degradation",
+			budget: LatencyBudget{
+				Duration:   metav1.Duration{Duration: time.Second},
+				DegradeFor: &metav1.Duration{Duration: -time.Minute},
+			},
+			wantErr: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if errs := tc.budget.Validate(field.NewPath("latencyBudget")); len(errs) != tc.wantErr {
+				t.Errorf("LatencyBudget.Validate() = %v, want %d errors", errs, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerateSource_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  GenerateSource
+		wantErr int
+	}{
+		{
+			name:   "global reference",
+			source: GenerateSource{GlobalReference: &GlobalContextEntryReference{Name: "allowed-cidrs"}},
+		},
+		{
+			name:   "api call",
+			source: GenerateSource{APICall: &ContextAPICall{APICall: APICall{URLPath: "/api/v1/namespaces"}}},
+		},
+		{
+			name:    "empty source",
+			source:  GenerateSource{},
+			wantErr: 1,
+		},
+		{
+			name: "both sources",
+			source: GenerateSource{
+				GlobalReference: &GlobalContextEntryReference{Name: "allowed-cidrs"},
+				APICall:         &ContextAPICall{APICall: APICall{URLPath: "/api/v1/namespaces"}},
+			},
+			wantErr: 1,
+		},
+		{
+			name:    "global reference without name",
+			source:  GenerateSource{GlobalReference: &GlobalContextEntryReference{}},
+			wantErr: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if errs := tc.source.Validate(field.NewPath("source")); len(errs) != tc.wantErr {
+				t.Errorf("GenerateSource.Validate() = %v, want %d errors", errs, tc.wantErr)
+			}
+		})
+	}
+}
@@ -73,6 +73,34 @@ type WebhookConfiguration struct {
 	// Requires Kubernetes 1.27 or later.
 	// +optional
 	MatchConditions []admissionregistrationv1.MatchCondition `json:"matchConditions,omitempty"`
+
+	// LatencyBudget configures the maximum time allowed to evaluate this policy for a single admission request.
+	// When the budget is exceeded, the policy evaluation is aborted and the failure policy is applied.
+	// +optional
+	LatencyBudget *LatencyBudget `json:"latencyBudget,omitempty"`
+}
+
+// LatencyBudget defines the evaluation latency budget of a policy during admission.
+type LatencyBudget struct {
+	// Duration is the maximum time allowed to evaluate the policy for a single admission request.
+	Duration metav1.Duration `json:"duration"`
+
+	// DegradeFor marks the policy as degraded once its budget is exceeded.
+	// Degraded policies are skipped by subsequent admission requests until the duration elapses.
+	// If not set, the policy is never degraded.
+	// +optional
+	DegradeFor *metav1.Duration `json:"degradeFor,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (b *LatencyBudget) Validate(path *field.Path) (errs field.ErrorList) {
+	if b.Duration.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("duration"), b.Duration.Duration.String(), "the latency budget must be a positive duration"))
+	}
+	if b.DegradeFor != nil && b.DegradeFor.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("degradeFor"), b.DegradeFor.Duration.String(), "the degradation period must be a positive duration"))
+	}
+	return errs
 }
 
 // AnyAllConditions consists of conditions wrapped denoting a logical criteria to be fulfilled.
@@ -471,10 +499,11 @@ func (m *ForEachMutation) SetPatchStrategicMerge(in any) {
 type Validation struct {
 	// FailureAction defines if a validation policy rule violation should block
 	// the admission review request (Enforce), or allow (Audit) the admission review request
-	// and report an error in a policy report. Optional.
-	// Allowed values are Audit or Enforce.
+	// and report an error in a policy report. Shadow evaluates the rule with Enforce
+	// semantics without blocking the request and reports would-be denials separately. Optional.
+	// Allowed values are Audit, Enforce or Shadow.
 	// +optional
-	// +kubebuilder:validation:Enum=Audit;Enforce
+	// +kubebuilder:validation:Enum=Audit;Enforce;Shadow
 	FailureAction *ValidationFailureAction `json:"failureAction,omitempty"`
 
 	// FailureActionOverrides is a Cluster Policy attribute that specifies FailureAction
@@ -824,6 +853,36 @@ type GeneratePattern struct {
 	// CloneList specifies the list of source resource used to populate each generated resource.
 	// +optional
 	CloneList CloneList `json:"cloneList,omitempty"`
+
+	// Source specifies an external data source, a GlobalContextEntry or an APICall, used to render Data.
+	// The source data is available to the Data template as the `source` variable. Resources generated
+	// from a GlobalContextEntry are synchronized when the entry data changes, the ones generated from an APICall
+	// are resynchronized periodically.
+	// +optional
+	Source *GenerateSource `json:"source,omitempty"`
+}
+
+// GenerateSource is an external data source used to render the data of the generated resources.
+type GenerateSource struct {
+	// GlobalReference is a reference to a cached global context entry.
+	// +optional
+	GlobalReference *GlobalContextEntryReference `json:"globalReference,omitempty"`
+
+	// APICall is an HTTP request to the Kubernetes API server, or other JSON web service.
+	// +optional
+	APICall *ContextAPICall `json:"apiCall,omitempty"`
+}
+
+// GenerateSourceVariable is the name of the variable holding the generate source data
+const GenerateSourceVariable = "source"
+
+// ContextEntry returns the context entry loading the source data in the GenerateSourceVariable variable
+func (s *GenerateSource) ContextEntry() ContextEntry {
+	return ContextEntry{
+		Name:            GenerateSourceVariable,
+		APICall:         s.APICall,
+		GlobalReference: s.GlobalReference,
+	}
 }
 
 type ForEachGeneration struct {
@@ -921,6 +980,13 @@ func (g *GeneratePattern) Validate(path *field.Path, namespaced bool, policyName
 		warnings = append(warnings, fmt.Sprintf("variables in %s would lead to incorrect synchronization behavior", path.Child("clone/cloneList").String()))
 	}
 
+	if g.Source != nil {
+		if g.RawData == nil {
+			errs = append(errs, field.Required(path.Child("data"), "data is required to render the source"))
+		}
+		errs = append(errs, g.Source.Validate(path.Child("source"))...)
+	}
+
 	if len(g.CloneList.Kinds) == 0 {
 		if g.Kind == "" {
 			errs = append(errs, field.Forbidden(path.Child("kind"), "kind can not be empty"))
@@ -973,6 +1039,16 @@ func (g *GeneratePattern) ValidateCloneList(path *field.Path, namespaced bool, p
 	return errs
 }
 
+func (s *GenerateSource) Validate(path *field.Path) (errs field.ErrorList) {
+	if (s.GlobalReference == nil) == (s.APICall == nil) {
+		errs = append(errs, field.Forbidden(path, "exactly one of globalReference or apiCall must be specified"))
+	}
+	if s.GlobalReference != nil && s.GlobalReference.Name == "" {
+		errs = append(errs, field.Required(path.Child("globalReference").Child("name"), "global context entry name is required"))
+	}
+	return errs
+}
+
 func (g *GeneratePattern) GetType() GenerateType {
 	if g.RawData != nil {
 		return Data
@@ -248,6 +248,14 @@ type CertificateAttestor struct {
 	// Timestamps (SCTs). If the value is unset, the default behavior by Cosign is used.
 	// +kubebuilder:validation:Optional
 	CTLog *CTLog `json:"ctlog,omitempty"`
+
+	// TrustPolicy is the name of a trust policy of the Notation trust policy document configured in
+	// Kyverno. When set, Notary signatures are verified against the trust policy, its trust stores
+	// and verification plugins instead of the cert and certChain. Use `*` to select the trust policy
+	// by the registry scope of the image. The registry scopes of a named trust policy still apply and
+	// trust policies skipping the verification fail it.
+	// +kubebuilder:validation:Optional
+	TrustPolicy string `json:"trustPolicy,omitempty"`
 }
 
 type KeylessAttestor struct {
@@ -262,18 +270,23 @@ type KeylessAttestor struct {
 	CTLog *CTLog `json:"ctlog,omitempty"`
 
 	// Issuer is the certificate issuer used for keyless signing.
+	// It supports the same image placeholders as the subject.
 	// +kubebuilder:validation:Optional
 	Issuer string `json:"issuer,omitempty"`
 
 	// IssuerRegExp is the regular expression to match certificate issuer used for keyless signing.
+	// It supports the same image placeholders as the subject, the replaced values are quoted.
 	// +kubebuilder:validation:Optional
 	IssuerRegExp string `json:"issuerRegExp,omitempty"`
 
 	// Subject is the verified identity used for keyless signing, for example the email address.
+	// It can be a template of the verified image with the registry, repository, org, repo and tag
+	// placeholders between double curly braces, escaped from variable substitution with a backslash.
 	// +kubebuilder:validation:Optional
 	Subject string `json:"subject,omitempty"`
 
 	// SubjectRegExp is the regular expression to match identity used for keyless signing, for example the email address.
+	// It supports the same image placeholders as the subject, the replaced values are quoted.
 	// +kubebuilder:validation:Optional
 	SubjectRegExp string `json:"subjectRegExp,omitempty"`
 
@@ -523,8 +536,8 @@ func (ska *StaticKeyAttestor) Validate(path *field.Path) (errs field.ErrorList)
 }
 
 func (ca *CertificateAttestor) Validate(path *field.Path) (errs field.ErrorList) {
-	if ca.Certificate == "" && ca.CertificateChain == "" {
-		errs = append(errs, field.Invalid(path, ca, "cert or certChain required"))
+	if ca.Certificate == "" && ca.CertificateChain == "" && ca.TrustPolicy == "" {
+		errs = append(errs, field.Invalid(path, ca, "cert, certChain or trustPolicy required"))
 	}
 
 	return errs
@@ -31,6 +31,9 @@ type PolicyStatus struct {
 	// ValidatingAdmissionPolicy contains status information
 	// +optional
 	ValidatingAdmissionPolicy ValidatingAdmissionPolicyStatus `json:"validatingadmissionpolicy"`
+	// BackgroundScan contains the progress of the background scan
+	// +optional
+	BackgroundScan *BackgroundScanStatus `json:"backgroundScan,omitempty"`
 }
 
 // RuleCountStatus contains four variables which describes counts for
@@ -82,3 +85,25 @@ type ValidatingAdmissionPolicyStatus struct {
 	// It is an empty string when validating admission policy is successfully generated.
 	Message string `json:"message"`
 }
+
+// BackgroundScanStatus contains the progress of the background scan for a policy generation
+type BackgroundScanStatus struct {
+	// ObservedGeneration is the policy generation the progress refers to
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Total is the number of resources to be scanned
+	Total int `json:"total"`
+	// Scanned is the number of resources already scanned
+	Scanned int `json:"scanned"`
+	// StartTime is the time the scan started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time all resources were scanned
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// IsCompleted indicates if all resources were scanned
+func (status *BackgroundScanStatus) IsCompleted() bool {
+	return status.Scanned >= status.Total
+}
@@ -26,6 +26,9 @@ const (
 	Enforce ValidationFailureAction = "Enforce"
 	// Audit doesn't block the request on failure
 	Audit ValidationFailureAction = "Audit"
+	// Shadow evaluates the request with Enforce semantics but never blocks it,
+	// would-be denials are reported separately from audit results
+	Shadow ValidationFailureAction = "Shadow"
 )
 
 func (a ValidationFailureAction) Enforce() bool {
@@ -33,15 +36,19 @@ func (a ValidationFailureAction) Enforce() bool {
 }
 
 func (a ValidationFailureAction) Audit() bool {
-	return !a.Enforce()
+	return !a.Enforce() && !a.Shadow()
+}
+
+func (a ValidationFailureAction) Shadow() bool {
+	return a == Shadow
 }
 
 func (a ValidationFailureAction) IsValid() bool {
-	return a == enforceOld || a == auditOld || a == Enforce || a == Audit
+	return a == enforceOld || a == auditOld || a == Enforce || a == Audit || a == Shadow
 }
 
 type ValidationFailureActionOverride struct {
-	// +kubebuilder:validation:Enum=audit;enforce;Audit;Enforce
+	// +kubebuilder:validation:Enum=audit;enforce;Audit;Enforce;Shadow
 	Action            ValidationFailureAction `json:"action,omitempty"`
 	Namespaces        []string                `json:"namespaces,omitempty"`
 	NamespaceSelector *metav1.LabelSelector   `json:"namespaceSelector,omitempty"`
@@ -64,7 +71,7 @@ type Spec struct {
 	FailurePolicy *FailurePolicyType `json:"failurePolicy,omitempty"`
 
 	// Deprecated, use validationFailureAction under the validate rule instead.
-	// +kubebuilder:validation:Enum=audit;enforce;Audit;Enforce
+	// +kubebuilder:validation:Enum=audit;enforce;Audit;Enforce;Shadow
 	// +kubebuilder:default=Audit
 	ValidationFailureAction ValidationFailureAction `json:"validationFailureAction,omitempty"`
 
@@ -190,6 +197,29 @@ func (s *Spec) HasValidateEnforce() bool {
 	return s.ValidationFailureAction.Enforce()
 }
 
+// HasValidateShadow checks if the policy has any validate rules with shadow action
+func (s *Spec) HasValidateShadow() bool {
+	for _, rule := range s.Rules {
+		if rule.HasValidate() {
+			action := rule.Validation.FailureAction
+			if action != nil && action.Shadow() {
+				return true
+			}
+			for _, override := range rule.Validation.FailureActionOverrides {
+				if override.Action.Shadow() {
+					return true
+				}
+			}
+		}
+	}
+	for _, override := range s.ValidationFailureActionOverrides {
+		if override.Action.Shadow() {
+			return true
+		}
+	}
+	return s.ValidationFailureAction.Shadow()
+}
+
 // HasGenerate checks for generate rule types
 func (s *Spec) HasGenerate() bool {
 	for _, rule := range s.Rules {
@@ -295,6 +325,14 @@ func (s *Spec) GetWebhookTimeoutSeconds() *int32 {
 	return nil
 }
 
+// GetLatencyBudget returns the admission latency budget in webhookConfiguration
+func (s *Spec) GetLatencyBudget() *LatencyBudget {
+	if s.WebhookConfiguration != nil {
+		return s.WebhookConfiguration.LatencyBudget
+	}
+	return nil
+}
+
 // GetMatchConditions returns matchConditions in webhookConfiguration
 func (s *Spec) GetMatchConditions() []admissionregistrationv1.MatchCondition {
 	if s.WebhookConfiguration != nil {
@@ -384,6 +422,9 @@ func (s *Spec) Validate(path *field.Path, namespaced bool, policyNamespace strin
 	if s.WebhookConfiguration != nil && s.WebhookConfiguration.TimeoutSeconds != nil && (*s.WebhookConfiguration.TimeoutSeconds < 1 || *s.WebhookConfiguration.TimeoutSeconds > 30) {
 		errs = append(errs, field.Invalid(path.Child("webhookConfiguration.timeoutSeconds"), s.WebhookConfiguration.TimeoutSeconds, "the timeout value must be between 1 and 30 seconds"))
 	}
+	if s.WebhookConfiguration != nil && s.WebhookConfiguration.LatencyBudget != nil {
+		errs = append(errs, s.WebhookConfiguration.LatencyBudget.Validate(path.Child("webhookConfiguration", "latencyBudget"))...)
+	}
 	warning, errors := s.ValidateRules(path.Child("rules"), namespaced, policyNamespace, clusterResources)
 	warnings = append(warnings, warning...)
 	errs = append(errs, errors...)
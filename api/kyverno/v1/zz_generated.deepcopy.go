@@ -212,6 +212,30 @@ func (in *AutogenStatus) DeepCopy() *AutogenStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackgroundScanStatus) DeepCopyInto(out *BackgroundScanStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackgroundScanStatus.
+func (in *BackgroundScanStatus) DeepCopy() *BackgroundScanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackgroundScanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CEL) DeepCopyInto(out *CEL) {
 	*out = *in
@@ -679,6 +703,11 @@ func (in *GeneratePattern) DeepCopyInto(out *GeneratePattern) {
 	}
 	out.Clone = in.Clone
 	in.CloneList.DeepCopyInto(&out.CloneList)
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(GenerateSource)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -692,6 +721,32 @@ func (in *GeneratePattern) DeepCopy() *GeneratePattern {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenerateSource) DeepCopyInto(out *GenerateSource) {
+	*out = *in
+	if in.GlobalReference != nil {
+		in, out := &in.GlobalReference, &out.GlobalReference
+		*out = new(GlobalContextEntryReference)
+		**out = **in
+	}
+	if in.APICall != nil {
+		in, out := &in.APICall, &out.APICall
+		*out = new(ContextAPICall)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenerateSource.
+func (in *GenerateSource) DeepCopy() *GenerateSource {
+	if in == nil {
+		return nil
+	}
+	out := new(GenerateSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Generation) DeepCopyInto(out *Generation) {
 	*out = *in
@@ -966,6 +1021,28 @@ func (in *KeylessAttestor) DeepCopy() *KeylessAttestor {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatencyBudget) DeepCopyInto(out *LatencyBudget) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.DegradeFor != nil {
+		in, out := &in.DegradeFor, &out.DegradeFor
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatencyBudget.
+func (in *LatencyBudget) DeepCopy() *LatencyBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(LatencyBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Manifests) DeepCopyInto(out *Manifests) {
 	*out = *in
@@ -1223,6 +1300,11 @@ func (in *PolicyStatus) DeepCopyInto(out *PolicyStatus) {
 	in.Autogen.DeepCopyInto(&out.Autogen)
 	out.RuleCount = in.RuleCount
 	out.ValidatingAdmissionPolicy = in.ValidatingAdmissionPolicy
+	if in.BackgroundScan != nil {
+		in, out := &in.BackgroundScan, &out.BackgroundScan
+		*out = new(BackgroundScanStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1888,6 +1970,11 @@ func (in *WebhookConfiguration) DeepCopyInto(out *WebhookConfiguration) {
 		*out = make([]admissionregistrationv1.MatchCondition, len(*in))
 		copy(*out, *in)
 	}
+	if in.LatencyBudget != nil {
+		in, out := &in.LatencyBudget, &out.LatencyBudget
+		*out = new(LatencyBudget)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
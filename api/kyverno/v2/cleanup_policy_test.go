@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"gotest.tools/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -44,6 +45,32 @@ func Test_CleanupPolicy_Schedule(t *testing.T) {
 	assert.Equal(t, errs[0].Error(), fmt.Sprintf(`spec.schedule: Invalid value: "%s": schedule spec in the cleanupPolicy is not in proper cron format`, subject.Spec.Schedule))
 }
 
+func Test_CleanupPolicy_PreDeletion(t *testing.T) {
+	subject := CleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-policy",
+		},
+		Spec: CleanupPolicySpec{
+			Schedule: "* * * * *",
+			PreDeletion: &PreDeletion{
+				GracePeriod: &metav1.Duration{Duration: -1},
+				Webhook:     &kyvernov1.ServiceCall{},
+			},
+			Notification: &CleanupNotification{
+				Webhook: &kyvernov1.ServiceCall{},
+			},
+		},
+	}
+	errs := subject.Validate(nil)
+	assert.Assert(t, len(errs) == 3)
+	assert.Equal(t, errs[0].Field, "spec.preDeletion.gracePeriod")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeInvalid)
+	assert.Equal(t, errs[1].Field, "spec.preDeletion.webhook.url")
+	assert.Equal(t, errs[1].Type, field.ErrorTypeRequired)
+	assert.Equal(t, errs[2].Field, "spec.notification.webhook.url")
+	assert.Equal(t, errs[2].Type, field.ErrorTypeRequired)
+}
+
 func Test_ClusterCleanupPolicy_Name(t *testing.T) {
 	subject := ClusterCleanupPolicy{
 		ObjectMeta: metav1.ObjectMeta{
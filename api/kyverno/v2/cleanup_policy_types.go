@@ -229,6 +229,71 @@ type CleanupPolicySpec struct {
 	// +optional
 	// +kubebuilder:validation:Enum=Foreground;Background;Orphan
 	DeletionPropagationPolicy *metav1.DeletionPropagation `json:"deletionPropagationPolicy,omitempty"`
+
+	// PreDeletion defines the actions taken before a matching resource is deleted.
+	// +optional
+	PreDeletion *PreDeletion `json:"preDeletion,omitempty"`
+
+	// Notification defines how the summary of each cleanup run is reported.
+	// +optional
+	Notification *CleanupNotification `json:"notification,omitempty"`
+}
+
+// PreDeletion defines the actions taken before a resource is deleted by a cleanup policy.
+type PreDeletion struct {
+	// Event emits an event regarding the controller owner of the resource, or the resource itself
+	// when it has no owner, the first time the resource matches the policy.
+	// +optional
+	Event *PreDeletionEvent `json:"event,omitempty"`
+
+	// GracePeriod delays the deletion of the matching resources. A resource is annotated with the time
+	// it first matches and its deletion time, under keys suffixed with the policy UID, and is deleted by
+	// the first run after that time. The annotations are removed when the resource no longer matches
+	// or when the policy is deleted.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+
+	// Webhook is a service called before a resource is deleted. The resource is only deleted if the
+	// service responds with a 2xx status code, otherwise its deletion is retried by the next run.
+	// +optional
+	Webhook *kyvernov1.ServiceCall `json:"webhook,omitempty"`
+}
+
+// PreDeletionEvent defines the event emitted before a resource is deleted.
+type PreDeletionEvent struct {
+	// Message of the event. It can contain variables, the resource is available as the `target` variable.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// CleanupNotification defines how the summary of a cleanup run is reported.
+type CleanupNotification struct {
+	// Event emits an event regarding the policy with the summary of each run.
+	// +optional
+	Event bool `json:"event,omitempty"`
+
+	// Webhook is a service the summary of each run is posted to.
+	// +optional
+	Webhook *kyvernov1.ServiceCall `json:"webhook,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (p *PreDeletion) Validate(path *field.Path) (errs field.ErrorList) {
+	if p.GracePeriod != nil && p.GracePeriod.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("gracePeriod"), p.GracePeriod.Duration.String(), "grace period must be positive"))
+	}
+	if p.Webhook != nil && p.Webhook.URL == "" {
+		errs = append(errs, field.Required(path.Child("webhook").Child("url"), "webhook url is required"))
+	}
+	return errs
+}
+
+// Validate implements programmatic validation
+func (n *CleanupNotification) Validate(path *field.Path) (errs field.ErrorList) {
+	if n.Webhook != nil && n.Webhook.URL == "" {
+		errs = append(errs, field.Required(path.Child("webhook").Child("url"), "webhook url is required"))
+	}
+	return errs
 }
 
 // CleanupPolicyStatus stores the status of the policy.
@@ -255,6 +320,12 @@ func (p *CleanupPolicySpec) Validate(path *field.Path, clusterResources sets.Set
 		}
 	}
 	errs = append(errs, p.ValidateMatchExcludeConflict(path)...)
+	if p.PreDeletion != nil {
+		errs = append(errs, p.PreDeletion.Validate(path.Child("preDeletion"))...)
+	}
+	if p.Notification != nil {
+		errs = append(errs, p.Notification.Validate(path.Child("notification"))...)
+	}
 	return errs
 }
 
@@ -37,6 +37,31 @@ type UpdateRequestStatus struct {
 	GeneratedResources []kyvernov1.ResourceSpec `json:"generatedResources,omitempty"`
 
 	RetryCount int `json:"retryCount,omitempty"`
+
+	// Conflicts lists the fields owned by other field managers that were encountered
+	// while applying the resources of this request with server-side apply.
+	// +optional
+	Conflicts []FieldConflict `json:"conflicts,omitempty"`
+}
+
+// FieldConflict describes a field of a resource written by the background controller
+// that is owned by another field manager.
+type FieldConflict struct {
+	// Resource is the resource the conflict was detected on.
+	Resource kyvernov1.ResourceSpec `json:"resource"`
+
+	// Manager is the field manager owning the conflicting field.
+	// +optional
+	Manager string `json:"manager,omitempty"`
+
+	// Field is the path of the conflicting field.
+	// +optional
+	Field string `json:"field,omitempty"`
+
+	// Forced is true when the background controller took over the ownership of the field,
+	// false when it left the field to its current manager.
+	// +optional
+	Forced bool `json:"forced,omitempty"`
 }
 
 // +genclient
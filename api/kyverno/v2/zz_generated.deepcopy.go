@@ -80,6 +80,27 @@ func (in *AnyAllConditions) DeepCopy() *AnyAllConditions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleanupNotification) DeepCopyInto(out *CleanupNotification) {
+	*out = *in
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(kyvernov1.ServiceCall)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupNotification.
+func (in *CleanupNotification) DeepCopy() *CleanupNotification {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupNotification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CleanupPolicy) DeepCopyInto(out *CleanupPolicy) {
 	*out = *in
@@ -167,6 +188,16 @@ func (in *CleanupPolicySpec) DeepCopyInto(out *CleanupPolicySpec) {
 		*out = new(metav1.DeletionPropagation)
 		**out = **in
 	}
+	if in.PreDeletion != nil {
+		in, out := &in.PreDeletion, &out.PreDeletion
+		*out = new(PreDeletion)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notification != nil {
+		in, out := &in.Notification, &out.Notification
+		*out = new(CleanupNotification)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -332,6 +363,23 @@ func (in *ExternalAPICall) DeepCopy() *ExternalAPICall {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldConflict) DeepCopyInto(out *FieldConflict) {
+	*out = *in
+	out.Resource = in.Resource
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldConflict.
+func (in *FieldConflict) DeepCopy() *FieldConflict {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldConflict)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GlobalContextEntry) DeepCopyInto(out *GlobalContextEntry) {
 	*out = *in
@@ -581,6 +629,53 @@ func (in *PolicyExceptionSpec) DeepCopy() *PolicyExceptionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreDeletion) DeepCopyInto(out *PreDeletion) {
+	*out = *in
+	if in.Event != nil {
+		in, out := &in.Event, &out.Event
+		*out = new(PreDeletionEvent)
+		**out = **in
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(kyvernov1.ServiceCall)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreDeletion.
+func (in *PreDeletion) DeepCopy() *PreDeletion {
+	if in == nil {
+		return nil
+	}
+	out := new(PreDeletion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreDeletionEvent) DeepCopyInto(out *PreDeletionEvent) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreDeletionEvent.
+func (in *PreDeletionEvent) DeepCopy() *PreDeletionEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(PreDeletionEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RequestInfo) DeepCopyInto(out *RequestInfo) {
 	*out = *in
@@ -735,6 +830,11 @@ func (in *UpdateRequestStatus) DeepCopyInto(out *UpdateRequestStatus) {
 		*out = make([]kyvernov1.ResourceSpec, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conflicts != nil {
+		in, out := &in.Conflicts, &out.Conflicts
+		*out = make([]FieldConflict, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
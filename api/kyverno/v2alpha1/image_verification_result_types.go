@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v2alpha1
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ImageVerificationStatus is the outcome of an image verification
+// +kubebuilder:validation:Enum=Pass;Fail
+type ImageVerificationStatus string
+
+const (
+	// ImageVerificationPass means the image was verified
+	ImageVerificationPass ImageVerificationStatus = "Pass"
+	// ImageVerificationFail means the image failed verification
+	ImageVerificationFail ImageVerificationStatus = "Fail"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName=ivr,categories=kyverno,scope="Cluster"
+// +kubebuilder:printcolumn:name="DIGEST",type="string",JSONPath=".spec.digest"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ImageVerificationResult records the verification outcomes of an image digest.
+type ImageVerificationResult struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the verification outcomes.
+	Spec ImageVerificationResultSpec `json:"spec"`
+}
+
+// ImageVerificationResultSpec holds the verification outcomes of an image digest
+type ImageVerificationResultSpec struct {
+	// Digest is the verified image digest.
+	Digest string `json:"digest"`
+
+	// Images are the image references the digest was verified for.
+	// +optional
+	Images []string `json:"images,omitempty"`
+
+	// Verifications are the verification outcomes, one per policy rule.
+	// +optional
+	Verifications []ImageVerificationRecord `json:"verifications,omitempty"`
+}
+
+// ImageVerificationRecord is the outcome of the verification of an image digest by a policy rule
+type ImageVerificationRecord struct {
+	// Policy is the key of the policy that verified the image.
+	Policy string `json:"policy"`
+
+	// PolicyResourceVersion is the resource version of the policy when the image was verified.
+	// +optional
+	PolicyResourceVersion string `json:"policyResourceVersion,omitempty"`
+
+	// Rule is the name of the rule, or of the attestor for image validating policies, that verified the image.
+	// +optional
+	Rule string `json:"rule,omitempty"`
+
+	// Status is the verification outcome.
+	Status ImageVerificationStatus `json:"status"`
+
+	// Message is the verification failure message.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Signers are the signer identities trusted by the rule.
+	// +optional
+	Signers []ImageSigner `json:"signers,omitempty"`
+
+	// Attestations are the predicate types of the verified attestations.
+	// +optional
+	Attestations []string `json:"attestations,omitempty"`
+
+	// Time is the time the image was verified.
+	Time metav1.Time `json:"time"`
+
+	// Signature authenticates the record, records without a valid signature are not reused by Kyverno.
+	// +optional
+	Signature string `json:"signature,omitempty"`
+}
+
+// ImageSigner is a signer identity trusted by a policy rule
+type ImageSigner struct {
+	// Type is the attestor type, e.g. `cosign/keyless`, `cosign/key`, `cosign/certificate` or `notary`.
+	Type string `json:"type"`
+
+	// Issuer is the OIDC issuer of keyless signatures, it can be a regular expression.
+	// +optional
+	Issuer string `json:"issuer,omitempty"`
+
+	// Subject is the subject of keyless signatures, it can be a regular expression.
+	// +optional
+	Subject string `json:"subject,omitempty"`
+
+	// Key identifies the public key or certificate, either a reference (KMS, Secret) or
+	// the `sha256:` fingerprint of the PEM encoded material.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// ImageVerificationResultName returns the name of the result object of an image digest
+func ImageVerificationResultName(digest string) string {
+	return strings.ReplaceAll(digest, ":", "-")
+}
+
+// FindVerification returns the verification of a policy rule
+func (s *ImageVerificationResultSpec) FindVerification(policy, rule string) *ImageVerificationRecord {
+	for i := range s.Verifications {
+		if s.Verifications[i].Policy == policy && s.Verifications[i].Rule == rule {
+			return &s.Verifications[i]
+		}
+	}
+	return nil
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ImageVerificationResultList is a list of ImageVerificationResult instances.
+type ImageVerificationResultList struct {
+	metav1.TypeMeta `json:",inline" yaml:",inline"`
+	metav1.ListMeta `json:"metadata" yaml:"metadata"`
+	Items           []ImageVerificationResult `json:"items" yaml:"items"`
+}
@@ -13,10 +13,11 @@ type AssertionTree = kjson.Any
 type Validation struct {
 	// FailureAction defines if a validation policy rule violation should block
 	// the admission review request (Enforce), or allow (Audit) the admission review request
-	// and report an error in a policy report. Optional.
-	// Allowed values are Audit or Enforce.
+	// and report an error in a policy report. Shadow evaluates the rule with Enforce
+	// semantics without blocking the request and reports would-be denials separately. Optional.
+	// Allowed values are Audit, Enforce or Shadow.
 	// +optional
-	// +kubebuilder:validation:Enum=Audit;Enforce
+	// +kubebuilder:validation:Enum=Audit;Enforce;Shadow
 	FailureAction *kyvernov1.ValidationFailureAction `json:"failureAction,omitempty"`
 
 	// FailureActionOverrides is a Cluster Policy attribute that specifies FailureAction
@@ -27,7 +27,7 @@ type Spec struct {
 	FailurePolicy *kyvernov1.FailurePolicyType `json:"failurePolicy,omitempty"`
 
 	// Deprecated, use validationFailureAction under the validate rule instead.
-	// +kubebuilder:validation:Enum=audit;enforce;Audit;Enforce
+	// +kubebuilder:validation:Enum=audit;enforce;Audit;Enforce;Shadow
 	// +kubebuilder:default=Audit
 	ValidationFailureAction kyvernov1.ValidationFailureAction `json:"validationFailureAction,omitempty"`
 
@@ -264,6 +264,14 @@ func (s *Spec) GetWebhookTimeoutSeconds() *int32 {
 	return nil
 }
 
+// GetLatencyBudget returns the admission latency budget in webhookConfiguration
+func (s *Spec) GetLatencyBudget() *kyvernov1.LatencyBudget {
+	if s.WebhookConfiguration != nil {
+		return s.WebhookConfiguration.LatencyBudget
+	}
+	return nil
+}
+
 // GetApplyRules returns the apply rules type
 func (s *Spec) GetApplyRules() kyvernov1.ApplyRulesType {
 	if s.ApplyRules == nil {
@@ -340,6 +348,9 @@ func (s *Spec) Validate(path *field.Path, namespaced bool, policyNamespace strin
 	if s.WebhookConfiguration != nil && s.WebhookConfiguration.TimeoutSeconds != nil && (*s.WebhookConfiguration.TimeoutSeconds < 1 || *s.WebhookConfiguration.TimeoutSeconds > 30) {
 		errs = append(errs, field.Invalid(path.Child("webhookConfiguration.timeoutSeconds"), s.WebhookConfiguration.TimeoutSeconds, "the timeout value must be between 1 and 30 seconds"))
 	}
+	if s.WebhookConfiguration != nil && s.WebhookConfiguration.LatencyBudget != nil {
+		errs = append(errs, s.WebhookConfiguration.LatencyBudget.Validate(path.Child("webhookConfiguration", "latencyBudget"))...)
+	}
 	warning, errors := s.ValidateRules(path.Child("rules"), namespaced, policyNamespace, clusterResources)
 	warnings = append(warnings, warning...)
 	errs = append(errs, errors...)
@@ -42,6 +42,12 @@
   {{- $flags = append $flags (print "--backgroundScan=" .enabled) -}}
   {{- $flags = append $flags (print "--backgroundScanWorkers=" .backgroundScanWorkers) -}}
   {{- $flags = append $flags (print "--backgroundScanInterval=" .backgroundScanInterval) -}}
+  {{- with .backgroundScanRateLimit -}}
+    {{- $flags = append $flags (print "--backgroundScanRateLimit=" .) -}}
+  {{- end -}}
+  {{- with .backgroundScanRateBurst -}}
+    {{- $flags = append $flags (print "--backgroundScanRateBurst=" .) -}}
+  {{- end -}}
   {{- $flags = append $flags (print "--skipResourceFilters=" .skipResourceFilters) -}}
 {{- end -}}
 {{- with .configMapCaching -}}
@@ -59,6 +65,17 @@
 {{- with .forceFailurePolicyIgnore -}}
   {{- $flags = append $flags (print "--forceFailurePolicyIgnore=" .enabled) -}}
 {{- end -}}
+{{- with .generateDrift -}}
+  {{- $flags = append $flags (print "--generateDrift=" .enabled) -}}
+  {{- $flags = append $flags (print "--generateDriftInterval=" .interval) -}}
+{{- end -}}
+{{- with .generateGC -}}
+  {{- $flags = append $flags (print "--generateGC=" .enabled) -}}
+  {{- $flags = append $flags (print "--generateGCInterval=" .interval) -}}
+  {{- $flags = append $flags (print "--generateGCDryRun=" .dryRun) -}}
+  {{- $flags = append $flags (print "--generateGCReasons=" (join "," .reasons)) -}}
+  {{- $flags = append $flags (print "--generateGCMinAge=" .minAge) -}}
+{{- end -}}
 {{- with .generateValidatingAdmissionPolicy -}}
   {{- $flags = append $flags (print "--generateValidatingAdmissionPolicy=" .enabled) -}}
 {{- end -}}
@@ -72,10 +89,43 @@
   {{- $flags = append $flags (print "--maxAPICallResponseLength=" (int .maxApiCallResponseLength)) -}}
   {{- $flags = append $flags (print "--apiCallTimeout=" .apiCallTimeout) -}}
 {{- end -}}
+{{- with .imageVerifyCache -}}
+  {{- with .shared -}}
+    {{- $flags = append $flags (print "--imageVerifyCacheShared=" .enabled) -}}
+    {{- if .enabled -}}
+      {{- $flags = append $flags (print "--imageVerifyCacheSharedConfigMap=" .configMap) -}}
+      {{- $flags = append $flags (print "--imageVerifyCacheSharedSecret=" .secret) -}}
+    {{- end -}}
+  {{- end -}}
+{{- end -}}
+{{- with .imageVerificationResults -}}
+  {{- $flags = append $flags (print "--imageVerificationResults=" .enabled) -}}
+  {{- if .enabled -}}
+    {{- $flags = append $flags (print "--imageVerificationResultsRetention=" .retention) -}}
+    {{- $flags = append $flags (print "--imageVerificationResultsSecret=" .secret) -}}
+  {{- end -}}
+{{- end -}}
+{{- with .imageReverification -}}
+  {{- if .enabled -}}
+    {{- $flags = append $flags (print "--imageReverificationInterval=" .interval) -}}
+    {{- $flags = append $flags (print "--imageReverificationAction=" .action) -}}
+  {{- end -}}
+{{- end -}}
 {{- with .logging -}}
   {{- $flags = append $flags (print "--loggingFormat=" .format) -}}
   {{- $flags = append $flags (print "--v=" .verbosity) -}}
 {{- end -}}
+{{- with .notation -}}
+  {{- with .trustPolicyConfigMap -}}
+    {{- $flags = append $flags (print "--notationTrustPolicyConfigMap=" .) -}}
+  {{- end -}}
+  {{- with .trustStoreSecret -}}
+    {{- $flags = append $flags (print "--notationTrustStoreSecret=" .) -}}
+  {{- end -}}
+  {{- with .pluginDirectory -}}
+    {{- $flags = append $flags (print "--notationPluginDirectory=" .) -}}
+  {{- end -}}
+{{- end -}}
 {{- with .omitEvents -}}
   {{- with .eventTypes -}}
     {{- $flags = append $flags (print "--omitEvents=" (join "," .)) -}}
@@ -93,9 +143,44 @@
 {{- with .registryClient -}}
   {{- $flags = append $flags (print "--allowInsecureRegistry=" .allowInsecure) -}}
   {{- $flags = append $flags (print "--registryCredentialHelpers=" (join "," .credentialHelpers)) -}}
+  {{- with .mirrors -}}
+    {{- $flags = append $flags (print "--registryMirrors=" (toJson .)) -}}
+  {{- end -}}
+{{- end -}}
+{{- with .reportEnrichment -}}
+  {{- with .namespaceLabels -}}
+    {{- $flags = append $flags (print "--reportNamespaceLabels=" (join "," .)) -}}
+  {{- end -}}
+  {{- with .topLevelOwner -}}
+    {{- $flags = append $flags (print "--reportTopLevelOwner=" .) -}}
+  {{- end -}}
+{{- end -}}
+{{- with .reportHistory -}}
+  {{- if .enabled -}}
+    {{- $flags = append $flags (print "--reportHistoryInterval=" .interval) -}}
+    {{- $flags = append $flags (print "--reportHistoryRetention=" .retention) -}}
+    {{- $flags = append $flags (print "--reportHistoryMaxSnapshots=" .maxSnapshots) -}}
+  {{- end -}}
+{{- end -}}
+{{- with .reportRollup -}}
+  {{- $flags = append $flags (print "--reportRollup=" .enabled) -}}
+  {{- $flags = append $flags (print "--reportRollupPods=" .pods) -}}
+{{- end -}}
+{{- with .serverSideApply -}}
+  {{- $flags = append $flags (print "--serverSideApply=" .enabled) -}}
+  {{- $flags = append $flags (print "--serverSideApplyConflicts=" .conflicts) -}}
 {{- end -}}
 {{- with .ttlController -}}
   {{- $flags = append $flags (print "--ttlReconciliationInterval=" .reconciliationInterval) -}}
+  {{- with .resources -}}
+    {{- $flags = append $flags (print "--ttlResources=" (join "," .)) -}}
+  {{- end -}}
+  {{- with .namespaces -}}
+    {{- $flags = append $flags (print "--ttlNamespaces=" (join "," .)) -}}
+  {{- end -}}
+  {{- with .lazyWatch -}}
+    {{- $flags = append $flags (print "--ttlLazyWatch=" .) -}}
+  {{- end -}}
 {{- end -}}
 {{- with .tuf -}}
   {{- with .enabled -}}
@@ -111,6 +196,23 @@
     {{- $flags = append $flags (print "--tufRootRaw=" .) -}}
   {{- end -}}
 {{- end -}}
+{{- with .updateRequestBatching -}}
+  {{- $flags = append $flags (print "--updateRequestBatching=" .enabled) -}}
+{{- end -}}
+{{- with .updateRequestQueue -}}
+  {{- $flags = append $flags (print "--updateRequestQueue=" .mode) -}}
+{{- end -}}
+{{- with .verificationBundles -}}
+  {{- with .configMap -}}
+    {{- $flags = append $flags (print "--verificationBundlesConfigMap=" .) -}}
+  {{- end -}}
+  {{- with .image -}}
+    {{- $flags = append $flags (print "--verificationBundlesImage=" .) -}}
+  {{- end -}}
+  {{- with .refresh -}}
+    {{- $flags = append $flags (print "--verificationBundlesRefresh=" .) -}}
+  {{- end -}}
+{{- end -}}
 {{- with .reporting }}
   {{- $reportingConfig := list }}
   {{- with .validate }}
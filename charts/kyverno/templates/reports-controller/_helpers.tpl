@@ -43,3 +43,13 @@
 {{- define "kyverno.reports-controller.caCertificatesConfigMapName" -}}
 {{ printf "%s-ca-certificates" (include "kyverno.reports-controller.name" .) }}
 {{- end -}}
+
+{{- define "kyverno.reports-controller.reportExportSecretName" -}}
+{{ .Values.reportsController.reportExport.existingSecret | default (printf "%s-report-export" (include "kyverno.reports-controller.name" .)) }}
+{{- end -}}
+
+{{- define "kyverno.reports-controller.reportExportEnabled" -}}
+{{- if or .Values.reportsController.reportExport.config .Values.reportsController.reportExport.existingSecret -}}
+true
+{{- end -}}
+{{- end -}}
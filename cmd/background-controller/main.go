@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
 	"os"
 	"strings"
 	"time"
@@ -11,7 +12,10 @@ import (
 	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
 	"github.com/kyverno/kyverno/cmd/internal"
 	"github.com/kyverno/kyverno/pkg/background"
+	backgroundcommon "github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/background/generate"
 	"github.com/kyverno/kyverno/pkg/background/gpol"
+	"github.com/kyverno/kyverno/pkg/background/inmemory"
 	"github.com/kyverno/kyverno/pkg/breaker"
 	"github.com/kyverno/kyverno/pkg/cel/libs"
 	"github.com/kyverno/kyverno/pkg/cel/matching"
@@ -23,17 +27,23 @@ import (
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers/certmanager"
+	driftcontroller "github.com/kyverno/kyverno/pkg/controllers/drift"
+	generategccontroller "github.com/kyverno/kyverno/pkg/controllers/generategc"
 	globalcontextcontroller "github.com/kyverno/kyverno/pkg/controllers/globalcontext"
 	policymetricscontroller "github.com/kyverno/kyverno/pkg/controllers/metrics/policy"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
+	entryevent "github.com/kyverno/kyverno/pkg/globalcontext/event"
 	"github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"github.com/kyverno/kyverno/pkg/informers"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/policy"
+	"github.com/kyverno/kyverno/pkg/tls"
 	"github.com/kyverno/kyverno/pkg/utils/generator"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
@@ -44,6 +54,8 @@ import (
 	kruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	kubeinformers "k8s.io/client-go/informers"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	kyamlopenapi "sigs.k8s.io/kustomize/kyaml/openapi"
@@ -72,6 +84,17 @@ func createrLeaderControllers(
 	mpolEngine mpolengine.Engine,
 	mapper meta.RESTMapper,
 	reportsConfig reportutils.ReportingConfiguration,
+	generateDrift bool,
+	generateDriftInterval time.Duration,
+	generateGC bool,
+	generateGCConfig generategccontroller.Config,
+	serverSideApply bool,
+	conflictStrategy backgroundcommon.ConflictStrategy,
+	updateRequestBatching bool,
+	urStore *inmemory.Store,
+	urPersistAfter time.Duration,
+	gctxChanges entryevent.Broadcaster,
+	generateSourceResyncInterval time.Duration,
 ) ([]internal.Controller, error) {
 	watchManager := gpol.NewWatchManager(logging.WithName("WatchManager"), dynamicClient)
 	policyCtrl, err := policy.NewPolicyController(
@@ -91,6 +114,8 @@ func createrLeaderControllers(
 		jp,
 		urGenerator,
 		watchManager,
+		gctxChanges,
+		generateSourceResyncInterval,
 	)
 	if err != nil {
 		return nil, err
@@ -113,11 +138,43 @@ func createrLeaderControllers(
 		configuration,
 		jp,
 		reportsConfig,
+		serverSideApply,
+		conflictStrategy,
+		updateRequestBatching,
+		urStore,
+		urPersistAfter,
 	)
-	return []internal.Controller{
+	leaderControllers := []internal.Controller{
 		internal.NewController("policy-controller", policyCtrl, 2),
 		internal.NewController("background-controller", backgroundController, genWorkers),
-	}, err
+	}
+	if generateDrift {
+		leaderControllers = append(leaderControllers, internal.NewController(
+			driftcontroller.ControllerName,
+			driftcontroller.NewController(
+				generate.NewPreviewer(dynamicClient, eng, configuration, jp),
+				kyvernoInformer.Kyverno().V1().ClusterPolicies().Lister(),
+				kyvernoInformer.Kyverno().V1().Policies().Lister(),
+				eventGenerator,
+				generateDriftInterval,
+			),
+			driftcontroller.Workers,
+		))
+	}
+	if generateGC {
+		leaderControllers = append(leaderControllers, internal.NewController(
+			generategccontroller.ControllerName,
+			generategccontroller.NewController(
+				dynamicClient,
+				kyvernoInformer.Kyverno().V1().ClusterPolicies().Lister(),
+				kyvernoInformer.Kyverno().V1().Policies().Lister(),
+				eventGenerator,
+				generateGCConfig,
+			),
+			generategccontroller.Workers,
+		))
+	}
+	return leaderControllers, err
 }
 
 func main() {
@@ -129,6 +186,24 @@ func main() {
 		apiCallTimeout                  time.Duration
 		maxBackgroundReports            int
 		controllerRuntimeMetricsAddress string
+		generateDrift                   bool
+		generateDriftInterval           time.Duration
+		generateSourceResyncInterval    time.Duration
+		generateGC                      bool
+		generateGCInterval              time.Duration
+		generateGCDryRun                bool
+		generateGCReasons               string
+		generateGCMinAge                time.Duration
+		serverSideApply                 bool
+		serverSideApplyConflicts        string
+		updateRequestBatching           bool
+		updateRequestQueue              string
+		updateRequestQueuePort          int
+		updateRequestQueuePersistAfter  time.Duration
+		updateRequestQueueService       string
+		updateRequestQueueCASecretName  string
+		updateRequestQueueTLSSecretName string
+		updateRequestQueueRenewBefore   time.Duration
 	)
 	flagset := flag.NewFlagSet("updaterequest-controller", flag.ExitOnError)
 	flagset.IntVar(&genWorkers, "genWorkers", 10, "Workers for the background controller.")
@@ -138,6 +213,24 @@ func main() {
 	flagset.DurationVar(&apiCallTimeout, "apiCallTimeout", 30*time.Second, "Timeout for HTTP API calls made by policies. A value of 0 means no timeout.")
 	flagset.IntVar(&maxBackgroundReports, "maxBackgroundReports", 10000, "Maximum number of ephemeralreports created for the background policies.")
 	flagset.StringVar(&controllerRuntimeMetricsAddress, "controllerRuntimeMetricsAddress", "", `Bind address for controller-runtime metrics server. It will be defaulted to ":8080" if unspecified. Set this to "0" to disable the metrics server.`)
+	flagset.BoolVar(&generateDrift, "generateDrift", false, "Periodically compare the downstream resources of synchronized generate rules to their expected state.")
+	flagset.DurationVar(&generateDriftInterval, "generateDriftInterval", time.Hour, "Interval between two generate drift scans.")
+	flagset.DurationVar(&generateSourceResyncInterval, "generateSourceResyncInterval", 5*time.Minute, "Interval between two resyncs of the resources generated from an API call source, 0 disables the resync.")
+	flagset.BoolVar(&generateGC, "generateGC", false, "Periodically look for generated resources whose policy, rule, target or trigger no longer exists and collect them.")
+	flagset.DurationVar(&generateGCInterval, "generateGCInterval", time.Hour, "Interval between two generated resources garbage collection scans.")
+	flagset.BoolVar(&generateGCDryRun, "generateGCDryRun", true, "Only report the orphaned generated resources and simulate their deletion.")
+	flagset.StringVar(&generateGCReasons, "generateGCReasons", strings.Join(generategccontroller.Reasons, ","), "Comma separated list of reasons the orphaned generated resources are deleted for, among "+strings.Join(generategccontroller.Reasons, ", ")+".")
+	flagset.DurationVar(&generateGCMinAge, "generateGCMinAge", 10*time.Minute, "Minimum age of an orphaned generated resource before it is deleted.")
+	flagset.BoolVar(&serverSideApply, "serverSideApply", true, "Write generated and mutated existing resources with server-side apply using a dedicated field manager.")
+	flagset.StringVar(&serverSideApplyConflicts, "serverSideApplyConflicts", string(backgroundcommon.ConflictStrategyForce), "Set to force to take over the fields owned by other field managers on conflicts, or yield to leave them and fail the update request.")
+	flagset.StringVar(&updateRequestQueue, "updateRequestQueue", inmemory.ModeCRD, "Set to memory to accept the update requests handed over in memory by the admission controller, or crd to only process the persisted update requests.")
+	flagset.IntVar(&updateRequestQueuePort, "updateRequestQueuePort", 9444, "Port used to accept the update requests handed over in memory.")
+	flagset.DurationVar(&updateRequestQueuePersistAfter, "updateRequestQueuePersistAfter", time.Minute, "Delay after which the in-memory update requests still pending are persisted as UpdateRequest resources, the ones not persisted yet are lost if the background controller crashes.")
+	flagset.StringVar(&updateRequestQueueService, "updateRequestQueueService", "", "Name of the service without selector routing the update requests handed over in memory, the leader publishes itself as its only endpoint.")
+	flagset.StringVar(&updateRequestQueueCASecretName, "updateRequestQueueCASecretName", "", "Name of the secret containing the CA of the update requests server.")
+	flagset.StringVar(&updateRequestQueueTLSSecretName, "updateRequestQueueTLSSecretName", "", "Name of the secret containing the TLS pair of the update requests server.")
+	flagset.DurationVar(&updateRequestQueueRenewBefore, "updateRequestQueueRenewBefore", 15*24*time.Hour, "The update requests server certificate renewal time before expiration.")
+	flagset.BoolVar(&updateRequestBatching, "updateRequestBatching", true, "Coalesce the pending update requests created for the same policy and trigger and only process the most recent one.")
 
 	// config
 	appConfig := internal.NewConfiguration(
@@ -177,6 +270,63 @@ func main() {
 			}
 		}
 		setup.Logger.V(2).Info("setting the background scan interval", "value", bgscanInterval.String())
+		conflictStrategy, err := backgroundcommon.ParseConflictStrategy(serverSideApplyConflicts)
+		if err != nil {
+			setup.Logger.Error(err, "failed to parse server-side apply conflict strategy")
+			os.Exit(1)
+		}
+		if err := inmemory.ValidateMode(updateRequestQueue); err != nil {
+			setup.Logger.Error(err, "failed to parse update request queue mode")
+			os.Exit(1)
+		}
+		var urStore *inmemory.Store
+		var urCASecret, urTLSSecret corev1informers.SecretInformer
+		var urPodIP string
+		if updateRequestQueue == inmemory.ModeMemory {
+			if updateRequestQueueService == "" || updateRequestQueueCASecretName == "" || updateRequestQueueTLSSecretName == "" {
+				setup.Logger.Error(errors.New("exiting... updateRequestQueueService, updateRequestQueueCASecretName and updateRequestQueueTLSSecretName are required when updateRequestQueue is memory"), "exiting... updateRequestQueueService, updateRequestQueueCASecretName and updateRequestQueueTLSSecretName are required when updateRequestQueue is memory")
+				os.Exit(1)
+			}
+			token, err := inmemory.TokenFromEnv()
+			if err != nil {
+				setup.Logger.Error(err, "failed to read update request queue token")
+				os.Exit(1)
+			}
+			urPodIP, err = inmemory.PodIPFromEnv()
+			if err != nil {
+				setup.Logger.Error(err, "failed to read update request queue pod IP")
+				os.Exit(1)
+			}
+			urCASecret = informers.NewSecretInformer(setup.KubeClient, config.KyvernoNamespace(), updateRequestQueueCASecretName, setup.ResyncPeriod)
+			urTLSSecret = informers.NewSecretInformer(setup.KubeClient, config.KyvernoNamespace(), updateRequestQueueTLSSecretName, setup.ResyncPeriod)
+			if !informers.StartInformersAndWaitForCacheSync(signalCtx, setup.Logger, urCASecret, urTLSSecret) {
+				setup.Logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+				os.Exit(1)
+			}
+			urStore = inmemory.NewStore()
+			// every replica listens but only the leader is published as the service endpoint, the others
+			// reply unavailable so that the admission controller falls back to the CRD if it reaches them
+			urServer := inmemory.NewServer(
+				setup.Logger.WithName("update-request-server"),
+				fmt.Sprintf(":%d", updateRequestQueuePort),
+				token,
+				urStore,
+				func() ([]byte, []byte, error) {
+					secret, err := urTLSSecret.Lister().Secrets(config.KyvernoNamespace()).Get(updateRequestQueueTLSSecretName)
+					if err != nil {
+						return nil, nil, err
+					}
+					return secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], nil
+				},
+			)
+			urServer.Run()
+			defer urServer.Stop()
+		}
+		generateGCReasonSet, err := generategccontroller.ParseReasons(generateGCReasons)
+		if err != nil {
+			setup.Logger.Error(err, "failed to parse generated resources garbage collection reasons")
+			os.Exit(1)
+		}
 		// THIS IS AN UGLY FIX
 		// ELSE KYAML IS NOT THREAD SAFE
 		kyamlopenapi.Schema()
@@ -201,6 +351,7 @@ func main() {
 		)
 		urGenerator := generator.NewUpdateRequestGenerator(setup.Configuration, setup.MetadataClient)
 		gcstore := store.New()
+		gctxChanges := entryevent.NewBroadcaster()
 		gceController := internal.NewController(
 			globalcontextcontroller.ControllerName,
 			globalcontextcontroller.NewController(
@@ -210,6 +361,7 @@ func main() {
 				setup.KyvernoClient,
 				gcstore,
 				eventGenerator,
+				gctxChanges,
 				maxAPICallResponseLength,
 				apiCallTimeout,
 				false,
@@ -393,11 +545,67 @@ func main() {
 					mpolEngine,
 					restMapper,
 					setup.ReportingConfiguration,
+					generateDrift,
+					generateDriftInterval,
+					generateGC,
+					generategccontroller.Config{
+						Interval: generateGCInterval,
+						DryRun:   generateGCDryRun,
+						Reasons:  generateGCReasonSet,
+						MinAge:   generateGCMinAge,
+					},
+					serverSideApply,
+					conflictStrategy,
+					updateRequestBatching,
+					urStore,
+					updateRequestQueuePersistAfter,
+					gctxChanges,
+					generateSourceResyncInterval,
 				)
 				if err != nil {
 					logger.Error(err, "failed to create leader controllers")
 					os.Exit(1)
 				}
+				if urStore != nil {
+					renewer := tls.NewCertRenewer(
+						setup.KubeClient.CoreV1().Secrets(config.KyvernoNamespace()),
+						tls.CertRenewalInterval,
+						tls.CAValidityDuration,
+						tls.TLSValidityDuration,
+						updateRequestQueueRenewBefore,
+						"",
+						updateRequestQueueService,
+						config.DnsNames(updateRequestQueueService, config.KyvernoNamespace()),
+						config.KyvernoNamespace(),
+						updateRequestQueueCASecretName,
+						updateRequestQueueTLSSecretName,
+						tls.DefaultKeyAlgorithm,
+					)
+					leaderControllers = append(leaderControllers, internal.NewController(
+						certmanager.ControllerName,
+						certmanager.NewController(
+							urCASecret,
+							urTLSSecret,
+							renewer,
+							updateRequestQueueCASecretName,
+							updateRequestQueueTLSSecretName,
+							config.KyvernoNamespace(),
+						),
+						certmanager.Workers,
+					))
+					// route the update requests handed over in memory to this replica
+					if err := retry.OnError(retry.DefaultBackoff, func(error) bool { return ctx.Err() == nil }, func() error {
+						return inmemory.PublishEndpoint(
+							ctx,
+							setup.KubeClient.DiscoveryV1().EndpointSlices(config.KyvernoNamespace()),
+							updateRequestQueueService,
+							urPodIP,
+							int32(updateRequestQueuePort), //nolint:gosec
+						)
+					}); err != nil {
+						logger.Error(err, "failed to publish the update request queue endpoint")
+					}
+				}
 				// start informers and wait for cache sync
 				// Use ctx (leader election context) so informers/controllers stop when leadership is lost
 				if !internal.StartInformersAndWaitForCacheSync(ctx, logger, kyvernoInformer, kubeInformer) {
@@ -16,11 +16,15 @@ import (
 
 type validationHandlers struct {
 	checker checker.AuthChecker
+	scope   manager.Scope
+	tracker *manager.Tracker
 }
 
-func New(checker checker.AuthChecker) *validationHandlers {
+func New(checker checker.AuthChecker, scope manager.Scope, tracker *manager.Tracker) *validationHandlers {
 	return &validationHandlers{
 		checker: checker,
+		scope:   scope,
+		tracker: tracker,
 	}
 }
 
@@ -30,12 +34,23 @@ func (h *validationHandlers) Validate(ctx context.Context, logger logr.Logger, r
 		logger.Error(err, "failed to unmarshal metadatas from admission request")
 		return admissionutils.ResponseSuccess(request.UID, err.Error())
 	}
-	if !manager.HasResourcePermissions(logger, schema.GroupVersionResource(request.AdmissionRequest.Resource), h.checker) {
+	gvr := schema.GroupVersionResource(request.AdmissionRequest.Resource)
+	if !h.scope.AllowsResource(gvr) || !h.scope.AllowsNamespace(request.Namespace) {
+		return admissionutils.ResponseSuccess(request.UID, "cleanup.kyverno.io/ttl label is ignored, the resource is not handled by the cleanup controller")
+	}
+	if !manager.HasResourcePermissions(logger, gvr, h.checker) {
 		logger.Info("doesn't have required permissions for deletion", "gvr", request.AdmissionRequest.Resource.String())
 	}
 	if err := validation.ValidateTtlLabel(ctx, metadata); err != nil {
 		logger.Error(err, "metadata validation errors")
 		return admissionutils.ResponseSuccess(request.UID, fmt.Sprintf("cleanup.kyverno.io/ttl label value cannot be parsed as any recognizable format (%s)", err.Error()))
 	}
+	if err := validation.ValidateTtlAnchor(ctx, metadata); err != nil {
+		logger.Error(err, "metadata validation errors")
+		return admissionutils.ResponseSuccess(request.UID, fmt.Sprintf("cleanup.kyverno.io/ttl-anchor annotation is invalid (%s), the resource won't be cleaned up until it is fixed", err.Error()))
+	}
+	if h.tracker != nil && h.tracker.Observe(gvr) {
+		logger.V(2).Info("first labelled object observed for resource", "gvr", gvr.String())
+	}
 	return admissionutils.ResponseSuccess(request.UID)
 }
@@ -88,6 +88,9 @@ func main() {
 		apiCallTimeout           time.Duration
 		autoDeleteWebhooks       bool
 		tlsKeyAlgorithm          string
+		ttlResources             string
+		ttlNamespaces            string
+		ttlLazyWatch             bool
 	)
 	flagset := flag.NewFlagSet("cleanup-controller", flag.ExitOnError)
 	flagset.BoolVar(&dumpPayload, "dumpPayload", false, "Set this flag to activate/deactivate debug mode.")
@@ -97,6 +100,9 @@ func main() {
 	flagset.IntVar(&webhookServerPort, "webhookServerPort", 9443, "Port used by the webhook server. (deprecated: replaced by --cleanupServerPort)")
 	flagset.IntVar(&maxQueuedEvents, "maxQueuedEvents", 1000, "Maximum events to be queued.")
 	flagset.DurationVar(&interval, "ttlReconciliationInterval", time.Minute, "Set this flag to set the interval after which the resource controller reconciliation should occur")
+	flagset.StringVar(&ttlResources, "ttlResources", "", "Comma separated list of resources handled by the label based cleanup manager, e.g. --ttlResources=pods,services.v1,jobs.batch,deployments.v1.apps (all resources when empty)")
+	flagset.StringVar(&ttlNamespaces, "ttlNamespaces", "", "Comma separated list of namespaces handled by the label based cleanup manager (all namespaces when empty, cluster scoped resources are ignored otherwise)")
+	flagset.BoolVar(&ttlLazyWatch, "ttlLazyWatch", false, "Set this flag to 'true' to only watch a resource once an object carrying the cleanup.kyverno.io/ttl label was seen for it")
 	flagset.Func(toggle.ProtectManagedResourcesFlagName, toggle.ProtectManagedResourcesDescription, toggle.ProtectManagedResources.Parse)
 	flagset.StringVar(&caSecretName, "caSecretName", "", "Name of the secret containing CA.")
 	flagset.StringVar(&tlsSecretName, "tlsSecretName", "", "Name of the secret containing TLS pair.")
@@ -159,6 +165,8 @@ func main() {
 			os.Exit(1)
 		}
 		checker := checker.NewSelfChecker(setup.KubeClient.AuthorizationV1().SelfSubjectAccessReviews())
+		ttlScope := ttlcontroller.NewScope(strings.Split(ttlResources, ","), strings.Split(ttlNamespaces, ","), ttlLazyWatch)
+		ttlTracker := ttlcontroller.NewTracker()
 		// informer factories
 		kubeInformer := kubeinformers.NewSharedInformerFactoryWithOptions(setup.KubeClient, setup.ResyncPeriod)
 		kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(setup.KyvernoClient, setup.ResyncPeriod)
@@ -198,6 +206,7 @@ func main() {
 				setup.KyvernoClient,
 				gcstore,
 				eventGenerator,
+				nil,
 				maxAPICallResponseLength,
 				apiCallTimeout,
 				false,
@@ -222,7 +231,8 @@ func main() {
 		}
 
 		// setup leader election
-		le, err := leaderelection.New(
+		var le leaderelection.Interface
+		le, err = leaderelection.New(
 			setup.Logger.WithName("leader-election"),
 			"kyverno-cleanup-controller",
 			config.KyvernoNamespace(),
@@ -393,9 +403,14 @@ func main() {
 					ttlcontroller.NewManager(
 						setup.MetadataClient,
 						setup.KubeClient.Discovery(),
+						setup.KyvernoDynamicClient.GetDynamicInterface(),
 						checker,
+						eventGenerator,
 						interval,
 						setup.ResyncPeriod,
+						ttlScope,
+						ttlTracker,
+						le.IsLeader,
 					),
 					ttlcontroller.Workers,
 				)
@@ -422,7 +437,7 @@ func main() {
 		}
 		// create handlers
 		policyHandlers := policyhandlers.New(setup.KyvernoDynamicClient)
-		resourceHandlers := resourcehandlers.New(checker)
+		resourceHandlers := resourcehandlers.New(checker, ttlScope, ttlTracker)
 		// create server
 		server := NewServer(
 			func() ([]byte, []byte, error) {
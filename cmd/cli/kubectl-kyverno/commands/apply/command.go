@@ -103,6 +103,7 @@ type ApplyCommandConfig struct {
 	BatchSize                 int
 	ContinueOnError           bool
 	ShowPerformance           bool
+	GeneratePreview           bool
 	// Cloner is an optional function for cloning git repositories.
 	// If nil, defaults to gitutils.Clone. Tests can inject a fake
 	// to avoid real network calls while still exercising the git-URL
@@ -134,6 +135,9 @@ func Command() *cobra.Command {
 			out := cmd.OutOrStdout()
 			color.Init(removeColor)
 			applyCommandConfig.PolicyPaths = args
+			if applyCommandConfig.GeneratePreview {
+				return applyCommandConfig.generatePreview(out)
+			}
 			rc, _, skipInvalidPolicies, responses, err := applyCommandConfig.applyCommandHelper(out)
 			if err != nil {
 				return err
@@ -232,6 +236,7 @@ func Command() *cobra.Command {
 	cmd.Flags().IntVar(&applyCommandConfig.BatchSize, "batch-size", 100, "Number of resources to fetch per API call")
 	cmd.Flags().BoolVar(&applyCommandConfig.ContinueOnError, "continue-on-error", true, "Continue processing despite resource loading errors")
 	cmd.Flags().BoolVar(&applyCommandConfig.ShowPerformance, "show-performance", false, "Show resource loading performance metrics")
+	cmd.Flags().BoolVar(&applyCommandConfig.GeneratePreview, "generate-preview", false, "Preview the downstream resources generate rules would create, update or delete in the cluster, requires --cluster")
 	return cmd
 }
 
@@ -978,6 +983,9 @@ func (c *ApplyCommandConfig) checkArguments() error {
 	if len(c.ResourcePaths) == 0 && len(c.JSONPaths) == 0 && !c.Cluster {
 		return fmt.Errorf("resource file(s) or cluster required")
 	}
+	if c.GeneratePreview && !c.Cluster {
+		return fmt.Errorf("generate preview requires cluster")
+	}
 	return nil
 }
 
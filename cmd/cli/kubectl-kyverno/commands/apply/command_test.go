@@ -15,7 +15,9 @@ import (
 	git "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/report"
+	"github.com/kyverno/kyverno/pkg/background/generate"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	openreportsv1alpha1 "github.com/openreports/reports-api/apis/openreports.io/v1alpha1"
 	"github.com/stretchr/testify/assert"
@@ -1309,6 +1311,37 @@ func TestCommandWithJsonAndResource(t *testing.T) {
 	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
 }
 
+func TestCommandGeneratePreviewWithoutCluster(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--generate-preview", "--resource", "bar", "policy"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: generate preview requires cluster`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func Test_printGeneratePreview(t *testing.T) {
+	var out bytes.Buffer
+	printGeneratePreview(&out, nil)
+	assert.Equal(t, "No changes planned\n", out.String())
+	out.Reset()
+	printGeneratePreview(&out, []generate.Change{{
+		Operation: generate.OperationCreate,
+		Policy:    "sync-secret",
+		Rule:      "sync",
+		Trigger:   kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "Namespace", Name: "prod"},
+		Target:    kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "Secret", Namespace: "prod", Name: "registry"},
+	}})
+	assert.Contains(t, out.String(), "Create")
+	assert.Contains(t, out.String(), "sync-secret")
+	assert.Contains(t, out.String(), "prod/registry")
+}
+
 func TestCommandWarnExitCode(t *testing.T) {
 	var warnExitCode = 3
 
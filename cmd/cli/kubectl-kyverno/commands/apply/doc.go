@@ -19,6 +19,10 @@ var examples = [][]string{
 		"# Apply on a cluster",
 		"kyverno apply /path/to/policy.yaml /path/to/folderOfPolicies --cluster",
 	},
+	{
+		"# Preview the resources generate rules would create, update or delete in a cluster",
+		"kyverno apply /path/to/policy.yaml --cluster --generate-preview",
+	},
 	{
 		"# Apply policies from a gitSourceURL on a cluster",
 		"kyverno apply https://github.com/kyverno/policies/openshift/ --git-branch main --cluster",
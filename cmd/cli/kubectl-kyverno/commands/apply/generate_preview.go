@@ -0,0 +1,140 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
+	"github.com/kyverno/kyverno/pkg/background/generate"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/adapters"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	"github.com/kyverno/kyverno/pkg/logging"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type generatePreviewRow struct {
+	Operation string `header:"operation"`
+	Policy    string `header:"policy"`
+	Rule      string `header:"rule"`
+	Trigger   string `header:"trigger"`
+	Target    string `header:"target"`
+	Reason    string `header:"reason"`
+}
+
+// generatePreview runs the generate rules of the loaded policies in dry-run against the cluster
+// and prints the downstream resources that would be created, updated or deleted.
+func (c *ApplyCommandConfig) generatePreview(out io.Writer) error {
+	if err := c.checkArguments(); err != nil {
+		return err
+	}
+	kpols, _, _, _, _, _, _, _, _, _, _, _, err := c.loadPolicies()
+	if err != nil {
+		return err
+	}
+	var policies []kyvernov1.PolicyInterface
+	var genericPolicies []engineapi.GenericPolicy
+	for _, policy := range kpols {
+		if policy.GetSpec().HasGenerate() {
+			policies = append(policies, policy)
+			genericPolicies = append(genericPolicies, engineapi.NewKyvernoPolicy(policy))
+		}
+	}
+	if len(policies) == 0 {
+		fmt.Fprintln(out, "No policy with generate rules found")
+		return nil
+	}
+	var s store.Store
+	dClient, err := c.initStoreAndClusterClient(&s)
+	if err != nil {
+		return err
+	}
+	resources, _, err := c.loadResources(out, c.ResourcePaths, genericPolicies, dClient)
+	if err != nil {
+		return err
+	}
+	triggers := make([]unstructured.Unstructured, 0, len(resources))
+	for _, resource := range resources {
+		triggers = append(triggers, *resource)
+	}
+	cfg := config.NewDefaultConfiguration(false)
+	jp := jmespath.New(cfg)
+	previewer := generate.NewPreviewer(dClient, engine.NewEngine(
+		cfg,
+		jp,
+		adapters.Client(dClient),
+		nil,
+		imageverifycache.DisabledImageVerifyCache(),
+		store.ContextLoaderFactory(&s, nil),
+		nil,
+		nil,
+	), cfg, jp)
+	var changes []generate.Change
+	for _, policy := range policies {
+		deployed, err := deployedPolicy(context.Background(), dClient, policy)
+		if err != nil {
+			return err
+		}
+		policyChanges, err := previewer.Preview(context.Background(), logging.WithName("generate-preview"), policy, deployed, triggers)
+		if err != nil {
+			return fmt.Errorf("failed to preview policy %s (%w)", policy.GetName(), err)
+		}
+		changes = append(changes, policyChanges...)
+	}
+	printGeneratePreview(out, changes)
+	return nil
+}
+
+// deployedPolicy returns the version of the policy currently deployed in the cluster, if any
+func deployedPolicy(ctx context.Context, client dclient.Interface, policy kyvernov1.PolicyInterface) (kyvernov1.PolicyInterface, error) {
+	kind, namespace := "ClusterPolicy", ""
+	if policy.IsNamespaced() {
+		kind, namespace = "Policy", policy.GetNamespace()
+	}
+	obj, err := client.GetResource(ctx, kyvernov1.SchemeGroupVersion.String(), kind, namespace, policy.GetName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get deployed policy %s (%w)", policy.GetName(), err)
+	}
+	var deployed kyvernov1.PolicyInterface
+	if policy.IsNamespaced() {
+		deployed = &kyvernov1.Policy{}
+	} else {
+		deployed = &kyvernov1.ClusterPolicy{}
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), deployed); err != nil {
+		return nil, err
+	}
+	return deployed, nil
+}
+
+func printGeneratePreview(out io.Writer, changes []generate.Change) {
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "No changes planned")
+		return
+	}
+	rows := make([]generatePreviewRow, 0, len(changes))
+	for _, change := range changes {
+		rows = append(rows, generatePreviewRow{
+			Operation: string(change.Operation),
+			Policy:    change.Policy,
+			Rule:      change.Rule,
+			Trigger:   change.Trigger.String(),
+			Target:    change.Target.String(),
+			Reason:    change.Reason,
+		})
+	}
+	printer := table.NewTablePrinter(out)
+	printer.Print(rows)
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/json"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/migrate"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/oci"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/replay"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/report"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/test"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/version"
 	"github.com/spf13/cobra"
@@ -34,6 +36,8 @@ func RootCommand(experimental bool) *cobra.Command {
 		jp.Command(),
 		json.Command(),
 		migrate.Command(),
+		replay.Command(),
+		report.Command(),
 		test.Command(),
 		version.Command(),
 	)
@@ -8,6 +8,7 @@ import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/create/test"
 	userinfo "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/create/user-info"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/create/values"
+	verificationbundle "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/create/verification-bundle"
 	"github.com/spf13/cobra"
 )
 
@@ -30,6 +31,7 @@ func Command() *cobra.Command {
 		userinfo.Command(),
 		values.Command(),
 		role.Command(),
+		verificationbundle.Command(),
 	)
 	return cmd
 }
@@ -23,4 +23,8 @@ var examples = [][]string{
 		"# Create values file",
 		"kyverno create values -g request.mode=dev -n prod,env=prod --rule policy,rule,env=demo --resource policy,resource,env=demo",
 	},
+	{
+		"# Create verification bundle ConfigMap",
+		"kyverno create verification-bundle -i ghcr.io/kyverno/kyverno:v1.15.0 -o bundles.yaml",
+	},
 }
@@ -0,0 +1,156 @@
+package verificationbundle
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/kyverno/kyverno/pkg/imageverification/offline"
+	"github.com/sigstore/sigstore-go/pkg/root"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// maxConfigMapSize is the maximum size of the data a ConfigMap can hold
+const maxConfigMapSize = 1024 * 1024
+
+type options struct {
+	images      []string
+	trustedRoot string
+	name        string
+	namespace   string
+	output      string
+	push        string
+}
+
+func Command() *cobra.Command {
+	var opts options
+	cmd := &cobra.Command{
+		Use:          "verification-bundle",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return opts.execute(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+	cmd.Flags().StringArrayVarP(&opts.images, "image", "i", nil, "Image to fetch the Sigstore bundles of")
+	cmd.Flags().StringVar(&opts.trustedRoot, "trusted-root", "", "Sigstore trusted root file (uses the public Sigstore trusted root if not set)")
+	cmd.Flags().StringVar(&opts.name, "name", "kyverno-verification-bundles", "ConfigMap name")
+	cmd.Flags().StringVar(&opts.namespace, "namespace", "kyverno", "ConfigMap namespace")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "Output path (uses standard console output if not set)")
+	cmd.Flags().StringVar(&opts.push, "push", "", "Push the material as an OCI artifact to the given reference instead of creating a ConfigMap")
+	return cmd
+}
+
+func (o options) execute(out io.Writer, errOut io.Writer) error {
+	if len(o.images) == 0 {
+		return fmt.Errorf("at least one image is required")
+	}
+	trustedRoot, err := o.loadTrustedRoot()
+	if err != nil {
+		return err
+	}
+	files := map[string][]byte{
+		offline.TrustedRootFile: trustedRoot,
+	}
+	remoteOpts := []remote.Option{remote.WithAuthFromKeychain(authn.DefaultKeychain)}
+	for _, image := range o.images {
+		ref, err := name.ParseReference(image)
+		if err != nil {
+			return fmt.Errorf("failed to parse image %s: %w", image, err)
+		}
+		digest, bundles, err := offline.FetchBundles(ref, remoteOpts...)
+		if err != nil {
+			return err
+		}
+		if len(bundles) == 0 {
+			return fmt.Errorf("no Sigstore bundle found for %s", image)
+		}
+		for i, b := range bundles {
+			if !b.HasInclusionProof() {
+				fmt.Fprintf(errOut, "WARNING: bundle %d of %s has no transparency log inclusion proof, it can only be verified when the transparency log check is skipped\n", i, image)
+			}
+			data, err := b.MarshalJSON()
+			if err != nil {
+				return err
+			}
+			files[offline.BundleFileName(digest, i)] = data
+		}
+	}
+	// make sure the material can be loaded by kyverno
+	if _, err := offline.Parse(files); err != nil {
+		return err
+	}
+	if o.push != "" {
+		return push(o.push, files, remoteOpts...)
+	}
+	if o.output != "" {
+		file, err := os.Create(o.output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+	return writeConfigMap(out, o.name, o.namespace, files)
+}
+
+func (o options) loadTrustedRoot() ([]byte, error) {
+	if o.trustedRoot != "" {
+		return os.ReadFile(o.trustedRoot)
+	}
+	trustedRoot, err := root.FetchTrustedRoot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the Sigstore trusted root: %w", err)
+	}
+	return trustedRoot.MarshalJSON()
+}
+
+func push(image string, files map[string][]byte, opts ...remote.Option) error {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return fmt.Errorf("failed to parse image %s: %w", image, err)
+	}
+	img, err := offline.NewImage(files)
+	if err != nil {
+		return err
+	}
+	return remote.Write(ref, img, opts...)
+}
+
+func writeConfigMap(out io.Writer, name string, namespace string, files map[string][]byte) error {
+	cm := corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Data: map[string]string{},
+	}
+	size := 0
+	for file, data := range files {
+		cm.Data[file] = string(data)
+		size += len(file) + len(data)
+	}
+	if size > maxConfigMapSize {
+		return fmt.Errorf("verification material exceeds the ConfigMap size limit (%d bytes), use --push to create an OCI artifact instead", size)
+	}
+	data, err := yaml.Marshal(cm)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(data)
+	return err
+}
@@ -0,0 +1,129 @@
+package verificationbundle
+
+import (
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/kyverno/kyverno/pkg/imageverification/offline"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const testdata = "../../../../../../pkg/imageverification/offline/testdata"
+
+func TestCommand(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommandWithArgs(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{"foo"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommandWithInvalidArg(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"foo"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: unknown command "foo" for "verification-bundle"`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandWithInvalidFlag(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--xxx"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: unknown flag: --xxx`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
+
+func TestCommandWithImage(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.WithReferrersSupport(true)))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	img, err := random.Image(1024, 1)
+	assert.NoError(t, err)
+	ref, err := name.ParseReference(host + "/app:v1")
+	assert.NoError(t, err)
+	assert.NoError(t, remote.Write(ref, img))
+	digest, err := img.Digest()
+	assert.NoError(t, err)
+	manifest, err := img.Manifest()
+	assert.NoError(t, err)
+
+	// attach the bundle to the image as an OCI referrer
+	data, err := os.ReadFile(filepath.Join(testdata, "sha256-bc103b4a84971ef6459b294a2b98568a2bfb72cded09d4acd1e16366a401f95b.0.sigstore.json"))
+	assert.NoError(t, err)
+	referrer := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	referrer = mutate.ConfigMediaType(referrer, "application/vnd.dev.sigstore.bundle.v0.3+json")
+	referrer, err = mutate.Append(referrer, mutate.Addendum{
+		Layer:     static.NewLayer(data, "application/vnd.dev.sigstore.bundle.v0.3+json"),
+		MediaType: "application/vnd.dev.sigstore.bundle.v0.3+json",
+	})
+	assert.NoError(t, err)
+	referrer = mutate.Subject(referrer, v1.Descriptor{MediaType: manifest.MediaType, Digest: digest, Size: 0}).(v1.Image)
+	referrerDigest, err := referrer.Digest()
+	assert.NoError(t, err)
+	assert.NoError(t, remote.Write(ref.Context().Digest(referrerDigest.String()), referrer))
+
+	output := filepath.Join(t.TempDir(), "bundles.yaml")
+	cmd := Command()
+	cmd.SetArgs([]string{"-i", ref.String(), "--trusted-root", filepath.Join(testdata, offline.TrustedRootFile), "-o", output, "--namespace", "default"})
+	assert.NoError(t, cmd.Execute())
+
+	out, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	var cm corev1.ConfigMap
+	assert.NoError(t, yaml.Unmarshal(out, &cm))
+	assert.Equal(t, "kyverno-verification-bundles", cm.Name)
+	assert.Equal(t, "default", cm.Namespace)
+	assert.Contains(t, cm.Data, offline.TrustedRootFile)
+	assert.Contains(t, cm.Data, offline.BundleFileName(digest, 0))
+}
@@ -0,0 +1,21 @@
+package verificationbundle
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#create`
+
+var description = []string{
+	`Create a ConfigMap or an OCI artifact holding offline image verification material.`,
+	``,
+	`The Sigstore bundles attached to the images (as OCI referrers) are fetched and stored along with a Sigstore trusted root.`,
+	`The resulting material is used by Kyverno to verify images without reaching Rekor, Fulcio or the TUF repository.`,
+}
+
+var examples = [][]string{
+	{
+		"# Create a verification bundle ConfigMap for two images",
+		"kyverno create verification-bundle -i ghcr.io/kyverno/kyverno:v1.15.0 -i ghcr.io/kyverno/background-controller:v1.15.0 -o bundles.yaml",
+	},
+	{
+		"# Create a verification bundle with a custom trusted root and push it as an OCI artifact",
+		"kyverno create verification-bundle -i registry.internal/app:v1 --trusted-root trusted_root.json --push registry.internal/kyverno/bundles:v1",
+	},
+}
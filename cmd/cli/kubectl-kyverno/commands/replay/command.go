@@ -0,0 +1,47 @@
+package replay
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/kyverno/kyverno/pkg/webhooks/recorder"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	policyPaths    []string
+	exceptionPaths []string
+	namespacePaths []string
+	uid            string
+	webhook        string
+	trace          bool
+}
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "replay [records]",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(options.policyPaths) == 0 {
+				return errors.New("at least one policy path is required")
+			}
+			if !replayable(options.webhook) {
+				return fmt.Errorf("invalid webhook %s, must be one of %s, %s, %s or %s", options.webhook, recorder.WebhookMutate, recorder.WebhookValidate, recorder.WebhookValidatingPolicies, recorder.WebhookNamespacedValidatingPolicies)
+			}
+			return options.execute(cmd.OutOrStdout(), cmd.ErrOrStderr(), args[0])
+		},
+	}
+	cmd.Flags().StringSliceVarP(&options.policyPaths, "policy", "p", nil, "Path to policies")
+	cmd.Flags().StringSliceVarP(&options.exceptionPaths, "exception", "e", nil, "Path to policy exceptions")
+	cmd.Flags().StringSliceVar(&options.namespacePaths, "namespace", nil, "Path to namespaces used to resolve namespace selectors")
+	cmd.Flags().StringVar(&options.uid, "uid", "", "Only replay the recorded request with the given uid")
+	cmd.Flags().StringVar(&options.webhook, "webhook", recorder.WebhookValidate, "Webhook used to replay raw AdmissionReviews (mutate, validate, vpol or nvpol)")
+	cmd.Flags().BoolVar(&options.trace, "trace", false, "Print a trace of the request evaluation")
+	return cmd
+}
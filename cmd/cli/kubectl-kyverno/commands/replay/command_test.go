@@ -0,0 +1,109 @@
+package replay
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommand(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"../../../../../test/cli/replay/review.yaml",
+		"--policy", "../../../../../test/cli/replay/policy.yaml",
+	})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "Replaying CREATE Pod default/nginx (uid: 4d7e4a5c-1f0b-4d3e-9a52-6c8f2f0f5b10, webhook: validate)")
+	assert.Contains(t, string(out), "Response: denied")
+	assert.Contains(t, string(out), "label `team` is required")
+}
+
+func TestCommandWithRecords(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"../../../../../test/cli/replay/records.yaml",
+		"--policy", "../../../../../test/cli/replay/policy.yaml",
+		"--uid", "0b6a7f51-3f3c-4b8e-8f0e-2f1b5b9f3a01",
+	})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `
+Replaying CREATE Pod default/labelled (uid: 0b6a7f51-3f3c-4b8e-8f0e-2f1b5b9f3a01, webhook: validate)
+  Response: allowed
+  Result: differs from the recorded response (allowed, message)`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandWithUnknownUID(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{
+		"../../../../../test/cli/replay/records.yaml",
+		"--policy", "../../../../../test/cli/replay/policy.yaml",
+		"--uid", "unknown",
+	})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "no recorded request found with uid unknown")
+}
+
+func TestCommandWithInvalidWebhook(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{
+		"../../../../../test/cli/replay/review.yaml",
+		"--policy", "../../../../../test/cli/replay/policy.yaml",
+		"--webhook", "foo",
+	})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "invalid webhook foo, must be one of mutate, validate, vpol or nvpol")
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
+
+func TestCommandWithValidatingPolicy(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"../../../../../test/cli/replay/review.yaml",
+		"--policy", "../../../../../test/cli/replay/vpol.yaml",
+		"--webhook", "vpol",
+	})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "Replaying CREATE Pod default/nginx (uid: 4d7e4a5c-1f0b-4d3e-9a52-6c8f2f0f5b10, webhook: vpol)")
+	assert.Contains(t, string(out), "Response: denied")
+	assert.Contains(t, string(out), "label team is required")
+}
@@ -0,0 +1,39 @@
+package replay
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/usage/replay/`
+
+var description = []string{
+	`Replays recorded admission requests against local policies and exceptions.`,
+	``,
+	`Denied admission requests are recorded by the admission controller when started with --maxAdmissionRecords,`,
+	`recorded requests are served at /debug/admissionrecords on a separate debug listener (--admissionRecordsAddress),`,
+	`which only listens on localhost by default and is reachable with kubectl port-forward.`,
+	``,
+	`Each request is evaluated through the same resource handlers used by the admission controller and the`,
+	`replayed response is compared with the recorded one.`,
+	`Background processing, reports and events are disabled during replay.`,
+	`Requests of the mutate, validate and validating policies webhooks are replayed, other requests are skipped.`,
+	``,
+	`Replay runs against fake clients holding the given policies, exceptions and namespaces only.`,
+	`Context entries looking up the cluster (apiCall, configMap, globalReference, imageRegistry) and CEL`,
+	`resource or http lookups don't reach the cluster, so their results may differ from the admission controller.`,
+}
+
+var examples = [][]string{
+	{
+		"# Replay recorded admission requests",
+		"kyverno replay records.json --policy /path/to/policies",
+	},
+	{
+		"# Replay a single recorded admission request with exceptions and print a trace",
+		"kyverno replay records.json --policy /path/to/policies --exception /path/to/exceptions --uid <uid> --trace",
+	},
+	{
+		"# Replay a raw AdmissionReview against the validating webhook",
+		"kyverno replay review.yaml --policy /path/to/policies --webhook validate",
+	},
+	{
+		"# Replay with namespace labels available to namespace selectors",
+		"kyverno replay records.json --policy /path/to/policies --namespace /path/to/namespaces.yaml",
+	},
+}
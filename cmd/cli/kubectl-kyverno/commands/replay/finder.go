@@ -0,0 +1,42 @@
+package replay
+
+import (
+	"github.com/kyverno/kyverno/ext/wildcard"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// requestResourceFinder resolves policy kinds offline, only the resource of the replayed request can be found
+type requestResourceFinder struct {
+	request handlers.AdmissionRequest
+}
+
+func (f requestResourceFinder) FindResources(group, version, kind, subresource string) (map[dclient.TopLevelApiDescription]metav1.APIResource, error) {
+	gvk := schema.GroupVersionKind(f.request.Kind)
+	// for subresources, kinds are matched against the parent resource
+	if f.request.SubResource != "" && !f.request.GroupVersionKind.Empty() {
+		gvk = f.request.GroupVersionKind
+	}
+	if !wildcard.Match(group, gvk.Group) || !wildcard.Match(version, gvk.Version) || !wildcard.Match(kind, gvk.Kind) {
+		return nil, nil
+	}
+	if subresource != f.request.SubResource && !(subresource == "*" && f.request.SubResource != "") {
+		return nil, nil
+	}
+	description := dclient.TopLevelApiDescription{
+		GroupVersion: schema.GroupVersion{Group: f.request.Resource.Group, Version: f.request.Resource.Version},
+		Kind:         gvk.Kind,
+		Resource:     f.request.Resource.Resource,
+		SubResource:  f.request.SubResource,
+	}
+	return map[dclient.TopLevelApiDescription]metav1.APIResource{
+		description: {
+			Name:    f.request.Resource.Resource,
+			Group:   f.request.Resource.Group,
+			Version: f.request.Resource.Version,
+			Kind:    gvk.Kind,
+		},
+	}, nil
+}
@@ -0,0 +1,433 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"github.com/julienschmidt/httprouter"
+	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/exception"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
+	"github.com/kyverno/kyverno/pkg/cel/libs"
+	"github.com/kyverno/kyverno/pkg/cel/matching"
+	vpolcompiler "github.com/kyverno/kyverno/pkg/cel/policies/vpol/compiler"
+	vpolengine "github.com/kyverno/kyverno/pkg/cel/policies/vpol/engine"
+	fakekyvernov1 "github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernoinformers "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/adapters"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/exceptions"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"github.com/kyverno/kyverno/pkg/policycache"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	"github.com/kyverno/kyverno/pkg/webhooks/recorder"
+	webhooksresource "github.com/kyverno/kyverno/pkg/webhooks/resource"
+	"github.com/kyverno/kyverno/pkg/webhooks/resource/vpol"
+	"github.com/kyverno/kyverno/pkg/webhooks/updaterequest"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/yaml"
+)
+
+func (o options) execute(out io.Writer, errOut io.Writer, path string) error {
+	records, err := loadRecords(path, o.webhook)
+	if err != nil {
+		return err
+	}
+	if o.uid != "" {
+		var filtered []recorder.Record
+		for _, record := range records {
+			if record.Review.Request != nil && record.Review.Request.UID == types.UID(o.uid) {
+				filtered = append(filtered, record)
+			}
+		}
+		if len(filtered) == 0 {
+			return fmt.Errorf("no recorded request found with uid %s", o.uid)
+		}
+		records = filtered
+	}
+	policies, err := o.loadPolicies()
+	if err != nil {
+		return err
+	}
+	namespaces, err := o.loadNamespaces()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	replayer, err := newReplayer(ctx, policies, namespaces)
+	if err != nil {
+		return err
+	}
+	logger := logr.Discard()
+	if o.trace {
+		logger = funcr.New(func(prefix, args string) {
+			if prefix != "" {
+				fmt.Fprintf(errOut, "%s: %s\n", prefix, args)
+			} else {
+				fmt.Fprintln(errOut, args)
+			}
+		}, funcr.Options{Verbosity: 6})
+	}
+	for _, record := range records {
+		if record.Review.Request == nil {
+			return fmt.Errorf("recorded admission review has no request")
+		}
+		if !replayable(record.Webhook) {
+			fmt.Fprintf(out, "Skipping %s (uid: %s, webhook: %s), replaying the requests of this webhook is not supported\n", record.Review.Request.Kind.Kind, record.Review.Request.UID, record.Webhook)
+			continue
+		}
+		response, err := replayer.replay(ctx, logger, record)
+		if err != nil {
+			return err
+		}
+		printResult(out, record, response)
+	}
+	return nil
+}
+
+// loadedPolicies holds the policies and exceptions requests are replayed against
+type loadedPolicies struct {
+	policies           []kyvernov1.PolicyInterface
+	policyExceptions   []*kyvernov2.PolicyException
+	validatingPolicies []policiesv1beta1.ValidatingPolicyLike
+	celExceptions      []*policiesv1beta1.PolicyException
+}
+
+func (o options) loadPolicies() (loadedPolicies, error) {
+	results, err := policy.Load(nil, "", o.policyPaths...)
+	if err != nil {
+		return loadedPolicies{}, fmt.Errorf("failed to load policies (%w)", err)
+	}
+	loaded := loadedPolicies{
+		policies:           results.Policies,
+		policyExceptions:   results.PolicyExceptions,
+		validatingPolicies: results.ValidatingPolicies,
+		celExceptions:      results.PolicyCELExceptions,
+	}
+	if len(o.exceptionPaths) != 0 {
+		exceptionResults, err := exception.Load(o.exceptionPaths...)
+		if err != nil {
+			return loadedPolicies{}, fmt.Errorf("failed to load exceptions (%w)", err)
+		}
+		loaded.policyExceptions = append(loaded.policyExceptions, exceptionResults.Exceptions...)
+		loaded.celExceptions = append(loaded.celExceptions, exceptionResults.CELExceptions...)
+	}
+	return loaded, nil
+}
+
+func (o options) loadNamespaces() ([]runtime.Object, error) {
+	var namespaces []runtime.Object
+	for _, path := range o.namespacePaths {
+		content, err := resource.GetFileBytes(path)
+		if err != nil {
+			return nil, err
+		}
+		resources, err := resource.GetUnstructuredResources(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load namespaces (%w)", err)
+		}
+		for _, resource := range resources {
+			if resource.GetKind() != "Namespace" {
+				continue
+			}
+			var namespace corev1.Namespace
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(resource.Object, &namespace); err != nil {
+				return nil, fmt.Errorf("failed to convert namespace %s (%w)", resource.GetName(), err)
+			}
+			namespaces = append(namespaces, &namespace)
+		}
+	}
+	return namespaces, nil
+}
+
+// loadRecords loads recorded requests, the file can hold a list of records as served by the
+// admission controller, a single record, or a raw AdmissionReview replayed against the given webhook
+func loadRecords(path string, webhook string) ([]recorder.Record, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read records (%w)", err)
+	}
+	data, err := yaml.YAMLToJSON(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse records (%w)", err)
+	}
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("[")) {
+		var records []recorder.Record
+		if err := json.Unmarshal(data, &records); err != nil {
+			return nil, fmt.Errorf("failed to parse records (%w)", err)
+		}
+		return records, nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to parse records (%w)", err)
+	}
+	if _, ok := fields["review"]; ok {
+		var record recorder.Record
+		if err := json.Unmarshal(data, &record); err != nil {
+			return nil, fmt.Errorf("failed to parse record (%w)", err)
+		}
+		return []recorder.Record{record}, nil
+	}
+	var review admissionv1.AdmissionReview
+	if err := json.Unmarshal(data, &review); err != nil {
+		return nil, fmt.Errorf("failed to parse admission review (%w)", err)
+	}
+	record := recorder.Record{
+		Webhook:       webhook,
+		FailurePolicy: "all",
+		Review:        review,
+	}
+	if review.Request != nil {
+		record.GroupVersionKind = review.Request.Kind
+	}
+	return []recorder.Record{record}, nil
+}
+
+type replayer struct {
+	kyvernoClient *fakekyvernov1.Clientset
+	kubeClient    *fake.Clientset
+	configuration config.Configuration
+	jp            jmespath.Interface
+	engine        engineapi.Engine
+	kubeInformers kubeinformers.SharedInformerFactory
+	informers     kyvernoinformers.SharedInformerFactory
+	policies      []kyvernov1.PolicyInterface
+	vpol          vpolHandler
+}
+
+// vpolHandler evaluates validating policies the same way the validating policies webhooks do
+type vpolHandler interface {
+	ValidateClustered(context.Context, logr.Logger, handlers.AdmissionRequest, string, time.Time) handlers.AdmissionResponse
+	ValidateNamespaced(context.Context, logr.Logger, handlers.AdmissionRequest, string, time.Time) handlers.AdmissionResponse
+}
+
+// newReplayer returns a replayer evaluating requests against the given policies, the clients are fakes
+// holding the policies, exceptions and namespaces only
+func newReplayer(ctx context.Context, loaded loadedPolicies, namespaces []runtime.Object) (*replayer, error) {
+	var objects []runtime.Object
+	for _, policy := range loaded.policies {
+		if object, ok := policy.(runtime.Object); ok {
+			objects = append(objects, object)
+		}
+	}
+	for _, exception := range loaded.policyExceptions {
+		objects = append(objects, exception)
+	}
+	// replayed requests never produce reports
+	reportutils.NewReportingConfig(nil)
+	kyvernoClient := fakekyvernov1.NewSimpleClientset(objects...)
+	kubeClient := fake.NewSimpleClientset(namespaces...)
+	informers := kyvernoinformers.NewSharedInformerFactory(kyvernoClient, 0)
+	kubeInformers := kubeinformers.NewSharedInformerFactory(kubeClient, 0)
+	configuration := config.NewDefaultConfiguration(false)
+	jp := jmespath.New(configuration)
+	configMapResolver, err := resolvers.NewClientBasedResolver(kubeClient)
+	if err != nil {
+		return nil, err
+	}
+	rclient, err := registryclient.New()
+	if err != nil {
+		return nil, err
+	}
+	r := &replayer{
+		kyvernoClient: kyvernoClient,
+		kubeClient:    kubeClient,
+		configuration: configuration,
+		jp:            jp,
+		kubeInformers: kubeInformers,
+		informers:     informers,
+		policies:      loaded.policies,
+		engine: engine.NewEngine(
+			configuration,
+			jp,
+			adapters.Client(dclient.NewEmptyFakeClient()),
+			factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), nil),
+			imageverifycache.DisabledImageVerifyCache(),
+			factories.DefaultContextLoaderFactory(configMapResolver),
+			exceptions.New(informers.Kyverno().V2().PolicyExceptions().Lister()),
+			nil,
+		),
+	}
+	vpolProvider, err := vpolengine.NewProvider(vpolcompiler.NewCompiler(), loaded.validatingPolicies, loaded.celExceptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load validating policies (%w)", err)
+	}
+	nsLister := kubeInformers.Core().V1().Namespaces().Lister()
+	r.vpol = vpol.New(
+		vpolengine.NewEngine(vpolProvider, func(name string) *corev1.Namespace {
+			namespace, err := nsLister.Get(name)
+			if err != nil {
+				return nil
+			}
+			return namespace
+		}, matching.NewMatcher()),
+		libs.NewFakeContextProvider(),
+		kyvernoClient,
+		false,
+		event.NewFake(),
+	)
+	// listers must be requested before the informers are started
+	nsInformer := kubeInformers.Core().V1().Namespaces().Informer()
+	cpolInformer := informers.Kyverno().V1().ClusterPolicies().Informer()
+	polInformer := informers.Kyverno().V1().Policies().Informer()
+	urInformer := informers.Kyverno().V2().UpdateRequests().Informer()
+	kubeInformers.Start(ctx.Done())
+	informers.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), nsInformer.HasSynced, cpolInformer.HasSynced, polInformer.HasSynced, urInformer.HasSynced) {
+		return nil, fmt.Errorf("failed to wait for cache sync")
+	}
+	return r, nil
+}
+
+// replay evaluates the recorded request through the resource handlers used by the admission controller
+func (r *replayer) replay(ctx context.Context, logger logr.Logger, record recorder.Record) (handlers.AdmissionResponse, error) {
+	request := record.AdmissionRequest()
+	pCache := policycache.NewCache()
+	finder := requestResourceFinder{request: request}
+	for _, policy := range r.policies {
+		key, err := cache.MetaNamespaceKeyFunc(policy)
+		if err != nil {
+			return handlers.AdmissionResponse{}, err
+		}
+		if err := pCache.Set(key, policy, finder); err != nil {
+			return handlers.AdmissionResponse{}, fmt.Errorf("failed to load policy %s (%w)", key, err)
+		}
+	}
+	resourceHandlers := webhooksresource.NewHandlers(
+		r.engine,
+		dclient.NewEmptyFakeClient(),
+		r.kyvernoClient,
+		r.configuration,
+		metrics.NewFakeMetricsConfig(),
+		pCache,
+		r.kubeInformers.Core().V1().Namespaces().Lister(),
+		r.informers.Kyverno().V2().UpdateRequests().Lister().UpdateRequests(config.KyvernoNamespace()),
+		r.informers.Kyverno().V1().ClusterPolicies(),
+		r.informers.Kyverno().V1().Policies(),
+		updaterequest.NewFake(),
+		event.NewFake(),
+		false,
+		"",
+		"",
+		r.jp,
+		1,
+		100,
+	)
+	logger = logger.WithValues("uid", request.UID, "webhook", record.Webhook)
+	switch record.Webhook {
+	case recorder.WebhookMutate:
+		return resourceHandlers.Mutate(ctx, logger, request, record.FailurePolicy, time.Now()), nil
+	case recorder.WebhookValidate:
+		return resourceHandlers.Validate(ctx, logger, request, record.FailurePolicy, time.Now()), nil
+	case recorder.WebhookValidatingPolicies:
+		return r.vpol.ValidateClustered(withPolicies(ctx, record.Policies), logger, request, record.FailurePolicy, time.Now()), nil
+	case recorder.WebhookNamespacedValidatingPolicies:
+		return r.vpol.ValidateNamespaced(withPolicies(ctx, record.Policies), logger, request, record.FailurePolicy, time.Now()), nil
+	}
+	return handlers.AdmissionResponse{}, fmt.Errorf("unsupported webhook %s", record.Webhook)
+}
+
+// replayable returns true when the requests of a webhook can be replayed
+func replayable(webhook string) bool {
+	switch webhook {
+	case recorder.WebhookMutate, recorder.WebhookValidate, recorder.WebhookValidatingPolicies, recorder.WebhookNamespacedValidatingPolicies:
+		return true
+	}
+	return false
+}
+
+// withPolicies sets the policies of the recorded webhook path the same way the webhook router does,
+// all the policies are evaluated when there are none
+func withPolicies(ctx context.Context, policies []string) context.Context {
+	if len(policies) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, httprouter.ParamsKey, httprouter.Params{{Key: "policies", Value: "/" + strings.Join(policies, "/")}})
+}
+
+func printResult(out io.Writer, record recorder.Record, response handlers.AdmissionResponse) {
+	request := record.Review.Request
+	name := request.Name
+	if request.Namespace != "" {
+		name = request.Namespace + "/" + name
+	}
+	fmt.Fprintf(out, "Replaying %s %s %s (uid: %s, webhook: %s)\n", request.Operation, request.Kind.Kind, name, request.UID, record.Webhook)
+	if response.Allowed {
+		fmt.Fprintln(out, "  Response: allowed")
+	} else {
+		fmt.Fprintln(out, "  Response: denied")
+	}
+	if message := strings.TrimSpace(resultMessage(response)); message != "" {
+		fmt.Fprintf(out, "  Message: %s\n", message)
+	}
+	for _, warning := range response.Warnings {
+		fmt.Fprintf(out, "  Warning: %s\n", warning)
+	}
+	if len(response.Patch) != 0 {
+		fmt.Fprintf(out, "  Patch: %s\n", string(response.Patch))
+	}
+	if record.Review.Response == nil {
+		return
+	}
+	if diffs := compareResponses(*record.Review.Response, response); len(diffs) != 0 {
+		fmt.Fprintf(out, "  Result: differs from the recorded response (%s)\n", strings.Join(diffs, ", "))
+	} else {
+		fmt.Fprintln(out, "  Result: matches the recorded response")
+	}
+}
+
+func compareResponses(recorded, replayed handlers.AdmissionResponse) []string {
+	var diffs []string
+	if recorded.Allowed != replayed.Allowed {
+		diffs = append(diffs, "allowed")
+	}
+	if resultMessage(recorded) != resultMessage(replayed) {
+		diffs = append(diffs, "message")
+	}
+	if !reflect.DeepEqual(recorded.Warnings, replayed.Warnings) {
+		diffs = append(diffs, "warnings")
+	}
+	if !bytes.Equal(recorded.Patch, replayed.Patch) {
+		diffs = append(diffs, "patch")
+	}
+	return diffs
+}
+
+func resultMessage(response handlers.AdmissionResponse) string {
+	if response.Result == nil {
+		return ""
+	}
+	return response.Result.Message
+}
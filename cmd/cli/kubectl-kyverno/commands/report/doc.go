@@ -0,0 +1,14 @@
+package report
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#report`
+
+var description = []string{
+	`Inspect Kyverno policy reports.`,
+}
+
+var examples = [][]string{
+	{
+		"# Show the policy report history of a cluster",
+		"kyverno report history",
+	},
+}
@@ -0,0 +1,146 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/pkg/config"
+	reporthistory "github.com/kyverno/kyverno/pkg/controllers/report/history"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+type options struct {
+	KubeConfig       string
+	Context          string
+	KyvernoNamespace string
+	Policy           string
+	Namespace        string
+	Severity         string
+	Since            time.Duration
+	Output           string
+}
+
+type row struct {
+	Time      string `header:"time"`
+	Policy    string `header:"policy"`
+	Namespace string `header:"namespace"`
+	Severity  string `header:"severity"`
+	Pass      int    `header:"pass,text"`
+	Fail      int    `header:"fail,text"`
+	Warn      int    `header:"warn,text"`
+	Error     int    `header:"error,text"`
+	Skip      int    `header:"skip,text"`
+}
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "history",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+			clientConfig, err := config.CreateClientConfigWithContext(options.KubeConfig, options.Context)
+			if err != nil {
+				return err
+			}
+			kubeClient, err := kubernetes.NewForConfig(clientConfig)
+			if err != nil {
+				return err
+			}
+			store := reporthistory.NewConfigMapStore(
+				kubeClient.CoreV1().ConfigMaps(options.KyvernoNamespace),
+				reporthistory.ConfigMapName,
+				reporthistory.Retention{},
+			)
+			snapshots, err := store.List(context.Background())
+			if err != nil {
+				return err
+			}
+			return options.print(cmd.OutOrStdout(), snapshots, time.Now())
+		},
+	}
+	cmd.Flags().StringVar(&options.KubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVar(&options.Context, "context", "", "The name of the kubeconfig context to use")
+	cmd.Flags().StringVar(&options.KyvernoNamespace, "kyverno-namespace", "kyverno", "The namespace Kyverno is installed in")
+	cmd.Flags().StringVar(&options.Policy, "policy", "", "Only show the history of this policy")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "Only show the history of this namespace")
+	cmd.Flags().StringVar(&options.Severity, "severity", "", "Only show the history of this severity")
+	cmd.Flags().DurationVar(&options.Since, "since", 0, "Only show the snapshots recorded within this duration")
+	cmd.Flags().StringVarP(&options.Output, "output", "o", "table", "Output format (table or json)")
+	return cmd
+}
+
+func (o options) validate() error {
+	switch o.Output {
+	case "table", "json":
+		return nil
+	default:
+		return fmt.Errorf("invalid output format %q, must be one of table, json", o.Output)
+	}
+}
+
+func (o options) filter(snapshots []reporthistory.Snapshot, now time.Time) []reporthistory.Snapshot {
+	var out []reporthistory.Snapshot
+	for _, snapshot := range snapshots {
+		if o.Since > 0 && now.Sub(snapshot.Time.Time) > o.Since {
+			continue
+		}
+		entries := snapshot.Filter(o.Policy, o.Namespace, o.Severity)
+		if len(entries) == 0 {
+			continue
+		}
+		out = append(out, reporthistory.Snapshot{
+			Time:      snapshot.Time,
+			Entries:   entries,
+			Truncated: snapshot.Truncated,
+		})
+	}
+	return out
+}
+
+func (o options) print(out io.Writer, snapshots []reporthistory.Snapshot, now time.Time) error {
+	snapshots = o.filter(snapshots, now)
+	if o.Output == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if snapshots == nil {
+			snapshots = []reporthistory.Snapshot{}
+		}
+		return encoder.Encode(snapshots)
+	}
+	if len(snapshots) == 0 {
+		fmt.Fprintln(out, "No report history found")
+		return nil
+	}
+	var rows []row
+	for _, snapshot := range snapshots {
+		for _, entry := range snapshot.Entries {
+			rows = append(rows, row{
+				Time:      snapshot.Time.UTC().Format(time.RFC3339),
+				Policy:    entry.Policy,
+				Namespace: entry.Namespace,
+				Severity:  entry.Severity,
+				Pass:      entry.Pass,
+				Fail:      entry.Fail,
+				Warn:      entry.Warn,
+				Error:     entry.Error,
+				Skip:      entry.Skip,
+			})
+		}
+	}
+	printer := table.NewTablePrinter(out)
+	printer.Print(rows)
+	return nil
+}
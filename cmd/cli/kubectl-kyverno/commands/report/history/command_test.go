@@ -0,0 +1,79 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	reporthistory "github.com/kyverno/kyverno/pkg/controllers/report/history"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCommand(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{"--output", "yaml"})
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, `invalid output format "yaml"`)
+}
+
+func TestCommandWithArgs(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{"foo"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(b.String(), cmd.Long))
+}
+
+func Test_options_print(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	snapshots := []reporthistory.Snapshot{{
+		Time: metav1.NewTime(now.Add(-48 * time.Hour)),
+		Entries: []reporthistory.Entry{
+			{Policy: "require-labels", Namespace: "team-a", Severity: "medium", Pass: 1, Fail: 3},
+			{Policy: "disallow-latest", Namespace: "team-b", Warn: 2},
+		},
+	}, {
+		Time: metav1.NewTime(now.Add(-time.Hour)),
+		Entries: []reporthistory.Entry{
+			{Policy: "require-labels", Namespace: "team-a", Severity: "medium", Pass: 3, Fail: 1},
+			{Policy: "disallow-latest", Namespace: "team-b", Warn: 1},
+		},
+	}}
+
+	var out bytes.Buffer
+	opts := options{Policy: "require-labels", Output: "json"}
+	assert.NoError(t, opts.print(&out, snapshots, now))
+	var got []reporthistory.Snapshot
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &got))
+	assert.Len(t, got, 2)
+	assert.Equal(t, 3, got[0].Entries[0].Fail)
+	assert.Equal(t, 1, got[1].Entries[0].Fail)
+
+	out.Reset()
+	opts = options{Namespace: "team-b", Since: 24 * time.Hour, Output: "table"}
+	assert.NoError(t, opts.print(&out, snapshots, now))
+	assert.Contains(t, out.String(), "2025-01-09T23:00:00Z")
+	assert.Contains(t, out.String(), "disallow-latest")
+	assert.NotContains(t, out.String(), "require-labels")
+	assert.NotContains(t, out.String(), "2025-01-08")
+
+	out.Reset()
+	opts = options{Severity: "critical", Output: "table"}
+	assert.NoError(t, opts.print(&out, snapshots, now))
+	assert.Equal(t, "No report history found\n", out.String())
+}
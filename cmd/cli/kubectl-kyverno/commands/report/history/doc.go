@@ -0,0 +1,25 @@
+package history
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#report`
+
+var description = []string{
+	`Show the policy report history recorded by the reports controller.`,
+	``,
+	`The reports controller periodically records the result counts of the policy reports per policy, namespace and severity when the report history is enabled.`,
+	`This command reads the recorded snapshots from the cluster and prints them, oldest first.`,
+}
+
+var examples = [][]string{
+	{
+		"# Show the report history",
+		"kyverno report history",
+	},
+	{
+		"# Show the report history of a policy in a namespace over the last week",
+		"kyverno report history --policy require-labels --namespace team-a --since 168h",
+	},
+	{
+		"# Show the report history as JSON",
+		"kyverno report history --output json",
+	},
+}
@@ -53,10 +53,19 @@ var (
 	tufMirror  string
 	tufRoot    string
 	tufRootRaw string
+	// offline verification
+	verificationBundlesConfigMap string
+	verificationBundlesImage     string
+	verificationBundlesRefresh   time.Duration
+	// notation
+	notationTrustPolicyConfigMap string
+	notationTrustStoreSecret     string
+	notationPluginDirectory      string
 	// registry client
 	imagePullSecrets          string
 	allowInsecureRegistry     bool
 	registryCredentialHelpers string
+	registryMirrors           string
 	// leader election
 	leaderElectionRetryPeriod time.Duration
 	// cleanupServer port and host for listening address
@@ -66,6 +75,14 @@ var (
 	imageVerifyCacheEnabled     bool
 	imageVerifyCacheTTLDuration time.Duration
 	imageVerifyCacheMaxSize     int64
+	// image verify cache shared tier
+	imageVerifyCacheShared          bool
+	imageVerifyCacheSharedConfigMap string
+	imageVerifyCacheSharedSecret    string
+	// image verification results
+	imageVerificationResults          bool
+	imageVerificationResultsRetention time.Duration
+	imageVerificationResultsSecret    string
 	// global context
 	enableGlobalContext bool
 	// reporting
@@ -150,18 +167,31 @@ func initCosignFlags() {
 	flag.StringVar(&tufMirror, "tufMirror", tuf.DefaultRemoteRoot, "Alternate TUF mirror for sigstore. If left blank, public sigstore one is used for cosign verification.")
 	flag.StringVar(&tufRoot, "tufRoot", "", "Path to alternate TUF root.json for sigstore (url or env). If left blank, public sigstore one is used for cosign verification.")
 	flag.StringVar(&tufRootRaw, "tufRootRaw", "", "The raw body of alternate TUF root.json for sigstore. If left blank, public sigstore one is used for cosign verification.")
+	flag.StringVar(&verificationBundlesConfigMap, "verificationBundlesConfigMap", "", "ConfigMap holding the offline verification material (trusted root and Sigstore bundles), images are verified against it instead of Rekor, Fulcio and TUF when set, except for the rules with certificate attestors, annotations or custom Rekor, CT log or roots settings.")
+	flag.StringVar(&verificationBundlesImage, "verificationBundlesImage", "", "OCI artifact holding the offline verification material (trusted root and Sigstore bundles), images are verified against it instead of Rekor, Fulcio and TUF when set, except for the rules with certificate attestors, annotations or custom Rekor, CT log or roots settings.")
+	flag.DurationVar(&verificationBundlesRefresh, "verificationBundlesRefresh", 10*time.Minute, "Interval after which the offline verification material OCI artifact is pulled again.")
+	flag.StringVar(&notationTrustPolicyConfigMap, "notationTrustPolicyConfigMap", "", "ConfigMap holding the Notation trust policy document (trustpolicy.json) and trust stores referenced by the trustPolicy of notary attestors.")
+	flag.StringVar(&notationTrustStoreSecret, "notationTrustStoreSecret", "", "Secret holding additional Notation trust stores referenced by the trust policy document.")
+	flag.StringVar(&notationPluginDirectory, "notationPluginDirectory", "", "Directory the Notation verification plugins are loaded from, each plugin is installed as <directory>/<name>/notation-<name>.")
 }
 
 func initRegistryClientFlags() {
 	flag.BoolVar(&allowInsecureRegistry, "allowInsecureRegistry", false, "Whether to allow insecure connections to registries. Don't use this for anything but testing.")
 	flag.StringVar(&imagePullSecrets, "imagePullSecrets", "", "Secret resource names for image registry access credentials.")
 	flag.StringVar(&registryCredentialHelpers, "registryCredentialHelpers", "", "Credential helpers to enable (default,google,amazon,azure,github). No helpers are added when this flag is empty.")
+	flag.StringVar(&registryMirrors, "registryMirrors", "", "Registry mirrors (YAML or JSON list), the endpoints of a registry mirror are tried in order before the registry itself.")
 }
 
 func initImageVerifyCacheFlags() {
 	flag.BoolVar(&imageVerifyCacheEnabled, "imageVerifyCacheEnabled", true, "Enable a TTL cache for verified images.")
 	flag.Int64Var(&imageVerifyCacheMaxSize, "imageVerifyCacheMaxSize", 1000, "Maximum number of keys that can be stored in the TTL cache. Keys are a combination of policy elements along with the image reference. Default is 1000. 0 sets the value to default.")
 	flag.DurationVar(&imageVerifyCacheTTLDuration, "imageVerifyCacheTTLDuration", 60*time.Minute, "Maximum TTL value for a cache expressed as duration. Default is 60m. 0 sets the value to default.")
+	flag.BoolVar(&imageVerifyCacheShared, "imageVerifyCacheShared", false, "Enable a second image verify cache tier stored in a ConfigMap and shared between the replicas, only the images with a known digest are shared.")
+	flag.StringVar(&imageVerifyCacheSharedConfigMap, "imageVerifyCacheSharedConfigMap", "kyverno-image-verify-cache", "Name of the ConfigMap storing the shared image verify cache entries.")
+	flag.StringVar(&imageVerifyCacheSharedSecret, "imageVerifyCacheSharedSecret", "kyverno-image-verify-cache-key", "Name of the Secret storing the key signing the shared image verify cache entries, it is created if it doesn't exist.")
+	flag.BoolVar(&imageVerificationResults, "imageVerificationResults", false, "Publish the image verification results per digest as ImageVerificationResult objects, passed verifications are reused by the other replicas and background scans.")
+	flag.DurationVar(&imageVerificationResultsRetention, "imageVerificationResultsRetention", 168*time.Hour, "Maximum age of the published image verification results, older results are ignored and removed (0 means no age limit).")
+	flag.StringVar(&imageVerificationResultsSecret, "imageVerificationResultsSecret", "kyverno-image-verification-results-key", "Name of the Secret storing the key signing the published image verification results, it is created if it doesn't exist.")
 }
 
 func initLeaderElectionFlags() {
@@ -0,0 +1,45 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	"github.com/kyverno/kyverno/pkg/config"
+	imageverificationresultcontroller "github.com/kyverno/kyverno/pkg/controllers/imageverificationresult"
+	"github.com/kyverno/kyverno/pkg/imageverification/results"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NewImageVerificationResultsController returns the controller publishing the image verification results,
+// nil when the feature is disabled
+func NewImageVerificationResultsController(
+	ctx context.Context,
+	logger logr.Logger,
+	kyvernoInformer kyvernoinformer.SharedInformerFactory,
+	kyvernoClient versioned.Interface,
+	kubeClient kubernetes.Interface,
+) Controller {
+	logger = logger.WithName("image-verification-results").WithValues("enabled", imageVerificationResults, "retention", imageVerificationResultsRetention, "secret", imageVerificationResultsSecret)
+	logger.V(2).Info("setup image verification results...")
+	if !imageVerificationResults {
+		return nil
+	}
+	// the published results are signed so that only the results published by kyverno are reused
+	signingKey, err := imageverifycache.LoadOrCreateSigningKey(ctx, kubeClient.CoreV1().Secrets(config.KyvernoNamespace()), imageVerificationResultsSecret)
+	checkError(logger, err, "failed to load image verification results signing key")
+	controller := imageverificationresultcontroller.NewController(
+		kyvernoClient,
+		kyvernoInformer.Kyverno().V2alpha1().ImageVerificationResults(),
+		imageVerificationResultsRetention,
+		signingKey,
+	)
+	results.SetStore(controller)
+	return NewController(
+		imageverificationresultcontroller.ControllerName,
+		controller,
+		imageverificationresultcontroller.Workers,
+	)
+}
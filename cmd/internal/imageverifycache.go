@@ -1,12 +1,18 @@
 package internal
 
 import (
+	"context"
+	"errors"
+
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	"github.com/kyverno/kyverno/pkg/informers"
+	"k8s.io/client-go/kubernetes"
 )
 
-func setupImageVerifyCache(logger logr.Logger) imageverifycache.Client {
-	logger = logger.WithName("image-verify-cache").WithValues("enabled", imageVerifyCacheEnabled, "maxsize", imageVerifyCacheMaxSize, "ttl", imageVerifyCacheTTLDuration)
+func setupImageVerifyCache(ctx context.Context, logger logr.Logger, client kubernetes.Interface) imageverifycache.Client {
+	logger = logger.WithName("image-verify-cache").WithValues("enabled", imageVerifyCacheEnabled, "maxsize", imageVerifyCacheMaxSize, "ttl", imageVerifyCacheTTLDuration, "shared", imageVerifyCacheShared)
 	logger.V(2).Info("setup image verify cache...")
 	opts := []imageverifycache.Option{
 		imageverifycache.WithLogger(logger),
@@ -14,7 +20,29 @@ func setupImageVerifyCache(logger logr.Logger) imageverifycache.Client {
 		imageverifycache.WithMaxSize(imageVerifyCacheMaxSize),
 		imageverifycache.WithTTLDuration(imageVerifyCacheTTLDuration),
 	}
+	if imageVerifyCacheEnabled && imageVerifyCacheShared {
+		opts = append(opts, imageverifycache.WithSharedStore(setupImageVerifyCacheStore(ctx, logger, client)))
+	}
 	imageVerifyCache, err := imageverifycache.New(opts...)
 	checkError(logger, err, "failed to create image verify cache client")
 	return imageVerifyCache
 }
+
+func setupImageVerifyCacheStore(ctx context.Context, logger logr.Logger, client kubernetes.Interface) imageverifycache.Store {
+	logger = logger.WithValues("configmap", imageVerifyCacheSharedConfigMap, "secret", imageVerifyCacheSharedSecret)
+	logger.V(2).Info("setup shared image verify cache tier...")
+	signingKey, err := imageverifycache.LoadOrCreateSigningKey(ctx, client.CoreV1().Secrets(config.KyvernoNamespace()), imageVerifyCacheSharedSecret)
+	checkError(logger, err, "failed to load image verify cache signing key")
+	informer := informers.NewConfigMapInformer(client, config.KyvernoNamespace(), imageVerifyCacheSharedConfigMap, resyncPeriod)
+	if !informers.StartInformersAndWaitForCacheSync(ctx, logger, informer) {
+		checkError(logger, errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+	}
+	return imageverifycache.NewConfigMapStore(
+		logger,
+		client.CoreV1().ConfigMaps(config.KyvernoNamespace()),
+		informer.Lister().ConfigMaps(config.KyvernoNamespace()),
+		imageVerifyCacheSharedConfigMap,
+		signingKey,
+		int(imageVerifyCacheMaxSize),
+	)
+}
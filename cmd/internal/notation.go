@@ -0,0 +1,46 @@
+package internal
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/informers"
+	"github.com/kyverno/kyverno/pkg/notary"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+func setupNotation(ctx context.Context, logger logr.Logger, client kubernetes.Interface) {
+	if notationPluginDirectory != "" {
+		logger.V(2).Info("setup notation plugins...", "directory", notationPluginDirectory)
+		notary.SetPluginDirectory(notationPluginDirectory)
+	}
+	if notationTrustPolicyConfigMap == "" {
+		if notationTrustStoreSecret != "" {
+			checkError(logger, errors.New("a trust policy config map is required"), "invalid notation trust policy flags")
+		}
+		return
+	}
+	logger = logger.WithName("notation").WithValues("configmap", notationTrustPolicyConfigMap, "secret", notationTrustStoreSecret)
+	logger.V(2).Info("setup notation trust policy...")
+	configMapInformer := informers.NewConfigMapInformer(client, config.KyvernoNamespace(), notationTrustPolicyConfigMap, resyncPeriod)
+	if !informers.StartInformersAndWaitForCacheSync(ctx, logger, configMapInformer) {
+		checkError(logger, errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+	}
+	var secrets corev1listers.SecretNamespaceLister
+	if notationTrustStoreSecret != "" {
+		secretInformer := informers.NewSecretInformer(client, config.KyvernoNamespace(), notationTrustStoreSecret, resyncPeriod)
+		if !informers.StartInformersAndWaitForCacheSync(ctx, logger, secretInformer) {
+			checkError(logger, errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+		}
+		secrets = secretInformer.Lister().Secrets(config.KyvernoNamespace())
+	}
+	notary.SetTrustConfigSource(notary.NewKubernetesTrustConfigSource(
+		configMapInformer.Lister().ConfigMaps(config.KyvernoNamespace()),
+		notationTrustPolicyConfigMap,
+		secrets,
+		notationTrustStoreSecret,
+	))
+}
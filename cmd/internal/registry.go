@@ -35,6 +35,11 @@ func setupRegistryClient(ctx context.Context, logger logr.Logger, client kuberne
 	if len(registryCredentialHelpers) > 0 {
 		registryOptions = append(registryOptions, registryclient.WithCredentialProviders(strings.Split(registryCredentialHelpers, ",")...))
 	}
+	if registryMirrors != "" {
+		mirrors, err := registryclient.ParseMirrors(registryMirrors)
+		checkError(logger, err, "failed to parse registry mirrors")
+		registryOptions = append(registryOptions, registryclient.WithMirrors(secretLister, config.KyvernoNamespace(), mirrors...))
+	}
 	registryClient, err := registryclient.New(registryOptions...)
 	checkError(logger, err, "failed to create registry client")
 	return registryClient, secretLister
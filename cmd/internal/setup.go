@@ -80,10 +80,12 @@ func Setup(config Configuration, name string, skipResourceFilters bool) (context
 	}
 	var imageVerifyCache imageverifycache.Client
 	if config.UsesImageVerifyCache() {
-		imageVerifyCache = setupImageVerifyCache(logger)
+		imageVerifyCache = setupImageVerifyCache(ctx, logger, client)
 	}
 	if config.UsesCosign() {
 		setupSigstoreTUF(ctx, logger)
+		setupVerificationBundles(ctx, logger, client, registryClient)
+		setupNotation(ctx, logger, client)
 	}
 	var leaderElectionClient kubeclient.UpstreamInterface
 	if config.UsesLeaderElection() {
@@ -0,0 +1,39 @@
+package internal
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/imageverification/offline"
+	"github.com/kyverno/kyverno/pkg/informers"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	"k8s.io/client-go/kubernetes"
+)
+
+func setupVerificationBundles(ctx context.Context, logger logr.Logger, client kubernetes.Interface, registryClient registryclient.Client) {
+	if verificationBundlesConfigMap == "" && verificationBundlesImage == "" {
+		return
+	}
+	logger = logger.WithName("verification-bundles").WithValues("configmap", verificationBundlesConfigMap, "image", verificationBundlesImage)
+	logger.V(2).Info("setup offline verification material...")
+	if verificationBundlesConfigMap != "" && verificationBundlesImage != "" {
+		checkError(logger, errors.New("only one source can be configured"), "invalid offline verification material flags")
+	}
+	if verificationBundlesConfigMap != "" {
+		informer := informers.NewConfigMapInformer(client, config.KyvernoNamespace(), verificationBundlesConfigMap, resyncPeriod)
+		if !informers.StartInformersAndWaitForCacheSync(ctx, logger, informer) {
+			checkError(logger, errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+		}
+		offline.SetSource(offline.NewConfigMapSource(informer.Lister().ConfigMaps(config.KyvernoNamespace()), verificationBundlesConfigMap))
+		return
+	}
+	if registryClient == nil {
+		checkError(logger, errors.New("registry client is not configured"), "failed to setup offline verification material")
+	}
+	ref, err := name.ParseReference(verificationBundlesImage, registryClient.NameOptions()...)
+	checkError(logger, err, "failed to parse offline verification material image")
+	offline.SetSource(offline.NewImageSource(ref, registryClient.Options, verificationBundlesRefresh))
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/kyverno/kyverno/cmd/internal"
 	"github.com/kyverno/kyverno/pkg/admissionpolicy"
 	"github.com/kyverno/kyverno/pkg/auth/checker"
+	"github.com/kyverno/kyverno/pkg/background/inmemory"
 	"github.com/kyverno/kyverno/pkg/breaker"
 	"github.com/kyverno/kyverno/pkg/cel/libs"
 	"github.com/kyverno/kyverno/pkg/cel/matching"
@@ -55,6 +56,7 @@ import (
 	webhooksexception "github.com/kyverno/kyverno/pkg/webhooks/exception"
 	webhooksglobalcontext "github.com/kyverno/kyverno/pkg/webhooks/globalcontext"
 	webhookspolicy "github.com/kyverno/kyverno/pkg/webhooks/policy"
+	"github.com/kyverno/kyverno/pkg/webhooks/recorder"
 	webhooksresource "github.com/kyverno/kyverno/pkg/webhooks/resource"
 	"github.com/kyverno/kyverno/pkg/webhooks/resource/gpol"
 	"github.com/kyverno/kyverno/pkg/webhooks/resource/ivpol"
@@ -74,6 +76,7 @@ import (
 	corev1informers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/restmapper"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	kyamlopenapi "sigs.k8s.io/kustomize/kyaml/openapi"
@@ -352,12 +355,18 @@ func main() {
 		maxAdmissionReports             int
 		controllerRuntimeMetricsAddress string
 		tlsKeyAlgorithm                 string
+		maxAdmissionRecords             int
+		admissionRecordsAddress         string
+		admissionRecordsRedactedKinds   string
+		updateRequestQueue              string
+		updateRequestQueueAddress       string
+		updateRequestQueueCASecretName  string
 	)
 	flagset := flag.NewFlagSet("kyverno", flag.ExitOnError)
 	flagset.BoolVar(&dumpPayload, "dumpPayload", false, "Set this flag to activate/deactivate debug mode.")
 	flagset.IntVar(&webhookTimeout, "webhookTimeout", webhookcontroller.DefaultWebhookTimeout, "Timeout for webhook configurations (number of seconds, integer).")
 	flagset.IntVar(&maxQueuedEvents, "maxQueuedEvents", 1000, "Maximum events to be queued.")
-	flagset.StringVar(&omitEvents, "omitEvents", "", "Set this flag to a comma sperated list of PolicyViolation, PolicyApplied, PolicyError, PolicySkipped to disable events, e.g. --omitEvents=PolicyApplied,PolicyViolation")
+	flagset.StringVar(&omitEvents, "omitEvents", "", "Set this flag to a comma sperated list of PolicyViolation, PolicyApplied, PolicyError, PolicySkipped, PolicyShadowViolation to disable events, e.g. --omitEvents=PolicyApplied,PolicyViolation")
 	flagset.StringVar(&serverIP, "serverIP", "", "IP address where Kyverno controller runs. Only required if out-of-cluster.")
 	flagset.BoolVar(&autoUpdateWebhooks, "autoUpdateWebhooks", true, "Set this flag to 'false' to disable auto-configuration of the webhook.")
 	flagset.DurationVar(&webhookRegistrationTimeout, "webhookRegistrationTimeout", 120*time.Second, "Timeout for webhook registration, e.g., 30s, 1m, 5m.")
@@ -382,6 +391,12 @@ func main() {
 	flagset.IntVar(&maxAdmissionReports, "maxAdmissionReports", 10000, "Maximum number of admission reports before we stop creating new ones")
 	flagset.StringVar(&controllerRuntimeMetricsAddress, "controllerRuntimeMetricsAddress", "", `Bind address for controller-runtime metrics server. It will be defaulted to ":8080" if unspecified. Set this to "0" to disable the metrics server.`)
 	flagset.StringVar(&tlsKeyAlgorithm, "tlsKeyAlgorithm", "RSA", "Key algorithm for self-signed TLS certificates (RSA, ECDSA, Ed25519)")
+	flagset.StringVar(&updateRequestQueue, "updateRequestQueue", inmemory.ModeCRD, "Set to memory to hand the update requests over to the background controller in memory, or crd to persist them as UpdateRequest resources.")
+	flagset.StringVar(&updateRequestQueueAddress, "updateRequestQueueAddress", "", "Address of the background controller accepting the update requests handed over in memory, e.g. https://kyverno-background-controller-update-requests.kyverno.svc:9444.")
+	flagset.StringVar(&updateRequestQueueCASecretName, "updateRequestQueueCASecretName", "", "Name of the secret containing the CA the background controller update requests server certificate is signed with.")
+	flagset.IntVar(&maxAdmissionRecords, "maxAdmissionRecords", 0, "Maximum number of denied admission requests recorded for replay, recorded requests are served at "+config.AdmissionRecordsServicePath+" on the admission records address. Set to 0 to disable recording.")
+	flagset.StringVar(&admissionRecordsAddress, "admissionRecordsAddress", "127.0.0.1:6061", "Address of the debug listener serving the recorded admission requests, it only listens on localhost by default and is reachable with kubectl port-forward.")
+	flagset.StringVar(&admissionRecordsRedactedKinds, "admissionRecordsRedactedKinds", "Secret", "Comma separated list of kinds whose payloads are redacted before being recorded, as Kind for the core group or group/Kind, e.g. Secret,ConfigMap. The data of Secrets and the string values of other kinds are masked.")
 	// config
 	appConfig := internal.NewConfiguration(
 		internal.WithProfiling(),
@@ -412,6 +427,18 @@ func main() {
 		// setup
 		signalCtx, setup, sdown := internal.Setup(appConfig, "kyverno-admission-controller", false)
 		defer sdown()
+		if err := inmemory.ValidateMode(updateRequestQueue); err != nil {
+			setup.Logger.Error(err, "failed to parse update request queue mode")
+			os.Exit(1)
+		}
+		if updateRequestQueue == inmemory.ModeMemory && (updateRequestQueueAddress == "" || updateRequestQueueCASecretName == "") {
+			setup.Logger.Error(errors.New("exiting... updateRequestQueueAddress and updateRequestQueueCASecretName are required when updateRequestQueue is memory"), "exiting... updateRequestQueueAddress and updateRequestQueueCASecretName are required when updateRequestQueue is memory")
+			os.Exit(1)
+		}
+		if updateRequestQueue == inmemory.ModeMemory && !strings.HasPrefix(updateRequestQueueAddress, "https://") {
+			setup.Logger.Error(errors.New("exiting... updateRequestQueueAddress must be an https address"), "exiting... updateRequestQueueAddress must be an https address")
+			os.Exit(1)
+		}
 		if caSecretName == "" {
 			setup.Logger.Error(errors.New("exiting... caSecretName is a required flag"), "exiting... caSecretName is a required flag")
 			os.Exit(1)
@@ -492,6 +519,7 @@ func main() {
 				setup.KyvernoClient,
 				gcstore,
 				eventGenerator,
+				nil,
 				maxAPICallResponseLength,
 				apiCallTimeout,
 				true,
@@ -500,6 +528,7 @@ func main() {
 			globalcontextcontroller.Workers,
 		)
 		polexCache, polexController := internal.NewExceptionSelector(setup.Logger, kyvernoInformer)
+		ivrController := internal.NewImageVerificationResultsController(signalCtx, setup.Logger, kyvernoInformer, setup.KyvernoClient, setup.KubeClient)
 		eventController := internal.NewController(
 			event.ControllerName,
 			eventGenerator,
@@ -635,11 +664,33 @@ func main() {
 		}
 		urGenerator := generator.NewUpdateRequestGenerator(setup.Configuration, setup.MetadataClient)
 		// create webhooks server
-		urgen := webhookgenerate.NewGenerator(
-			setup.KyvernoClient,
-			kyvernoInformer.Kyverno().V2().UpdateRequests(),
-			urGenerator,
-		)
+		var urgen webhookgenerate.Generator
+		if updateRequestQueue == inmemory.ModeMemory {
+			token, err := inmemory.TokenFromEnv()
+			if err != nil {
+				setup.Logger.Error(err, "failed to read update request queue token")
+				os.Exit(1)
+			}
+			urCASecret := informers.NewSecretInformer(setup.KubeClient, config.KyvernoNamespace(), updateRequestQueueCASecretName, setup.ResyncPeriod)
+			if !informers.StartInformersAndWaitForCacheSync(signalCtx, setup.Logger, urCASecret) {
+				setup.Logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+				os.Exit(1)
+			}
+			urgen = webhookgenerate.NewInMemoryGenerator(
+				inmemory.NewClient(updateRequestQueueAddress, token, func() ([]byte, error) {
+					return tls.ReadRootCASecret(updateRequestQueueCASecretName, config.KyvernoNamespace(), urCASecret.Lister().Secrets(config.KyvernoNamespace()))
+				}),
+				setup.KyvernoClient,
+				kyvernoInformer.Kyverno().V2().UpdateRequests(),
+				urGenerator,
+			)
+		} else {
+			urgen = webhookgenerate.NewGenerator(
+				setup.KyvernoClient,
+				kyvernoInformer.Kyverno().V2().UpdateRequests(),
+				urGenerator,
+			)
+		}
 		policyHandlers := webhookspolicy.NewHandlers(
 			setup.KyvernoDynamicClient,
 			backgroundServiceAccountName,
@@ -800,6 +851,19 @@ func main() {
 			}))
 		}
 
+		var admissionRecords recorder.Store
+		var admissionRecordsRedaction recorder.Redaction
+		if maxAdmissionRecords > 0 {
+			admissionRecordsRedaction, err = recorder.ParseRedaction(admissionRecordsRedactedKinds)
+			if err != nil {
+				setup.Logger.Error(err, "failed to parse admission records redacted kinds")
+				os.Exit(1)
+			}
+			admissionRecords = recorder.NewStore(maxAdmissionRecords)
+			recorder.Start(signalCtx, setup.Logger.WithName("admission-records"), admissionRecordsAddress, admissionRecords)
+		}
+		// policies exceeding their admission latency budget are degraded through the policy breaker
+		breaker.SetPolicyBreaker(breaker.NewPolicyBreaker(clock.RealClock{}))
 		resourceHandlers := webhooksresource.NewHandlers(
 			engine,
 			setup.KyvernoDynamicClient,
@@ -874,7 +938,9 @@ func main() {
 			setup.Configuration,
 			setup.MetricsManager,
 			webhooks.DebugModeOptions{
-				DumpPayload: dumpPayload,
+				DumpPayload:               dumpPayload,
+				AdmissionRecords:          admissionRecords,
+				AdmissionRecordsRedaction: admissionRecordsRedaction,
 			},
 			func() ([]byte, []byte, error) {
 				secret, err := tlsSecret.Lister().Secrets(config.KyvernoNamespace()).Get(tlsSecretName)
@@ -908,6 +974,9 @@ func main() {
 		if polexController != nil {
 			polexController.Run(signalCtx, setup.Logger, &wg)
 		}
+		if ivrController != nil {
+			ivrController.Run(signalCtx, setup.Logger, &wg)
+		}
 		for _, controller := range nonLeaderControllers {
 			controller.Run(signalCtx, setup.Logger.WithName("controllers"), &wg)
 		}
@@ -20,7 +20,11 @@ import (
 	globalcontextcontroller "github.com/kyverno/kyverno/pkg/controllers/globalcontext"
 	aggregatereportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/aggregate"
 	backgroundscancontroller "github.com/kyverno/kyverno/pkg/controllers/report/background"
+	exportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/export"
+	historycontroller "github.com/kyverno/kyverno/pkg/controllers/report/history"
+	reportcontrollerutils "github.com/kyverno/kyverno/pkg/controllers/report/utils"
 	resourcereportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/resource"
+	reverificationcontroller "github.com/kyverno/kyverno/pkg/controllers/report/reverification"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
@@ -41,6 +45,7 @@ import (
 	admissionregistrationv1beta1informers "k8s.io/client-go/informers/admissionregistration/v1beta1"
 	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
 	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/util/flowcontrol"
 	kyamlopenapi "sigs.k8s.io/kustomize/kyaml/openapi"
 )
 
@@ -80,11 +85,19 @@ func createReportControllers(
 	kubeInformer kubeinformers.SharedInformerFactory,
 	kyvernoInformer kyvernoinformer.SharedInformerFactory,
 	backgroundScanInterval time.Duration,
+	backgroundScanRateLimiter flowcontrol.RateLimiter,
 	configuration config.Configuration,
 	jp jmespath.Interface,
 	eventGenerator event.Interface,
 	gcstore store.Store,
 	typeConverter patch.TypeConverterManager,
+	reportExportConfig *exportcontroller.Config,
+	reportExportSinks []exportcontroller.Sink,
+	reportHistoryStore historycontroller.Store,
+	reportHistoryInterval time.Duration,
+	reportEnrichment reportcontrollerutils.EnrichmentConfig,
+	reportRollup aggregatereportcontroller.RollupConfig,
+	imageReverification reverificationcontroller.Config,
 ) ([]internal.Controller, func(context.Context) error) {
 	var ctrls []internal.Controller
 	var warmups []func(context.Context) error
@@ -135,6 +148,7 @@ func createReportControllers(
 			resourcereportcontroller.Workers,
 		))
 		if aggregateReports {
+			owners := reportcontrollerutils.NewOwnerResolver(client)
 			ctrls = append(ctrls, internal.NewController(
 				aggregatereportcontroller.ControllerName,
 				aggregatereportcontroller.NewController(
@@ -155,9 +169,41 @@ func createReportControllers(
 					vapInformer,
 					mapInformer,
 					mapAlphaInformer,
+					reportcontrollerutils.NewEnricher(reportEnrichment, client, kubeInformer.Core().V1().Namespaces().Lister()),
+					owners,
+					reportRollup,
 				),
 				aggregationWorkers,
 			))
+			if len(reportExportSinks) != 0 {
+				ctrls = append(ctrls, internal.NewController(
+					exportcontroller.ControllerName,
+					exportcontroller.NewController(
+						kyvernoClient,
+						orClient,
+						metadataFactory,
+						reportExportConfig.GetBatchSize(),
+						exportcontroller.NewConfigMapStateStore(
+							client.GetKubeClient().CoreV1().ConfigMaps(config.KyvernoNamespace()),
+							exportcontroller.StateConfigMapName,
+						),
+						reportExportSinks...,
+					),
+					exportcontroller.Workers,
+				))
+			}
+			if reportHistoryStore != nil {
+				ctrls = append(ctrls, internal.NewController(
+					historycontroller.ControllerName,
+					historycontroller.NewController(
+						kyvernoClient,
+						orClient,
+						reportHistoryStore,
+						reportHistoryInterval,
+					),
+					historycontroller.Workers,
+				))
+			}
 		}
 		if backgroundScan {
 			restMapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(client.GetKubeClient().Discovery()))
@@ -186,6 +232,7 @@ func createReportControllers(
 				kubeInformer.Core().V1().Namespaces(),
 				resourceReportController,
 				backgroundScanInterval,
+				backgroundScanRateLimiter,
 				configuration,
 				jp,
 				eventGenerator,
@@ -199,6 +246,24 @@ func createReportControllers(
 				backgroundScanController,
 				backgroundScanWorkers,
 			))
+			if imageReverification.Interval > 0 {
+				ctrls = append(ctrls, internal.NewController(
+					reverificationcontroller.ControllerName,
+					reverificationcontroller.NewController(
+						client.GetKubeClient(),
+						kyvernoClient,
+						reportcontrollerutils.NewScanner(logging.WithName(reverificationcontroller.ControllerName), eng, configuration, jp, client, gcstore, restMapper, typeConverter),
+						kubeInformer.Core().V1().Pods(),
+						kubeInformer.Core().V1().Namespaces().Lister(),
+						kyvernoV1.ClusterPolicies().Lister(),
+						kyvernoV1.Policies().Lister(),
+						policiesV1beta1.ImageValidatingPolicies().Lister(),
+						policiesV1beta1.PolicyExceptions().Lister(),
+						imageReverification,
+					),
+					reverificationcontroller.Workers,
+				))
+			}
 		}
 	}
 	return ctrls, func(ctx context.Context) error {
@@ -233,8 +298,16 @@ func createrLeaderControllers(
 	jp jmespath.Interface,
 	eventGenerator event.Interface,
 	backgroundScanInterval time.Duration,
+	backgroundScanRateLimiter flowcontrol.RateLimiter,
 	gcstore store.Store,
 	typeConverter patch.TypeConverterManager,
+	reportExportConfig *exportcontroller.Config,
+	reportExportSinks []exportcontroller.Sink,
+	reportHistoryStore historycontroller.Store,
+	reportHistoryInterval time.Duration,
+	reportEnrichment reportcontrollerutils.EnrichmentConfig,
+	reportRollup aggregatereportcontroller.RollupConfig,
+	imageReverification reverificationcontroller.Config,
 ) ([]internal.Controller, func(context.Context) error, error) {
 	reportControllers, warmup := createReportControllers(
 		eng,
@@ -254,11 +327,19 @@ func createrLeaderControllers(
 		kubeInformer,
 		kyvernoInformer,
 		backgroundScanInterval,
+		backgroundScanRateLimiter,
 		configuration,
 		jp,
 		eventGenerator,
 		gcstore,
 		typeConverter,
+		reportExportConfig,
+		reportExportSinks,
+		reportHistoryStore,
+		reportHistoryInterval,
+		reportEnrichment,
+		reportRollup,
+		imageReverification,
 	)
 	return reportControllers, warmup, nil
 }
@@ -274,6 +355,8 @@ func main() {
 		reportsCRDsSanityChecks          bool
 		backgroundScanWorkers            int
 		backgroundScanInterval           time.Duration
+		backgroundScanRateLimit          float64
+		backgroundScanRateBurst          int
 		aggregationWorkers               int
 		maxQueuedEvents                  int
 		omitEvents                       string
@@ -281,6 +364,16 @@ func main() {
 		maxAPICallResponseLength         int64
 		apiCallTimeout                   time.Duration
 		maxBackgroundReports             int
+		reportExportConfigPath           string
+		reportHistoryInterval            time.Duration
+		reportHistoryRetention           time.Duration
+		reportHistoryMaxSnapshots        int
+		reportNamespaceLabels            string
+		reportTopLevelOwner              bool
+		reportRollup                     bool
+		reportRollupPods                 bool
+		imageReverificationInterval      time.Duration
+		imageReverificationAction        string
 	)
 	flagset := flag.NewFlagSet("reports-controller", flag.ExitOnError)
 	flagset.BoolVar(&backgroundScan, "backgroundScan", true, "Enable or disable background scan.")
@@ -292,12 +385,24 @@ func main() {
 	flagset.IntVar(&aggregationWorkers, "aggregationWorkers", aggregatereportcontroller.Workers, "Configure the number of ephemeral reports aggregation workers.")
 	flagset.IntVar(&backgroundScanWorkers, "backgroundScanWorkers", backgroundscancontroller.Workers, "Configure the number of background scan workers.")
 	flagset.DurationVar(&backgroundScanInterval, "backgroundScanInterval", time.Hour, "Configure background scan interval.")
+	flagset.Float64Var(&backgroundScanRateLimit, "backgroundScanRateLimit", 0, "Configure the maximum number of resources scanned per second by the background scan, 0 means no limit.")
+	flagset.IntVar(&backgroundScanRateBurst, "backgroundScanRateBurst", 10, "Configure the maximum burst of resources scanned by the background scan when rate limited.")
 	flagset.IntVar(&maxQueuedEvents, "maxQueuedEvents", 1000, "Maximum events to be queued.")
 	flagset.StringVar(&omitEvents, "omitEvents", "", "Set this flag to a comma separated list of PolicyViolation, PolicyApplied, PolicyError, PolicySkipped to disable events, e.g. --omitEvents=PolicyApplied,PolicyViolation")
 	flagset.BoolVar(&skipResourceFilters, "skipResourceFilters", true, "If true, resource filters wont be considered.")
 	flagset.Int64Var(&maxAPICallResponseLength, "maxAPICallResponseLength", 2*1000*1000, "Maximum allowed response size from API Calls. A value of 0 bypasses checks (not recommended).")
 	flagset.DurationVar(&apiCallTimeout, "apiCallTimeout", 30*time.Second, "Timeout for HTTP API calls made by policies. A value of 0 means no timeout.")
 	flagset.IntVar(&maxBackgroundReports, "maxBackgroundReports", 10000, "Maximum number of ephemeralreports created for the background policies before we stop creating new ones")
+	flagset.StringVar(&reportExportConfigPath, "reportExportConfig", "", "Path to the report export configuration, policy report deltas are streamed to the configured sinks when set and the delivery state is kept in the kyverno-report-export config maps.")
+	flagset.DurationVar(&reportHistoryInterval, "reportHistoryInterval", 0, "Configure the interval at which policy report summaries are recorded in the report history, 0 disables the report history.")
+	flagset.DurationVar(&reportHistoryRetention, "reportHistoryRetention", 30*24*time.Hour, "Configure how long report history snapshots are kept, 0 means no age limit.")
+	flagset.IntVar(&reportHistoryMaxSnapshots, "reportHistoryMaxSnapshots", 0, "Configure the maximum number of report history snapshots kept, 0 means no count limit.")
+	flagset.StringVar(&reportNamespaceLabels, "reportNamespaceLabels", "", "Comma separated list of namespace labels copied to the aggregated policy report results, e.g. --reportNamespaceLabels=team,cost-center")
+	flagset.BoolVar(&reportTopLevelOwner, "reportTopLevelOwner", false, "Enable or disable adding the top-level owner of the resource to the aggregated policy report results.")
+	flagset.BoolVar(&reportRollup, "reportRollup", false, "Enable or disable attributing the results of controller-owned resources to their top-level owner in the aggregated policy reports.")
+	flagset.BoolVar(&reportRollupPods, "reportRollupPods", true, "Enable or disable reporting the results of controller-owned pods when report rollup is enabled.")
+	flagset.DurationVar(&imageReverificationInterval, "imageReverificationInterval", 0, "Configure the interval at which the images of running pods are verified again, 0 disables the image reverification.")
+	flagset.StringVar(&imageReverificationAction, "imageReverificationAction", string(reverificationcontroller.ActionReport), "Configure the action taken on running pods whose images fail reverification, one of report, annotate or evict. Pods are only annotated or evicted for the failures of enforcing rules, audit failures are reported.")
 	flagset.BoolVar(&reportsCRDsSanityChecks, "reportsCRDsSanityChecks", true, "Enable or disable sanity checks for policy reports and ephemeral reports CRDs.")
 	// config
 	appConfig := internal.NewConfiguration(
@@ -351,6 +456,74 @@ func main() {
 			}
 		}
 		setup.Logger.V(2).Info("background scan interval", "duration", backgroundScanInterval.String())
+		var backgroundScanRateLimiter flowcontrol.RateLimiter
+		if backgroundScanRateLimit > 0 {
+			setup.Logger.V(2).Info("background scan rate limit", "qps", backgroundScanRateLimit, "burst", backgroundScanRateBurst)
+			backgroundScanRateLimiter = flowcontrol.NewTokenBucketRateLimiter(float32(backgroundScanRateLimit), backgroundScanRateBurst)
+		}
+		var reportExportConfig *exportcontroller.Config
+		var reportExportSinks []exportcontroller.Sink
+		if reportExportConfigPath != "" {
+			exportConfig, err := exportcontroller.LoadConfig(reportExportConfigPath)
+			if err != nil {
+				setup.Logger.Error(err, "failed to load report export configuration")
+				os.Exit(1)
+			}
+			exportSinks, err := exportConfig.NewSinks(ctx)
+			if err != nil {
+				setup.Logger.Error(err, "failed to create report export sinks")
+				os.Exit(1)
+			}
+			reportExportConfig, reportExportSinks = exportConfig, exportSinks
+			if !aggregateReports {
+				setup.Logger.Info("report export is configured but aggregated reports are disabled, nothing will be exported")
+			}
+		}
+
+		var reportHistoryStore historycontroller.Store
+		if reportHistoryInterval > 0 {
+			if !aggregateReports {
+				setup.Logger.Info("report history is enabled but aggregated reports are disabled, nothing will be recorded")
+			} else {
+				reportHistoryStore = historycontroller.NewConfigMapStore(
+					setup.KubeClient.CoreV1().ConfigMaps(config.KyvernoNamespace()),
+					historycontroller.ConfigMapName,
+					historycontroller.Retention{
+						MaxAge:       reportHistoryRetention,
+						MaxSnapshots: reportHistoryMaxSnapshots,
+					},
+				)
+			}
+		}
+
+		reportEnrichment := reportcontrollerutils.EnrichmentConfig{
+			TopLevelOwner: reportTopLevelOwner,
+		}
+		for _, label := range strings.Split(reportNamespaceLabels, ",") {
+			if label := strings.TrimSpace(label); label != "" {
+				reportEnrichment.NamespaceLabels = append(reportEnrichment.NamespaceLabels, label)
+			}
+		}
+
+		reportRollupConfig := aggregatereportcontroller.RollupConfig{
+			Enabled: reportRollup,
+			Pods:    reportRollupPods,
+		}
+
+		imageReverificationConfig := reverificationcontroller.Config{
+			Interval: imageReverificationInterval,
+		}
+		if imageReverificationInterval > 0 {
+			action, err := reverificationcontroller.ParseAction(imageReverificationAction)
+			if err != nil {
+				setup.Logger.Error(err, "failed to parse image reverification action")
+				os.Exit(1)
+			}
+			imageReverificationConfig.Action = action
+			if !backgroundScan {
+				setup.Logger.Info("image reverification is enabled but background scan is disabled, images will not be verified again")
+			}
+		}
 
 		// call NewContextProvider to initialize the libraries context globally, needed during background scan
 		gcstore := store.New()
@@ -369,6 +542,7 @@ func main() {
 		// informer factories
 		kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(setup.KyvernoClient, setup.ResyncPeriod)
 		polexCache, polexController := internal.NewExceptionSelector(setup.Logger, kyvernoInformer)
+		ivrController := internal.NewImageVerificationResultsController(ctx, setup.Logger, kyvernoInformer, setup.KyvernoClient, setup.KubeClient)
 		eventGenerator := event.NewEventGenerator(
 			setup.EventsClient,
 			logging.WithName("EventGenerator"),
@@ -390,6 +564,7 @@ func main() {
 				setup.KyvernoClient,
 				gcstore,
 				eventGenerator,
+				nil,
 				maxAPICallResponseLength,
 				apiCallTimeout,
 				false,
@@ -491,8 +666,16 @@ func main() {
 					setup.Jp,
 					eventGenerator,
 					backgroundScanInterval,
+					backgroundScanRateLimiter,
 					gcstore,
 					typeConverter,
+					reportExportConfig,
+					reportExportSinks,
+					reportHistoryStore,
+					reportHistoryInterval,
+					reportEnrichment,
+					reportRollupConfig,
+					imageReverificationConfig,
 				)
 				if err != nil {
 					logger.Error(err, "failed to create leader controllers")
@@ -532,6 +715,9 @@ func main() {
 		if polexController != nil {
 			polexController.Run(ctx, setup.Logger, &wg)
 		}
+		if ivrController != nil {
+			ivrController.Run(ctx, setup.Logger, &wg)
+		}
 		// start leader election
 		le.Run(ctx)
 	}()
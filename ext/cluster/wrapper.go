@@ -88,6 +88,10 @@ func (c *Client) ApplyResource(ctx context.Context, apiVersion string, kind stri
 	return c.fake.ApplyResource(ctx, apiVersion, kind, namespace, name, obj, dryRun, fieldManager, subresources...)
 }
 
+func (c *Client) ApplyResourceWithOptions(ctx context.Context, apiVersion string, kind string, namespace string, name string, obj interface{}, options metav1.ApplyOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	return c.fake.ApplyResourceWithOptions(ctx, apiVersion, kind, namespace, name, obj, options, subresources...)
+}
+
 func (c *Client) ApplyStatusResource(ctx context.Context, apiVersion string, kind string, namespace string, name string, obj interface{}, dryRun bool, fieldManager string) (*unstructured.Unstructured, error) {
 	return c.fake.ApplyStatusResource(ctx, apiVersion, kind, namespace, name, obj, dryRun, fieldManager)
 }
@@ -63,6 +63,11 @@ func checkPolicy(spec *kyvernov1.Spec, validate bool) (bool, string) {
 		return false, msg
 	}
 
+	if spec.HasValidateShadow() {
+		msg = "skip generating ValidatingAdmissionPolicy: Shadow validationFailureAction is not applicable."
+		return false, msg
+	}
+
 	if ok, msg := checkValidationFailureActionOverrides(spec.ValidationFailureActionOverrides); !ok {
 		return false, msg
 	}
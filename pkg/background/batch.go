@@ -0,0 +1,84 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/config"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// batchIndex indexes the update requests by batch key
+const batchIndex = "batch"
+
+// batchKey identifies the update requests that would produce the same result when processed,
+// they were created for the same policy, trigger, operation and set of rules.
+func batchKey(ur *kyvernov2.UpdateRequest) string {
+	rules := make([]string, 0, len(ur.Spec.RuleContext))
+	for _, rule := range ur.Spec.RuleContext {
+		rules = append(rules, fmt.Sprintf("%s|%s|%t|%t|%t", rule.Rule, rule.Trigger.String(), rule.DeleteDownstream, rule.Synchronize, rule.CacheRestore))
+	}
+	sort.Strings(rules)
+	return strings.Join([]string{
+		string(ur.Spec.GetRequestType()),
+		ur.Spec.Policy,
+		ur.Spec.Rule,
+		ur.Spec.Resource.String(),
+		string(ur.Spec.Context.AdmissionRequestInfo.Operation),
+		strings.Join(rules, ","),
+	}, "/")
+}
+
+func batchIndexFunc(obj interface{}) ([]string, error) {
+	ur, ok := obj.(*kyvernov2.UpdateRequest)
+	if !ok {
+		return nil, nil
+	}
+	return []string{batchKey(ur)}, nil
+}
+
+// newer returns true if a was created after b
+func newer(a, b *kyvernov2.UpdateRequest) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return b.CreationTimestamp.Before(&a.CreationTimestamp)
+	}
+	return a.GetName() > b.GetName()
+}
+
+// coalesce collapses the pending update requests of the same batch into the most recent one and deletes the others.
+// It returns true when ur was superseded by a more recent request and must not be processed.
+func (c *controller) coalesce(ur *kyvernov2.UpdateRequest) (bool, error) {
+	if c.urIndexer == nil {
+		return false, nil
+	}
+	objs, err := c.urIndexer.ByIndex(batchIndex, batchKey(ur))
+	if err != nil {
+		return false, err
+	}
+	latest := ur
+	var stale []*kyvernov2.UpdateRequest
+	for _, obj := range objs {
+		other := obj.(*kyvernov2.UpdateRequest)
+		if other.GetName() == ur.GetName() || other.GetDeletionTimestamp() != nil || other.Status.State != kyvernov2.Pending {
+			continue
+		}
+		if newer(other, latest) {
+			stale = append(stale, latest)
+			latest = other
+		} else {
+			stale = append(stale, other)
+		}
+	}
+	for _, s := range stale {
+		err := c.kyvernoClient.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).Delete(context.TODO(), s.GetName(), metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		logger.V(3).Info("coalesced update request", "name", s.GetName(), "into", latest.GetName())
+	}
+	return latest != ur, nil
+}
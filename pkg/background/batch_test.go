@@ -0,0 +1,201 @@
+package background
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newBatchUR(name string, created time.Time, mutate func(*kyvernov2.UpdateRequest)) *kyvernov2.UpdateRequest {
+	ur := &kyvernov2.UpdateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         config.KyvernoNamespace(),
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: kyvernov2.UpdateRequestSpec{
+			Type:   kyvernov2.Generate,
+			Policy: "pol",
+			Resource: kyvernov1.ResourceSpec{
+				Kind:      "Namespace",
+				Name:      "ns",
+				Namespace: "",
+			},
+			RuleContext: []kyvernov2.RuleContext{{Rule: "a"}, {Rule: "b"}},
+			Context: kyvernov2.UpdateRequestSpecContext{
+				AdmissionRequestInfo: kyvernov2.AdmissionRequestInfoObject{
+					Operation: admissionv1.Create,
+				},
+			},
+		},
+		Status: kyvernov2.UpdateRequestStatus{
+			State: kyvernov2.Pending,
+		},
+	}
+	if mutate != nil {
+		mutate(ur)
+	}
+	return ur
+}
+
+func Test_batchKey(t *testing.T) {
+	now := time.Now()
+	base := newBatchUR("base", now, nil)
+	tests := []struct {
+		name   string
+		mutate func(*kyvernov2.UpdateRequest)
+		same   bool
+	}{{
+		name: "identical",
+		same: true,
+	}, {
+		name: "rules in another order",
+		mutate: func(ur *kyvernov2.UpdateRequest) {
+			ur.Spec.RuleContext = []kyvernov2.RuleContext{{Rule: "b"}, {Rule: "a"}}
+		},
+		same: true,
+	}, {
+		name:   "other policy",
+		mutate: func(ur *kyvernov2.UpdateRequest) { ur.Spec.Policy = "other" },
+	}, {
+		name:   "other trigger",
+		mutate: func(ur *kyvernov2.UpdateRequest) { ur.Spec.Resource.Name = "other" },
+	}, {
+		name: "other operation",
+		mutate: func(ur *kyvernov2.UpdateRequest) {
+			ur.Spec.Context.AdmissionRequestInfo.Operation = admissionv1.Update
+		},
+	}, {
+		name: "other rules",
+		mutate: func(ur *kyvernov2.UpdateRequest) {
+			ur.Spec.RuleContext = []kyvernov2.RuleContext{{Rule: "a"}}
+		},
+	}, {
+		name: "other request type",
+		mutate: func(ur *kyvernov2.UpdateRequest) {
+			ur.Spec.Type = kyvernov2.Mutate
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ur := newBatchUR("ur", now, tt.mutate)
+			assert.Equal(t, tt.same, batchKey(base) == batchKey(ur))
+		})
+	}
+}
+
+func Test_newer(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		a    *kyvernov2.UpdateRequest
+		b    *kyvernov2.UpdateRequest
+		want bool
+	}{{
+		name: "created after",
+		a:    newBatchUR("a", now.Add(time.Second), nil),
+		b:    newBatchUR("b", now, nil),
+		want: true,
+	}, {
+		name: "created before",
+		a:    newBatchUR("b", now, nil),
+		b:    newBatchUR("a", now.Add(time.Second), nil),
+		want: false,
+	}, {
+		name: "same time, greater name",
+		a:    newBatchUR("b", now, nil),
+		b:    newBatchUR("a", now, nil),
+		want: true,
+	}, {
+		name: "same time, lower name",
+		a:    newBatchUR("a", now, nil),
+		b:    newBatchUR("b", now, nil),
+		want: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, newer(tt.a, tt.b))
+		})
+	}
+}
+
+func Test_coalesce(t *testing.T) {
+	now := time.Now()
+	deleting := metav1.NewTime(now)
+	tests := []struct {
+		name       string
+		ur         *kyvernov2.UpdateRequest
+		others     []*kyvernov2.UpdateRequest
+		superseded bool
+		deleted    []string
+	}{{
+		name: "alone",
+		ur:   newBatchUR("ur", now, nil),
+	}, {
+		name:    "older pending requests are deleted",
+		ur:      newBatchUR("ur", now, nil),
+		others:  []*kyvernov2.UpdateRequest{newBatchUR("old", now.Add(-time.Second), nil)},
+		deleted: []string{"old"},
+	}, {
+		name:       "superseded by a newer pending request",
+		ur:         newBatchUR("ur", now, nil),
+		others:     []*kyvernov2.UpdateRequest{newBatchUR("new", now.Add(time.Second), nil)},
+		superseded: true,
+		deleted:    []string{"ur"},
+	}, {
+		name: "non pending and deleting requests are ignored",
+		ur:   newBatchUR("ur", now, nil),
+		others: []*kyvernov2.UpdateRequest{
+			newBatchUR("failed", now.Add(time.Second), func(ur *kyvernov2.UpdateRequest) { ur.Status.State = kyvernov2.Failed }),
+			newBatchUR("deleting", now.Add(time.Second), func(ur *kyvernov2.UpdateRequest) { ur.DeletionTimestamp = &deleting }),
+		},
+	}, {
+		name:   "other batches are ignored",
+		ur:     newBatchUR("ur", now, nil),
+		others: []*kyvernov2.UpdateRequest{newBatchUR("other", now.Add(-time.Second), func(ur *kyvernov2.UpdateRequest) { ur.Spec.Policy = "other" })},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{batchIndex: batchIndexFunc})
+			client := fake.NewSimpleClientset()
+			for _, ur := range append([]*kyvernov2.UpdateRequest{tt.ur}, tt.others...) {
+				assert.NoError(t, indexer.Add(ur))
+				_, err := client.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).Create(context.TODO(), ur, metav1.CreateOptions{})
+				assert.NoError(t, err)
+			}
+			c := &controller{
+				kyvernoClient: client,
+				urIndexer:     indexer,
+			}
+			superseded, err := c.coalesce(tt.ur)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.superseded, superseded)
+			list, err := client.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).List(context.TODO(), metav1.ListOptions{})
+			assert.NoError(t, err)
+			remaining := map[string]bool{}
+			for _, ur := range list.Items {
+				remaining[ur.GetName()] = true
+			}
+			for _, name := range tt.deleted {
+				assert.False(t, remaining[name], name)
+			}
+			assert.Len(t, list.Items, 1+len(tt.others)-len(tt.deleted))
+		})
+	}
+}
+
+func Test_coalesce_disabled(t *testing.T) {
+	c := &controller{}
+	superseded, err := c.coalesce(newBatchUR("ur", time.Now(), nil))
+	assert.NoError(t, err)
+	assert.False(t, superseded)
+}
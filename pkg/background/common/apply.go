@@ -0,0 +1,400 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// FieldManager is the field manager of the resources written by the background controller
+const FieldManager = "kyverno-background-controller"
+
+// ConflictStrategy defines how the background controller resolves conflicts with other field managers
+type ConflictStrategy string
+
+const (
+	// ConflictStrategyForce takes over the ownership of the conflicting fields
+	ConflictStrategyForce ConflictStrategy = "force"
+	// ConflictStrategyYield leaves the conflicting fields to their current manager
+	ConflictStrategyYield ConflictStrategy = "yield"
+)
+
+// ParseConflictStrategy parses a conflict strategy, empty defaults to force
+func ParseConflictStrategy(value string) (ConflictStrategy, error) {
+	switch ConflictStrategy(value) {
+	case "", ConflictStrategyForce:
+		return ConflictStrategyForce, nil
+	case ConflictStrategyYield:
+		return ConflictStrategyYield, nil
+	}
+	return "", fmt.Errorf("invalid conflict strategy %q, must be %q or %q", value, ConflictStrategyForce, ConflictStrategyYield)
+}
+
+// ConflictError is returned when the background controller yielded fields to other field managers
+type ConflictError struct {
+	Conflicts []kyvernov2.FieldConflict
+}
+
+func (e ConflictError) Error() string {
+	fields := make([]string, 0, len(e.Conflicts))
+	for _, conflict := range e.Conflicts {
+		fields = append(fields, fmt.Sprintf("%s %s (%s)", conflict.Resource.String(), conflict.Field, conflict.Manager))
+	}
+	return fmt.Sprintf("yielded conflicting fields to other managers: %s", strings.Join(fields, ", "))
+}
+
+var conflictManagerRegex = regexp.MustCompile(`conflict with "([^"]*)"`)
+
+// Applier writes resources with server-side apply using the background field manager.
+// A first apply is never forced so that conflicts with other field managers are detected,
+// they are then resolved according to the conflict strategy and recorded.
+type Applier struct {
+	client   dclient.Interface
+	strategy ConflictStrategy
+
+	lock      sync.Mutex
+	conflicts []kyvernov2.FieldConflict
+}
+
+func NewApplier(client dclient.Interface, strategy ConflictStrategy) *Applier {
+	return &Applier{
+		client:   client,
+		strategy: strategy,
+	}
+}
+
+// Apply applies the desired state of the fields managed by the background controller.
+// Fields previously applied by the background controller and missing from obj are removed.
+func (a *Applier) Apply(ctx context.Context, obj *unstructured.Unstructured, subresources ...string) (*unstructured.Unstructured, error) {
+	config := obj.DeepCopy()
+	unstructured.RemoveNestedField(config.Object, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(config.Object, "metadata", "uid")
+	unstructured.RemoveNestedField(config.Object, "metadata", "selfLink")
+	unstructured.RemoveNestedField(config.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(config.Object, "metadata", "generation")
+	unstructured.RemoveNestedField(config.Object, "metadata", "managedFields")
+	if !isStatus(subresources) {
+		unstructured.RemoveNestedField(config.Object, "status")
+	}
+	return a.apply(ctx, config, subresources...)
+}
+
+// ApplyChanges applies the changes made by a mutation of current. The applied configuration contains the
+// changed fields and the fields already owned by the background controller so that fields set by
+// previous mutations are kept. Nothing is written when patched doesn't change current.
+func (a *Applier) ApplyChanges(ctx context.Context, current, patched *unstructured.Unstructured, subresources ...string) (*unstructured.Unstructured, error) {
+	changes, _ := diff(current.Object, patched.Object).(map[string]interface{})
+	if isStatus(subresources) {
+		status, ok := changes["status"]
+		changes = map[string]interface{}{}
+		if ok {
+			changes["status"] = status
+		}
+	} else {
+		delete(changes, "status")
+	}
+	unstructured.RemoveNestedField(changes, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(changes, "metadata", "managedFields")
+	if len(changes) == 0 {
+		return current, nil
+	}
+	subresource := ""
+	if isStatus(subresources) {
+		subresource = "status"
+	}
+	config := &unstructured.Unstructured{Object: ownedFields(patched, subresource)}
+	merge(config.Object, changes)
+	config.SetAPIVersion(patched.GetAPIVersion())
+	config.SetKind(patched.GetKind())
+	config.SetName(patched.GetName())
+	config.SetNamespace(patched.GetNamespace())
+	return a.apply(ctx, config, subresources...)
+}
+
+// Conflicts returns the conflicts encountered by the applier
+func (a *Applier) Conflicts() []kyvernov2.FieldConflict {
+	if a == nil {
+		return nil
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return append([]kyvernov2.FieldConflict(nil), a.conflicts...)
+}
+
+func (a *Applier) apply(ctx context.Context, config *unstructured.Unstructured, subresources ...string) (*unstructured.Unstructured, error) {
+	apiVersion, kind, namespace, name := config.GetAPIVersion(), config.GetKind(), config.GetNamespace(), config.GetName()
+	options := metav1.ApplyOptions{FieldManager: FieldManager}
+	result, err := a.client.ApplyResourceWithOptions(ctx, apiVersion, kind, namespace, name, config, options, subresources...)
+	if err == nil || !apierrors.IsConflict(err) {
+		return result, err
+	}
+	resource := kyvernov1.ResourceSpec{APIVersion: apiVersion, Kind: kind, Namespace: namespace, Name: name}
+	conflicts := conflictsOf(err, resource, a.strategy == ConflictStrategyForce)
+	if len(conflicts) == 0 {
+		// optimistic concurrency conflict, not a field ownership one
+		return nil, err
+	}
+	a.lock.Lock()
+	a.conflicts = append(a.conflicts, conflicts...)
+	a.lock.Unlock()
+	if a.strategy == ConflictStrategyForce {
+		options.Force = true
+		return a.client.ApplyResourceWithOptions(ctx, apiVersion, kind, namespace, name, config, options, subresources...)
+	}
+	for _, conflict := range conflicts {
+		if !removeField(config.Object, conflict.Field) {
+			return nil, ConflictError{Conflicts: conflicts}
+		}
+	}
+	if _, err := a.client.ApplyResourceWithOptions(ctx, apiVersion, kind, namespace, name, config, options, subresources...); err != nil {
+		return nil, errors.Join(ConflictError{Conflicts: conflicts}, err)
+	}
+	return nil, ConflictError{Conflicts: conflicts}
+}
+
+func conflictsOf(err error, resource kyvernov1.ResourceSpec, forced bool) []kyvernov2.FieldConflict {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) || status.Status().Details == nil {
+		return nil
+	}
+	var conflicts []kyvernov2.FieldConflict
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		conflict := kyvernov2.FieldConflict{
+			Resource: resource,
+			Field:    cause.Field,
+			Forced:   forced,
+		}
+		if match := conflictManagerRegex.FindStringSubmatch(cause.Message); match != nil {
+			conflict.Manager = match[1]
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts
+}
+
+func isStatus(subresources []string) bool {
+	return len(subresources) == 1 && subresources[0] == "status"
+}
+
+// ownedFields returns the fields of obj owned by the background field manager.
+// Lists are atomic, a list is returned as a whole as soon as one of its items is owned.
+func ownedFields(obj *unstructured.Unstructured, subresource string) map[string]interface{} {
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager != FieldManager || entry.Operation != metav1.ManagedFieldsOperationApply || entry.Subresource != subresource || entry.FieldsV1 == nil {
+			continue
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			return map[string]interface{}{}
+		}
+		return extractFields(obj.Object, fields)
+	}
+	return map[string]interface{}{}
+}
+
+func extractFields(obj map[string]interface{}, fields map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for key, children := range fields {
+		name, ok := strings.CutPrefix(key, "f:")
+		if !ok {
+			continue
+		}
+		value, ok := obj[name]
+		if !ok {
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			if children, ok := children.(map[string]interface{}); ok && hasFields(children) {
+				out[name] = extractFields(nested, children)
+				continue
+			}
+		}
+		out[name] = value
+	}
+	return out
+}
+
+func hasFields(fields map[string]interface{}) bool {
+	for key := range fields {
+		if strings.HasPrefix(key, "f:") {
+			return true
+		}
+	}
+	return false
+}
+
+// diff returns the fields of patched that differ from current, removed fields are nil
+func diff(current, patched interface{}) interface{} {
+	currentMap, ok1 := current.(map[string]interface{})
+	patchedMap, ok2 := patched.(map[string]interface{})
+	if !ok1 || !ok2 {
+		if reflect.DeepEqual(current, patched) {
+			return nil
+		}
+		return patched
+	}
+	out := map[string]interface{}{}
+	for key, value := range patchedMap {
+		if existing, ok := currentMap[key]; !ok {
+			out[key] = value
+		} else if changed := diff(existing, value); changed != nil {
+			if nested, ok := changed.(map[string]interface{}); !ok || len(nested) > 0 {
+				out[key] = changed
+			}
+		}
+	}
+	for key := range currentMap {
+		if _, ok := patchedMap[key]; !ok {
+			out[key] = nil
+		}
+	}
+	return out
+}
+
+// merge merges the changes into the configuration, nil values remove the field
+func merge(config, changes map[string]interface{}) {
+	for key, value := range changes {
+		if value == nil {
+			delete(config, key)
+			continue
+		}
+		nestedChanges, ok1 := value.(map[string]interface{})
+		nestedConfig, ok2 := config[key].(map[string]interface{})
+		if ok1 && ok2 {
+			merge(nestedConfig, nestedChanges)
+		} else if ok1 {
+			nested := map[string]interface{}{}
+			merge(nested, nestedChanges)
+			config[key] = nested
+		} else {
+			config[key] = value
+		}
+	}
+}
+
+// removeField removes a field designated by a server-side apply field path such as
+// `.metadata.labels.app` or `.spec.containers[name="nginx"].image`, it returns false
+// when the path can't be resolved.
+func removeField(obj map[string]interface{}, path string) bool {
+	path = strings.TrimPrefix(path, ".")
+	// map keys can contain dots, use the longest key matching the path
+	var key string
+	for candidate := range obj {
+		if (path == candidate || strings.HasPrefix(path, candidate+".") || strings.HasPrefix(path, candidate+"[")) && len(candidate) > len(key) {
+			key = candidate
+		}
+	}
+	if key == "" {
+		return false
+	}
+	rest := path[len(key):]
+	if rest == "" {
+		delete(obj, key)
+		return true
+	}
+	if rest[0] == '.' {
+		nested, ok := obj[key].(map[string]interface{})
+		return ok && removeField(nested, rest)
+	}
+	list, ok := obj[key].([]interface{})
+	if !ok {
+		return false
+	}
+	end := strings.Index(rest, "]")
+	if end < 0 {
+		return false
+	}
+	index := findItem(list, rest[1:end])
+	if index < 0 {
+		return false
+	}
+	rest = rest[end+1:]
+	if rest == "" {
+		obj[key] = append(list[:index:index], list[index+1:]...)
+		return true
+	}
+	item, ok := list[index].(map[string]interface{})
+	return ok && removeField(item, rest)
+}
+
+var selectorRegex = regexp.MustCompile(`([^=,]+)=("(?:[^"\\]|\\.)*"|[^,]+)`)
+
+// findItem returns the index of the list item matching a selector, either an index (`0`),
+// a value (`="value"`) or a set of keys (`name="nginx",protocol="TCP"`)
+func findItem(list []interface{}, selector string) int {
+	if index, err := strconv.Atoi(selector); err == nil {
+		if index < len(list) {
+			return index
+		}
+		return -1
+	}
+	if value, ok := strings.CutPrefix(selector, "="); ok {
+		var expected interface{}
+		if err := json.Unmarshal([]byte(value), &expected); err != nil {
+			return -1
+		}
+		for i, item := range list {
+			if reflect.DeepEqual(item, expected) {
+				return i
+			}
+		}
+		return -1
+	}
+	keys := map[string]interface{}{}
+	for _, match := range selectorRegex.FindAllStringSubmatch(selector, -1) {
+		var value interface{}
+		if err := json.Unmarshal([]byte(match[2]), &value); err != nil {
+			return -1
+		}
+		keys[match[1]] = value
+	}
+	if len(keys) == 0 {
+		return -1
+	}
+	for i, item := range list {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		matches := true
+		for key, value := range keys {
+			if !reflect.DeepEqual(normalize(object[key]), normalize(value)) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return i
+		}
+	}
+	return -1
+}
+
+// normalize converts numbers to float64 so that values decoded differently compare equal
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int64:
+		return float64(v)
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	}
+	return value
+}
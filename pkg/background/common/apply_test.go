@@ -0,0 +1,179 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type applyCall struct {
+	obj     *unstructured.Unstructured
+	options metav1.ApplyOptions
+}
+
+type fakeApplyClient struct {
+	dclient.Interface
+	calls    []applyCall
+	conflict error
+}
+
+func (c *fakeApplyClient) ApplyResourceWithOptions(ctx context.Context, apiVersion string, kind string, namespace string, name string, obj interface{}, options metav1.ApplyOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	u := obj.(*unstructured.Unstructured).DeepCopy()
+	c.calls = append(c.calls, applyCall{obj: u, options: options})
+	if len(c.calls) == 1 && c.conflict != nil {
+		return nil, c.conflict
+	}
+	return u, nil
+}
+
+func newConfigMap(data map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"data": data}}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace("default")
+	obj.SetName("cm")
+	return obj
+}
+
+func newConflict(field string) error {
+	return apierrors.NewApplyConflict([]metav1.StatusCause{{
+		Type:    metav1.CauseTypeFieldManagerConflict,
+		Message: `conflict with "argocd-controller" using v1`,
+		Field:   field,
+	}}, "Apply failed with 1 conflict")
+}
+
+func TestParseConflictStrategy(t *testing.T) {
+	strategy, err := ParseConflictStrategy("")
+	assert.NoError(t, err)
+	assert.Equal(t, ConflictStrategyForce, strategy)
+	strategy, err = ParseConflictStrategy("yield")
+	assert.NoError(t, err)
+	assert.Equal(t, ConflictStrategyYield, strategy)
+	_, err = ParseConflictStrategy("ignore")
+	assert.Error(t, err)
+}
+
+func TestApplier_Apply(t *testing.T) {
+	client := &fakeApplyClient{}
+	obj := newConfigMap(map[string]interface{}{"key": "value"})
+	obj.SetResourceVersion("42")
+	_, err := NewApplier(client, ConflictStrategyForce).Apply(context.TODO(), obj)
+	require.NoError(t, err)
+	require.Len(t, client.calls, 1)
+	assert.Equal(t, FieldManager, client.calls[0].options.FieldManager)
+	assert.False(t, client.calls[0].options.Force)
+	assert.Empty(t, client.calls[0].obj.GetResourceVersion())
+}
+
+func TestApplier_Apply_force(t *testing.T) {
+	client := &fakeApplyClient{conflict: newConflict(".data.key")}
+	applier := NewApplier(client, ConflictStrategyForce)
+	_, err := applier.Apply(context.TODO(), newConfigMap(map[string]interface{}{"key": "value"}))
+	require.NoError(t, err)
+	require.Len(t, client.calls, 2)
+	assert.True(t, client.calls[1].options.Force)
+	conflicts := applier.Conflicts()
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, "argocd-controller", conflicts[0].Manager)
+	assert.Equal(t, ".data.key", conflicts[0].Field)
+	assert.Equal(t, "cm", conflicts[0].Resource.Name)
+	assert.True(t, conflicts[0].Forced)
+}
+
+func TestApplier_Apply_yield(t *testing.T) {
+	client := &fakeApplyClient{conflict: newConflict(".data.key")}
+	applier := NewApplier(client, ConflictStrategyYield)
+	_, err := applier.Apply(context.TODO(), newConfigMap(map[string]interface{}{"key": "value", "other": "value"}))
+	var conflictErr ConflictError
+	require.True(t, errors.As(err, &conflictErr))
+	assert.Len(t, conflictErr.Conflicts, 1)
+	require.Len(t, client.calls, 2)
+	assert.False(t, client.calls[1].options.Force)
+	data, _, _ := unstructured.NestedStringMap(client.calls[1].obj.Object, "data")
+	assert.Equal(t, map[string]string{"other": "value"}, data)
+	assert.False(t, applier.Conflicts()[0].Forced)
+}
+
+func TestApplier_ApplyChanges(t *testing.T) {
+	current := newConfigMap(map[string]interface{}{"owned": "1", "foreign": "2"})
+	current.SetManagedFields([]metav1.ManagedFieldsEntry{{
+		Manager:   FieldManager,
+		Operation: metav1.ManagedFieldsOperationApply,
+		FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:owned":{}}}`)},
+	}, {
+		Manager:   "kubectl",
+		Operation: metav1.ManagedFieldsOperationUpdate,
+		FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:foreign":{}}}`)},
+	}})
+	client := &fakeApplyClient{}
+	applier := NewApplier(client, ConflictStrategyForce)
+
+	// no changes, nothing is written
+	_, err := applier.ApplyChanges(context.TODO(), current, current.DeepCopy())
+	require.NoError(t, err)
+	assert.Empty(t, client.calls)
+
+	patched := current.DeepCopy()
+	require.NoError(t, unstructured.SetNestedField(patched.Object, "3", "data", "added"))
+	_, err = applier.ApplyChanges(context.TODO(), current, patched)
+	require.NoError(t, err)
+	require.Len(t, client.calls, 1)
+	data, _, _ := unstructured.NestedStringMap(client.calls[0].obj.Object, "data")
+	assert.Equal(t, map[string]string{"owned": "1", "added": "3"}, data)
+	assert.Equal(t, "cm", client.calls[0].obj.GetName())
+	assert.Empty(t, client.calls[0].obj.GetManagedFields())
+}
+
+func Test_removeField(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  map[string]interface{}
+		path string
+		want map[string]interface{}
+		ok   bool
+	}{{
+		name: "key with dots",
+		obj:  map[string]interface{}{"metadata": map[string]interface{}{"labels": map[string]interface{}{"app.kubernetes.io/name": "a", "app": "b"}}},
+		path: ".metadata.labels.app.kubernetes.io/name",
+		want: map[string]interface{}{"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": "b"}}},
+		ok:   true,
+	}, {
+		name: "keyed list item",
+		obj: map[string]interface{}{"spec": map[string]interface{}{"containers": []interface{}{
+			map[string]interface{}{"name": "a", "image": "a:1"},
+			map[string]interface{}{"name": "b", "image": "b:1"},
+		}}},
+		path: `.spec.containers[name="b"].image`,
+		want: map[string]interface{}{"spec": map[string]interface{}{"containers": []interface{}{
+			map[string]interface{}{"name": "a", "image": "a:1"},
+			map[string]interface{}{"name": "b"},
+		}}},
+		ok: true,
+	}, {
+		name: "set item",
+		obj:  map[string]interface{}{"finalizers": []interface{}{"a", "b"}},
+		path: `.finalizers[="a"]`,
+		want: map[string]interface{}{"finalizers": []interface{}{"b"}},
+		ok:   true,
+	}, {
+		name: "unknown field",
+		obj:  map[string]interface{}{"data": map[string]interface{}{}},
+		path: ".data.missing",
+		want: map[string]interface{}{"data": map[string]interface{}{}},
+		ok:   false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.ok, removeField(tt.obj, tt.path))
+			assert.Equal(t, tt.want, tt.obj)
+		})
+	}
+}
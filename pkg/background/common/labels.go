@@ -2,6 +2,7 @@ package common
 
 import (
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/kyverno/kyverno/api/kyverno"
@@ -70,6 +71,13 @@ func PolicyInfo(labels map[string]string, policy kyvernov1.PolicyInterface, rule
 	labels[GenerateRuleLabel] = ruleName
 }
 
+// OrphanInfo records whether the downstream resource is kept when its policy or rule is deleted,
+// this is the case for the rules that are not synchronized or that orphan their downstream resources
+func OrphanInfo(labels map[string]string, rule kyvernov1.Rule) {
+	sync, orphanDownstream := rule.GetSyncAndOrphanDownstream()
+	labels[GenerateOrphanLabel] = strconv.FormatBool(!sync || orphanDownstream)
+}
+
 func TriggerInfo(labels map[string]string, obj unstructured.Unstructured) {
 	labels[GenerateTriggerVersionLabel] = obj.GroupVersionKind().Version
 	labels[GenerateTriggerGroupLabel] = obj.GroupVersionKind().Group
@@ -329,3 +329,30 @@ func TestManageLabels_PreservesExistingLabels(t *testing.T) {
 	assert.Equal(t, "my-app", resultLabels["app"], "existing labels should be preserved")
 	assert.Equal(t, kyverno.ValueKyvernoApp, resultLabels[kyverno.LabelAppManagedBy])
 }
+
+func Test_OrphanInfo(t *testing.T) {
+	tests := []struct {
+		name       string
+		generation kyvernov1.Generation
+		want       string
+	}{{
+		name:       "synchronized",
+		generation: kyvernov1.Generation{Synchronize: true},
+		want:       "false",
+	}, {
+		name:       "synchronized with orphaned downstream",
+		generation: kyvernov1.Generation{Synchronize: true, OrphanDownstreamOnPolicyDelete: true},
+		want:       "true",
+	}, {
+		name:       "not synchronized",
+		generation: kyvernov1.Generation{},
+		want:       "true",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labels := map[string]string{}
+			OrphanInfo(labels, kyvernov1.Rule{Name: "generate", Generation: &tt.generation})
+			assert.Equal(t, tt.want, labels[GenerateOrphanLabel])
+		})
+	}
+}
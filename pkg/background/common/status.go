@@ -18,26 +18,30 @@ type StatusControlInterface interface {
 type statusControl struct {
 	client   versioned.Interface
 	urLister kyvernov2listers.UpdateRequestNamespaceLister
+	applier  *Applier
 }
 
-func NewStatusControl(client versioned.Interface, urLister kyvernov2listers.UpdateRequestNamespaceLister) StatusControlInterface {
+// NewStatusControl returns a status control, the conflicts encountered by the applier (if any)
+// are recorded in the status of the update requests
+func NewStatusControl(client versioned.Interface, urLister kyvernov2listers.UpdateRequestNamespaceLister, applier *Applier) StatusControlInterface {
 	return &statusControl{
 		client:   client,
 		urLister: urLister,
+		applier:  applier,
 	}
 }
 
 // Failed sets ur status.state to failed with message
 func (sc *statusControl) Failed(name, message string, genResources []kyvernov1.ResourceSpec) (*kyvernov2.UpdateRequest, error) {
-	return UpdateStatus(sc.client, sc.urLister, name, kyvernov2.Failed, message, genResources)
+	return UpdateStatus(sc.client, sc.urLister, name, kyvernov2.Failed, message, genResources, sc.applier.Conflicts())
 }
 
 // Success sets the ur status.state to completed and clears message
 func (sc *statusControl) Success(name string, genResources []kyvernov1.ResourceSpec) (*kyvernov2.UpdateRequest, error) {
-	return UpdateStatus(sc.client, sc.urLister, name, kyvernov2.Completed, "", genResources)
+	return UpdateStatus(sc.client, sc.urLister, name, kyvernov2.Completed, "", genResources, sc.applier.Conflicts())
 }
 
 // Success sets the ur status.state to completed and clears message
 func (sc *statusControl) Skip(name string, genResources []kyvernov1.ResourceSpec) (*kyvernov2.UpdateRequest, error) {
-	return UpdateStatus(sc.client, sc.urLister, name, kyvernov2.Skip, "", genResources)
+	return UpdateStatus(sc.client, sc.urLister, name, kyvernov2.Skip, "", genResources, sc.applier.Conflicts())
 }
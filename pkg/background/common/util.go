@@ -15,7 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
-func UpdateStatus(client versioned.Interface, urLister kyvernov2listers.UpdateRequestNamespaceLister, name string, state kyvernov2.UpdateRequestState, message string, genResources []kyvernov1.ResourceSpec) (*kyvernov2.UpdateRequest, error) {
+func UpdateStatus(client versioned.Interface, urLister kyvernov2listers.UpdateRequestNamespaceLister, name string, state kyvernov2.UpdateRequestState, message string, genResources []kyvernov1.ResourceSpec, conflicts []kyvernov2.FieldConflict) (*kyvernov2.UpdateRequest, error) {
 	var latest *kyvernov2.UpdateRequest
 	ur, err := client.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).Get(context.TODO(), name, metav1.GetOptions{})
 	if err != nil {
@@ -27,6 +27,7 @@ func UpdateStatus(client versioned.Interface, urLister kyvernov2listers.UpdateRe
 	if genResources != nil {
 		latest.Status.GeneratedResources = genResources
 	}
+	latest.Status.Conflicts = conflicts
 
 	if state == kyvernov2.Failed {
 		if latest, err = retryOrDeleteOnFailure(client, latest, 3); err != nil {
@@ -42,6 +42,7 @@ type GenerateController struct {
 	client        dclient.Interface
 	kyvernoClient versioned.Interface
 	statusControl common.StatusControlInterface
+	applier       *common.Applier
 	engine        engineapi.Engine
 
 	// listers
@@ -62,6 +63,7 @@ func NewGenerateController(
 	client dclient.Interface,
 	kyvernoClient versioned.Interface,
 	statusControl common.StatusControlInterface,
+	applier *common.Applier,
 	engine engineapi.Engine,
 	policyLister kyvernov1listers.ClusterPolicyLister,
 	npolicyLister kyvernov1listers.PolicyLister,
@@ -76,6 +78,7 @@ func NewGenerateController(
 		client:        client,
 		kyvernoClient: kyvernoClient,
 		statusControl: statusControl,
+		applier:       applier,
 		engine:        engine,
 		policyLister:  policyLister,
 		npolicyLister: npolicyLister,
@@ -295,10 +298,10 @@ func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext
 		}
 
 		if rule.Generation.ForEachGeneration != nil {
-			g := newForeachGenerator(c.client, logger, policyContext, policy, rule, rule.Context, rule.GetAnyAllConditions(), policyContext.NewResource(), rule.Generation.ForEachGeneration, contextLoader)
+			g := newForeachGenerator(c.client, c.applier, logger, policyContext, policy, rule, rule.Context, rule.GetAnyAllConditions(), policyContext.NewResource(), rule.Generation.ForEachGeneration, contextLoader)
 			genResource, err = g.generateForeach()
 		} else {
-			g := newGenerator(c.client, logger, policyContext, policy, rule, rule.Context, rule.GetAnyAllConditions(), policyContext.NewResource(), rule.Generation.GeneratePattern, contextLoader)
+			g := newGenerator(c.client, c.applier, logger, policyContext, policy, rule, rule.Context, rule.GetAnyAllConditions(), policyContext.NewResource(), rule.Generation.GeneratePattern, contextLoader)
 			genResource, err = g.generate()
 		}
 
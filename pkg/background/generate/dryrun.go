@@ -0,0 +1,99 @@
+package generate
+
+import (
+	"context"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// dryRunClient reads from the cluster but records the writes of the generator instead of performing them.
+// Only writes to downstream resources are recorded, tagging clone sources is bookkeeping and is dropped.
+type dryRunClient struct {
+	dclient.Interface
+	changes []Change
+}
+
+func newDryRunClient(client dclient.Interface) *dryRunClient {
+	return &dryRunClient{
+		Interface: client,
+	}
+}
+
+func (c *dryRunClient) CreateResource(ctx context.Context, apiVersion string, kind string, namespace string, obj interface{}, dryRun bool) (*unstructured.Unstructured, error) {
+	return c.record(OperationCreate, apiVersion, kind, namespace, obj)
+}
+
+func (c *dryRunClient) UpdateResource(ctx context.Context, apiVersion string, kind string, namespace string, obj interface{}, dryRun bool, subresources ...string) (*unstructured.Unstructured, error) {
+	return c.record(OperationUpdate, apiVersion, kind, namespace, obj)
+}
+
+func (c *dryRunClient) ApplyResource(ctx context.Context, apiVersion string, kind string, namespace string, name string, obj interface{}, dryRun bool, fieldManager string, subresources ...string) (*unstructured.Unstructured, error) {
+	operation := OperationUpdate
+	if _, err := c.Interface.GetResource(ctx, apiVersion, kind, namespace, name); apierrors.IsNotFound(err) {
+		operation = OperationCreate
+	}
+	return c.record(operation, apiVersion, kind, namespace, obj)
+}
+
+func (c *dryRunClient) ApplyResourceWithOptions(ctx context.Context, apiVersion string, kind string, namespace string, name string, obj interface{}, options metav1.ApplyOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	return c.ApplyResource(ctx, apiVersion, kind, namespace, name, obj, true, options.FieldManager, subresources...)
+}
+
+func (c *dryRunClient) PatchResource(ctx context.Context, apiVersion string, kind string, namespace string, name string, patch []byte) (*unstructured.Unstructured, error) {
+	return c.Interface.GetResource(ctx, apiVersion, kind, namespace, name)
+}
+
+func (c *dryRunClient) DeleteResource(ctx context.Context, apiVersion string, kind string, namespace string, name string, dryRun bool, options metav1.DeleteOptions) error {
+	c.changes = append(c.changes, Change{
+		Operation: OperationDelete,
+		Target:    newResourceSpec(apiVersion, kind, namespace, name),
+	})
+	return nil
+}
+
+func (c *dryRunClient) UpdateStatusResource(ctx context.Context, apiVersion string, kind string, namespace string, obj interface{}, dryRun bool) (*unstructured.Unstructured, error) {
+	return kubeutils.ObjToUnstructured(obj)
+}
+
+func (c *dryRunClient) ApplyStatusResource(ctx context.Context, apiVersion string, kind string, namespace string, name string, obj interface{}, dryRun bool, fieldManager string) (*unstructured.Unstructured, error) {
+	return kubeutils.ObjToUnstructured(obj)
+}
+
+func (c *dryRunClient) record(operation Operation, apiVersion, kind, namespace string, obj interface{}) (*unstructured.Unstructured, error) {
+	resource, err := kubeutils.ObjToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := resource.GetLabels()[common.GeneratePolicyLabel]; !ok {
+		return resource, nil
+	}
+	if apiVersion == "" {
+		apiVersion = resource.GetAPIVersion()
+	}
+	if namespace == "" {
+		namespace = resource.GetNamespace()
+	}
+	c.changes = append(c.changes, Change{
+		Operation: operation,
+		Target: kyvernov1.ResourceSpec{
+			APIVersion: apiVersion,
+			Kind:       kind,
+			Namespace:  namespace,
+			Name:       resource.GetName(),
+		},
+	})
+	return resource, nil
+}
+
+// flush returns the recorded changes and resets the recorder
+func (c *dryRunClient) flush() []Change {
+	changes := c.changes
+	c.changes = nil
+	return changes
+}
@@ -20,6 +20,7 @@ import (
 
 type generator struct {
 	client           dclient.Interface
+	applier          *common.Applier
 	logger           logr.Logger
 	policyContext    engineapi.PolicyContext
 	policy           kyvernov1.PolicyInterface
@@ -33,6 +34,7 @@ type generator struct {
 }
 
 func newGenerator(client dclient.Interface,
+	applier *common.Applier,
 	logger logr.Logger,
 	policyContext engineapi.PolicyContext,
 	policy kyvernov1.PolicyInterface,
@@ -45,6 +47,7 @@ func newGenerator(client dclient.Interface,
 ) *generator {
 	return &generator{
 		client:           client,
+		applier:          applier,
 		logger:           logger,
 		policyContext:    policyContext,
 		policy:           policy,
@@ -58,6 +61,7 @@ func newGenerator(client dclient.Interface,
 }
 
 func newForeachGenerator(client dclient.Interface,
+	applier *common.Applier,
 	logger logr.Logger,
 	policyContext engineapi.PolicyContext,
 	policy kyvernov1.PolicyInterface,
@@ -70,6 +74,7 @@ func newForeachGenerator(client dclient.Interface,
 ) *generator {
 	return &generator{
 		client:           client,
+		applier:          applier,
 		logger:           logger,
 		policyContext:    policyContext,
 		policy:           policy,
@@ -106,6 +111,12 @@ func (g *generator) generate() ([]kyvernov1.ResourceSpec, error) {
 		return newGenResources, nil
 	}
 
+	if g.pattern.Source != nil {
+		if err := g.loadSource(context.TODO()); err != nil {
+			return newGenResources, fmt.Errorf("failed to load generate source: %v", err)
+		}
+	}
+
 	pattern, err := variables.SubstituteAllInType(g.logger, g.policyContext.JSONContext(), &g.pattern)
 	if err != nil {
 		g.logger.Error(err, "variable substitution failed for rule", "rule", g.rule.Name)
@@ -115,11 +126,12 @@ func (g *generator) generate() ([]kyvernov1.ResourceSpec, error) {
 	target := pattern.ResourceSpec
 	logger := g.logger.WithValues("target", target.String())
 
+	serverSideApply := g.applier != nil || g.policy.GetSpec().UseServerSideApply
 	if pattern.Clone.Name != "" {
-		resp := manageClone(logger.WithValues("type", "clone"), target, kyvernov1.ResourceSpec{}, g.policy.GetSpec().UseServerSideApply, *pattern, g.client)
+		resp := manageClone(logger.WithValues("type", "clone"), target, kyvernov1.ResourceSpec{}, serverSideApply, *pattern, g.client)
 		responses = append(responses, resp)
 	} else if len(pattern.CloneList.Kinds) != 0 {
-		responses = manageCloneList(logger.WithValues("type", "cloneList"), target.GetNamespace(), g.policy.GetSpec().UseServerSideApply, *pattern, g.client)
+		responses = manageCloneList(logger.WithValues("type", "cloneList"), target.GetNamespace(), serverSideApply, *pattern, g.client)
 	} else {
 		resp := manageData(logger.WithValues("type", "data"), target, pattern.RawData, g.rule.Generation.Synchronize, g.client)
 		responses = append(responses, resp)
@@ -152,13 +164,12 @@ func (g *generator) generate() ([]kyvernov1.ResourceSpec, error) {
 
 		newResource.SetAPIVersion(targetMeta.GetAPIVersion())
 		common.ManageLabels(newResource, g.trigger, g.policy, g.rule.Name)
+		labels := newResource.GetLabels()
+		common.OrphanInfo(labels, g.rule)
+		newResource.SetLabels(labels)
 		if response.GetAction() == Create {
 			newResource.SetResourceVersion("")
-			if g.policy.GetSpec().UseServerSideApply {
-				_, err = g.client.ApplyResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), targetMeta.GetName(), newResource, false, "generate")
-			} else {
-				_, err = g.client.CreateResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), newResource, false)
-			}
+			err = g.create(targetMeta, newResource)
 			if err != nil {
 				if !apierrors.IsAlreadyExists(err) {
 					return newGenResources, err
@@ -170,11 +181,7 @@ func (g *generator) generate() ([]kyvernov1.ResourceSpec, error) {
 			generatedObj, err := g.client.GetResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), targetMeta.GetName())
 			if err != nil {
 				logger.V(2).Info("creating new target due to the failure when fetching", "err", err.Error())
-				if g.policy.GetSpec().UseServerSideApply {
-					_, err = g.client.ApplyResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), targetMeta.GetName(), newResource, false, "generate")
-				} else {
-					_, err = g.client.CreateResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), newResource, false)
-				}
+				err = g.create(targetMeta, newResource)
 				if err != nil {
 					return newGenResources, err
 				}
@@ -200,11 +207,7 @@ func (g *generator) generate() ([]kyvernov1.ResourceSpec, error) {
 					newResource.SetNamespace("default")
 				}
 
-				if g.policy.GetSpec().UseServerSideApply {
-					_, err = g.client.ApplyResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), targetMeta.GetName(), newResource, false, "generate")
-				} else {
-					_, err = g.client.UpdateResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), newResource, false)
-				}
+				err = g.update(targetMeta, newResource)
 				if err != nil {
 					logger.Error(err, "failed to update resource")
 					return newGenResources, err
@@ -216,6 +219,34 @@ func (g *generator) generate() ([]kyvernov1.ResourceSpec, error) {
 	return newGenResources, nil
 }
 
+// create creates the downstream resource, with server-side apply when an applier is configured
+// or the policy requests it
+func (g *generator) create(target kyvernov1.ResourceSpec, resource *unstructured.Unstructured) error {
+	var err error
+	if g.applier != nil {
+		_, err = g.applier.Apply(context.TODO(), resource)
+	} else if g.policy.GetSpec().UseServerSideApply {
+		_, err = g.client.ApplyResource(context.TODO(), target.GetAPIVersion(), target.GetKind(), target.GetNamespace(), target.GetName(), resource, false, "generate")
+	} else {
+		_, err = g.client.CreateResource(context.TODO(), target.GetAPIVersion(), target.GetKind(), target.GetNamespace(), resource, false)
+	}
+	return err
+}
+
+// update updates the downstream resource, with server-side apply when an applier is configured
+// or the policy requests it
+func (g *generator) update(target kyvernov1.ResourceSpec, resource *unstructured.Unstructured) error {
+	var err error
+	if g.applier != nil {
+		_, err = g.applier.Apply(context.TODO(), resource)
+	} else if g.policy.GetSpec().UseServerSideApply {
+		_, err = g.client.ApplyResource(context.TODO(), target.GetAPIVersion(), target.GetKind(), target.GetNamespace(), target.GetName(), resource, false, "generate")
+	} else {
+		_, err = g.client.UpdateResource(context.TODO(), target.GetAPIVersion(), target.GetKind(), target.GetNamespace(), resource, false)
+	}
+	return err
+}
+
 func (g *generator) generateForeach() ([]kyvernov1.ResourceSpec, error) {
 	var errors []error
 	var genResources []kyvernov1.ResourceSpec
@@ -257,6 +288,7 @@ func (g *generator) generateElements(foreach kyvernov1.ForEachGeneration, elemen
 		}
 
 		gen, err := newGenerator(g.client,
+			g.applier,
 			g.logger,
 			policyContext,
 			g.policy,
@@ -288,3 +320,13 @@ func (g *generator) loadContext(ctx context.Context) error {
 	}
 	return nil
 }
+
+// loadSource adds the data of the generate source to the context, the data template is rendered with it
+func (g *generator) loadSource(ctx context.Context) error {
+	entries := []kyvernov1.ContextEntry{g.pattern.Source.ContextEntry()}
+	if err := g.contextLoader(ctx, entries, g.policyContext.JSONContext()); err != nil {
+		g.logger.Error(err, "failed to load generate source")
+		return err
+	}
+	return nil
+}
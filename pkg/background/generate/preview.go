@@ -0,0 +1,382 @@
+package generate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"go.uber.org/multierr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Operation is the operation planned on a downstream resource
+type Operation string
+
+const (
+	// OperationCreate : the downstream resource would be created
+	OperationCreate Operation = "Create"
+	// OperationUpdate : the downstream resource would be updated
+	OperationUpdate Operation = "Update"
+	// OperationDelete : the downstream resource would be deleted
+	OperationDelete Operation = "Delete"
+)
+
+const (
+	reasonNoLongerMatches = "trigger no longer matches"
+	reasonTriggerDeleted  = "trigger no longer exists"
+	reasonRuleRemoved     = "rule removed from the policy"
+)
+
+// Change is an operation a generate rule would apply to a downstream resource
+type Change struct {
+	Operation Operation              `json:"operation"`
+	Policy    string                 `json:"policy"`
+	Rule      string                 `json:"rule"`
+	Trigger   kyvernov1.ResourceSpec `json:"trigger"`
+	Target    kyvernov1.ResourceSpec `json:"target"`
+	Reason    string                 `json:"reason,omitempty"`
+}
+
+// Previewer runs generate rules in dry-run against the cluster and returns the changes
+// they would apply to downstream resources, the cluster is never modified
+type Previewer struct {
+	client        dclient.Interface
+	engine        engineapi.Engine
+	configuration config.Configuration
+	jp            jmespath.Interface
+}
+
+// NewPreviewer returns a previewer reading the cluster with the given client
+func NewPreviewer(client dclient.Interface, engine engineapi.Engine, configuration config.Configuration, jp jmespath.Interface) *Previewer {
+	return &Previewer{
+		client:        client,
+		engine:        engine,
+		configuration: configuration,
+		jp:            jp,
+	}
+}
+
+// Preview returns the changes the generate rules of the policy would apply for the given triggers.
+// Downstream resources of synchronized rules no longer matching their trigger are planned for deletion.
+// When the deployed version of the policy is given, the downstream resources of its synchronized data
+// rules that were removed from the policy are planned for deletion as well.
+func (p *Previewer) Preview(ctx context.Context, logger logr.Logger, policy, deployed kyvernov1.PolicyInterface, triggers []unstructured.Unstructured) ([]Change, error) {
+	recorder := newDryRunClient(p.client)
+	controller := &GenerateController{
+		client: recorder,
+		engine: p.engine,
+		log:    logger,
+	}
+	namespaceLabels := map[string]map[string]string{}
+	var changes []Change
+	var errs []error
+	for _, rule := range policy.GetSpec().Rules {
+		if !rule.HasGenerate() {
+			continue
+		}
+		var downstreams map[types.UID][]unstructured.Unstructured
+		if rule.Generation.Synchronize {
+			var err error
+			if downstreams, err = p.downstreams(ctx, policy, rule); err != nil {
+				errs = append(errs, fmt.Errorf("failed to fetch downstream resources of rule %s: %w", rule.Name, err))
+				continue
+			}
+		}
+		for i := range triggers {
+			trigger := &triggers[i]
+			ruleChanges, err := p.previewRule(ctx, logger, controller, recorder, policy, rule, trigger, downstreams, namespaceLabels)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to preview rule %s for %s: %w", rule.Name, common.ResourceSpecFromUnstructured(*trigger).String(), err))
+				continue
+			}
+			changes = append(changes, ruleChanges...)
+		}
+	}
+	if deployed != nil {
+		removed, err := p.removedRules(ctx, policy, deployed)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		changes = append(changes, removed...)
+	}
+	return changes, multierr.Combine(errs...)
+}
+
+// Drift compares the downstream resources of the synchronized generate rules of the policy to their expected state.
+// Missing and modified downstream resources are returned as creations and updates, downstream resources whose
+// trigger no longer exists or no longer matches are returned as deletions.
+func (p *Previewer) Drift(ctx context.Context, logger logr.Logger, policy kyvernov1.PolicyInterface) ([]Change, error) {
+	synchronized := policy.CreateDeepCopy()
+	var rules []kyvernov1.Rule
+	for _, rule := range policy.GetSpec().Rules {
+		if rule.HasGenerate() && rule.Generation.Synchronize {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	synchronized.GetSpec().SetRules(rules)
+	var errs []error
+	var triggers []unstructured.Unstructured
+	seen := map[types.UID]struct{}{}
+	for _, rule := range rules {
+		resources, err := p.triggers(ctx, policy, rule)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list triggers of rule %s: %w", rule.Name, err))
+			continue
+		}
+		for _, resource := range resources {
+			if _, ok := seen[resource.GetUID()]; ok {
+				continue
+			}
+			seen[resource.GetUID()] = struct{}{}
+			triggers = append(triggers, resource)
+		}
+	}
+	changes, err := p.Preview(ctx, logger, synchronized, nil, triggers)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	// downstream resources of triggers that were not listed are orphans
+	for _, rule := range rules {
+		downstreams, err := p.downstreams(ctx, policy, rule)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to fetch downstream resources of rule %s: %w", rule.Name, err))
+			continue
+		}
+		for uid, resources := range downstreams {
+			// downstream resources without a trigger uid can't be attributed
+			if _, ok := seen[uid]; ok || uid == "" {
+				continue
+			}
+			for _, downstream := range resources {
+				trigger := TriggerFromLabels(downstream.GetLabels())
+				reason := reasonTriggerDeleted
+				if obj, err := p.client.GetResource(ctx, trigger.GetAPIVersion(), trigger.GetKind(), trigger.GetNamespace(), trigger.GetName()); err == nil && obj.GetUID() == trigger.GetUID() {
+					reason = reasonNoLongerMatches
+				} else if err != nil && !apierrors.IsNotFound(err) {
+					errs = append(errs, err)
+					continue
+				}
+				changes = append(changes, Change{
+					Operation: OperationDelete,
+					Policy:    policyKey(policy),
+					Rule:      rule.Name,
+					Trigger:   trigger,
+					Target:    common.ResourceSpecFromUnstructured(downstream),
+					Reason:    reason,
+				})
+			}
+		}
+	}
+	return changes, multierr.Combine(errs...)
+}
+
+func (p *Previewer) previewRule(
+	ctx context.Context,
+	logger logr.Logger,
+	controller *GenerateController,
+	recorder *dryRunClient,
+	policy kyvernov1.PolicyInterface,
+	rule kyvernov1.Rule,
+	trigger *unstructured.Unstructured,
+	downstreams map[types.UID][]unstructured.Unstructured,
+	namespaceLabels map[string]map[string]string,
+) ([]Change, error) {
+	triggerSpec := common.ResourceSpecFromUnstructured(*trigger)
+	policy, _ = buildPolicyWithAppliedRules(policy, rule.Name)
+	labels, err := p.namespaceLabels(ctx, trigger, namespaceLabels)
+	if err != nil {
+		return nil, err
+	}
+	policyContext, err := common.NewBackgroundContext(logger, p.client, kyvernov2.UpdateRequestSpecContext{}, policy, trigger, p.configuration, p.jp, labels)
+	if err != nil {
+		return nil, err
+	}
+	applies := false
+	for _, r := range p.engine.Generate(ctx, policyContext).PolicyResponse.Rules {
+		if r.Status() == engineapi.RuleStatusPass {
+			applies = true
+		}
+	}
+	var changes []Change
+	if !applies {
+		for _, downstream := range downstreams[trigger.GetUID()] {
+			changes = append(changes, Change{
+				Operation: OperationDelete,
+				Target:    common.ResourceSpecFromUnstructured(downstream),
+				Reason:    reasonNoLongerMatches,
+			})
+		}
+	} else {
+		_, err = controller.ApplyGeneratePolicy(logger, policyContext, []string{rule.Name})
+		changes = recorder.flush()
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i := range changes {
+		changes[i].Policy = policyKey(policy)
+		changes[i].Rule = rule.Name
+		changes[i].Trigger = triggerSpec
+	}
+	return changes, nil
+}
+
+// removedRules returns the deletions of the downstream resources of the synchronized data rules
+// of the deployed policy that no longer exist in the policy
+func (p *Previewer) removedRules(ctx context.Context, policy, deployed kyvernov1.PolicyInterface) ([]Change, error) {
+	rules := map[string]struct{}{}
+	for _, rule := range policy.GetSpec().Rules {
+		rules[rule.Name] = struct{}{}
+	}
+	var changes []Change
+	var errs []error
+	for _, rule := range deployed.GetSpec().Rules {
+		if _, ok := rules[rule.Name]; ok || !rule.HasGenerate() || !rule.Generation.Synchronize {
+			continue
+		}
+		patterns := []kyvernov1.GeneratePattern{rule.Generation.GeneratePattern}
+		for _, foreach := range rule.Generation.ForEachGeneration {
+			patterns = append(patterns, foreach.GeneratePattern)
+		}
+		for _, pattern := range patterns {
+			if pattern.GetData() == nil {
+				continue
+			}
+			downstreams, err := common.FindDownstream(p.client, pattern.GetAPIVersion(), pattern.GetKind(), downstreamLabels(deployed, rule.Name))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to fetch downstream resources of rule %s: %w", rule.Name, err))
+				continue
+			}
+			for _, downstream := range downstreams.Items {
+				changes = append(changes, Change{
+					Operation: OperationDelete,
+					Policy:    policyKey(deployed),
+					Rule:      rule.Name,
+					Trigger:   TriggerFromLabels(downstream.GetLabels()),
+					Target:    common.ResourceSpecFromUnstructured(downstream),
+					Reason:    reasonRuleRemoved,
+				})
+			}
+		}
+	}
+	return changes, multierr.Combine(errs...)
+}
+
+// downstreams returns the downstream resources of a rule indexed by the uid of their trigger
+func (p *Previewer) downstreams(ctx context.Context, policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) (map[types.UID][]unstructured.Unstructured, error) {
+	patterns := []kyvernov1.GeneratePattern{rule.Generation.GeneratePattern}
+	for _, foreach := range rule.Generation.ForEachGeneration {
+		patterns = append(patterns, foreach.GeneratePattern)
+	}
+	type gvk struct{ apiVersion, kind string }
+	var kinds []gvk
+	for _, pattern := range patterns {
+		if pattern.GetKind() != "" {
+			kinds = append(kinds, gvk{pattern.GetAPIVersion(), pattern.GetKind()})
+		}
+		for _, kind := range pattern.CloneList.Kinds {
+			apiVersion, kind := kubeutils.GetKindFromGVK(kind)
+			kinds = append(kinds, gvk{apiVersion, kind})
+		}
+	}
+	index := map[types.UID][]unstructured.Unstructured{}
+	seen := map[types.UID]struct{}{}
+	for _, kind := range kinds {
+		list, err := common.FindDownstream(p.client, kind.apiVersion, kind.kind, downstreamLabels(policy, rule.Name))
+		if err != nil {
+			return nil, err
+		}
+		for _, downstream := range list.Items {
+			if _, ok := seen[downstream.GetUID()]; ok {
+				continue
+			}
+			seen[downstream.GetUID()] = struct{}{}
+			uid := types.UID(downstream.GetLabels()[common.GenerateTriggerUIDLabel])
+			index[uid] = append(index[uid], downstream)
+		}
+	}
+	return index, nil
+}
+
+// triggers lists the resources of the kinds matched by a rule
+func (p *Previewer) triggers(ctx context.Context, policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) ([]unstructured.Unstructured, error) {
+	descriptions := []kyvernov1.ResourceDescription{rule.MatchResources.ResourceDescription}
+	for _, filter := range rule.MatchResources.Any {
+		descriptions = append(descriptions, filter.ResourceDescription)
+	}
+	for _, filter := range rule.MatchResources.All {
+		descriptions = append(descriptions, filter.ResourceDescription)
+	}
+	namespace := ""
+	if policy.IsNamespaced() {
+		namespace = policy.GetNamespace()
+	}
+	var triggers []unstructured.Unstructured
+	for _, description := range descriptions {
+		for _, kind := range description.Kinds {
+			group, version, kind, _ := kubeutils.ParseKindSelector(kind)
+			if kind == "*" {
+				continue
+			}
+			groupVersion := ""
+			if group != "*" && version != "*" {
+				groupVersion = group + "/" + version
+			} else if version != "*" {
+				groupVersion = version
+			}
+			list, err := p.client.ListResource(ctx, groupVersion, kind, namespace, description.Selector)
+			if err != nil {
+				return nil, err
+			}
+			triggers = append(triggers, list.Items...)
+		}
+	}
+	return triggers, nil
+}
+
+func (p *Previewer) namespaceLabels(ctx context.Context, trigger *unstructured.Unstructured, known map[string]map[string]string) (map[string]string, error) {
+	namespace := trigger.GetNamespace()
+	if namespace == "" || trigger.GetKind() == "Namespace" {
+		return nil, nil
+	}
+	if labels, ok := known[namespace]; ok {
+		return labels, nil
+	}
+	ns, err := p.client.GetResource(ctx, "v1", "Namespace", "", namespace)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		ns = &unstructured.Unstructured{}
+	}
+	known[namespace] = ns.GetLabels()
+	return known[namespace], nil
+}
+
+func downstreamLabels(policy kyvernov1.PolicyInterface, rule string) map[string]string {
+	return map[string]string{
+		common.GeneratePolicyLabel:          policy.GetName(),
+		common.GeneratePolicyNamespaceLabel: policy.GetNamespace(),
+		common.GenerateRuleLabel:            rule,
+		kyverno.LabelAppManagedBy:           kyverno.ValueKyvernoApp,
+	}
+}
+
+func policyKey(policy kyvernov1.PolicyInterface) string {
+	return cache.MetaObjectToName(policy).String()
+}
@@ -0,0 +1,184 @@
+package generate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/adapters"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newPreviewNamespace(name string, labels map[string]string) *unstructured.Unstructured {
+	ns := &unstructured.Unstructured{}
+	ns.SetAPIVersion("v1")
+	ns.SetKind("Namespace")
+	ns.SetName(name)
+	ns.SetUID(types.UID(name + "-uid"))
+	ns.SetLabels(labels)
+	return ns
+}
+
+func newPreviewSecret(namespace, trigger, rule string, data map[string]interface{}) *unstructured.Unstructured {
+	secret := &unstructured.Unstructured{Object: map[string]interface{}{"data": data}}
+	secret.SetAPIVersion("v1")
+	secret.SetKind("Secret")
+	secret.SetName("registry")
+	secret.SetNamespace(namespace)
+	secret.SetUID(types.UID(namespace + "-secret-uid"))
+	secret.SetLabels(map[string]string{
+		"app.kubernetes.io/managed-by":      "kyverno",
+		common.GeneratePolicyLabel:          "sync-secret",
+		common.GeneratePolicyNamespaceLabel: "",
+		common.GenerateRuleLabel:            rule,
+		common.GenerateTriggerUIDLabel:      trigger + "-uid",
+		common.GenerateTriggerNSLabel:       "",
+		common.GenerateTriggerKindLabel:     "Namespace",
+		common.GenerateTriggerGroupLabel:    "",
+		common.GenerateTriggerVersionLabel:  "v1",
+		common.GenerateTriggerNameLabel:     trigger,
+	})
+	return secret
+}
+
+func newPreviewPolicy(rules ...string) *kyvernov1.ClusterPolicy {
+	policy := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "sync-secret"},
+	}
+	for _, rule := range rules {
+		policy.Spec.Rules = append(policy.Spec.Rules, kyvernov1.Rule{
+			Name: rule,
+			MatchResources: kyvernov1.MatchResources{
+				ResourceDescription: kyvernov1.ResourceDescription{
+					Kinds: []string{"Namespace"},
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"env": "prod"},
+					},
+				},
+			},
+			Generation: &kyvernov1.Generation{
+				Synchronize: true,
+				GeneratePattern: kyvernov1.GeneratePattern{
+					ResourceSpec: kyvernov1.ResourceSpec{
+						APIVersion: "v1",
+						Kind:       "Secret",
+						Name:       "registry",
+						Namespace:  "{{request.object.metadata.name}}",
+					},
+					RawData: &apiextv1.JSON{Raw: []byte(`{"data":{"token":"bmV3"}}`)},
+				},
+			},
+		})
+	}
+	return policy
+}
+
+func newPreviewer(t *testing.T, objects ...runtime.Object) *Previewer {
+	t.Helper()
+	namespaces := schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+	secrets := schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		namespaces: "NamespaceList",
+		secrets:    "SecretList",
+	}, objects...)
+	require.NoError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient([]schema.GroupVersionResource{namespaces, secrets}))
+	cfg := config.NewDefaultConfiguration(false)
+	jp := jmespath.New(cfg)
+	eng := engine.NewEngine(
+		cfg,
+		jp,
+		adapters.Client(client),
+		nil,
+		imageverifycache.DisabledImageVerifyCache(),
+		factories.DefaultContextLoaderFactory(nil),
+		nil,
+		nil,
+	)
+	return NewPreviewer(client, eng, cfg, jp)
+}
+
+func changesByTarget(changes []Change) map[string]Change {
+	out := map[string]Change{}
+	for _, change := range changes {
+		out[change.Target.GetNamespace()+"/"+change.Target.GetName()] = change
+	}
+	return out
+}
+
+func TestPreviewer_Preview(t *testing.T) {
+	created := newPreviewNamespace("created", map[string]string{"env": "prod"})
+	updated := newPreviewNamespace("updated", map[string]string{"env": "prod"})
+	unmatched := newPreviewNamespace("unmatched", nil)
+	previewer := newPreviewer(t,
+		created,
+		updated,
+		unmatched,
+		newPreviewSecret("updated", "updated", "sync", map[string]interface{}{"token": "b2xk"}),
+		newPreviewSecret("unmatched", "unmatched", "sync", map[string]interface{}{"token": "bmV3"}),
+	)
+	changes, err := previewer.Preview(context.TODO(), logr.Discard(), newPreviewPolicy("sync"), nil, []unstructured.Unstructured{*created, *updated, *unmatched})
+	require.NoError(t, err)
+	byTarget := changesByTarget(changes)
+	require.Len(t, byTarget, 3)
+
+	assert.Equal(t, OperationCreate, byTarget["created/registry"].Operation)
+	assert.Equal(t, "sync-secret", byTarget["created/registry"].Policy)
+	assert.Equal(t, "sync", byTarget["created/registry"].Rule)
+	assert.Equal(t, "created", byTarget["created/registry"].Trigger.GetName())
+	assert.Equal(t, "Secret", byTarget["created/registry"].Target.GetKind())
+
+	assert.Equal(t, OperationUpdate, byTarget["updated/registry"].Operation)
+
+	assert.Equal(t, OperationDelete, byTarget["unmatched/registry"].Operation)
+	assert.Equal(t, reasonNoLongerMatches, byTarget["unmatched/registry"].Reason)
+
+	// the cluster is left untouched
+	_, err = previewer.client.GetResource(context.TODO(), "v1", "Secret", "created", "registry")
+	assert.Error(t, err)
+	_, err = previewer.client.GetResource(context.TODO(), "v1", "Secret", "unmatched", "registry")
+	assert.NoError(t, err)
+}
+
+func TestPreviewer_Preview_removedRule(t *testing.T) {
+	previewer := newPreviewer(t,
+		newPreviewNamespace("prod", map[string]string{"env": "prod"}),
+		newPreviewSecret("prod", "prod", "removed", map[string]interface{}{"token": "bmV3"}),
+	)
+	changes, err := previewer.Preview(context.TODO(), logr.Discard(), newPreviewPolicy(), newPreviewPolicy("removed"), nil)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, OperationDelete, changes[0].Operation)
+	assert.Equal(t, "removed", changes[0].Rule)
+	assert.Equal(t, reasonRuleRemoved, changes[0].Reason)
+	assert.Equal(t, "prod", changes[0].Trigger.GetName())
+}
+
+func TestPreviewer_Drift(t *testing.T) {
+	previewer := newPreviewer(t,
+		newPreviewNamespace("missing", map[string]string{"env": "prod"}),
+		newPreviewSecret("deleted", "deleted", "sync", map[string]interface{}{"token": "bmV3"}),
+	)
+	changes, err := previewer.Drift(context.TODO(), logr.Discard(), newPreviewPolicy("sync"))
+	require.NoError(t, err)
+	byTarget := changesByTarget(changes)
+	require.Len(t, byTarget, 2)
+	assert.Equal(t, OperationCreate, byTarget["missing/registry"].Operation)
+	assert.Equal(t, OperationDelete, byTarget["deleted/registry"].Operation)
+	assert.Equal(t, reasonTriggerDeleted, byTarget["deleted/registry"].Reason)
+}
@@ -117,6 +117,9 @@ func (m *MockClient) UpdateStatusResource(ctx context.Context, apiVersion string
 func (m *MockClient) ApplyResource(ctx context.Context, apiVersion string, kind string, namespace, name string, obj interface{}, dryRun bool, fieldManager string, subresources ...string) (*unstructured.Unstructured, error) {
 	return nil, nil
 }
+func (m *MockClient) ApplyResourceWithOptions(ctx context.Context, apiVersion string, kind string, namespace, name string, obj interface{}, options metav1.ApplyOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	return nil, nil
+}
 func (m *MockClient) ApplyStatusResource(ctx context.Context, apiVersion string, kind string, namespace, name string, obj interface{}, dryRun bool, fieldManager string) (*unstructured.Unstructured, error) {
 	return nil, nil
 }
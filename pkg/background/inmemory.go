@@ -0,0 +1,90 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/background/inmemory"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/cache"
+)
+
+// syncInMemoryUpdateRequest processes an update request handed over in memory, failed update requests
+// are retried through the queue and persisted once the retries are exhausted
+func (c *controller) syncInMemoryUpdateRequest(name string) error {
+	startTime := time.Now()
+	ur, ok := c.memStore.Get(name)
+	if !ok {
+		// already processed, persisted or coalesced
+		return nil
+	}
+	if c.urIndexer != nil && ur.Status.State == kyvernov2.Pending && c.memStore.Coalesce(name, batchKey, newer) {
+		logger.V(4).Info("in-memory update request superseded by a more recent one", "name", name)
+		return nil
+	}
+	if !c.memStore.Start(name) {
+		return nil
+	}
+	if _, err := c.getPolicy(ur.Spec.Policy); err != nil && apierrors.IsNotFound(err) {
+		if ur.Spec.GetRequestType() == kyvernov2.Mutate {
+			c.memStore.Delete(name)
+			return nil
+		}
+	}
+	err := c.processUR(ur)
+	state := c.memStore.Finish(name)
+	if urMetrics := metrics.GetUpdateRequestMetrics(); urMetrics != nil {
+		success := err == nil && (state == kyvernov2.Completed || state == kyvernov2.Skip)
+		urMetrics.RecordProcessing(context.TODO(), metrics.UpdateRequestModeMemory, string(ur.Spec.GetRequestType()), success, time.Since(startTime))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to process in-memory UR %s: %v", name, err)
+	}
+	if state == kyvernov2.Failed {
+		return fmt.Errorf("failed to process in-memory UR %s", name)
+	}
+	logger.V(4).Info("synced in-memory update request", "name", name, "processingTime", time.Since(startTime).String(), "ur status", state)
+	return nil
+}
+
+// persistInMemory persists the in-memory update requests queued before the given time, the update requests
+// that cannot be persisted are kept in memory and retried on the next call
+func (c *controller) persistInMemory(ctx context.Context, before time.Time, reason string) {
+	for _, ur := range c.memStore.Drain(before) {
+		c.persist(ctx, ur, reason)
+	}
+}
+
+// persistFailedInMemory persists an in-memory update request that exhausted its retries, it returns false
+// if the key does not belong to an in-memory update request
+func (c *controller) persistFailedInMemory(key interface{}) bool {
+	if c.memStore == nil {
+		return false
+	}
+	_, name, err := cache.SplitMetaNamespaceKey(key.(string))
+	if err != nil || !inmemory.IsInMemory(name) {
+		return false
+	}
+	ur, ok := c.memStore.Remove(name)
+	if !ok {
+		return false
+	}
+	return c.persist(context.TODO(), ur, "retries")
+}
+
+func (c *controller) persist(ctx context.Context, ur *kyvernov2.UpdateRequest, reason string) bool {
+	persisted, err := inmemory.Persist(ctx, c.kyvernoClient, ur)
+	if err != nil {
+		logger.Error(err, "failed to persist in-memory update request", "name", ur.GetName(), "reason", reason)
+		c.memStore.Restore(ur)
+		return false
+	}
+	logger.V(3).Info("persisted in-memory update request", "name", ur.GetName(), "persisted", persisted.GetName(), "reason", reason)
+	if urMetrics := metrics.GetUpdateRequestMetrics(); urMetrics != nil {
+		urMetrics.RecordPersisted(ctx, reason)
+	}
+	return true
+}
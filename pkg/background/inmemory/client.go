@@ -0,0 +1,110 @@
+package inmemory
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+)
+
+// CAProvider returns the PEM encoded certificates of the CA the background controller certificate is signed with
+type CAProvider func() ([]byte, error)
+
+// Client hands update requests over to the background controller
+type Client interface {
+	Send(context.Context, kyvernov2.UpdateRequestSpec) error
+}
+
+type client struct {
+	url        string
+	token      []byte
+	caProvider CAProvider
+
+	lock   sync.Mutex
+	ca     []byte
+	client *http.Client
+}
+
+// NewClient returns a client posting the update requests over TLS to the background controller listening at address,
+// the server certificate is verified against the CA returned by caProvider
+func NewClient(address string, token []byte, caProvider CAProvider) Client {
+	return &client{
+		url:        strings.TrimSuffix(address, "/") + Path,
+		token:      token,
+		caProvider: caProvider,
+	}
+}
+
+// httpClient returns the http client trusting the current CA, it is rebuilt when the CA is renewed
+func (c *client) httpClient() (*http.Client, error) {
+	ca, err := c.caProvider()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the background controller CA: %w", err)
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.client != nil && bytes.Equal(c.ca, ca) {
+		return c.client, nil
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New("failed to parse the background controller CA")
+	}
+	if c.client != nil {
+		c.client.CloseIdleConnections()
+	}
+	c.ca = ca
+	c.client = &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    pool,
+				MinVersion: tls.VersionTLS12,
+			},
+		},
+	}
+	return c.client, nil
+}
+
+func (c *client) Send(ctx context.Context, spec kyvernov2.UpdateRequestSpec) error {
+	httpClient, err := c.httpClient()
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	nonce, err := newNonce()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(timestampHeader, timestamp)
+	req.Header.Set(nonceHeader, nonce)
+	req.Header.Set(signatureHeader, sign(c.token, timestamp, nonce, body))
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("update request was not accepted by the background controller: %s", resp.Status)
+	}
+	return nil
+}
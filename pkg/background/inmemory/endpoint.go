@@ -0,0 +1,93 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	"github.com/kyverno/kyverno/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	discoveryv1client "k8s.io/client-go/kubernetes/typed/discovery/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// PodIPEnv is the environment variable holding the IP address of the background controller pod
+	PodIPEnv = "UPDATE_REQUEST_QUEUE_POD_IP"
+	// PortName is the name of the service port routing the update requests
+	PortName = "update-requests"
+)
+
+// PodIPFromEnv returns the IP address of the background controller pod
+func PodIPFromEnv() (string, error) {
+	ip := os.Getenv(PodIPEnv)
+	if net.ParseIP(ip) == nil {
+		return "", fmt.Errorf("the %s environment variable must be set to the pod IP address when update requests are handed over in memory", PodIPEnv)
+	}
+	return ip, nil
+}
+
+// PublishEndpoint makes the endpoint slice of the service routing the update requests point to the given pod address.
+// The service has no selector, the leader publishes itself so that the admission controller never reaches a replica
+// that would reject the update requests. A stale endpoint left by a leader that crashed is replaced by the next leader.
+func PublishEndpoint(ctx context.Context, client discoveryv1client.EndpointSliceInterface, service, podIP string, port int32) error {
+	addressType := discoveryv1.AddressTypeIPv6
+	if ip := net.ParseIP(podIP); ip != nil && ip.To4() != nil {
+		addressType = discoveryv1.AddressTypeIPv4
+	}
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: service,
+			Labels: map[string]string{
+				discoveryv1.LabelServiceName: service,
+				discoveryv1.LabelManagedBy:   kyverno.ValueKyvernoApp,
+				kyverno.LabelAppManagedBy:    kyverno.ValueKyvernoApp,
+			},
+		},
+		AddressType: addressType,
+		Endpoints: []discoveryv1.Endpoint{{
+			Addresses:  []string{podIP},
+			Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)},
+			TargetRef: &corev1.ObjectReference{
+				Kind:      "Pod",
+				Namespace: config.KyvernoNamespace(),
+				Name:      config.KyvernoPodName(),
+			},
+		}},
+		Ports: []discoveryv1.EndpointPort{{
+			Name:     ptr.To(PortName),
+			Port:     ptr.To(port),
+			Protocol: ptr.To(corev1.ProtocolTCP),
+		}},
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := client.Get(ctx, service, metav1.GetOptions{})
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			_, err = client.Create(ctx, slice, metav1.CreateOptions{})
+			return err
+		}
+		if existing.AddressType != slice.AddressType {
+			// the address type is immutable
+			if err := client.Delete(ctx, service, metav1.DeleteOptions{}); err != nil {
+				return err
+			}
+			_, err = client.Create(ctx, slice, metav1.CreateOptions{})
+			return err
+		}
+		updated := existing.DeepCopy()
+		updated.Labels = slice.Labels
+		updated.Endpoints = slice.Endpoints
+		updated.Ports = slice.Ports
+		_, err = client.Update(ctx, updated, metav1.UpdateOptions{})
+		return err
+	})
+}
@@ -0,0 +1,35 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPublishEndpoint(t *testing.T) {
+	client := fake.NewSimpleClientset().DiscoveryV1().EndpointSlices("kyverno")
+	assert.NoError(t, PublishEndpoint(context.TODO(), client, "update-requests", "10.0.0.1", 9444))
+	slice, err := client.Get(context.TODO(), "update-requests", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "update-requests", slice.Labels[discoveryv1.LabelServiceName])
+	assert.Equal(t, discoveryv1.AddressTypeIPv4, slice.AddressType)
+	assert.Equal(t, []string{"10.0.0.1"}, slice.Endpoints[0].Addresses)
+	assert.Equal(t, PortName, *slice.Ports[0].Name)
+	assert.Equal(t, int32(9444), *slice.Ports[0].Port)
+
+	// a new leader replaces the endpoint
+	assert.NoError(t, PublishEndpoint(context.TODO(), client, "update-requests", "10.0.0.2", 9444))
+	slice, err = client.Get(context.TODO(), "update-requests", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, slice.Endpoints, 1)
+	assert.Equal(t, []string{"10.0.0.2"}, slice.Endpoints[0].Addresses)
+
+	assert.NoError(t, PublishEndpoint(context.TODO(), client, "update-requests", "fd00::2", 9444))
+	slice, err = client.Get(context.TODO(), "update-requests", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, discoveryv1.AddressTypeIPv6, slice.AddressType)
+}
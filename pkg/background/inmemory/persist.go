@@ -0,0 +1,31 @@
+package inmemory
+
+import (
+	"context"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Persist creates an UpdateRequest resource from an in-memory update request so that it survives a restart
+// of the background controller and is processed by the regular CRD path
+func Persist(ctx context.Context, client versioned.Interface, ur *kyvernov2.UpdateRequest) (*kyvernov2.UpdateRequest, error) {
+	persisted := &kyvernov2.UpdateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    config.KyvernoNamespace(),
+			GenerateName: "ur-",
+			Labels:       ur.GetLabels(),
+		},
+		Spec: ur.Spec,
+	}
+	created, err := client.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).Create(ctx, persisted, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	updated := created.DeepCopy()
+	updated.Status.State = kyvernov2.Pending
+	updated.Status.RetryCount = ur.Status.RetryCount
+	return client.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+}
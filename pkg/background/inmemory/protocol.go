@@ -0,0 +1,141 @@
+package inmemory
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// ModeCRD persists every update request as an UpdateRequest resource
+	ModeCRD = "crd"
+	// ModeMemory hands the update requests over to the background controller in memory
+	ModeMemory = "memory"
+)
+
+const (
+	// Path is the path the update requests are posted to
+	Path = "/updaterequests"
+	// TokenEnv is the environment variable holding the token shared by the admission and background controllers
+	TokenEnv = "UPDATE_REQUEST_QUEUE_TOKEN"
+
+	timestampHeader = "X-Kyverno-Timestamp"
+	nonceHeader     = "X-Kyverno-Nonce"
+	signatureHeader = "X-Kyverno-Signature"
+
+	// maxSkew is the maximum age of a signed request, older requests are rejected to prevent replays
+	maxSkew = time.Minute
+	// pruneInterval is the interval at which the expired nonces are forgotten
+	pruneInterval = 10 * time.Second
+)
+
+var (
+	errInvalidSignature = errors.New("invalid signature")
+	errReplayed         = errors.New("request was already received")
+)
+
+// ValidateMode returns an error if mode is not a known update request queue mode
+func ValidateMode(mode string) error {
+	switch mode {
+	case ModeCRD, ModeMemory:
+		return nil
+	}
+	return fmt.Errorf("invalid update request queue mode %q, must be %s or %s", mode, ModeCRD, ModeMemory)
+}
+
+// TokenFromEnv returns the token shared by the admission and background controllers
+func TokenFromEnv() ([]byte, error) {
+	token := os.Getenv(TokenEnv)
+	if token == "" {
+		return nil, fmt.Errorf("the %s environment variable must be set when update requests are handed over in memory", TokenEnv)
+	}
+	return []byte(token), nil
+}
+
+// newNonce returns a random value identifying a single request
+func newNonce() (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(nonce), nil
+}
+
+// sign returns the HMAC-SHA256 of the timestamp, nonce and body keyed with the shared token
+func sign(token []byte, timestamp, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, token)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte{'.'})
+	mac.Write([]byte(nonce))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verify checks the signature of a request, that it was signed recently enough and that it was
+// not received before, the nonces are remembered for as long as the signed requests are valid
+func verify(token []byte, timestamp, nonce, signature string, body []byte, now time.Time, nonces *nonceCache) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp: %w", err)
+	}
+	signedAt := time.Unix(seconds, 0)
+	if skew := now.Sub(signedAt); skew > maxSkew || skew < -maxSkew {
+		return fmt.Errorf("timestamp is outside of the allowed skew (%s)", maxSkew)
+	}
+	if nonce == "" {
+		return errors.New("missing nonce")
+	}
+	expected, err := hex.DecodeString(sign(token, timestamp, nonce, body))
+	if err != nil {
+		return err
+	}
+	actual, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, actual) {
+		return errInvalidSignature
+	}
+	if !nonces.add(nonce, signedAt.Add(maxSkew), now) {
+		return errReplayed
+	}
+	return nil
+}
+
+// nonceCache remembers the nonces of the accepted requests until their signature expires
+type nonceCache struct {
+	lock   sync.Mutex
+	expiry map[string]time.Time
+	pruned time.Time
+}
+
+func newNonceCache() *nonceCache {
+	return &nonceCache{
+		expiry: map[string]time.Time{},
+	}
+}
+
+// add records a nonce valid until expiry, it returns false if the nonce is already known
+func (c *nonceCache) add(nonce string, expiry, now time.Time) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	// expired nonces are dropped from time to time, not on every request
+	if now.Sub(c.pruned) > pruneInterval {
+		for known, until := range c.expiry {
+			if now.After(until) {
+				delete(c.expiry, known)
+			}
+		}
+		c.pruned = now
+	}
+	if _, ok := c.expiry[nonce]; ok {
+		return false
+	}
+	c.expiry[nonce] = expiry
+	return true
+}
@@ -0,0 +1,126 @@
+package inmemory
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/logging"
+)
+
+// maxBodySize limits the size of the update requests accepted by the server
+const maxBodySize = 1 << 20
+
+type Server interface {
+	// Run starts the server in a separate goroutine and returns immediately
+	Run()
+	// Stop stops the server and returns when it is shut down
+	Stop()
+}
+
+// TlsProvider returns the certificate and private key served by the server
+type TlsProvider func() ([]byte, []byte, error)
+
+type server struct {
+	server *http.Server
+	logger logr.Logger
+}
+
+// NewServer returns a server accepting over TLS the update requests signed with token and storing them in store
+func NewServer(logger logr.Logger, address string, token []byte, store *Store, tlsProvider TlsProvider) Server {
+	mux := http.NewServeMux()
+	mux.Handle(Path, NewHandler(logger, token, store))
+	return &server{
+		server: &http.Server{
+			Addr: address,
+			TLSConfig: &tls.Config{
+				GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+					certPem, keyPem, err := tlsProvider()
+					if err != nil {
+						return nil, err
+					}
+					pair, err := tls.X509KeyPair(certPem, keyPem)
+					if err != nil {
+						return nil, err
+					}
+					return &pair, nil
+				},
+				MinVersion: tls.VersionTLS12,
+				CipherSuites: []uint16{
+					// AEADs w/ ECDHE
+					tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+					tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+					tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+					tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+					tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+					tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
+				},
+			},
+			Handler:           mux,
+			ReadTimeout:       30 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			ReadHeaderTimeout: 30 * time.Second,
+			IdleTimeout:       5 * time.Minute,
+			ErrorLog:          logging.StdLogger(logger, ""),
+		},
+		logger: logger,
+	}
+}
+
+func (s *server) Run() {
+	go func() {
+		if err := s.server.ListenAndServeTLS("", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error(err, "failed to start server")
+		}
+	}()
+}
+
+func (s *server) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := s.server.Shutdown(ctx); err != nil {
+		if err := s.server.Close(); err != nil {
+			s.logger.Error(err, "failed to stop server")
+		}
+	}
+}
+
+// NewHandler returns the http handler accepting the update requests handed over by the admission controller,
+// a signed request is accepted only once
+func NewHandler(logger logr.Logger, token []byte, store *Store) http.Handler {
+	nonces := newNonceCache()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+		if err := verify(token, r.Header.Get(timestampHeader), r.Header.Get(nonceHeader), r.Header.Get(signatureHeader), body, time.Now(), nonces); err != nil {
+			logger.V(2).Info("rejected unauthenticated update request", "error", err.Error())
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		var spec kyvernov2.UpdateRequestSpec
+		if err := json.Unmarshal(body, &spec); err != nil {
+			http.Error(w, "failed to decode update request", http.StatusBadRequest)
+			return
+		}
+		ur, err := store.Accept(spec)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		logger.V(4).Info("accepted update request", "name", ur.GetName(), "type", spec.GetRequestType(), "policy", spec.Policy)
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
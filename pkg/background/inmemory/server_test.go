@@ -0,0 +1,103 @@
+package inmemory
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_verify(t *testing.T) {
+	token := []byte("secret")
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	body := []byte(`{"policy":"pol"}`)
+	signature := sign(token, timestamp, "nonce", body)
+	assert.NoError(t, verify(token, timestamp, "nonce", signature, body, now, newNonceCache()))
+	assert.ErrorIs(t, verify([]byte("other"), timestamp, "nonce", signature, body, now, newNonceCache()), errInvalidSignature)
+	assert.ErrorIs(t, verify(token, timestamp, "nonce", signature, []byte(`{"policy":"other"}`), now, newNonceCache()), errInvalidSignature)
+	assert.ErrorIs(t, verify(token, timestamp, "other", signature, body, now, newNonceCache()), errInvalidSignature)
+	assert.Error(t, verify(token, timestamp, "", sign(token, timestamp, "", body), body, now, newNonceCache()))
+	assert.Error(t, verify(token, timestamp, "nonce", signature, body, now.Add(2*maxSkew), newNonceCache()))
+	assert.Error(t, verify(token, "not-a-timestamp", "nonce", signature, body, now, newNonceCache()))
+}
+
+func Test_verify_replay(t *testing.T) {
+	token := []byte("secret")
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	body := []byte(`{"policy":"pol"}`)
+	nonces := newNonceCache()
+	assert.NoError(t, verify(token, timestamp, "nonce", sign(token, timestamp, "nonce", body), body, now, nonces))
+	// the same signed request is rejected while its signature is valid
+	assert.ErrorIs(t, verify(token, timestamp, "nonce", sign(token, timestamp, "nonce", body), body, now.Add(maxSkew/2), nonces), errReplayed)
+	assert.NoError(t, verify(token, timestamp, "other", sign(token, timestamp, "other", body), body, now, nonces))
+	// expired nonces are forgotten
+	later := now.Add(2 * maxSkew)
+	laterTimestamp := strconv.FormatInt(later.Unix(), 10)
+	assert.NoError(t, verify(token, laterTimestamp, "third", sign(token, laterTimestamp, "third", body), body, later, nonces))
+	assert.Len(t, nonces.expiry, 1)
+}
+
+func Test_Handler(t *testing.T) {
+	store := NewStore()
+	server := httptest.NewTLSServer(NewHandler(logr.Discard(), []byte("secret"), store))
+	defer server.Close()
+	ca := func() ([]byte, error) {
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), nil
+	}
+	spec := kyvernov2.UpdateRequestSpec{Type: kyvernov2.Generate, Policy: "pol"}
+	// no controller registered, the update request is rejected
+	assert.Error(t, NewClient(server.URL, []byte("secret"), ca).Send(context.TODO(), spec))
+	var handled []*kyvernov2.UpdateRequest
+	store.Register(func(ur *kyvernov2.UpdateRequest) { handled = append(handled, ur) })
+	// wrong token
+	assert.Error(t, NewClient(server.URL, []byte("wrong"), ca).Send(context.TODO(), spec))
+	assert.Empty(t, handled)
+	// untrusted server
+	untrusted := func() ([]byte, error) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: "other"},
+			NotBefore:             time.Now(),
+			NotAfter:              time.Now().Add(time.Hour),
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageCertSign,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		if err != nil {
+			return nil, err
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), nil
+	}
+	assert.Error(t, NewClient(server.URL, []byte("secret"), untrusted).Send(context.TODO(), spec))
+	assert.Empty(t, handled)
+	client := NewClient(server.URL, []byte("secret"), ca)
+	assert.NoError(t, client.Send(context.TODO(), spec))
+	assert.NoError(t, client.Send(context.TODO(), spec))
+	assert.Len(t, handled, 2)
+	assert.Equal(t, spec, handled[0].Spec)
+	// only posts are accepted
+	resp, err := server.Client().Get(server.URL + Path)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
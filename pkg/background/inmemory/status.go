@@ -0,0 +1,34 @@
+package inmemory
+
+import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/background/common"
+)
+
+// statusControl records the status of the in-memory update requests in the store
+type statusControl struct {
+	store   *Store
+	applier *common.Applier
+}
+
+// NewStatusControl returns a status control updating the update requests held in store, the conflicts
+// encountered by the applier (if any) are recorded in their status
+func NewStatusControl(store *Store, applier *common.Applier) common.StatusControlInterface {
+	return &statusControl{
+		store:   store,
+		applier: applier,
+	}
+}
+
+func (sc *statusControl) Failed(name, message string, genResources []kyvernov1.ResourceSpec) (*kyvernov2.UpdateRequest, error) {
+	return sc.store.updateStatus(name, kyvernov2.Failed, message, genResources, sc.applier.Conflicts())
+}
+
+func (sc *statusControl) Success(name string, genResources []kyvernov1.ResourceSpec) (*kyvernov2.UpdateRequest, error) {
+	return sc.store.updateStatus(name, kyvernov2.Completed, "", genResources, sc.applier.Conflicts())
+}
+
+func (sc *statusControl) Skip(name string, genResources []kyvernov1.ResourceSpec) (*kyvernov2.UpdateRequest, error) {
+	return sc.store.updateStatus(name, kyvernov2.Skip, "", genResources, sc.applier.Conflicts())
+}
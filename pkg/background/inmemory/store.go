@@ -0,0 +1,233 @@
+package inmemory
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/rand"
+)
+
+// namePrefix is the prefix of the in-memory update requests names, it differs from the
+// generate name of the persisted update requests so that both can never collide
+const namePrefix = "mem-"
+
+// ErrNotReady is returned when no background controller is consuming the store, this is
+// the case on the replicas that are not the leader
+var ErrNotReady = errors.New("background controller is not ready to process update requests")
+
+type entry struct {
+	ur         *kyvernov2.UpdateRequest
+	queued     time.Time
+	processing bool
+}
+
+// Store holds the update requests handed over in memory until they are processed or persisted
+type Store struct {
+	lock    sync.Mutex
+	entries map[string]*entry
+	handler func(*kyvernov2.UpdateRequest)
+}
+
+// NewStore returns an empty store
+func NewStore() *Store {
+	return &Store{
+		entries: map[string]*entry{},
+	}
+}
+
+// IsInMemory returns true if the update request name was given by the store
+func IsInMemory(name string) bool {
+	return strings.HasPrefix(name, namePrefix)
+}
+
+// Register sets the function called for every accepted update request, a nil handler stops accepting them
+func (s *Store) Register(handler func(*kyvernov2.UpdateRequest)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.handler = handler
+}
+
+// Accept stores a new update request built from spec and passes it to the registered handler
+func (s *Store) Accept(spec kyvernov2.UpdateRequestSpec) (*kyvernov2.UpdateRequest, error) {
+	s.lock.Lock()
+	handler := s.handler
+	if handler == nil {
+		s.lock.Unlock()
+		return nil, ErrNotReady
+	}
+	now := time.Now()
+	ur := &kyvernov2.UpdateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         config.KyvernoNamespace(),
+			Name:              namePrefix + rand.String(10),
+			Labels:            labelsOf(spec),
+			CreationTimestamp: metav1.NewTime(now),
+		},
+		Spec: spec,
+		Status: kyvernov2.UpdateRequestStatus{
+			State: kyvernov2.Pending,
+		},
+	}
+	s.entries[ur.GetName()] = &entry{ur: ur, queued: now}
+	s.lock.Unlock()
+	handler(ur.DeepCopy())
+	return ur, nil
+}
+
+// Get returns a copy of the update request with the given name
+func (s *Store) Get(name string) (*kyvernov2.UpdateRequest, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	e, ok := s.entries[name]
+	if !ok {
+		return nil, false
+	}
+	return e.ur.DeepCopy(), true
+}
+
+// Start marks an update request as being processed, it returns false if it is not in the store anymore
+func (s *Store) Start(name string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	e, ok := s.entries[name]
+	if !ok {
+		return false
+	}
+	e.processing = true
+	return true
+}
+
+// Finish marks the end of the processing of an update request and returns its state.
+// Completed and skipped update requests are removed, failed ones are set back to pending.
+func (s *Store) Finish(name string) kyvernov2.UpdateRequestState {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	e, ok := s.entries[name]
+	if !ok {
+		return kyvernov2.Skip
+	}
+	e.processing = false
+	state := e.ur.Status.State
+	switch state {
+	case kyvernov2.Completed, kyvernov2.Skip:
+		delete(s.entries, name)
+	case kyvernov2.Failed:
+		e.ur.Status.State = kyvernov2.Pending
+		e.ur.Status.RetryCount++
+	}
+	return state
+}
+
+// Delete removes an update request from the store
+func (s *Store) Delete(name string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.entries, name)
+}
+
+// Remove removes an update request from the store and returns it, unless it is being processed
+func (s *Store) Remove(name string) (*kyvernov2.UpdateRequest, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	e, ok := s.entries[name]
+	if !ok || e.processing {
+		return nil, false
+	}
+	delete(s.entries, name)
+	return e.ur, true
+}
+
+// Drain removes and returns the update requests queued before the given time that are not being processed
+func (s *Store) Drain(before time.Time) []*kyvernov2.UpdateRequest {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var urs []*kyvernov2.UpdateRequest
+	for name, e := range s.entries {
+		if e.processing || !e.queued.Before(before) {
+			continue
+		}
+		urs = append(urs, e.ur)
+		delete(s.entries, name)
+	}
+	return urs
+}
+
+// Coalesce collapses the pending update requests having the same key as the named one into the most recent
+// of them and removes the others from the store. It returns true when the named update request was superseded
+// by a more recent one and must not be processed.
+func (s *Store) Coalesce(name string, key func(*kyvernov2.UpdateRequest) string, newer func(a, b *kyvernov2.UpdateRequest) bool) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	e, ok := s.entries[name]
+	if !ok {
+		return false
+	}
+	batch := key(e.ur)
+	latest := e.ur
+	var stale []string
+	for other, o := range s.entries {
+		if other == name || o.processing || o.ur.Status.State != kyvernov2.Pending || key(o.ur) != batch {
+			continue
+		}
+		if newer(o.ur, latest) {
+			stale = append(stale, latest.GetName())
+			latest = o.ur
+		} else {
+			stale = append(stale, other)
+		}
+	}
+	for _, name := range stale {
+		delete(s.entries, name)
+	}
+	return latest != e.ur
+}
+
+// Restore puts back an update request that could not be persisted
+func (s *Store) Restore(ur *kyvernov2.UpdateRequest) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.entries[ur.GetName()]; !ok {
+		s.entries[ur.GetName()] = &entry{ur: ur, queued: ur.CreationTimestamp.Time}
+	}
+}
+
+// Len returns the number of update requests in the store
+func (s *Store) Len() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.entries)
+}
+
+func (s *Store) updateStatus(name string, state kyvernov2.UpdateRequestState, message string, genResources []kyvernov1.ResourceSpec, conflicts []kyvernov2.FieldConflict) (*kyvernov2.UpdateRequest, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	e, ok := s.entries[name]
+	if !ok {
+		return nil, errors.New("update request not found in memory: " + name)
+	}
+	e.ur.Status.State = state
+	e.ur.Status.Message = message
+	if genResources != nil {
+		e.ur.Status.GeneratedResources = genResources
+	}
+	e.ur.Status.Conflicts = conflicts
+	return e.ur.DeepCopy(), nil
+}
+
+func labelsOf(spec kyvernov2.UpdateRequestSpec) labels.Set {
+	switch spec.GetRequestType() {
+	case kyvernov2.Mutate:
+		return common.MutateLabelsSet(spec.Policy, spec.GetResource())
+	case kyvernov2.Generate:
+		return common.GenerateLabelsSet(spec.Policy)
+	}
+	return nil
+}
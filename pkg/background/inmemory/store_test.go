@@ -0,0 +1,85 @@
+package inmemory
+
+import (
+	"testing"
+	"time"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Store_notReady(t *testing.T) {
+	s := NewStore()
+	_, err := s.Accept(kyvernov2.UpdateRequestSpec{Type: kyvernov2.Mutate, Policy: "pol"})
+	assert.ErrorIs(t, err, ErrNotReady)
+	assert.Equal(t, 0, s.Len())
+}
+
+func Test_Store_lifecycle(t *testing.T) {
+	s := NewStore()
+	var handled []*kyvernov2.UpdateRequest
+	s.Register(func(ur *kyvernov2.UpdateRequest) { handled = append(handled, ur) })
+	ur, err := s.Accept(kyvernov2.UpdateRequestSpec{Type: kyvernov2.Mutate, Policy: "pol"})
+	assert.NoError(t, err)
+	assert.True(t, IsInMemory(ur.GetName()))
+	assert.Len(t, handled, 1)
+	assert.Equal(t, kyvernov2.Pending, ur.Status.State)
+	// failed update requests go back to pending
+	assert.True(t, s.Start(ur.GetName()))
+	_, err = NewStatusControl(s, nil).Failed(ur.GetName(), "boom", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, kyvernov2.Failed, s.Finish(ur.GetName()))
+	got, ok := s.Get(ur.GetName())
+	assert.True(t, ok)
+	assert.Equal(t, kyvernov2.Pending, got.Status.State)
+	assert.Equal(t, 1, got.Status.RetryCount)
+	// completed update requests are removed
+	assert.True(t, s.Start(ur.GetName()))
+	_, err = NewStatusControl(s, nil).Success(ur.GetName(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, kyvernov2.Completed, s.Finish(ur.GetName()))
+	_, ok = s.Get(ur.GetName())
+	assert.False(t, ok)
+	assert.False(t, s.Start(ur.GetName()))
+}
+
+func Test_Store_Drain(t *testing.T) {
+	s := NewStore()
+	s.Register(func(*kyvernov2.UpdateRequest) {})
+	first, _ := s.Accept(kyvernov2.UpdateRequestSpec{Type: kyvernov2.Mutate, Policy: "a"})
+	second, _ := s.Accept(kyvernov2.UpdateRequestSpec{Type: kyvernov2.Mutate, Policy: "b"})
+	assert.True(t, s.Start(second.GetName()))
+	assert.Empty(t, s.Drain(time.Now().Add(-time.Hour)))
+	drained := s.Drain(time.Now().Add(time.Second))
+	assert.Len(t, drained, 1)
+	assert.Equal(t, first.GetName(), drained[0].GetName())
+	_, removed := s.Remove(second.GetName())
+	assert.False(t, removed, "update requests being processed must not be removed")
+	s.Restore(drained[0])
+	assert.Equal(t, 2, s.Len())
+}
+
+func Test_Store_Coalesce(t *testing.T) {
+	s := NewStore()
+	s.Register(func(*kyvernov2.UpdateRequest) {})
+	key := func(ur *kyvernov2.UpdateRequest) string { return ur.Spec.Policy }
+	newer := func(a, b *kyvernov2.UpdateRequest) bool { return b.CreationTimestamp.Before(&a.CreationTimestamp) }
+	first, _ := s.Accept(kyvernov2.UpdateRequestSpec{Type: kyvernov2.Mutate, Policy: "a"})
+	time.Sleep(time.Millisecond)
+	processing, _ := s.Accept(kyvernov2.UpdateRequestSpec{Type: kyvernov2.Mutate, Policy: "a"})
+	time.Sleep(time.Millisecond)
+	second, _ := s.Accept(kyvernov2.UpdateRequestSpec{Type: kyvernov2.Mutate, Policy: "a"})
+	other, _ := s.Accept(kyvernov2.UpdateRequestSpec{Type: kyvernov2.Mutate, Policy: "b"})
+	assert.True(t, s.Start(processing.GetName()))
+	// the oldest update request is superseded and removed
+	assert.True(t, s.Coalesce(first.GetName(), key, newer))
+	_, ok := s.Get(first.GetName())
+	assert.False(t, ok)
+	// update requests being processed or with another key are kept
+	for _, name := range []string{processing.GetName(), second.GetName(), other.GetName()} {
+		_, ok := s.Get(name)
+		assert.True(t, ok, name)
+	}
+	assert.False(t, s.Coalesce(second.GetName(), key, newer))
+	assert.False(t, s.Coalesce("unknown", key, newer))
+}
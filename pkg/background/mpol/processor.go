@@ -45,6 +45,7 @@ type processor struct {
 	mapper        meta.RESTMapper
 	context       libs.Context
 	statusControl common.StatusControlInterface
+	applier       *common.Applier
 
 	eventGen event.Interface
 }
@@ -55,6 +56,7 @@ func NewProcessor(client dclient.Interface,
 	mapper meta.RESTMapper,
 	context libs.Context,
 	statusControl common.StatusControlInterface,
+	applier *common.Applier,
 	eventGen event.Interface,
 ) *processor {
 	return &processor{
@@ -64,6 +66,7 @@ func NewProcessor(client dclient.Interface,
 		mapper:        mapper,
 		context:       context,
 		statusControl: statusControl,
+		applier:       applier,
 		eventGen:      eventGen,
 	}
 }
@@ -145,7 +148,13 @@ func (p *processor) Process(ur *kyvernov2.UpdateRequest) error {
 			if err != nil {
 				failures = append(failures, fmt.Errorf("failed to refresh target resource for mpol %s: %v", ur.Spec.GetPolicyKey(), err))
 			}
-			if _, err := p.client.UpdateResource(context.TODO(), new.GetAPIVersion(), new.GetKind(), new.GetNamespace(), new.Object, false, ""); err != nil {
+			var updateErr error
+			if p.applier != nil && object != nil {
+				_, updateErr = p.applier.ApplyChanges(context.TODO(), object, new)
+			} else {
+				_, updateErr = p.client.UpdateResource(context.TODO(), new.GetAPIVersion(), new.GetKind(), new.GetNamespace(), new.Object, false, "")
+			}
+			if err := updateErr; err != nil {
 				failures = append(failures, fmt.Errorf("failed to update target resource for mpol %s: %v", ur.Spec.GetPolicyKey(), err))
 			}
 
@@ -60,7 +60,7 @@ var (
 		Version: "v1",
 	}})
 	ctx           = &fakeContext{}
-	statusControl = common.NewStatusControl(&kyvernoClient, nil)
+	statusControl = common.NewStatusControl(&kyvernoClient, nil, nil)
 	reportsConfig = reportutils.NewReportingConfig([]string{})
 )
 
@@ -116,6 +116,7 @@ func TestProcess_NoPolicyFound(t *testing.T) {
 		meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "kyverno.io", Version: "v1"}}),
 		&libs.FakeContextProvider{},
 		&fakeStatusControl{},
+		nil,
 		event.NewFake())
 
 	ur := &kyvernov2.UpdateRequest{
@@ -166,6 +167,7 @@ func TestProcess_EngineEvaluateError(t *testing.T) {
 		meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "", Version: "v1"}}),
 		&libs.FakeContextProvider{},
 		&fakeStatusControl{},
+		nil,
 		event.NewFake(),
 	)
 
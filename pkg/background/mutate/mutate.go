@@ -34,6 +34,7 @@ type mutateExistingController struct {
 	client        dclient.Interface
 	kyvernoClient versioned.Interface
 	statusControl common.StatusControlInterface
+	applier       *common.Applier
 	engine        engineapi.Engine
 
 	// listers
@@ -53,6 +54,7 @@ func NewMutateExistingController(
 	client dclient.Interface,
 	kyvernoClient versioned.Interface,
 	statusControl common.StatusControlInterface,
+	applier *common.Applier,
 	engine engineapi.Engine,
 	policyLister kyvernov1listers.ClusterPolicyLister,
 	npolicyLister kyvernov1listers.PolicyLister,
@@ -66,6 +68,7 @@ func NewMutateExistingController(
 		client:        client,
 		kyvernoClient: kyvernoClient,
 		statusControl: statusControl,
+		applier:       applier,
 		engine:        engine,
 		policyLister:  policyLister,
 		npolicyLister: npolicyLister,
@@ -194,7 +197,9 @@ func (c *mutateExistingController) ProcessUR(ur *kyvernov2.UpdateRequest) error
 
 				patchedNew.SetResourceVersion(patched.GetResourceVersion())
 				var updateErr error
-				if patchedSubresource == "status" {
+				if c.applier != nil && (patchedSubresource == "" || patchedSubresource == "status") {
+					updateErr = c.applyChanges(patchedNew, patchedSubresource)
+				} else if patchedSubresource == "status" {
 					_, updateErr = c.client.UpdateStatusResource(context.TODO(), patchedNew.GetAPIVersion(), patchedNew.GetKind(), patchedNew.GetNamespace(), patchedNew.Object, false)
 				} else if patchedSubresource != "" {
 					parentResourceGVR := parentGVR
@@ -225,6 +230,20 @@ func (c *mutateExistingController) ProcessUR(ur *kyvernov2.UpdateRequest) error
 	return updateURStatus(c.statusControl, *ur, err)
 }
 
+// applyChanges applies the changes made to the target with server-side apply
+func (c *mutateExistingController) applyChanges(patched *unstructured.Unstructured, subresource string) error {
+	current, err := c.client.GetResource(context.TODO(), patched.GetAPIVersion(), patched.GetKind(), patched.GetNamespace(), patched.GetName())
+	if err != nil {
+		return err
+	}
+	var subresources []string
+	if subresource != "" {
+		subresources = append(subresources, subresource)
+	}
+	_, err = c.applier.ApplyChanges(context.TODO(), current, patched, subresources...)
+	return err
+}
+
 func (c *mutateExistingController) getPolicy(ur *kyvernov2.UpdateRequest) (policy kyvernov1.PolicyInterface, err error) {
 	pNamespace, pName, err := cache.SplitMetaNamespaceKey(ur.Spec.Policy)
 	if err != nil {
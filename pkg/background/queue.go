@@ -0,0 +1,116 @@
+package background
+
+import (
+	"strings"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+)
+
+// priority is the processing priority of the update requests created for a policy.
+type priority int
+
+const (
+	priorityLow priority = iota
+	priorityNormal
+	priorityHigh
+)
+
+// priorityOf returns the priority set on the policy with the kyverno.io/update-request-priority annotation,
+// policies without the annotation or with an unknown value get the normal priority.
+func priorityOf(annotations map[string]string) priority {
+	switch strings.ToLower(annotations[kyverno.AnnotationUpdateRequestPriority]) {
+	case "high":
+		return priorityHigh
+	case "low":
+		return priorityLow
+	default:
+		return priorityNormal
+	}
+}
+
+// weights are the number of items served per priority in every round, the lower priorities
+// keep being served while higher priority items are pending so that they cannot starve.
+var weights = [priorityHigh + 1]int{
+	priorityLow:    1,
+	priorityNormal: 2,
+	priorityHigh:   4,
+}
+
+// fairQueue is a workqueue.Queue serving update requests by priority.
+// Items are served in weighted rounds, every round serves up to the weight of each priority, higher
+// priorities first. Items of the same priority are served round-robin across policies and items of
+// the same policy are served in FIFO order.
+// A policy creating thousands of update requests therefore cannot starve the other policies.
+type fairQueue struct {
+	classify func(any) (string, priority)
+	tiers    [priorityHigh + 1]fairTier
+	// credits are the numbers of items left to serve per priority in the current round
+	credits [priorityHigh + 1]int
+	len     int
+}
+
+type fairTier struct {
+	// policies with pending items, in the order they will be served
+	order []string
+	items map[string][]any
+}
+
+func newFairQueue(classify func(any) (string, priority)) *fairQueue {
+	return &fairQueue{
+		classify: classify,
+	}
+}
+
+func (q *fairQueue) Touch(item any) {}
+
+func (q *fairQueue) Push(item any) {
+	policy, priority := q.classify(item)
+	if priority < priorityLow || priority > priorityHigh {
+		priority = priorityNormal
+	}
+	tier := &q.tiers[priority]
+	if tier.items == nil {
+		tier.items = map[string][]any{}
+	}
+	if len(tier.items[policy]) == 0 {
+		tier.order = append(tier.order, policy)
+	}
+	tier.items[policy] = append(tier.items[policy], item)
+	q.len++
+}
+
+func (q *fairQueue) Len() int {
+	return q.len
+}
+
+func (q *fairQueue) Pop() any {
+	if q.len == 0 {
+		return nil
+	}
+	for {
+		for p := priorityHigh; p >= priorityLow; p-- {
+			if len(q.tiers[p].order) == 0 || q.credits[p] == 0 {
+				continue
+			}
+			q.credits[p]--
+			q.len--
+			return q.tiers[p].pop()
+		}
+		// the pending priorities used their credits, start a new round
+		q.credits = weights
+	}
+}
+
+func (t *fairTier) pop() any {
+	policy := t.order[0]
+	t.order = t.order[1:]
+	items := t.items[policy]
+	item := items[0]
+	if len(items) == 1 {
+		delete(t.items, policy)
+	} else {
+		t.items[policy] = items[1:]
+		t.order = append(t.order, policy)
+	}
+	return item
+}
@@ -0,0 +1,82 @@
+package background
+
+import (
+	"testing"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_priorityOf(t *testing.T) {
+	assert.Equal(t, priorityNormal, priorityOf(nil))
+	assert.Equal(t, priorityHigh, priorityOf(map[string]string{kyverno.AnnotationUpdateRequestPriority: "High"}))
+	assert.Equal(t, priorityLow, priorityOf(map[string]string{kyverno.AnnotationUpdateRequestPriority: "low"}))
+	assert.Equal(t, priorityNormal, priorityOf(map[string]string{kyverno.AnnotationUpdateRequestPriority: "urgent"}))
+}
+
+func Test_fairQueue(t *testing.T) {
+	classes := map[string]struct {
+		policy   string
+		priority priority
+	}{
+		"a1": {"a", priorityNormal},
+		"a2": {"a", priorityNormal},
+		"a3": {"a", priorityNormal},
+		"b1": {"b", priorityNormal},
+		"c1": {"c", priorityLow},
+		"d1": {"d", priorityHigh},
+		"d2": {"d", priorityHigh},
+	}
+	q := newFairQueue(func(item any) (string, priority) {
+		class := classes[item.(string)]
+		return class.policy, class.priority
+	})
+	for _, item := range []string{"c1", "a1", "a2", "a3", "b1", "d1", "d2"} {
+		q.Push(item)
+	}
+	assert.Equal(t, 7, q.Len())
+	var got []string
+	for q.Len() > 0 {
+		got = append(got, q.Pop().(string))
+	}
+	// the low priority item is served once the other priorities used their weight
+	assert.Equal(t, []string{"d1", "d2", "a1", "b1", "c1", "a2", "a3"}, got)
+}
+
+func Test_fairQueue_noStarvation(t *testing.T) {
+	q := newFairQueue(func(item any) (string, priority) {
+		switch item.(string)[:1] {
+		case "h":
+			return "high", priorityHigh
+		case "n":
+			return "normal", priorityNormal
+		default:
+			return "low", priorityLow
+		}
+	})
+	q.Push("l1")
+	q.Push("n1")
+	for i := 0; i < 100; i++ {
+		q.Push("h")
+	}
+	var got []string
+	for i := 0; i < 7; i++ {
+		got = append(got, q.Pop().(string))
+	}
+	// high priority items keep coming but the other priorities are served every round
+	assert.Equal(t, []string{"h", "h", "h", "h", "n1", "l1", "h"}, got)
+	assert.Equal(t, 95, q.Len())
+}
+
+func Test_fairQueue_pushAfterPop(t *testing.T) {
+	q := newFairQueue(func(item any) (string, priority) {
+		return item.(string)[:1], priorityNormal
+	})
+	q.Push("a1")
+	q.Push("a2")
+	assert.Equal(t, "a1", q.Pop())
+	q.Push("b1")
+	assert.Equal(t, "a2", q.Pop())
+	assert.Equal(t, "b1", q.Pop())
+	assert.Equal(t, 0, q.Len())
+}
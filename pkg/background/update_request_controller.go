@@ -10,6 +10,7 @@ import (
 	common "github.com/kyverno/kyverno/pkg/background/common"
 	"github.com/kyverno/kyverno/pkg/background/generate"
 	"github.com/kyverno/kyverno/pkg/background/gpol"
+	"github.com/kyverno/kyverno/pkg/background/inmemory"
 	"github.com/kyverno/kyverno/pkg/background/mpol"
 	"github.com/kyverno/kyverno/pkg/background/mutate"
 	"github.com/kyverno/kyverno/pkg/cel/libs"
@@ -25,6 +26,7 @@ import (
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -59,6 +61,15 @@ type controller struct {
 	urLister   kyvernov2listers.UpdateRequestNamespaceLister
 	nsLister   corev1listers.NamespaceLister
 
+	// urIndexer indexes update requests by batch key, nil when batching is disabled
+	urIndexer cache.Indexer
+
+	// memStore holds the update requests handed over in memory, nil when they are only persisted as CRDs
+	memStore *inmemory.Store
+	// persistAfter is the delay after which the in-memory update requests still pending are persisted,
+	// the pending ones that were not persisted yet are lost if the controller crashes
+	persistAfter time.Duration
+
 	informersSynced []cache.InformerSynced
 
 	// queue
@@ -74,6 +85,9 @@ type controller struct {
 	eventGen      event.Interface
 	configuration config.Configuration
 	jp            jmespath.Interface
+
+	serverSideApply  bool
+	conflictStrategy common.ConflictStrategy
 }
 
 // NewController returns an instance of the Generate-Request Controller
@@ -95,6 +109,11 @@ func NewController(
 	configuration config.Configuration,
 	jp jmespath.Interface,
 	reportsConfig reportutils.ReportingConfiguration,
+	serverSideApply bool,
+	conflictStrategy common.ConflictStrategy,
+	batching bool,
+	memStore *inmemory.Store,
+	persistAfter time.Duration,
 ) Controller {
 	urLister := urInformer.Lister().UpdateRequests(config.KyvernoNamespace())
 	c := controller{
@@ -105,10 +124,6 @@ func NewController(
 		polLister:     polInformer.Lister(),
 		urLister:      urLister,
 		nsLister:      namespaceInformer.Lister(),
-		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
-			workqueue.DefaultTypedControllerRateLimiter[any](),
-			workqueue.TypedRateLimitingQueueConfig[any]{Name: "background"},
-		),
 		context:       context,
 		gpolEngine:    gpolEngine,
 		gpolProvider:  gpolProvider,
@@ -118,6 +133,32 @@ func NewController(
 		eventGen:      eventGen,
 		configuration: configuration,
 		jp:            jp,
+
+		serverSideApply:  serverSideApply,
+		conflictStrategy: conflictStrategy,
+
+		memStore:     memStore,
+		persistAfter: persistAfter,
+	}
+	c.queue = workqueue.NewTypedRateLimitingQueueWithConfig(
+		workqueue.DefaultTypedControllerRateLimiter[any](),
+		workqueue.TypedRateLimitingQueueConfig[any]{
+			Name: "background",
+			DelayingQueue: workqueue.NewTypedDelayingQueueWithConfig(workqueue.TypedDelayingQueueConfig[any]{
+				Name: "background",
+				Queue: workqueue.NewTypedWithConfig(workqueue.TypedQueueConfig[any]{
+					Name:  "background",
+					Queue: newFairQueue(c.classify),
+				}),
+			}),
+		},
+	)
+	if batching {
+		if err := urInformer.Informer().AddIndexers(cache.Indexers{batchIndex: batchIndexFunc}); err != nil {
+			logger.Error(err, "failed to add update request batch index, batching is disabled")
+		} else {
+			c.urIndexer = urInformer.Informer().GetIndexer()
+		}
 	}
 	_, _ = urInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.addUR,
@@ -144,6 +185,20 @@ func (c *controller) Run(ctx context.Context, workers int) {
 		go wait.UntilWithContext(ctx, c.worker, time.Second)
 	}
 
+	if c.memStore != nil {
+		c.memStore.Register(func(ur *kyvernov2.UpdateRequest) { c.enqueueUpdateRequest(ur) })
+		// stop accepting in-memory update requests and persist the pending ones so that they are not lost
+		defer func() {
+			c.memStore.Register(nil)
+			c.persistInMemory(context.Background(), time.Now(), "shutdown")
+		}()
+		// the store is checked several times per delay so that an update request is persisted
+		// close to the delay, they are lost if the controller crashes before
+		go wait.UntilWithContext(ctx, func(ctx context.Context) {
+			c.persistInMemory(ctx, time.Now().Add(-c.persistAfter), "stale")
+		}, max(c.persistAfter/10, time.Second))
+	}
+
 	<-ctx.Done()
 }
 
@@ -184,6 +239,11 @@ func (c *controller) handleErr(err error, key interface{}) {
 		return
 	}
 
+	if c.persistFailedInMemory(key) {
+		c.queue.Forget(key)
+		return
+	}
+
 	logger.Error(err, "failed to process update request", "key", key)
 	c.queue.Forget(key)
 }
@@ -195,6 +255,9 @@ func (c *controller) syncUpdateRequest(key string) error {
 	if err != nil {
 		return err
 	}
+	if c.memStore != nil && inmemory.IsInMemory(urName) {
+		return c.syncInMemoryUpdateRequest(urName)
+	}
 	ur, err := c.urLister.Get(urName)
 	if err != nil {
 		return err
@@ -209,7 +272,19 @@ func (c *controller) syncUpdateRequest(key string) error {
 	}
 
 	if ur.Status.State == kyvernov2.Pending {
-		if err := c.processUR(ur); err != nil {
+		superseded, err := c.coalesce(ur)
+		if err != nil {
+			return fmt.Errorf("failed to coalesce UR %s: %v", key, err)
+		}
+		if superseded {
+			logger.V(4).Info("update request superseded by a more recent one", "key", key)
+			return nil
+		}
+		err = c.processUR(ur)
+		if urMetrics := metrics.GetUpdateRequestMetrics(); urMetrics != nil {
+			urMetrics.RecordProcessing(context.TODO(), metrics.UpdateRequestModeCRD, string(ur.Spec.GetRequestType()), err == nil, time.Since(startTime))
+		}
+		if err != nil {
 			return fmt.Errorf("failed to process UR %s: %v", key, err)
 		}
 	}
@@ -247,19 +322,28 @@ func (c *controller) updateUR(_, cur interface{}) {
 }
 
 func (c *controller) processUR(ur *kyvernov2.UpdateRequest) error {
-	statusControl := common.NewStatusControl(c.kyvernoClient, c.urLister)
+	var applier *common.Applier
+	if c.serverSideApply {
+		applier = common.NewApplier(c.client, c.conflictStrategy)
+	}
+	var statusControl common.StatusControlInterface
+	if c.memStore != nil && inmemory.IsInMemory(ur.GetName()) {
+		statusControl = inmemory.NewStatusControl(c.memStore, applier)
+	} else {
+		statusControl = common.NewStatusControl(c.kyvernoClient, c.urLister, applier)
+	}
 	switch ur.Spec.GetRequestType() {
 	case kyvernov2.Mutate:
-		ctrl := mutate.NewMutateExistingController(c.client, c.kyvernoClient, statusControl, c.engine, c.cpolLister, c.polLister, c.nsLister, c.configuration, c.eventGen, logger, c.jp)
+		ctrl := mutate.NewMutateExistingController(c.client, c.kyvernoClient, statusControl, applier, c.engine, c.cpolLister, c.polLister, c.nsLister, c.configuration, c.eventGen, logger, c.jp)
 		return ctrl.ProcessUR(ur)
 	case kyvernov2.Generate:
-		ctrl := generate.NewGenerateController(c.client, c.kyvernoClient, statusControl, c.engine, c.cpolLister, c.polLister, c.urLister, c.nsLister, c.configuration, c.eventGen, logger, c.jp)
+		ctrl := generate.NewGenerateController(c.client, c.kyvernoClient, statusControl, applier, c.engine, c.cpolLister, c.polLister, c.urLister, c.nsLister, c.configuration, c.eventGen, logger, c.jp)
 		return ctrl.ProcessUR(ur)
 	case kyvernov2.CELGenerate:
 		ctrl := gpol.NewCELGenerateController(c.client, c.kyvernoClient, c.context, c.gpolEngine, c.gpolProvider, c.watchManager, statusControl, c.eventGen, logger)
 		return ctrl.ProcessUR(ur)
 	case kyvernov2.CELMutate:
-		processor := mpol.NewProcessor(c.client, c.kyvernoClient, c.mpolEngine, c.restMapper, c.context, statusControl, c.eventGen)
+		processor := mpol.NewProcessor(c.client, c.kyvernoClient, c.mpolEngine, c.restMapper, c.context, statusControl, applier, c.eventGen)
 		return processor.Process(ur)
 	}
 	return nil
@@ -289,6 +373,44 @@ func (c *controller) reconcileURStatus(ur *kyvernov2.UpdateRequest) (kyvernov2.U
 	return new.Status.State, errUpdate
 }
 
+// classify returns the policy and the priority of a queued update request key
+func (c *controller) classify(item any) (string, priority) {
+	key, ok := item.(string)
+	if !ok {
+		return "", priorityNormal
+	}
+	_, urName, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return "", priorityNormal
+	}
+	ur, err := c.getUR(urName)
+	if err != nil {
+		return "", priorityNormal
+	}
+	policyKey := ur.Spec.Policy
+	switch ur.Spec.GetRequestType() {
+	case kyvernov2.Mutate, kyvernov2.Generate:
+		policy, err := c.getPolicy(policyKey)
+		if err != nil {
+			return policyKey, priorityNormal
+		}
+		return policyKey, priorityOf(policy.GetAnnotations())
+	default:
+		return string(ur.Spec.GetRequestType()) + "/" + policyKey, priorityNormal
+	}
+}
+
+// getUR returns the update request with the given name, from the in-memory store or the lister
+func (c *controller) getUR(name string) (*kyvernov2.UpdateRequest, error) {
+	if c.memStore != nil && inmemory.IsInMemory(name) {
+		if ur, ok := c.memStore.Get(name); ok {
+			return ur, nil
+		}
+		return nil, apierrors.NewNotFound(kyvernov2.Resource("updaterequests"), name)
+	}
+	return c.urLister.Get(name)
+}
+
 func (c *controller) getPolicy(key string) (kyvernov1.PolicyInterface, error) {
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
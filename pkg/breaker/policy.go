@@ -0,0 +1,69 @@
+package breaker
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+var policyBreaker atomic.Value
+
+func GetPolicyBreaker() PolicyBreaker {
+	if v := policyBreaker.Load(); v != nil {
+		return v.(PolicyBreaker)
+	}
+	return nil
+}
+
+func SetPolicyBreaker(b PolicyBreaker) {
+	policyBreaker.Store(b)
+}
+
+// PolicyBreaker tracks policies degraded after exceeding their admission latency budget.
+type PolicyBreaker interface {
+	// Degraded returns true if the policy identified by key is currently degraded
+	Degraded(key string) bool
+	// Trip marks the policy identified by key as degraded for the given duration
+	Trip(key string, duration time.Duration)
+	// Reset marks the policy identified by key as healthy
+	Reset(key string)
+}
+
+type perPolicyBreaker struct {
+	lock     sync.RWMutex
+	clock    clock.PassiveClock
+	degraded map[string]time.Time
+}
+
+func NewPolicyBreaker(clock clock.PassiveClock) *perPolicyBreaker {
+	return &perPolicyBreaker{
+		clock:    clock,
+		degraded: map[string]time.Time{},
+	}
+}
+
+func (b *perPolicyBreaker) Degraded(key string) bool {
+	b.lock.RLock()
+	until, ok := b.degraded[key]
+	b.lock.RUnlock()
+	if !ok {
+		return false
+	}
+	// once the degradation period elapsed the policy is evaluated again,
+	// it stays healthy unless it exceeds its budget and trips the breaker again
+	return b.clock.Now().Before(until)
+}
+
+func (b *perPolicyBreaker) Trip(key string, duration time.Duration) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.degraded[key] = b.clock.Now().Add(duration)
+}
+
+func (b *perPolicyBreaker) Reset(key string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	delete(b.degraded, key)
+}
@@ -0,0 +1,26 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func Test_perPolicyBreaker(t *testing.T) {
+	clock := clocktesting.NewFakePassiveClock(time.Now())
+	subject := NewPolicyBreaker(clock)
+	assert.False(t, subject.Degraded("foo"))
+	subject.Trip("foo", time.Minute)
+	assert.True(t, subject.Degraded("foo"))
+	assert.False(t, subject.Degraded("bar"))
+	clock.SetTime(clock.Now().Add(30 * time.Second))
+	assert.True(t, subject.Degraded("foo"))
+	clock.SetTime(clock.Now().Add(time.Minute))
+	assert.False(t, subject.Degraded("foo"))
+	subject.Trip("foo", time.Minute)
+	assert.True(t, subject.Degraded("foo"))
+	subject.Reset("foo")
+	assert.False(t, subject.Degraded("foo"))
+}
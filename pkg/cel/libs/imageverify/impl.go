@@ -3,19 +3,24 @@ package imageverify
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/cel-go/common/types"
 	"github.com/google/cel-go/common/types/ref"
 	"github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/cel/compiler"
 	"github.com/kyverno/kyverno/pkg/cel/matching"
 	"github.com/kyverno/kyverno/pkg/imageverification/imagedataloader"
 	"github.com/kyverno/kyverno/pkg/imageverification/imageverifiers/cosign"
 	"github.com/kyverno/kyverno/pkg/imageverification/imageverifiers/notary"
+	"github.com/kyverno/kyverno/pkg/imageverification/imageverifiers/predicates"
+	"github.com/kyverno/kyverno/pkg/imageverification/results"
 	"github.com/kyverno/sdk/cel/utils"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	k8scorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
 )
 
 type ivfuncs struct {
@@ -23,6 +28,8 @@ type ivfuncs struct {
 
 	logger          logr.Logger
 	imgCtx          imagedataloader.ImageContext
+	policy          string
+	policyVersion   string
 	creds           *v1beta1.Credentials
 	imgRules        []compiler.MatchImageReference
 	attestationList map[string]v1beta1.Attestation
@@ -52,6 +59,8 @@ func ImageVerifyCELFuncs(
 	return &ivfuncs{
 		Adapter:         adapter,
 		imgCtx:          imgCtx,
+		policy:          cache.MetaObjectToName(ivpol).String(),
+		policyVersion:   ivpol.GetResourceVersion(),
 		creds:           spec.Credentials,
 		imgRules:        imgRules,
 		attestationList: attestationMap(ivpol),
@@ -83,8 +92,10 @@ func (f *ivfuncs) verify_image_signature_string_stringarray(image ref.Val, attes
 			if attestor.IsCosign() {
 				if err := f.cosignVerifier.VerifyImageSignature(ctx, img, &attestor); err != nil {
 					f.logger.Info("failed to verify image cosign", "error", err)
+					f.record(img, attestor, "", err)
 				} else {
 					count += 1
+					f.record(img, attestor, "", nil)
 				}
 			} else if attestor.IsNotary() {
 				var certs, tsaCerts string
@@ -96,8 +107,10 @@ func (f *ivfuncs) verify_image_signature_string_stringarray(image ref.Val, attes
 				}
 				if err := f.notaryVerifier.VerifyImageSignature(ctx, img, certs, tsaCerts); err != nil {
 					f.logger.Info("failed to verify image notary", "error", err)
+					f.record(img, attestor, "", err)
 				} else {
 					count += 1
+					f.record(img, attestor, "", nil)
 				}
 			}
 		}
@@ -136,8 +149,10 @@ func (f *ivfuncs) verify_image_attestations_string_string_stringarray(args ...re
 			if attestor.IsCosign() {
 				if err := f.cosignVerifier.VerifyAttestationSignature(ctx, img, &attest, &attestor); err != nil {
 					f.logger.Info("failed to verify attestation cosign", "error", err)
+					f.record(img, attestor, attestation, err)
 				} else {
 					count += 1
+					f.record(img, attestor, attestation, nil)
 				}
 			} else if attestor.IsNotary() {
 				if attest.Referrer == nil {
@@ -152,8 +167,10 @@ func (f *ivfuncs) verify_image_attestations_string_string_stringarray(args ...re
 				}
 				if err := f.notaryVerifier.VerifyAttestationSignature(ctx, img, attest.Referrer.Type, certs, tsaCerts); err != nil {
 					f.logger.Info("failed to verify attestation notary", "error", err)
+					f.record(img, attestor, attestation, err)
 				} else {
 					count += 1
+					f.record(img, attestor, attestation, nil)
 				}
 			}
 		}
@@ -161,6 +178,35 @@ func (f *ivfuncs) verify_image_attestations_string_string_stringarray(args ...re
 	}
 }
 
+// record publishes the outcome of the verification of an image by an attestor, attestation verifications
+// are recorded as `<attestor>/<attestation>`
+func (f *ivfuncs) record(img *imagedataloader.ImageData, attestor v1beta1.Attestor, attestation string, err error) {
+	result := results.Result{
+		Image:                 img.Image,
+		Digest:                img.Digest,
+		Policy:                f.policy,
+		PolicyResourceVersion: f.policyVersion,
+		Rule:                  attestor.Name,
+		Status:                kyvernov2alpha1.ImageVerificationPass,
+		Signers:               results.SignersFromAttestor(attestor),
+	}
+	if attestation != "" {
+		result.Rule = attestor.Name + "/" + attestation
+		if attest, ok := f.attestationList[attestation]; ok && err == nil {
+			if attest.InToto != nil {
+				result.Attestations = []string{attest.InToto.Type}
+			} else if attest.Referrer != nil {
+				result.Attestations = []string{attest.Referrer.Type}
+			}
+		}
+	}
+	if err != nil {
+		result.Status = kyvernov2alpha1.ImageVerificationFail
+		result.Message = err.Error()
+	}
+	results.Record(result)
+}
+
 func (f *ivfuncs) payload_string_string(image ref.Val, attestation ref.Val) ref.Val {
 	ctx := context.TODO()
 	if image, err := utils.ConvertToNative[string](image); err != nil {
@@ -198,3 +244,71 @@ func (f *ivfuncs) get_image_data_string(image ref.Val) ref.Val {
 		return f.NativeToValue(*img)
 	}
 }
+
+func (f *ivfuncs) parse_provenance_dyn(payload ref.Val) ref.Val {
+	if payload, err := nativePayload(payload); err != nil {
+		return types.WrapErr(err)
+	} else if provenance, err := predicates.ParseProvenance(payload); err != nil {
+		return types.NewErr("failed to parse provenance: %v", err)
+	} else {
+		return f.predicateToValue(provenance)
+	}
+}
+
+func (f *ivfuncs) parse_sbom_dyn(payload ref.Val) ref.Val {
+	if payload, err := nativePayload(payload); err != nil {
+		return types.WrapErr(err)
+	} else if sbom, err := predicates.ParseSBOM(payload); err != nil {
+		return types.NewErr("failed to parse sbom: %v", err)
+	} else {
+		return f.predicateToValue(sbom)
+	}
+}
+
+func (f *ivfuncs) parse_vulnerability_scan_dyn(payload ref.Val) ref.Val {
+	if payload, err := nativePayload(payload); err != nil {
+		return types.WrapErr(err)
+	} else if scan, err := predicates.ParseVulnerabilityScan(payload); err != nil {
+		return types.NewErr("failed to parse vulnerability scan: %v", err)
+	} else {
+		return f.predicateToValue(scan)
+	}
+}
+
+func (f *ivfuncs) provenance_builder_allowed_dyn_stringarray(payload ref.Val, allowed ref.Val) ref.Val {
+	if payload, err := nativePayload(payload); err != nil {
+		return types.WrapErr(err)
+	} else if allowed, err := utils.ConvertToNative[[]string](allowed); err != nil {
+		return types.WrapErr(err)
+	} else if provenance, err := predicates.ParseProvenance(payload); err != nil {
+		return types.NewErr("failed to parse provenance: %v", err)
+	} else {
+		return types.Bool(provenance.BuilderAllowed(allowed...))
+	}
+}
+
+func (f *ivfuncs) count_vulnerabilities_dyn_string_int(args ...ref.Val) ref.Val {
+	if len(args) != 3 {
+		return types.NewErr("function usage: <payload> <severity> <min age in days>")
+	}
+	if payload, err := nativePayload(args[0]); err != nil {
+		return types.WrapErr(err)
+	} else if severity, err := utils.ConvertToNative[string](args[1]); err != nil {
+		return types.WrapErr(err)
+	} else if days, err := utils.ConvertToNative[int64](args[2]); err != nil {
+		return types.WrapErr(err)
+	} else if scan, err := predicates.ParseVulnerabilityScan(payload); err != nil {
+		return types.NewErr("failed to parse vulnerability scan: %v", err)
+	} else {
+		vulnerabilities := scan.Filter(severity, time.Duration(days)*24*time.Hour, time.Now())
+		return types.Int(len(vulnerabilities))
+	}
+}
+
+func (f *ivfuncs) predicateToValue(predicate any) ref.Val {
+	out, err := predicates.ToMap(predicate)
+	if err != nil {
+		return types.WrapErr(err)
+	}
+	return f.NativeToValue(out)
+}
@@ -131,3 +131,87 @@ func Test_impl_verify_image_attestations_string_string_stringarray(t *testing.T)
 	assert.NoError(t, err)
 	assert.Equal(t, out.Value(), int64(1))
 }
+
+func Test_impl_predicates(t *testing.T) {
+	imgCtx, err := imagedataloader.NewImageContext(nil)
+	assert.NoError(t, err)
+	env, err := cel.NewEnv(
+		cel.Variable("payload", cel.DynType),
+		Lib(nil, imgCtx, ivpol, nil),
+	)
+	assert.NoError(t, err)
+	provenance := map[string]any{
+		"_type":         "https://in-toto.io/Statement/v1",
+		"predicateType": "https://slsa.dev/provenance/v1",
+		"predicate": map[string]any{
+			"buildDefinition": map[string]any{"buildType": "https://slsa-framework.github.io/github-actions-buildtypes/workflow/v1"},
+			"runDetails": map[string]any{
+				"builder": map[string]any{"id": "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_container_slsa3.yml@refs/tags/v2.1.0"},
+			},
+		},
+	}
+	vulnerabilities := map[string]any{
+		"_type":         "https://in-toto.io/Statement/v0.1",
+		"predicateType": "https://cosign.sigstore.dev/attestation/vuln/v1",
+		"predicate": map[string]any{
+			"scanner": map[string]any{
+				"result": map[string]any{
+					"SchemaVersion": 2,
+					"Results": []any{map[string]any{
+						"Vulnerabilities": []any{
+							map[string]any{"VulnerabilityID": "CVE-2024-0001", "Severity": "CRITICAL", "PublishedDate": "2024-01-01T00:00:00Z"},
+							map[string]any{"VulnerabilityID": "CVE-2024-0002", "Severity": "HIGH", "PublishedDate": "2024-01-01T00:00:00Z"},
+						},
+					}},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name       string
+		expression string
+		payload    any
+		want       any
+	}{{
+		name:       "builder allowed",
+		expression: `isProvenanceBuilderAllowed(payload, ["https://github.com/slsa-framework/slsa-github-generator/*"])`,
+		payload:    provenance,
+		want:       true,
+	}, {
+		name:       "builder not allowed",
+		expression: `isProvenanceBuilderAllowed(payload, ["https://github.com/kyverno/*"])`,
+		payload:    provenance,
+		want:       false,
+	}, {
+		name:       "parse provenance",
+		expression: `parseProvenance(payload).runDetails.builder.id.startsWith("https://github.com/slsa-framework/")`,
+		payload:    provenance,
+		want:       true,
+	}, {
+		name:       "count vulnerabilities",
+		expression: `countVulnerabilities(payload, "CRITICAL", 30)`,
+		payload:    vulnerabilities,
+		want:       int64(1),
+	}, {
+		name:       "parse vulnerability scan",
+		expression: `parseVulnerabilityScan(payload).vulnerabilities.exists(v, v.id == "CVE-2024-0002")`,
+		payload:    vulnerabilities,
+		want:       true,
+	}, {
+		name:       "parse sbom",
+		expression: `parseSBOM(payload).components.exists(c, c.name == "openssl" && c.licenses == ["Apache-2.0"])`,
+		payload:    `{"spdxVersion": "SPDX-2.3", "packages": [{"name": "openssl", "licenseDeclared": "Apache-2.0"}]}`,
+		want:       true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ast, issues := env.Compile(tt.expression)
+			assert.Nil(t, issues)
+			prog, err := env.Program(ast)
+			assert.NoError(t, err)
+			out, _, err := prog.Eval(map[string]any{"payload": tt.payload})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, out.Value())
+		})
+	}
+}
@@ -93,6 +93,46 @@ func (c *lib) extendEnv(env *cel.Env) (*cel.Env, error) {
 				cel.BinaryBinding(impl.payload_string_string),
 			),
 		},
+		"parseProvenance": {
+			cel.Overload(
+				"parse_provenance_dyn",
+				[]*cel.Type{types.DynType},
+				types.DynType,
+				cel.UnaryBinding(impl.parse_provenance_dyn),
+			),
+		},
+		"parseSBOM": {
+			cel.Overload(
+				"parse_sbom_dyn",
+				[]*cel.Type{types.DynType},
+				types.DynType,
+				cel.UnaryBinding(impl.parse_sbom_dyn),
+			),
+		},
+		"parseVulnerabilityScan": {
+			cel.Overload(
+				"parse_vulnerability_scan_dyn",
+				[]*cel.Type{types.DynType},
+				types.DynType,
+				cel.UnaryBinding(impl.parse_vulnerability_scan_dyn),
+			),
+		},
+		"isProvenanceBuilderAllowed": {
+			cel.Overload(
+				"provenance_builder_allowed_dyn_stringarray",
+				[]*cel.Type{types.DynType, types.NewListType(types.StringType)},
+				types.BoolType,
+				cel.BinaryBinding(impl.provenance_builder_allowed_dyn_stringarray),
+			),
+		},
+		"countVulnerabilities": {
+			cel.Overload(
+				"count_vulnerabilities_dyn_string_int",
+				[]*cel.Type{types.DynType, types.StringType, types.IntType},
+				types.IntType,
+				cel.FunctionBinding(impl.count_vulnerabilities_dyn_string_int),
+			),
+		},
 	}
 	// create env options corresponding to our function overloads
 	options := []cel.EnvOption{}
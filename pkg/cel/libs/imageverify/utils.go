@@ -1,8 +1,11 @@
 package imageverify
 
 import (
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
 	"github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
 	"github.com/kyverno/kyverno/pkg/imageverification/imagedataloader"
+	"github.com/kyverno/sdk/cel/utils"
 )
 
 func attestationMap(ivpol v1beta1.ImageValidatingPolicyLike) map[string]v1beta1.Attestation {
@@ -40,3 +43,11 @@ func GetRemoteOptsFromPolicy(creds *v1beta1.Credentials) []imagedataloader.Optio
 
 	return imagedataloader.BuildRemoteOpts(creds.Secrets, providers, creds.AllowInsecureRegistry)
 }
+
+// nativePayload converts an attestation payload, as returned by extractPayload or given as a JSON string, to a native value
+func nativePayload(payload ref.Val) (any, error) {
+	if payload, ok := payload.(types.String); ok {
+		return string(payload), nil
+	}
+	return utils.ConvertToNative[map[string]any](payload)
+}
@@ -0,0 +1,18 @@
+package vpol
+
+import (
+	"slices"
+
+	"github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+)
+
+// ShadowAction is the validation action evaluating a policy with Deny semantics,
+// its would-be denials are reported separately and never block the request
+const ShadowAction admissionregistrationv1.ValidationAction = "Shadow"
+
+// IsShadow returns true when the policy validation actions contain the Shadow action
+func IsShadow(policy v1beta1.ValidatingPolicyLike) bool {
+	spec := policy.GetValidatingPolicySpec()
+	return spec != nil && slices.Contains(spec.ValidationActions(), ShadowAction)
+}
@@ -1,6 +1,8 @@
 package vpol
 
 import (
+	"slices"
+
 	"github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
 	"github.com/kyverno/kyverno/pkg/cel/policies/vpol/compiler"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -27,6 +29,10 @@ func Validate(vpol v1beta1.ValidatingPolicyLike) ([]string, error) {
 		err = errList
 	}
 
+	if actions := spec.ValidationActions(); slices.Contains(actions, ShadowAction) && len(actions) > 1 {
+		err = append(err, field.Invalid(field.NewPath("spec").Child("validationActions"), actions, "Shadow may not be used together with other actions"))
+	}
+
 	if spec.MatchConstraints == nil || len(spec.MatchConstraints.ResourceRules) == 0 {
 		err = append(err, field.Required(field.NewPath("spec").Child("matchConstraints"), "a matchConstraints with at least one resource rule is required"))
 	}
@@ -32,6 +32,10 @@ func (c *FakeKyvernoV2alpha1) GlobalContextEntries() v2alpha1.GlobalContextEntry
 	return newFakeGlobalContextEntries(c)
 }
 
+func (c *FakeKyvernoV2alpha1) ImageVerificationResults() v2alpha1.ImageVerificationResultInterface {
+	return newFakeImageVerificationResults(c)
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeKyvernoV2alpha1) RESTClient() rest.Interface {
@@ -26,6 +26,8 @@ import (
 type Interface interface {
 	// GlobalContextEntries returns a GlobalContextEntryInformer.
 	GlobalContextEntries() GlobalContextEntryInformer
+	// ImageVerificationResults returns a ImageVerificationResultInformer.
+	ImageVerificationResults() ImageVerificationResultInformer
 }
 
 type version struct {
@@ -43,3 +45,8 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (v *version) GlobalContextEntries() GlobalContextEntryInformer {
 	return &globalContextEntryInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// ImageVerificationResults returns a ImageVerificationResultInformer.
+func (v *version) ImageVerificationResults() ImageVerificationResultInformer {
+	return &imageVerificationResultInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
@@ -21,3 +21,7 @@ package v2alpha1
 // GlobalContextEntryListerExpansion allows custom methods to be added to
 // GlobalContextEntryLister.
 type GlobalContextEntryListerExpansion interface{}
+
+// ImageVerificationResultListerExpansion allows custom methods to be added to
+// ImageVerificationResultLister.
+type ImageVerificationResultListerExpansion interface{}
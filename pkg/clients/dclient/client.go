@@ -52,6 +52,8 @@ type Interface interface {
 	UpdateStatusResource(ctx context.Context, apiVersion string, kind string, namespace string, obj interface{}, dryRun bool) (*unstructured.Unstructured, error)
 	// ApplyResource applies object for the specified resource/namespace using server-side apply
 	ApplyResource(ctx context.Context, apiVersion string, kind string, namespace string, name string, obj interface{}, dryRun bool, fieldManager string, subresources ...string) (*unstructured.Unstructured, error)
+	// ApplyResourceWithOptions applies object for the specified resource/namespace using server-side apply with the given options
+	ApplyResourceWithOptions(ctx context.Context, apiVersion string, kind string, namespace string, name string, obj interface{}, options metav1.ApplyOptions, subresources ...string) (*unstructured.Unstructured, error)
 	// ApplyStatusResource applies the resource "status" subresource using server-side apply
 	ApplyStatusResource(ctx context.Context, apiVersion string, kind string, namespace string, name string, obj interface{}, dryRun bool, fieldManager string) (*unstructured.Unstructured, error)
 }
@@ -344,6 +346,11 @@ func (c *client) ApplyResource(ctx context.Context, apiVersion string, kind stri
 	if dryRun {
 		options.DryRun = []string{metav1.DryRunAll}
 	}
+	return c.ApplyResourceWithOptions(ctx, apiVersion, kind, namespace, name, obj, options, subresources...)
+}
+
+// ApplyResourceWithOptions applies object for the specified resource/namespace using server-side apply with the given options
+func (c *client) ApplyResourceWithOptions(ctx context.Context, apiVersion string, kind string, namespace string, name string, obj interface{}, options metav1.ApplyOptions, subresources ...string) (*unstructured.Unstructured, error) {
 	// convert typed to unstructured obj
 	if unstructuredObj, err := kubeutils.ObjToUnstructured(obj); err == nil && unstructuredObj != nil {
 		return c.getResourceInterface(apiVersion, kind, namespace).Apply(ctx, name, unstructuredObj, options, subresources...)
@@ -4,6 +4,7 @@ import (
 	"github.com/go-logr/logr"
 	github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1 "github.com/kyverno/kyverno/pkg/client/clientset/versioned/typed/kyverno/v2alpha1"
 	globalcontextentries "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/globalcontextentries"
+	imageverificationresults "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/imageverificationresults"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"k8s.io/client-go/rest"
 )
@@ -33,6 +34,10 @@ func (c *withMetrics) GlobalContextEntries() github_com_kyverno_kyverno_pkg_clie
 	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "GlobalContextEntry", c.clientType)
 	return globalcontextentries.WithMetrics(c.inner.GlobalContextEntries(), recorder)
 }
+func (c *withMetrics) ImageVerificationResults() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ImageVerificationResultInterface {
+	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "ImageVerificationResult", c.clientType)
+	return imageverificationresults.WithMetrics(c.inner.ImageVerificationResults(), recorder)
+}
 
 type withTracing struct {
 	inner  github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.KyvernoV2alpha1Interface
@@ -45,6 +50,9 @@ func (c *withTracing) RESTClient() rest.Interface {
 func (c *withTracing) GlobalContextEntries() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.GlobalContextEntryInterface {
 	return globalcontextentries.WithTracing(c.inner.GlobalContextEntries(), c.client, "GlobalContextEntry")
 }
+func (c *withTracing) ImageVerificationResults() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ImageVerificationResultInterface {
+	return imageverificationresults.WithTracing(c.inner.ImageVerificationResults(), c.client, "ImageVerificationResult")
+}
 
 type withLogging struct {
 	inner  github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.KyvernoV2alpha1Interface
@@ -57,3 +65,6 @@ func (c *withLogging) RESTClient() rest.Interface {
 func (c *withLogging) GlobalContextEntries() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.GlobalContextEntryInterface {
 	return globalcontextentries.WithLogging(c.inner.GlobalContextEntries(), c.logger.WithValues("resource", "GlobalContextEntries"))
 }
+func (c *withLogging) ImageVerificationResults() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ImageVerificationResultInterface {
+	return imageverificationresults.WithLogging(c.inner.ImageVerificationResults(), c.logger.WithValues("resource", "ImageVerificationResults"))
+}
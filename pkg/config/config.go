@@ -94,6 +94,8 @@ const (
 	LivenessServicePath = "/health/liveness"
 	// ReadinessServicePath is the path for check readness health
 	ReadinessServicePath = "/health/readiness"
+	// AdmissionRecordsServicePath is the path for retrieving recorded admission requests
+	AdmissionRecordsServicePath = "/debug/admissionrecords"
 	// MetricsPath is the path for exposing metrics
 	MetricsPath = "/metrics"
 	// FineGrainedWebhookPath is the sub-path for fine-grained webhook configurationss
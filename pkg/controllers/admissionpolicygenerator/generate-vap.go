@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/kyverno/kyverno/pkg/admissionpolicy"
+	vpolutils "github.com/kyverno/kyverno/pkg/cel/policies/vpol"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/event"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
@@ -80,7 +81,11 @@ func (c *controller) handleVAPGeneration(ctx context.Context, polType string, po
 		var reason string
 		if wantVap {
 			isAutogen := len(pol.GetStatus().Autogen.Configs) > 0
-			if isAutogen {
+			if vpolutils.IsShadow(pol) {
+				// a native ValidatingAdmissionPolicy would block the requests a shadow policy only reports
+				shouldDelete = true
+				reason = "skip generating ValidatingAdmissionPolicy: shadow mode is not applicable."
+			} else if isAutogen {
 				shouldDelete = true
 				reason = "skip generating ValidatingAdmissionPolicy: pod controllers autogen is enabled."
 			}
@@ -0,0 +1,89 @@
+package admissionpolicygenerator
+
+import (
+	"context"
+	"testing"
+
+	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	"github.com/kyverno/kyverno/pkg/auth/checker"
+	vpolutils "github.com/kyverno/kyverno/pkg/cel/policies/vpol"
+	kyvernofake "github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	admissionregistrationv1listers "k8s.io/client-go/listers/admissionregistration/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+type allowChecker struct{}
+
+func (allowChecker) Check(context.Context, string, string, string, string, string, string, string) (*checker.AuthResult, error) {
+	return &checker.AuthResult{Allowed: true}, nil
+}
+
+func TestHandleVAPGeneration_ShadowValidatingPolicy(t *testing.T) {
+	enabled := true
+	vpol := &policiesv1beta1.ValidatingPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "check-labels",
+		},
+		Spec: policiesv1beta1.ValidatingPolicySpec{
+			ValidationAction: []admissionregistrationv1.ValidationAction{vpolutils.ShadowAction},
+			AutogenConfiguration: &policiesv1beta1.ValidatingPolicyAutogenConfiguration{
+				ValidatingAdmissionPolicy: &policiesv1beta1.VapGenerationConfiguration{Enabled: &enabled},
+			},
+		},
+	}
+	// a VAP generated before the policy was switched to shadow mode
+	vap := &admissionregistrationv1.ValidatingAdmissionPolicy{ObjectMeta: metav1.ObjectMeta{Name: "vpol-check-labels"}}
+	binding := &admissionregistrationv1.ValidatingAdmissionPolicyBinding{ObjectMeta: metav1.ObjectMeta{Name: constructBindingName("vpol-check-labels")}}
+
+	vapIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NoError(t, vapIndexer.Add(vap))
+	bindingIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NoError(t, bindingIndexer.Add(binding))
+	client := kubefake.NewSimpleClientset(vap, binding)
+	kyvernoClient := kyvernofake.NewSimpleClientset(vpol)
+
+	c := &controller{
+		client:           client,
+		kyvernoClient:    kyvernoClient,
+		vapLister:        admissionregistrationv1listers.NewValidatingAdmissionPolicyLister(vapIndexer),
+		vapbindingLister: admissionregistrationv1listers.NewValidatingAdmissionPolicyBindingLister(bindingIndexer),
+		checker:          allowChecker{},
+	}
+	assert.NoError(t, c.handleVAPGeneration(context.TODO(), "ValidatingPolicy", engineapi.NewValidatingPolicy(vpol)))
+
+	_, err := client.AdmissionregistrationV1().ValidatingAdmissionPolicies().Get(context.TODO(), vap.Name, metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = client.AdmissionregistrationV1().ValidatingAdmissionPolicyBindings().Get(context.TODO(), binding.Name, metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	latest, err := kyvernoClient.PoliciesV1beta1().ValidatingPolicies().Get(context.TODO(), vpol.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.False(t, latest.Status.Generated)
+	assert.Equal(t, "skip generating ValidatingAdmissionPolicy: shadow mode is not applicable.", latest.Status.GetConditionStatus().Message)
+}
+
+func TestUpdateVP_ShadowAction(t *testing.T) {
+	c := &controller{queue: newTestQueue()}
+	old := &policiesv1beta1.ValidatingPolicy{ObjectMeta: metav1.ObjectMeta{Name: "check-labels"}}
+	obj := old.DeepCopy()
+	obj.Spec.ValidationAction = []admissionregistrationv1.ValidationAction{vpolutils.ShadowAction}
+
+	c.updateVP(old, old.DeepCopy())
+	assert.Equal(t, 0, c.queue.Len())
+	c.updateVP(old, obj)
+	assert.Equal(t, 1, c.queue.Len())
+}
+
+func newTestQueue() workqueue.TypedRateLimitingInterface[any] {
+	return workqueue.NewTypedRateLimitingQueueWithConfig(
+		workqueue.DefaultTypedControllerRateLimiter[any](),
+		workqueue.TypedRateLimitingQueueConfig[any]{Name: "test"},
+	)
+}
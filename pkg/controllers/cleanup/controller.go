@@ -2,6 +2,7 @@ package cleanup
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -54,6 +55,11 @@ type controller struct {
 	eventGen      event.Interface
 	jp            jmespath.Interface
 	gctxStore     loaders.Store
+
+	// deleted holds the deleted policies with pre-deletion actions, keyed by policy key,
+	// until their annotations are removed from the resources
+	deletedLock sync.Mutex
+	deleted     map[string]kyvernov2.CleanupPolicyInterface
 }
 
 const (
@@ -109,6 +115,7 @@ func NewController(
 		eventGen:      eventGen,
 		jp:            jp,
 		gctxStore:     gctxStore,
+		deleted:       map[string]kyvernov2.CleanupPolicyInterface{},
 	}
 	if _, err := controllerutils.AddEventHandlersT(
 		cpolInformer.Informer(),
@@ -119,7 +126,7 @@ func NewController(
 				_ = enqueueFunc(logger, "updated", "ClusterCleanupPolicy")(obj)
 			}
 		},
-		controllerutils.DeleteFuncT(logger, enqueueFunc(logger, "deleted", "ClusterCleanupPolicy")),
+		controllerutils.DeleteFuncT(logger, c.recordDeleted(enqueueFunc(logger, "deleted", "ClusterCleanupPolicy"))),
 	); err != nil {
 		logger.Error(err, "failed to register event handlers")
 	}
@@ -132,7 +139,7 @@ func NewController(
 				_ = enqueueFunc(logger, "updated", "CleanupPolicy")(obj)
 			}
 		},
-		controllerutils.DeleteFuncT(logger, enqueueFunc(logger, "deleted", "CleanupPolicy")),
+		controllerutils.DeleteFuncT(logger, c.recordDeleted(enqueueFunc(logger, "deleted", "CleanupPolicy"))),
 	); err != nil {
 		logger.Error(err, "failed to register event handlers")
 	}
@@ -169,6 +176,9 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 	deleteOptions := metav1.DeleteOptions{
 		PropagationPolicy: spec.DeletionPropagationPolicy,
 	}
+	now := time.Now()
+	summary := newRunSummary(policy, now)
+	defer c.notify(ctx, logger, policy, summary)
 	enginectx := enginecontext.NewContext(c.jp)
 	ctxFactory := factories.DefaultContextLoaderFactory(c.cmResolver, factories.WithGlobalContextStore(c.gctxStore))
 	loader := ctxFactory(nil, kyvernov1.Rule{})
@@ -233,6 +243,16 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 				debug.Info("resource namespace didn't match policy namespace", "result", err)
 				continue
 			}
+			// the pre-deletion schedule of a resource that no longer matches is reset
+			unschedule := func() {
+				if spec.PreDeletion == nil {
+					return
+				}
+				if err := c.unscheduleDeletion(ctx, policy, resource); err != nil {
+					debug.Error(err, "failed to remove pre-deletion annotations")
+					errs = append(errs, err)
+				}
+			}
 			// match resource with match/exclude clause
 			matched := match.CheckMatchesResources(
 				resource,
@@ -246,6 +266,7 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 			)
 			if matched != nil {
 				debug.Info("resource/match didn't match", "result", matched)
+				unschedule()
 				continue
 			}
 			if spec.ExcludeResources != nil {
@@ -261,6 +282,7 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 				)
 				if excluded == nil {
 					debug.Info("resource/exclude matched")
+					unschedule()
 					continue
 				} else {
 					debug.Info("resource/exclude didn't match", "result", excluded)
@@ -292,6 +314,19 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 				}
 				if !passed {
 					debug.Info("conditions did not pass")
+					unschedule()
+					continue
+				}
+			}
+			if spec.PreDeletion != nil {
+				proceed, err := c.preDelete(ctx, debug, policy, resource, enginectx, now, summary)
+				if err != nil {
+					debug.Error(err, "failed to run pre-deletion actions")
+					errs = append(errs, err)
+					summary.Failed++
+					continue
+				}
+				if !proceed {
 					continue
 				}
 			}
@@ -306,6 +341,7 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 				}
 				debug.Error(err, "failed to delete resource")
 				errs = append(errs, err)
+				summary.Failed++
 				e := event.NewCleanupPolicyEvent(policy, resource, err)
 				c.eventGen.Add(e)
 			} else {
@@ -313,6 +349,7 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 					metrics.RecordDeletedObject(ctx, kind, namespace, policy, deleteOptions.PropagationPolicy)
 				}
 				debug.Info("resource deleted")
+				summary.Deleted++
 				e := event.NewCleanupPolicyEvent(policy, resource, nil)
 				c.eventGen.Add(e)
 			}
@@ -323,13 +360,20 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 
 func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
 	policy, err := c.getPolicy(namespace, name)
-	if err != nil {
-		if apierrors.IsNotFound(err) {
-			return nil
-		}
+	if err != nil && !apierrors.IsNotFound(err) {
 		logger.Error(err, "unable to get the policy from policy informer")
 		return err
 	}
+	// a policy recreated with the same name has a new UID, the annotations of the deleted one are removed anyway
+	if deleted := c.popDeleted(key); deleted != nil && (policy == nil || policy.GetUID() != deleted.GetUID()) {
+		if err := c.clearPreDeletion(ctx, logger, deleted); err != nil {
+			c.pushDeleted(key, deleted)
+			return err
+		}
+	}
+	if policy == nil {
+		return nil
+	}
 
 	var nextExecutionTime *time.Time
 	executionTime, err := policy.GetExecutionTime()
@@ -29,6 +29,7 @@ type mockDClient struct {
 	dclient.Interface
 	listResource   func(ctx context.Context, apiVersion string, kind string, namespace string, lselector *metav1.LabelSelector) (*unstructured.UnstructuredList, error)
 	deleteResource func(ctx context.Context, apiVersion string, kind string, namespace string, name string, dryRun bool, options metav1.DeleteOptions) error
+	patchResource  func(ctx context.Context, apiVersion string, kind string, namespace string, name string, patch []byte) (*unstructured.Unstructured, error)
 }
 
 func (m *mockDClient) ListResource(ctx context.Context, apiVersion string, kind string, namespace string, lselector *metav1.LabelSelector) (*unstructured.UnstructuredList, error) {
@@ -45,6 +46,13 @@ func (m *mockDClient) DeleteResource(ctx context.Context, apiVersion string, kin
 	return m.Interface.DeleteResource(ctx, apiVersion, kind, namespace, name, dryRun, options)
 }
 
+func (m *mockDClient) PatchResource(ctx context.Context, apiVersion string, kind string, namespace string, name string, patch []byte) (*unstructured.Unstructured, error) {
+	if m.patchResource != nil {
+		return m.patchResource(ctx, apiVersion, kind, namespace, name, patch)
+	}
+	return m.Interface.PatchResource(ctx, apiVersion, kind, namespace, name, patch)
+}
+
 func Test_Cleanup_SkipResourceNamespaceMismatch(t *testing.T) {
 	// Define a CleanupPolicy in ns1
 	policy := &kyvernov2.CleanupPolicy{
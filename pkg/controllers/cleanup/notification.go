@@ -0,0 +1,53 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/event"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// runSummary counts the outcome of a cleanup policy run
+type runSummary struct {
+	Policy corev1.ObjectReference `json:"policy"`
+	Time   time.Time              `json:"time"`
+	// Deleted is the number of resources deleted
+	Deleted int `json:"deleted"`
+	// Failed is the number of resources that could not be deleted
+	Failed int `json:"failed"`
+	// Scheduled is the number of resources waiting for the end of their grace period
+	Scheduled int `json:"scheduled"`
+	// Vetoed is the number of resources whose deletion was refused by the pre-deletion webhook
+	Vetoed int `json:"vetoed"`
+}
+
+func newRunSummary(policy kyvernov2.CleanupPolicyInterface, now time.Time) *runSummary {
+	return &runSummary{
+		Policy: policyReference(policy),
+		Time:   now.UTC(),
+	}
+}
+
+func (s *runSummary) String() string {
+	return fmt.Sprintf("cleanup run completed: %d deleted, %d failed, %d scheduled, %d vetoed", s.Deleted, s.Failed, s.Scheduled, s.Vetoed)
+}
+
+// notify sends the summary of a run to the notification targets of a policy
+func (c *controller) notify(ctx context.Context, logger logr.Logger, policy kyvernov2.CleanupPolicyInterface, summary *runSummary) {
+	notification := policy.GetSpec().Notification
+	if notification == nil {
+		return
+	}
+	if notification.Event {
+		c.eventGen.Add(event.NewCleanupSummaryEvent(policy, summary.String(), summary.Failed != 0))
+	}
+	if notification.Webhook != nil {
+		if err := postJSON(ctx, notification.Webhook, summary); err != nil {
+			logger.Error(err, "failed to send cleanup notification")
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/kyverno/kyverno/pkg/event"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
+	"go.uber.org/multierr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+)
+
+// preDeletionRequest is posted to the pre-deletion webhook of a cleanup policy
+type preDeletionRequest struct {
+	Policy   corev1.ObjectReference `json:"policy"`
+	Resource corev1.ObjectReference `json:"resource"`
+}
+
+// preDelete runs the pre-deletion actions of a policy on a matching resource, it returns true if
+// the resource can be deleted by the current run
+func (c *controller) preDelete(ctx context.Context, logger logr.Logger, policy kyvernov2.CleanupPolicyInterface, resource unstructured.Unstructured, enginectx enginecontext.Interface, now time.Time, summary *runSummary) (bool, error) {
+	actions := policy.GetSpec().PreDeletion
+	// the first match is recorded so that the pre-deletion event is emitted once and the
+	// deletion time can't be pushed beyond the grace period
+	_, matched := resource.GetAnnotations()[matchedAtAnnotation(policy)]
+	matchedAt, valid := annotationTime(resource, matchedAtAnnotation(policy))
+	if !valid || matchedAt.After(now) {
+		matchedAt, valid = now.Truncate(time.Second), false
+	}
+	if !matched {
+		c.emitPreDeletionEvent(logger, policy, resource, enginectx)
+	}
+	if actions.GracePeriod != nil {
+		deletionTime := matchedAt.Add(actions.GracePeriod.Duration)
+		if scheduled, ok := annotationTime(resource, deletionTimeAnnotation(policy)); !valid || !ok || !scheduled.Equal(deletionTime) {
+			if err := c.scheduleDeletion(ctx, policy, resource, matchedAt, deletionTime); err != nil {
+				return false, err
+			}
+			logger.V(2).Info("resource deletion scheduled", "deletionTime", deletionTime)
+		}
+		if now.Before(deletionTime) {
+			summary.Scheduled++
+			return false, nil
+		}
+	}
+	if actions.Webhook != nil {
+		request := preDeletionRequest{
+			Policy:   policyReference(policy),
+			Resource: objectReference(resource),
+		}
+		if err := postJSON(ctx, actions.Webhook, request); err != nil {
+			logger.V(2).Info("pre-deletion webhook did not allow the deletion", "error", err.Error())
+			summary.Vetoed++
+			if !matched && actions.GracePeriod == nil {
+				// record the first match, the event must not be emitted again by the next runs
+				if err := c.scheduleDeletion(ctx, policy, resource, matchedAt, time.Time{}); err != nil {
+					return false, err
+				}
+			}
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// emitPreDeletionEvent emits the pre-deletion event of a policy, its message is rendered with the resource
+func (c *controller) emitPreDeletionEvent(logger logr.Logger, policy kyvernov2.CleanupPolicyInterface, resource unstructured.Unstructured, enginectx enginecontext.Interface) {
+	config := policy.GetSpec().PreDeletion.Event
+	if config == nil {
+		return
+	}
+	message := config.Message
+	if strings.Contains(message, "{{") {
+		rendered, err := renderMessage(logger, enginectx, resource, message)
+		if err != nil {
+			logger.Error(err, "failed to render pre-deletion event message")
+		} else {
+			message = rendered
+		}
+	}
+	c.eventGen.Add(event.NewCleanupScheduledEvent(policy, ownerReference(resource), resource, message))
+}
+
+func renderMessage(logger logr.Logger, enginectx enginecontext.Interface, resource unstructured.Unstructured, message string) (string, error) {
+	enginectx.Reset()
+	if err := enginectx.SetTargetResource(resource.Object); err != nil {
+		return "", err
+	}
+	rendered, err := variables.SubstituteAll(logger, enginectx, message)
+	if err != nil {
+		return "", err
+	}
+	if result, ok := rendered.(string); ok {
+		return result, nil
+	}
+	return fmt.Sprint(rendered), nil
+}
+
+// scheduleDeletion annotates a resource with the time it first matched the policy and its deletion time, if any
+func (c *controller) scheduleDeletion(ctx context.Context, policy kyvernov2.CleanupPolicyInterface, resource unstructured.Unstructured, matchedAt time.Time, deletionTime time.Time) error {
+	var patches [][]byte
+	if resource.GetAnnotations() == nil {
+		patch, err := jsonutils.MarshalPatchOperation("/metadata/annotations", "add", map[string]string{})
+		if err != nil {
+			return err
+		}
+		patches = append(patches, patch)
+	}
+	annotations := map[string]time.Time{matchedAtAnnotation(policy): matchedAt}
+	if !deletionTime.IsZero() {
+		annotations[deletionTimeAnnotation(policy)] = deletionTime
+	}
+	for key, value := range annotations {
+		patch, err := jsonutils.MarshalPatchOperation(annotationPath(key), "add", value.UTC().Format(time.RFC3339))
+		if err != nil {
+			return err
+		}
+		patches = append(patches, patch)
+	}
+	_, err := c.client.PatchResource(ctx, resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource.GetName(), jsonutils.JoinPatches(patches...))
+	return err
+}
+
+// unscheduleDeletion removes the pre-deletion annotations of a policy from a resource that no longer matches it
+func (c *controller) unscheduleDeletion(ctx context.Context, policy kyvernov2.CleanupPolicyInterface, resource unstructured.Unstructured) error {
+	var patches [][]byte
+	for _, key := range []string{matchedAtAnnotation(policy), deletionTimeAnnotation(policy)} {
+		if _, ok := resource.GetAnnotations()[key]; !ok {
+			continue
+		}
+		patch, err := jsonutils.MarshalPatchOperation(annotationPath(key), "remove", nil)
+		if err != nil {
+			return err
+		}
+		patches = append(patches, patch)
+	}
+	if len(patches) == 0 {
+		return nil
+	}
+	_, err := c.client.PatchResource(ctx, resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource.GetName(), jsonutils.JoinPatches(patches...))
+	return err
+}
+
+// matchedAtAnnotation returns the annotation recording the time a resource first matched a policy
+func matchedAtAnnotation(policy kyvernov2.CleanupPolicyInterface) string {
+	return kyverno.AnnotationPrefixCleanupMatchedAt + string(policy.GetUID())
+}
+
+// deletionTimeAnnotation returns the annotation recording the time a resource will be deleted by a policy
+func deletionTimeAnnotation(policy kyvernov2.CleanupPolicyInterface) string {
+	return kyverno.AnnotationPrefixCleanupDeletionTime + string(policy.GetUID())
+}
+
+func annotationPath(key string) string {
+	return "/metadata/annotations/" + strings.ReplaceAll(key, "/", "~1")
+}
+
+// annotationTime returns the time a resource was annotated with, if any
+func annotationTime(resource unstructured.Unstructured, key string) (time.Time, bool) {
+	value, ok := resource.GetAnnotations()[key]
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// ownerReference returns a reference to the controller owner of a resource, or to the resource itself
+func ownerReference(resource unstructured.Unstructured) corev1.ObjectReference {
+	if owner := metav1.GetControllerOf(&resource); owner != nil {
+		return corev1.ObjectReference{
+			APIVersion: owner.APIVersion,
+			Kind:       owner.Kind,
+			Name:       owner.Name,
+			Namespace:  resource.GetNamespace(),
+			UID:        owner.UID,
+		}
+	}
+	return objectReference(resource)
+}
+
+func objectReference(resource unstructured.Unstructured) corev1.ObjectReference {
+	return corev1.ObjectReference{
+		APIVersion: resource.GetAPIVersion(),
+		Kind:       resource.GetKind(),
+		Name:       resource.GetName(),
+		Namespace:  resource.GetNamespace(),
+		UID:        resource.GetUID(),
+	}
+}
+
+func policyReference(policy kyvernov2.CleanupPolicyInterface) corev1.ObjectReference {
+	return corev1.ObjectReference{
+		APIVersion: kyvernov2.SchemeGroupVersion.String(),
+		Kind:       policy.GetKind(),
+		Name:       policy.GetName(),
+		Namespace:  policy.GetNamespace(),
+		UID:        policy.GetUID(),
+	}
+}
+
+// recordDeleted records the deleted policies with pre-deletion actions before enqueuing them, the
+// reconciliation then removes their annotations from the resources
+func (c *controller) recordDeleted(enqueue controllerutils.EnqueueFuncT[kyvernov2.CleanupPolicyInterface]) controllerutils.EnqueueFuncT[kyvernov2.CleanupPolicyInterface] {
+	return func(policy kyvernov2.CleanupPolicyInterface) error {
+		if policy.GetSpec().PreDeletion != nil {
+			key, err := cache.MetaNamespaceKeyFunc(policy)
+			if err != nil {
+				return err
+			}
+			c.pushDeleted(key, policy)
+		}
+		return enqueue(policy)
+	}
+}
+
+func (c *controller) pushDeleted(key string, policy kyvernov2.CleanupPolicyInterface) {
+	c.deletedLock.Lock()
+	defer c.deletedLock.Unlock()
+	c.deleted[key] = policy
+}
+
+func (c *controller) popDeleted(key string) kyvernov2.CleanupPolicyInterface {
+	c.deletedLock.Lock()
+	defer c.deletedLock.Unlock()
+	policy := c.deleted[key]
+	delete(c.deleted, key)
+	return policy
+}
+
+// clearPreDeletion removes the pre-deletion annotations of a deleted policy from the resources it matched
+func (c *controller) clearPreDeletion(ctx context.Context, logger logr.Logger, policy kyvernov2.CleanupPolicyInterface) error {
+	var errs []error
+	for kind := range sets.New(policy.GetSpec().MatchResources.GetKinds()...) {
+		list, err := c.client.ListResource(ctx, "", kind, policy.GetNamespace(), nil)
+		if err != nil {
+			if dclient.IsRecoverableError(err) {
+				logger.V(2).Info("skipping resource kind due to access restrictions", "kind", kind, "error", err.Error())
+				continue
+			}
+			errs = append(errs, err)
+			continue
+		}
+		for _, resource := range list.Items {
+			if err := c.unscheduleDeletion(ctx, policy, resource); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return multierr.Combine(errs...)
+}
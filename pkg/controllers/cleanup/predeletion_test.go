@@ -0,0 +1,298 @@
+package cleanup
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	kyvernov2listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	configpkg "github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/config/mocks"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+)
+
+func Test_annotationTime(t *testing.T) {
+	resource := unstructured.Unstructured{}
+	_, ok := annotationTime(resource, "deletion-time.cleanup.kyverno.io/uid")
+	assert.False(t, ok)
+
+	resource.SetAnnotations(map[string]string{"deletion-time.cleanup.kyverno.io/uid": "tomorrow"})
+	_, ok = annotationTime(resource, "deletion-time.cleanup.kyverno.io/uid")
+	assert.False(t, ok)
+
+	resource.SetAnnotations(map[string]string{"deletion-time.cleanup.kyverno.io/uid": "2026-01-02T03:04:05Z"})
+	deletionTime, ok := annotationTime(resource, "deletion-time.cleanup.kyverno.io/uid")
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), deletionTime)
+	_, ok = annotationTime(resource, "deletion-time.cleanup.kyverno.io/other")
+	assert.False(t, ok)
+}
+
+func Test_postJSON(t *testing.T) {
+	var received map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		if received["veto"] == true {
+			w.WriteHeader(http.StatusConflict)
+		}
+	}))
+	defer server.Close()
+	service := &kyvernov1.ServiceCall{
+		URL:     server.URL,
+		Headers: []kyvernov1.HTTPHeader{{Key: "Authorization", Value: "secret"}},
+	}
+	assert.NoError(t, postJSON(context.Background(), service, map[string]any{"veto": false}))
+	assert.Equal(t, false, received["veto"])
+	assert.Error(t, postJSON(context.Background(), service, map[string]any{"veto": true}))
+}
+
+// recordingEventGen records the pre-deletion events
+type recordingEventGen struct {
+	events []event.Info
+}
+
+func (r *recordingEventGen) Add(infoList ...event.Info) {
+	for _, info := range infoList {
+		if info.Reason == event.CleanupScheduled {
+			r.events = append(r.events, info)
+		}
+	}
+}
+
+func newPreDeletionConfigMap(name string, annotations map[string]string) unstructured.Unstructured {
+	resource := unstructured.Unstructured{}
+	resource.SetAPIVersion("v1")
+	resource.SetKind("ConfigMap")
+	resource.SetName(name)
+	resource.SetAnnotations(annotations)
+	return resource
+}
+
+func newPreDeletionController(t *testing.T, resources []unstructured.Unstructured, deleted *[]string, patches map[string]string) *controller {
+	client := &mockDClient{
+		Interface: dclient.NewEmptyFakeClient(),
+		listResource: func(context.Context, string, string, string, *metav1.LabelSelector) (*unstructured.UnstructuredList, error) {
+			return &unstructured.UnstructuredList{Items: resources}, nil
+		},
+		deleteResource: func(_ context.Context, _ string, _ string, _ string, name string, _ bool, _ metav1.DeleteOptions) error {
+			*deleted = append(*deleted, name)
+			return nil
+		},
+		patchResource: func(_ context.Context, _ string, _ string, _ string, name string, patch []byte) (*unstructured.Unstructured, error) {
+			patches[name] = string(patch)
+			return nil, nil
+		},
+	}
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	config := mocks.NewMockConfiguration(ctrl)
+	config.EXPECT().ToFilter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false).AnyTimes()
+	return &controller{
+		client:        client,
+		configuration: config,
+		jp:            jmespath.New(configpkg.NewDefaultConfiguration(false)),
+		eventGen:      &recordingEventGen{},
+	}
+}
+
+func Test_Cleanup_PreDeletion(t *testing.T) {
+	const (
+		matchedAt    = kyverno.AnnotationPrefixCleanupMatchedAt + "uid"
+		deletionTime = kyverno.AnnotationPrefixCleanupDeletionTime + "uid"
+	)
+	now := time.Now()
+	format := func(d time.Duration) string {
+		return now.Add(d).UTC().Format(time.RFC3339)
+	}
+	resources := []unstructured.Unstructured{
+		newPreDeletionConfigMap("expired", map[string]string{matchedAt: format(-2 * time.Hour), deletionTime: format(-time.Hour)}),
+		newPreDeletionConfigMap("vetoed", map[string]string{matchedAt: format(-2 * time.Hour), deletionTime: format(-time.Hour)}),
+		newPreDeletionConfigMap("pending", map[string]string{matchedAt: format(-30 * time.Minute), deletionTime: format(30 * time.Minute)}),
+		// postponed beyond the grace period, the deletion time is clamped to the first match
+		newPreDeletionConfigMap("postponed", map[string]string{matchedAt: format(-2 * time.Hour), deletionTime: format(24 * time.Hour)}),
+		// scheduled by another policy
+		newPreDeletionConfigMap("new", map[string]string{"deletion-time.cleanup.kyverno.io/other": format(-time.Hour)}),
+	}
+
+	var summary runSummary
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pre-deletion":
+			var request preDeletionRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Equal(t, "test-policy", request.Policy.Name)
+			if request.Resource.Name == "vetoed" {
+				w.WriteHeader(http.StatusForbidden)
+			}
+		case "/notification":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&summary))
+		}
+	}))
+	defer server.Close()
+
+	policy := &kyvernov2.ClusterCleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-policy", UID: "uid"},
+		Spec: kyvernov2.CleanupPolicySpec{
+			MatchResources: kyvernov2.MatchResources{
+				Any: []kyvernov1.ResourceFilter{{
+					ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"ConfigMap"}},
+				}},
+			},
+			PreDeletion: &kyvernov2.PreDeletion{
+				GracePeriod: &metav1.Duration{Duration: time.Hour},
+				Webhook:     &kyvernov1.ServiceCall{URL: server.URL + "/pre-deletion"},
+				Event:       &kyvernov2.PreDeletionEvent{Message: "scheduled for deletion"},
+			},
+			Notification: &kyvernov2.CleanupNotification{
+				Webhook: &kyvernov1.ServiceCall{URL: server.URL + "/notification"},
+			},
+		},
+	}
+
+	var deleted []string
+	patches := map[string]string{}
+	c := newPreDeletionController(t, resources, &deleted, patches)
+	assert.NoError(t, c.cleanup(context.Background(), logr.Discard(), policy))
+	assert.Equal(t, []string{"expired", "postponed"}, deleted)
+	assert.Equal(t, "test-policy", summary.Policy.Name)
+	assert.Equal(t, 2, summary.Deleted)
+	assert.Equal(t, 0, summary.Failed)
+	assert.Equal(t, 2, summary.Scheduled)
+	assert.Equal(t, 1, summary.Vetoed)
+	// only the resources matching for the first time or with a stale deletion time are annotated
+	assert.Len(t, patches, 2)
+	assert.Contains(t, patches["postponed"], "deletion-time.cleanup.kyverno.io~1uid")
+	assert.Contains(t, patches["new"], "matched-at.cleanup.kyverno.io~1uid")
+	assert.Contains(t, patches["new"], "deletion-time.cleanup.kyverno.io~1uid")
+	// the event is emitted once, on the first match
+	assert.Len(t, c.eventGen.(*recordingEventGen).events, 1)
+}
+
+func Test_Cleanup_PreDeletion_NoGracePeriod(t *testing.T) {
+	resources := []unstructured.Unstructured{newPreDeletionConfigMap("vetoed", nil)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+	policy := &kyvernov2.ClusterCleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-policy", UID: "uid"},
+		Spec: kyvernov2.CleanupPolicySpec{
+			MatchResources: kyvernov2.MatchResources{
+				Any: []kyvernov1.ResourceFilter{{
+					ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"ConfigMap"}},
+				}},
+			},
+			PreDeletion: &kyvernov2.PreDeletion{
+				Webhook: &kyvernov1.ServiceCall{URL: server.URL},
+				Event:   &kyvernov2.PreDeletionEvent{Message: "about to be deleted"},
+			},
+		},
+	}
+
+	var deleted []string
+	patches := map[string]string{}
+	c := newPreDeletionController(t, resources, &deleted, patches)
+	assert.NoError(t, c.cleanup(context.Background(), logr.Discard(), policy))
+	assert.Empty(t, deleted)
+	assert.Len(t, c.eventGen.(*recordingEventGen).events, 1)
+	// the first match is recorded, without a deletion time
+	assert.Contains(t, patches["vetoed"], "matched-at.cleanup.kyverno.io~1uid")
+	assert.NotContains(t, patches["vetoed"], "deletion-time.cleanup.kyverno.io~1uid")
+
+	// the next runs don't emit the event again
+	resources[0].SetAnnotations(map[string]string{"matched-at.cleanup.kyverno.io/uid": time.Now().UTC().Format(time.RFC3339)})
+	delete(patches, "vetoed")
+	assert.NoError(t, c.cleanup(context.Background(), logr.Discard(), policy))
+	assert.Len(t, c.eventGen.(*recordingEventGen).events, 1)
+	assert.Empty(t, patches)
+}
+
+func Test_Cleanup_PreDeletion_NoLongerMatching(t *testing.T) {
+	annotations := map[string]string{
+		"matched-at.cleanup.kyverno.io/uid":      time.Now().UTC().Format(time.RFC3339),
+		"deletion-time.cleanup.kyverno.io/uid":   time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		"deletion-time.cleanup.kyverno.io/other": time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+	}
+	resources := []unstructured.Unstructured{newPreDeletionConfigMap("relabeled", annotations)}
+	policy := &kyvernov2.ClusterCleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-policy", UID: "uid"},
+		Spec: kyvernov2.CleanupPolicySpec{
+			MatchResources: kyvernov2.MatchResources{
+				Any: []kyvernov1.ResourceFilter{{
+					ResourceDescription: kyvernov1.ResourceDescription{
+						Kinds:    []string{"ConfigMap"},
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"expired": "true"}},
+					},
+				}},
+			},
+			PreDeletion: &kyvernov2.PreDeletion{
+				GracePeriod: &metav1.Duration{Duration: time.Hour},
+			},
+		},
+	}
+
+	var deleted []string
+	patches := map[string]string{}
+	c := newPreDeletionController(t, resources, &deleted, patches)
+	assert.NoError(t, c.cleanup(context.Background(), logr.Discard(), policy))
+	assert.Empty(t, deleted)
+	assert.Contains(t, patches["relabeled"], `{"path":"/metadata/annotations/matched-at.cleanup.kyverno.io~1uid","op":"remove"}`)
+	assert.Contains(t, patches["relabeled"], `{"path":"/metadata/annotations/deletion-time.cleanup.kyverno.io~1uid","op":"remove"}`)
+	assert.NotContains(t, patches["relabeled"], "other")
+}
+
+func Test_Reconcile_DeletedPolicy(t *testing.T) {
+	annotations := map[string]string{
+		"matched-at.cleanup.kyverno.io/uid":      time.Now().UTC().Format(time.RFC3339),
+		"deletion-time.cleanup.kyverno.io/uid":   time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		"deletion-time.cleanup.kyverno.io/other": time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+	}
+	resources := []unstructured.Unstructured{
+		newPreDeletionConfigMap("scheduled", annotations),
+		newPreDeletionConfigMap("unscheduled", nil),
+	}
+	policy := &kyvernov2.CleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-policy", Namespace: "ns1", UID: "uid"},
+		Spec: kyvernov2.CleanupPolicySpec{
+			MatchResources: kyvernov2.MatchResources{
+				Any: []kyvernov1.ResourceFilter{{
+					ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"ConfigMap"}},
+				}},
+			},
+			PreDeletion: &kyvernov2.PreDeletion{
+				GracePeriod: &metav1.Duration{Duration: time.Hour},
+			},
+		},
+	}
+
+	var deleted []string
+	patches := map[string]string{}
+	c := newPreDeletionController(t, resources, &deleted, patches)
+	c.polLister = kyvernov2listers.NewCleanupPolicyLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{}))
+	c.deleted = map[string]kyvernov2.CleanupPolicyInterface{}
+	assert.NoError(t, c.recordDeleted(func(kyvernov2.CleanupPolicyInterface) error { return nil })(policy))
+	assert.NoError(t, c.reconcile(context.Background(), logr.Discard(), "ns1/test-policy", "ns1", "test-policy"))
+	assert.Empty(t, deleted)
+	assert.Len(t, patches, 1)
+	assert.Contains(t, patches["scheduled"], `{"path":"/metadata/annotations/matched-at.cleanup.kyverno.io~1uid","op":"remove"}`)
+	assert.Contains(t, patches["scheduled"], `{"path":"/metadata/annotations/deletion-time.cleanup.kyverno.io~1uid","op":"remove"}`)
+	assert.NotContains(t, patches["scheduled"], "other")
+	// the deleted policy is forgotten once its annotations are removed
+	assert.Empty(t, c.deleted)
+}
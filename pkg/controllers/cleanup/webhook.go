@@ -0,0 +1,64 @@
+package cleanup
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// postJSON posts payload to a service, an error is returned unless the service responds with a 2xx status code
+func postJSON(ctx context.Context, service *kyvernov1.ServiceCall, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	client, err := webhookClient(service)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, service.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for _, header := range service.Headers {
+		req.Header.Add(header.Key, header.Value)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s responded with %s", service.URL, resp.Status)
+	}
+	return nil
+}
+
+func webhookClient(service *kyvernov1.ServiceCall) (*http.Client, error) {
+	if service.CABundle == "" {
+		return &http.Client{Timeout: webhookTimeout}, nil
+	}
+	caCertPool := x509.NewCertPool()
+	if ok := caCertPool.AppendCertsFromPEM([]byte(service.CABundle)); !ok {
+		return nil, fmt.Errorf("failed to parse PEM CA bundle for webhook %s", service.URL)
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    caCertPool,
+				MinVersion: tls.VersionTLS12,
+			},
+		},
+		Timeout: webhookTimeout,
+	}, nil
+}
@@ -0,0 +1,168 @@
+package drift
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/background/generate"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel/metric"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "generate-drift-controller"
+)
+
+const (
+	// DriftMissing : the downstream resource doesn't exist
+	DriftMissing = "missing"
+	// DriftModified : the downstream resource differs from the generate rule
+	DriftModified = "modified"
+	// DriftOrphaned : the trigger of the downstream resource no longer exists or no longer matches
+	DriftOrphaned = "orphaned"
+)
+
+type key struct {
+	policyNamespace string
+	policyName      string
+	rule            string
+	drift           string
+}
+
+// controller periodically compares the downstream resources of synchronized generate rules
+// to their expected state and reports the drift as events and gauges.
+type controller struct {
+	previewer *generate.Previewer
+
+	// listers
+	cpolLister kyvernov1listers.ClusterPolicyLister
+	polLister  kyvernov1listers.PolicyLister
+
+	eventGen event.Interface
+	interval time.Duration
+	metrics  metrics.GenerateDriftMetrics
+
+	// state
+	lock   sync.Mutex
+	latest map[key]int64
+}
+
+func NewController(
+	previewer *generate.Previewer,
+	cpolLister kyvernov1listers.ClusterPolicyLister,
+	polLister kyvernov1listers.PolicyLister,
+	eventGen event.Interface,
+	interval time.Duration,
+) controllers.Controller {
+	c := &controller{
+		previewer:  previewer,
+		cpolLister: cpolLister,
+		polLister:  polLister,
+		eventGen:   eventGen,
+		interval:   interval,
+		metrics:    metrics.GetGenerateDriftMetrics(),
+	}
+	if c.metrics != nil {
+		if _, err := c.metrics.RegisterCallback(c.report); err != nil {
+			logger.Error(err, "failed to register callback")
+		}
+	}
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, _ int) {
+	logger.V(2).Info("starting ...", "interval", c.interval)
+	defer logger.V(2).Info("stopping ...")
+	wait.UntilWithContext(ctx, c.scan, c.interval)
+}
+
+func (c *controller) scan(ctx context.Context) {
+	policies, err := c.listPolicies()
+	if err != nil {
+		logger.Error(err, "failed to list policies")
+		return
+	}
+	latest := map[key]int64{}
+	for _, policy := range policies {
+		logger := logger.WithValues("policy", policy.GetNamespace()+"/"+policy.GetName())
+		changes, err := c.previewer.Drift(ctx, logger, policy)
+		if err != nil {
+			logger.Error(err, "failed to compute generate drift")
+		}
+		for _, change := range changes {
+			drift := driftOf(change.Operation)
+			latest[key{policy.GetNamespace(), policy.GetName(), change.Rule, drift}]++
+			logger.V(2).Info("downstream resource drifted", "rule", change.Rule, "trigger", change.Trigger.String(), "target", change.Target.String(), "drift", drift, "reason", change.Reason)
+			if c.eventGen != nil {
+				c.eventGen.Add(event.NewGenerateDriftEvent(policy, change.Rule, drift, change.Target))
+			}
+		}
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.latest = latest
+}
+
+func (c *controller) listPolicies() ([]kyvernov1.PolicyInterface, error) {
+	var policies []kyvernov1.PolicyInterface
+	cpols, err := c.cpolLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, cpol := range cpols {
+		if hasSynchronizedGenerate(cpol) {
+			policies = append(policies, cpol)
+		}
+	}
+	pols, err := c.polLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, pol := range pols {
+		if hasSynchronizedGenerate(pol) {
+			policies = append(policies, pol)
+		}
+	}
+	return policies, nil
+}
+
+func (c *controller) report(ctx context.Context, observer metric.Observer) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for key, count := range c.latest {
+		c.metrics.RecordDrift(ctx, key.policyNamespace, key.policyName, key.rule, key.drift, count, observer)
+	}
+	return nil
+}
+
+func hasSynchronizedGenerate(policy kyvernov1.PolicyInterface) bool {
+	if !policy.GetDeletionTimestamp().IsZero() {
+		return false
+	}
+	for _, rule := range policy.GetSpec().Rules {
+		if rule.HasGenerate() && rule.Generation.Synchronize {
+			return true
+		}
+	}
+	return false
+}
+
+func driftOf(operation generate.Operation) string {
+	switch operation {
+	case generate.OperationCreate:
+		return DriftMissing
+	case generate.OperationUpdate:
+		return DriftModified
+	default:
+		return DriftOrphaned
+	}
+}
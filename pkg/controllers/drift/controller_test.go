@@ -0,0 +1,67 @@
+package drift
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/background/generate"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_driftOf(t *testing.T) {
+	assert.Equal(t, DriftMissing, driftOf(generate.OperationCreate))
+	assert.Equal(t, DriftModified, driftOf(generate.OperationUpdate))
+	assert.Equal(t, DriftOrphaned, driftOf(generate.OperationDelete))
+}
+
+func Test_hasSynchronizedGenerate(t *testing.T) {
+	policy := func(synchronize bool) *kyvernov1.ClusterPolicy {
+		return &kyvernov1.ClusterPolicy{
+			Spec: kyvernov1.Spec{
+				Rules: []kyvernov1.Rule{{
+					Name: "generate",
+					Generation: &kyvernov1.Generation{
+						Synchronize: synchronize,
+						GeneratePattern: kyvernov1.GeneratePattern{
+							ResourceSpec: kyvernov1.ResourceSpec{Kind: "ConfigMap", Name: "cm"},
+						},
+					},
+				}},
+			},
+		}
+	}
+	tests := []struct {
+		name   string
+		policy kyvernov1.PolicyInterface
+		want   bool
+	}{{
+		name:   "synchronized",
+		policy: policy(true),
+		want:   true,
+	}, {
+		name:   "not synchronized",
+		policy: policy(false),
+		want:   false,
+	}, {
+		name: "no generate rule",
+		policy: &kyvernov1.ClusterPolicy{
+			Spec: kyvernov1.Spec{Rules: []kyvernov1.Rule{{Name: "validate"}}},
+		},
+		want: false,
+	}, {
+		name: "deleted",
+		policy: func() kyvernov1.PolicyInterface {
+			p := policy(true)
+			now := metav1.Now()
+			p.SetDeletionTimestamp(&now)
+			return p
+		}(),
+		want: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, hasSynchronizedGenerate(tt.policy))
+		})
+	}
+}
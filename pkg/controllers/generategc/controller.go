@@ -0,0 +1,395 @@
+package generategc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/background/generate"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel/metric"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "generate-gc-controller"
+	// listPageSize is the number of resources fetched per list call
+	listPageSize = 500
+)
+
+// Config is the garbage collection policy applied to the orphaned generated resources
+type Config struct {
+	// Interval between two scans
+	Interval time.Duration
+	// DryRun reports the orphaned resources and only simulates their deletion
+	DryRun bool
+	// Reasons the orphaned resources are deleted for, orphaned resources with another reason are only reported
+	Reasons sets.Set[Reason]
+	// MinAge is the age an orphaned resource must reach before it is deleted, it leaves the time to the
+	// pending update requests to process a policy change before its previous downstream is collected
+	MinAge time.Duration
+}
+
+type key struct {
+	reason Reason
+	kind   string
+}
+
+// controller periodically looks for the resources carrying the generate labels whose policy, rule,
+// target or trigger no longer exists, reports them and deletes them according to its configuration.
+type controller struct {
+	client dclient.Interface
+
+	// listers
+	cpolLister kyvernov1listers.ClusterPolicyLister
+	polLister  kyvernov1listers.PolicyLister
+
+	eventGen event.Interface
+	config   Config
+	metrics  metrics.GenerateGCMetrics
+
+	// state
+	lock   sync.Mutex
+	latest map[key]int64
+	// kinds are the kinds downstream resources were found for, they keep being listed
+	// once the generate rules targeting them are deleted
+	kinds sets.Set[string]
+}
+
+func NewController(
+	client dclient.Interface,
+	cpolLister kyvernov1listers.ClusterPolicyLister,
+	polLister kyvernov1listers.PolicyLister,
+	eventGen event.Interface,
+	config Config,
+) controllers.Controller {
+	c := &controller{
+		client:     client,
+		cpolLister: cpolLister,
+		polLister:  polLister,
+		eventGen:   eventGen,
+		config:     config,
+		metrics:    metrics.GetGenerateGCMetrics(),
+		kinds:      sets.New[string](),
+	}
+	if c.metrics != nil {
+		if _, err := c.metrics.RegisterCallback(c.report); err != nil {
+			logger.Error(err, "failed to register callback")
+		}
+	}
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, _ int) {
+	logger.V(2).Info("starting ...", "interval", c.config.Interval, "dryRun", c.config.DryRun, "reasons", sets.List(c.config.Reasons), "minAge", c.config.MinAge)
+	defer logger.V(2).Info("stopping ...")
+	wait.UntilWithContext(ctx, c.scan, c.config.Interval)
+}
+
+func (c *controller) scan(ctx context.Context) {
+	resources, err := c.listDownstreams(ctx)
+	if err != nil {
+		logger.Error(err, "failed to list generated resources")
+		return
+	}
+	triggers := newTriggerCache(c.client)
+	orphans := orphanLabels(resources)
+	latest := map[key]int64{}
+	for _, resource := range resources {
+		logger := logger.WithValues("resource", resourceKey(resource))
+		reason, err := c.orphanReason(ctx, resource, triggers, orphans)
+		if err != nil {
+			logger.Error(err, "failed to check generated resource")
+			continue
+		}
+		if reason == "" {
+			continue
+		}
+		latest[key{reason, resource.GetKind()}]++
+		deleted, err := c.collect(ctx, resource, reason)
+		if err != nil {
+			logger.Error(err, "failed to delete orphaned generated resource", "reason", reason)
+		} else {
+			logger.V(2).Info("orphaned generated resource", "reason", reason, "deleted", deleted, "dryRun", c.config.DryRun)
+		}
+		if c.eventGen != nil {
+			c.eventGen.Add(event.NewGenerateOrphanEvent(resource, reason, deleted && !c.config.DryRun, err))
+		}
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.latest = latest
+}
+
+// orphanReason returns the reason a generated resource is orphaned, or an empty string if it is not
+func (c *controller) orphanReason(ctx context.Context, resource unstructured.Unstructured, triggers *triggerCache, orphans map[string]string) (Reason, error) {
+	labels := resource.GetLabels()
+	// the resources of rules that are not synchronized or that orphan their downstream resources are kept when
+	// the policy or rule is deleted, this is recorded in a label of the resource, or of the other resources
+	// generated by the same rule for the resources generated before the label was introduced
+	orphan, ok := labels[common.GenerateOrphanLabel]
+	if !ok {
+		orphan = orphans[ruleKey(labels)]
+	}
+	collectDeleted := orphan == "false"
+	policy, err := c.getPolicy(labels[common.GeneratePolicyNamespaceLabel], labels[common.GeneratePolicyLabel])
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			if !collectDeleted {
+				return "", nil
+			}
+			return ReasonPolicyDeleted, nil
+		}
+		return "", err
+	}
+	if !policy.GetDeletionTimestamp().IsZero() {
+		// the policy deletion is handled by the policy controller
+		return "", nil
+	}
+	rule := findGenerateRule(policy, labels[common.GenerateRuleLabel])
+	if rule == nil {
+		if !collectDeleted {
+			return "", nil
+		}
+		return ReasonRuleDeleted, nil
+	}
+	if !rule.Generation.Synchronize {
+		// downstream resources of unsynchronized rules are not managed once created
+		return "", nil
+	}
+	if !targets(rule, resource) {
+		return ReasonTargetChanged, nil
+	}
+	trigger := generate.TriggerFromLabels(labels)
+	if trigger.Kind == "" || (trigger.UID == "" && trigger.Name == "") {
+		return "", nil
+	}
+	exists, err := triggers.exists(ctx, trigger)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return ReasonTriggerDeleted, nil
+	}
+	return "", nil
+}
+
+// collect deletes an orphaned resource if the configuration allows it, it returns true if the resource
+// was deleted (or would have been deleted in dry-run mode)
+func (c *controller) collect(ctx context.Context, resource unstructured.Unstructured, reason Reason) (bool, error) {
+	if !c.config.Reasons.Has(reason) {
+		return false, nil
+	}
+	if time.Since(resource.GetCreationTimestamp().Time) < c.config.MinAge {
+		return false, nil
+	}
+	uid := resource.GetUID()
+	options := metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &uid},
+	}
+	err := c.client.DeleteResource(ctx, resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource.GetName(), c.config.DryRun, options)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, err
+	}
+	if c.metrics != nil {
+		c.metrics.RecordDeletion(ctx, reason, resource.GetKind(), c.config.DryRun)
+	}
+	return true, nil
+}
+
+// listDownstreams lists the resources carrying the generate labels, only the kinds generate rules
+// target are listed unless a rule targets a kind resolved at runtime
+func (c *controller) listDownstreams(ctx context.Context) ([]unstructured.Unstructured, error) {
+	kinds, all, err := c.generatedKinds()
+	if err != nil {
+		return nil, err
+	}
+	lists, err := c.client.Discovery().CachedDiscoveryInterface().ServerPreferredResources()
+	if err != nil {
+		if !discovery.IsGroupDiscoveryFailedError(err) || len(lists) == 0 {
+			return nil, err
+		}
+		logger.V(2).Info("failed to discover some api groups, their resources are not collected", "error", err.Error())
+	}
+	selector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			kyverno.LabelAppManagedBy: kyverno.ValueKyvernoApp,
+		},
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: common.GeneratePolicyLabel, Operator: metav1.LabelSelectorOpExists},
+			{Key: common.GenerateRuleLabel, Operator: metav1.LabelSelectorOpExists},
+		},
+	}
+	var resources []unstructured.Unstructured
+	var errs []error
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, resource := range list.APIResources {
+			if strings.Contains(resource.Name, "/") || !sets.New(resource.Verbs...).HasAll("list", "delete") {
+				continue
+			}
+			if !all && !kinds.Has(resource.Kind) {
+				continue
+			}
+			items, err := c.listResource(ctx, gv.WithResource(resource.Name), selector)
+			if err != nil {
+				// the kinds the controller is not allowed to list can't have been generated by it
+				if !apierrors.IsForbidden(err) && !apierrors.IsNotFound(err) && !apierrors.IsMethodNotSupported(err) {
+					errs = append(errs, err)
+				}
+				continue
+			}
+			for _, item := range items {
+				if isGenerateDownstream(item) {
+					resources = append(resources, item)
+					c.kinds.Insert(resource.Kind)
+				}
+			}
+		}
+	}
+	if len(errs) != 0 {
+		logger.V(2).Info("failed to list some generated resources", "error", errors.Join(errs...).Error())
+	}
+	return resources, nil
+}
+
+// listResource lists the resources matching the selector in pages
+func (c *controller) listResource(ctx context.Context, gvr schema.GroupVersionResource, selector *metav1.LabelSelector) ([]unstructured.Unstructured, error) {
+	options := metav1.ListOptions{
+		LabelSelector: metav1.FormatLabelSelector(selector),
+		Limit:         listPageSize,
+	}
+	var items []unstructured.Unstructured
+	for {
+		list, err := c.client.GetDynamicInterface().Resource(gvr).List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, list.Items...)
+		if list.GetContinue() == "" {
+			return items, nil
+		}
+		options.Continue = list.GetContinue()
+	}
+}
+
+// generatedKinds returns the kinds targeted by the generate rules of the policies and the kinds downstream
+// resources were previously found for, the returned boolean is true when every kind must be listed
+func (c *controller) generatedKinds() (sets.Set[string], bool, error) {
+	var policies []kyvernov1.PolicyInterface
+	cpols, err := c.cpolLister.List(labels.Everything())
+	if err != nil {
+		return nil, false, err
+	}
+	for _, cpol := range cpols {
+		policies = append(policies, cpol)
+	}
+	pols, err := c.polLister.List(labels.Everything())
+	if err != nil {
+		return nil, false, err
+	}
+	for _, pol := range pols {
+		policies = append(policies, pol)
+	}
+	kinds := c.kinds.Clone()
+	for _, policy := range policies {
+		for _, rule := range policy.GetSpec().Rules {
+			if !rule.HasGenerate() {
+				continue
+			}
+			for _, kind := range generatedRuleKinds(rule) {
+				if strings.Contains(kind, "{{") {
+					return nil, true, nil
+				}
+				kinds.Insert(kind)
+			}
+		}
+	}
+	return kinds, false, nil
+}
+
+func (c *controller) getPolicy(namespace, name string) (kyvernov1.PolicyInterface, error) {
+	if namespace == "" {
+		return c.cpolLister.Get(name)
+	}
+	return c.polLister.Policies(namespace).Get(name)
+}
+
+func (c *controller) report(ctx context.Context, observer metric.Observer) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for key, count := range c.latest {
+		c.metrics.RecordOrphans(ctx, key.reason, key.kind, count, observer)
+	}
+	return nil
+}
+
+// triggerCache remembers the triggers looked up during a scan
+type triggerCache struct {
+	client dclient.Interface
+	// uids of the resources by api version, kind and namespace
+	uids map[string]sets.Set[types.UID]
+}
+
+func newTriggerCache(client dclient.Interface) *triggerCache {
+	return &triggerCache{
+		client: client,
+		uids:   map[string]sets.Set[types.UID]{},
+	}
+}
+
+func (t *triggerCache) exists(ctx context.Context, trigger kyvernov1.ResourceSpec) (bool, error) {
+	if trigger.Name != "" {
+		obj, err := t.client.GetResource(ctx, trigger.GetAPIVersion(), trigger.GetKind(), trigger.GetNamespace(), trigger.GetName())
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return trigger.UID == "" || obj.GetUID() == trigger.UID, nil
+	}
+	key := trigger.GetAPIVersion() + "/" + trigger.GetKind() + "/" + trigger.GetNamespace()
+	uids, ok := t.uids[key]
+	if !ok {
+		list, err := t.client.ListResource(ctx, trigger.GetAPIVersion(), trigger.GetKind(), trigger.GetNamespace(), nil)
+		if err != nil {
+			return false, err
+		}
+		uids = sets.New[types.UID]()
+		for _, item := range list.Items {
+			uids.Insert(item.GetUID())
+		}
+		t.uids[key] = uids
+	}
+	return uids.Has(trigger.UID), nil
+}
+
+func resourceKey(resource unstructured.Unstructured) string {
+	if resource.GetNamespace() == "" {
+		return resource.GetKind() + "/" + resource.GetName()
+	}
+	return resource.GetKind() + "/" + resource.GetNamespace() + "/" + resource.GetName()
+}
@@ -0,0 +1,160 @@
+package generategc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+)
+
+func Test_orphanReason_deleted(t *testing.T) {
+	cpol := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cpol"},
+		Spec: kyvernov1.Spec{
+			Rules: []kyvernov1.Rule{{
+				Name: "generate",
+				Generation: &kyvernov1.Generation{
+					Synchronize: true,
+					GeneratePattern: kyvernov1.GeneratePattern{
+						ResourceSpec: kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Namespace: "ns", Name: "cm"},
+					},
+				},
+			}},
+		},
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NoError(t, indexer.Add(cpol))
+	c := &controller{cpolLister: kyvernov1listers.NewClusterPolicyLister(indexer)}
+	tests := []struct {
+		name   string
+		policy string
+		rule   string
+		orphan string
+		// orphan labels of the other resources generated by the rules
+		orphans map[string]string
+		want    Reason
+	}{{
+		name:   "policy deleted",
+		policy: "deleted",
+		rule:   "generate",
+		orphan: "false",
+		want:   ReasonPolicyDeleted,
+	}, {
+		name:   "policy deleted with orphaned downstream",
+		policy: "deleted",
+		rule:   "generate",
+		orphan: "true",
+	}, {
+		name:   "policy deleted without orphan label",
+		policy: "deleted",
+		rule:   "generate",
+	}, {
+		name:    "policy deleted without orphan label, recorded by the rule other resources",
+		policy:  "deleted",
+		rule:    "generate",
+		orphans: map[string]string{"/deleted/generate": "false"},
+		want:    ReasonPolicyDeleted,
+	}, {
+		name:    "policy deleted without orphan label, orphaned by the rule other resources",
+		policy:  "deleted",
+		rule:    "generate",
+		orphans: map[string]string{"/deleted/generate": "true"},
+	}, {
+		name:   "rule deleted",
+		policy: "cpol",
+		rule:   "deleted",
+		orphan: "false",
+		want:   ReasonRuleDeleted,
+	}, {
+		name:   "rule deleted with orphaned downstream",
+		policy: "cpol",
+		rule:   "deleted",
+		orphan: "true",
+	}, {
+		name:   "rule deleted without orphan label",
+		policy: "cpol",
+		rule:   "deleted",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := resource("v1", "ConfigMap", "ns", "cm")
+			labels := map[string]string{common.GeneratePolicyLabel: tt.policy, common.GenerateRuleLabel: tt.rule}
+			if tt.orphan != "" {
+				labels[common.GenerateOrphanLabel] = tt.orphan
+			}
+			obj.SetLabels(labels)
+			reason, err := c.orphanReason(context.TODO(), obj, nil, tt.orphans)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, reason)
+		})
+	}
+}
+
+func Test_listResource_pages(t *testing.T) {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{gvr: "ConfigMapList"})
+	// the fake client doesn't keep the pagination options, the first call returns a continue token
+	calls := 0
+	dyn.PrependReactor("list", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		calls++
+		list := &unstructured.UnstructuredList{}
+		item := resource("v1", "ConfigMap", "ns", fmt.Sprintf("cm-%d", calls))
+		item.SetLabels(map[string]string{"app": "kyverno"})
+		list.Items = append(list.Items, item)
+		if calls == 1 {
+			list.SetContinue("next")
+		}
+		return true, list, nil
+	})
+	c := &controller{client: dclient.NewFakeClientWithDisco(dyn, nil, nil)}
+	items, err := c.listResource(context.TODO(), gvr, &metav1.LabelSelector{MatchLabels: map[string]string{"app": "kyverno"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Len(t, items, 2)
+}
+
+func Test_generatedKinds(t *testing.T) {
+	rule := func(kind string) kyvernov1.Rule {
+		return kyvernov1.Rule{
+			Name: "generate",
+			Generation: &kyvernov1.Generation{
+				GeneratePattern: kyvernov1.GeneratePattern{
+					ResourceSpec: kyvernov1.ResourceSpec{Kind: kind},
+				},
+			},
+		}
+	}
+	newController := func(rules ...kyvernov1.Rule) *controller {
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		assert.NoError(t, indexer.Add(&kyvernov1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "cpol"},
+			Spec:       kyvernov1.Spec{Rules: rules},
+		}))
+		return &controller{
+			cpolLister: kyvernov1listers.NewClusterPolicyLister(indexer),
+			polLister:  kyvernov1listers.NewPolicyLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})),
+			kinds:      sets.New("Secret"),
+		}
+	}
+	kinds, all, err := newController(rule("ConfigMap")).generatedKinds()
+	assert.NoError(t, err)
+	assert.False(t, all)
+	// the kinds downstream resources were found for are kept
+	assert.Equal(t, sets.New("ConfigMap", "Secret"), kinds)
+	_, all, err = newController(rule("ConfigMap"), rule("{{ request.object.kind }}")).generatedKinds()
+	assert.NoError(t, err)
+	assert.True(t, all)
+}
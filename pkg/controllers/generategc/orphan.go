@@ -0,0 +1,149 @@
+package generategc
+
+import (
+	"fmt"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Reason explains why a generated resource is orphaned
+type Reason = string
+
+const (
+	// ReasonPolicyDeleted : the policy that generated the resource no longer exists
+	ReasonPolicyDeleted Reason = "PolicyDeleted"
+	// ReasonRuleDeleted : the policy no longer has a generate rule with the name of the rule that generated the resource
+	ReasonRuleDeleted Reason = "RuleDeleted"
+	// ReasonTargetChanged : the generate rule no longer targets the kind, namespace or name of the resource
+	ReasonTargetChanged Reason = "TargetChanged"
+	// ReasonTriggerDeleted : the trigger of the generate rule no longer exists
+	ReasonTriggerDeleted Reason = "TriggerDeleted"
+)
+
+// Reasons are all the reasons a generated resource can be orphaned for
+var Reasons = []Reason{ReasonPolicyDeleted, ReasonRuleDeleted, ReasonTargetChanged, ReasonTriggerDeleted}
+
+// ParseReasons parses a comma separated list of reasons
+func ParseReasons(value string) (sets.Set[Reason], error) {
+	known := sets.New(Reasons...)
+	reasons := sets.New[Reason]()
+	for _, reason := range strings.Split(value, ",") {
+		reason = strings.TrimSpace(reason)
+		if reason == "" {
+			continue
+		}
+		if !known.Has(reason) {
+			return nil, fmt.Errorf("unknown orphan reason %q, must be one of %s", reason, strings.Join(Reasons, ", "))
+		}
+		reasons.Insert(reason)
+	}
+	return reasons, nil
+}
+
+// findGenerateRule returns the generate rule with the given name
+func findGenerateRule(policy kyvernov1.PolicyInterface, name string) *kyvernov1.Rule {
+	rules := policy.GetSpec().Rules
+	for i := range rules {
+		if rules[i].Name == name && rules[i].HasGenerate() {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// targets returns true if the generate rule can have produced the resource
+func targets(rule *kyvernov1.Rule, resource unstructured.Unstructured) bool {
+	patterns := []kyvernov1.GeneratePattern{rule.Generation.GeneratePattern}
+	for _, foreach := range rule.Generation.ForEachGeneration {
+		patterns = append(patterns, foreach.GeneratePattern)
+	}
+	for _, pattern := range patterns {
+		if pattern.GetKind() != "" {
+			if matches(pattern.GetKind(), resource.GetKind()) &&
+				matchesAPIVersion(pattern.GetAPIVersion(), resource.GetAPIVersion()) &&
+				matches(pattern.GetNamespace(), resource.GetNamespace()) &&
+				matches(pattern.GetName(), resource.GetName()) {
+				return true
+			}
+			continue
+		}
+		for _, kind := range pattern.CloneList.Kinds {
+			apiVersion, kind := kubeutils.GetKindFromGVK(kind)
+			if matches(kind, resource.GetKind()) && matchesAPIVersion(apiVersion, resource.GetAPIVersion()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// generatedRuleKinds returns the kinds a generate rule can produce
+func generatedRuleKinds(rule kyvernov1.Rule) []string {
+	patterns := []kyvernov1.GeneratePattern{rule.Generation.GeneratePattern}
+	for _, foreach := range rule.Generation.ForEachGeneration {
+		patterns = append(patterns, foreach.GeneratePattern)
+	}
+	var kinds []string
+	for _, pattern := range patterns {
+		if kind := pattern.GetKind(); kind != "" {
+			kinds = append(kinds, kind)
+			continue
+		}
+		for _, kind := range pattern.CloneList.Kinds {
+			_, kind := kubeutils.GetKindFromGVK(kind)
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds
+}
+
+// ruleKey identifies the generate rule of a downstream resource
+func ruleKey(labels map[string]string) string {
+	return labels[common.GeneratePolicyNamespaceLabel] + "/" + labels[common.GeneratePolicyLabel] + "/" + labels[common.GenerateRuleLabel]
+}
+
+// orphanLabels returns the orphan label recorded by the downstream resources of every generate rule
+func orphanLabels(resources []unstructured.Unstructured) map[string]string {
+	orphans := map[string]string{}
+	for _, resource := range resources {
+		labels := resource.GetLabels()
+		if orphan, ok := labels[common.GenerateOrphanLabel]; ok {
+			orphans[ruleKey(labels)] = orphan
+		}
+	}
+	return orphans
+}
+
+// matches compares a value of a generate pattern with the value of a resource, patterns
+// containing variables or left empty are considered matching as they can't be resolved here
+func matches(pattern, value string) bool {
+	return pattern == "" || strings.Contains(pattern, "{{") || pattern == value
+}
+
+func matchesAPIVersion(pattern, value string) bool {
+	if pattern == "" || strings.Contains(pattern, "{{") {
+		return true
+	}
+	expected, err := schema.ParseGroupVersion(pattern)
+	if err != nil {
+		return true
+	}
+	actual, err := schema.ParseGroupVersion(value)
+	if err != nil {
+		return true
+	}
+	return expected.Group == actual.Group
+}
+
+// isGenerateDownstream returns true if the resource was generated by a ClusterPolicy or Policy rule,
+// resources generated by GeneratingPolicies don't carry a rule label and are not collected
+func isGenerateDownstream(resource unstructured.Unstructured) bool {
+	labels := resource.GetLabels()
+	return labels[common.GeneratePolicyLabel] != "" && labels[common.GenerateRuleLabel] != ""
+}
@@ -0,0 +1,141 @@
+package generategc
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func Test_ParseReasons(t *testing.T) {
+	reasons, err := ParseReasons("PolicyDeleted, TriggerDeleted,")
+	assert.NoError(t, err)
+	assert.Equal(t, sets.New(ReasonPolicyDeleted, ReasonTriggerDeleted), reasons)
+	_, err = ParseReasons("PolicyDeleted,Unknown")
+	assert.Error(t, err)
+}
+
+func resource(apiVersion, kind, namespace, name string) unstructured.Unstructured {
+	var obj unstructured.Unstructured
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+func Test_targets(t *testing.T) {
+	data := func(apiVersion, kind, namespace, name string) *kyvernov1.Rule {
+		return &kyvernov1.Rule{
+			Name: "generate",
+			Generation: &kyvernov1.Generation{
+				GeneratePattern: kyvernov1.GeneratePattern{
+					ResourceSpec: kyvernov1.ResourceSpec{APIVersion: apiVersion, Kind: kind, Namespace: namespace, Name: name},
+				},
+			},
+		}
+	}
+	cloneList := &kyvernov1.Rule{
+		Name: "clone",
+		Generation: &kyvernov1.Generation{
+			GeneratePattern: kyvernov1.GeneratePattern{
+				CloneList: kyvernov1.CloneList{Kinds: []string{"v1/Secret", "ConfigMap"}},
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		rule     *kyvernov1.Rule
+		resource unstructured.Unstructured
+		want     bool
+	}{{
+		name:     "same target",
+		rule:     data("v1", "ConfigMap", "default", "cm"),
+		resource: resource("v1", "ConfigMap", "default", "cm"),
+		want:     true,
+	}, {
+		name:     "variables",
+		rule:     data("v1", "ConfigMap", "{{request.object.metadata.name}}", "cm"),
+		resource: resource("v1", "ConfigMap", "team-a", "cm"),
+		want:     true,
+	}, {
+		name:     "name changed",
+		rule:     data("v1", "ConfigMap", "default", "cm-renamed"),
+		resource: resource("v1", "ConfigMap", "default", "cm"),
+		want:     false,
+	}, {
+		name:     "kind changed",
+		rule:     data("v1", "Secret", "default", "cm"),
+		resource: resource("v1", "ConfigMap", "default", "cm"),
+		want:     false,
+	}, {
+		name:     "group changed",
+		rule:     data("networking.k8s.io/v1", "NetworkPolicy", "default", "np"),
+		resource: resource("example.io/v1", "NetworkPolicy", "default", "np"),
+		want:     false,
+	}, {
+		name:     "clone list",
+		rule:     cloneList,
+		resource: resource("v1", "Secret", "default", "secret"),
+		want:     true,
+	}, {
+		name:     "not in clone list",
+		rule:     cloneList,
+		resource: resource("v1", "Pod", "default", "pod"),
+		want:     false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, targets(tt.rule, tt.resource))
+		})
+	}
+}
+
+func Test_findGenerateRule(t *testing.T) {
+	policy := &kyvernov1.ClusterPolicy{
+		Spec: kyvernov1.Spec{
+			Rules: []kyvernov1.Rule{{
+				Name: "validate",
+			}, {
+				Name: "generate",
+				Generation: &kyvernov1.Generation{
+					GeneratePattern: kyvernov1.GeneratePattern{
+						ResourceSpec: kyvernov1.ResourceSpec{Kind: "ConfigMap", Name: "cm"},
+					},
+				},
+			}},
+		},
+	}
+	assert.NotNil(t, findGenerateRule(policy, "generate"))
+	assert.Nil(t, findGenerateRule(policy, "validate"))
+	assert.Nil(t, findGenerateRule(policy, "renamed"))
+}
+
+func Test_isGenerateDownstream(t *testing.T) {
+	obj := resource("v1", "ConfigMap", "default", "cm")
+	assert.False(t, isGenerateDownstream(obj))
+	obj.SetLabels(map[string]string{common.GeneratePolicyLabel: "gpol"})
+	assert.False(t, isGenerateDownstream(obj))
+	obj.SetLabels(map[string]string{common.GeneratePolicyLabel: "pol", common.GenerateRuleLabel: "rule"})
+	assert.True(t, isGenerateDownstream(obj))
+}
+
+func Test_generatedRuleKinds(t *testing.T) {
+	rule := kyvernov1.Rule{
+		Generation: &kyvernov1.Generation{
+			ForEachGeneration: []kyvernov1.ForEachGeneration{{
+				GeneratePattern: kyvernov1.GeneratePattern{
+					ResourceSpec: kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap"},
+				},
+			}, {
+				GeneratePattern: kyvernov1.GeneratePattern{
+					CloneList: kyvernov1.CloneList{Kinds: []string{"v1/Secret", "networking.k8s.io/v1/NetworkPolicy"}},
+				},
+			}},
+		},
+	}
+	assert.Equal(t, []string{"ConfigMap", "Secret", "NetworkPolicy"}, generatedRuleKinds(rule))
+}
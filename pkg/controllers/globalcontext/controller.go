@@ -14,6 +14,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/adapters"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
+	entryevent "github.com/kyverno/kyverno/pkg/globalcontext/event"
 	"github.com/kyverno/kyverno/pkg/globalcontext/externalapi"
 	"github.com/kyverno/kyverno/pkg/globalcontext/k8sresource"
 	"github.com/kyverno/kyverno/pkg/globalcontext/store"
@@ -46,6 +47,7 @@ type controller struct {
 	kyvernoClient      versioned.Interface
 	store              store.Store
 	eventGen           event.Interface
+	changes            entryevent.Broadcaster
 	maxResponseLength  int64
 	apiCallTimeout     time.Duration
 	shouldUpdateStatus bool
@@ -59,6 +61,7 @@ func NewController(
 	kyvernoClient versioned.Interface,
 	storage store.Store,
 	eventGen event.Interface,
+	changes entryevent.Broadcaster,
 	maxResponseLength int64,
 	apiCallTimeout time.Duration,
 	shouldUpdateStatus bool,
@@ -76,6 +79,7 @@ func NewController(
 		kyvernoClient:      kyvernoClient,
 		store:              storage,
 		eventGen:           eventGen,
+		changes:            changes,
 		maxResponseLength:  maxResponseLength,
 		apiCallTimeout:     apiCallTimeout,
 		shouldUpdateStatus: shouldUpdateStatus,
@@ -155,6 +159,7 @@ func (c *controller) makeStoreEntry(ctx context.Context, gce *kyvernov2beta1.Glo
 			ctx,
 			gce,
 			c.eventGen,
+			c.changes,
 			c.dclient.GetDynamicInterface(),
 			logger,
 			gvr,
@@ -166,6 +171,7 @@ func (c *controller) makeStoreEntry(ctx context.Context, gce *kyvernov2beta1.Glo
 		ctx,
 		gce,
 		c.eventGen,
+		c.changes,
 		c.kyvernoClient,
 		c.gceLister,
 		logger,
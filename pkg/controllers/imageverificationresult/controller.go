@@ -0,0 +1,216 @@
+package imageverificationresult
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/imageverification/results"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 2
+	ControllerName = "image-verification-result-controller"
+	maxRetries     = 10
+	// maxPending is the maximum number of results waiting to be published for a digest
+	maxPending = 100
+	// maxImages is the maximum number of image references kept for a digest
+	maxImages = 20
+)
+
+// Controller publishes the image verification results as ImageVerificationResult objects
+type Controller interface {
+	controllers.Controller
+	results.Store
+}
+
+type controller struct {
+	// clients
+	client versioned.Interface
+
+	// listers
+	lister kyvernov2alpha1listers.ImageVerificationResultLister
+
+	// queue
+	queue workqueue.TypedRateLimitingInterface[any]
+
+	// config
+	retention  time.Duration
+	signingKey []byte
+
+	// state
+	lock    sync.Mutex
+	pending map[string][]results.Result
+}
+
+func NewController(
+	client versioned.Interface,
+	informer kyvernov2alpha1informers.ImageVerificationResultInformer,
+	retention time.Duration,
+	signingKey []byte,
+) Controller {
+	return &controller{
+		client: client,
+		lister: informer.Lister(),
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[any](),
+			workqueue.TypedRateLimitingQueueConfig[any]{Name: ControllerName},
+		),
+		retention:  retention,
+		signingKey: signingKey,
+		pending:    map[string][]results.Result{},
+	}
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) Record(result results.Result) {
+	name := kyvernov2alpha1.ImageVerificationResultName(result.Digest)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	pending := append(c.pending[name], result)
+	if len(pending) > maxPending {
+		pending = pending[len(pending)-maxPending:]
+	}
+	c.pending[name] = pending
+	c.queue.Add(name)
+}
+
+func (c *controller) Lookup(digest, policy, rule string) (*kyvernov2alpha1.ImageVerificationRecord, bool) {
+	obj, err := c.lister.Get(kyvernov2alpha1.ImageVerificationResultName(digest))
+	if err != nil {
+		return nil, false
+	}
+	if obj.Spec.Digest != digest {
+		return nil, false
+	}
+	record := obj.Spec.FindVerification(policy, rule)
+	if record == nil || c.expired(record, time.Now()) {
+		return nil, false
+	}
+	// anyone allowed to write the objects could forge a passed verification
+	if !hmac.Equal([]byte(record.Signature), []byte(c.sign(digest, record))) {
+		return nil, false
+	}
+	return record.DeepCopy(), true
+}
+
+// sign returns the signature of the record of a digest, it covers the fields the lookups rely on
+func (c *controller) sign(digest string, record *kyvernov2alpha1.ImageVerificationRecord) string {
+	mac := hmac.New(sha256.New, c.signingKey)
+	for _, part := range []string{digest, record.Policy, record.PolicyResourceVersion, record.Rule, string(record.Status), record.Time.UTC().Format(time.RFC3339)} {
+		mac.Write([]byte(part))
+		mac.Write([]byte{0})
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (c *controller) expired(record *kyvernov2alpha1.ImageVerificationRecord, now time.Time) bool {
+	return c.retention > 0 && now.Sub(record.Time.Time) > c.retention
+}
+
+func (c *controller) take(name string) []results.Result {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	pending := c.pending[name]
+	delete(c.pending, name)
+	return pending
+}
+
+// restore puts back results that failed to be published, unless newer results replaced them
+func (c *controller) restore(name string, pending []results.Result) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	pending = append(pending, c.pending[name]...)
+	if len(pending) > maxPending {
+		pending = pending[len(pending)-maxPending:]
+	}
+	c.pending[name] = pending
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, _ string) error {
+	pending := c.take(key)
+	if len(pending) == 0 {
+		return nil
+	}
+	if err := c.publish(ctx, key, pending); err != nil {
+		c.restore(key, pending)
+		return err
+	}
+	logger.V(4).Info("published image verification results", "count", len(pending))
+	return nil
+}
+
+func (c *controller) publish(ctx context.Context, name string, pending []results.Result) error {
+	now := time.Now()
+	obj, err := c.lister.Get(name)
+	if apierrors.IsNotFound(err) {
+		obj = &kyvernov2alpha1.ImageVerificationResult{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					kyverno.LabelAppManagedBy: kyverno.ValueKyvernoApp,
+				},
+			},
+			Spec: kyvernov2alpha1.ImageVerificationResultSpec{
+				Digest: pending[0].Digest,
+			},
+		}
+		if c.retention > 0 {
+			obj.Labels[kyverno.LabelCleanupTtl] = c.retention.String()
+			obj.Annotations = map[string]string{
+				kyverno.AnnotationCleanupTtlAnchor: kyverno.ValueTtlAnchorLastUpdate,
+			}
+		}
+		c.merge(&obj.Spec, pending, now)
+		_, err := c.client.KyvernoV2alpha1().ImageVerificationResults().Create(ctx, obj, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	}
+	obj = obj.DeepCopy()
+	c.merge(&obj.Spec, pending, now)
+	_, err = c.client.KyvernoV2alpha1().ImageVerificationResults().Update(ctx, obj, metav1.UpdateOptions{})
+	return err
+}
+
+// merge adds the results to the spec, keeping the latest verification of every policy rule and
+// dropping the verifications older than the retention
+func (c *controller) merge(spec *kyvernov2alpha1.ImageVerificationResultSpec, pending []results.Result, now time.Time) {
+	for _, result := range pending {
+		if result.Image != "" && !slices.Contains(spec.Images, result.Image) {
+			spec.Images = append(spec.Images, result.Image)
+		}
+		record := result.Record()
+		record.Signature = c.sign(spec.Digest, &record)
+		if existing := spec.FindVerification(record.Policy, record.Rule); existing == nil {
+			spec.Verifications = append(spec.Verifications, record)
+		} else if !record.Time.Before(&existing.Time) {
+			*existing = record
+		}
+	}
+	if len(spec.Images) > maxImages {
+		spec.Images = spec.Images[len(spec.Images)-maxImages:]
+	}
+	spec.Verifications = slices.DeleteFunc(spec.Verifications, func(record kyvernov2alpha1.ImageVerificationRecord) bool {
+		return c.expired(&record, now)
+	})
+}
@@ -0,0 +1,147 @@
+package imageverificationresult
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	"github.com/kyverno/kyverno/pkg/imageverification/results"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testDigest = "sha256:bc103b4a84971ef6459b294a2b98568a2bfb72cded09d4acd1e16366a401f95b"
+
+var testSigningKey = []byte("test-signing-key")
+
+func signed(record kyvernov2alpha1.ImageVerificationRecord) kyvernov2alpha1.ImageVerificationRecord {
+	record.Signature = (&controller{signingKey: testSigningKey}).sign(testDigest, &record)
+	return record
+}
+
+func newTestController(t *testing.T, objs ...*kyvernov2alpha1.ImageVerificationResult) (*controller, *fake.Clientset) {
+	client := fake.NewSimpleClientset()
+	factory := kyvernoinformer.NewSharedInformerFactory(client, 0)
+	informer := factory.Kyverno().V2alpha1().ImageVerificationResults()
+	for _, obj := range objs {
+		_, err := client.KyvernoV2alpha1().ImageVerificationResults().Create(context.Background(), obj, metav1.CreateOptions{})
+		assert.NoError(t, err)
+		assert.NoError(t, informer.Informer().GetIndexer().Add(obj))
+	}
+	return NewController(client, informer, time.Hour, testSigningKey).(*controller), client
+}
+
+func TestRecordCreates(t *testing.T) {
+	c, client := newTestController(t)
+	c.Record(results.Result{
+		Image:   "ghcr.io/kyverno/test:v1",
+		Digest:  testDigest,
+		Policy:  "check-images",
+		Rule:    "verify",
+		Status:  kyvernov2alpha1.ImageVerificationPass,
+		Signers: []kyvernov2alpha1.ImageSigner{{Type: "cosign/keyless", Issuer: "https://token.actions.githubusercontent.com"}},
+		Time:    time.Now(),
+	})
+	name := kyvernov2alpha1.ImageVerificationResultName(testDigest)
+	assert.Equal(t, 1, c.queue.Len())
+	assert.NoError(t, c.reconcile(context.Background(), logr.Discard(), name, "", ""))
+
+	obj, err := client.KyvernoV2alpha1().ImageVerificationResults().Get(context.Background(), name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, testDigest, obj.Spec.Digest)
+	assert.Equal(t, []string{"ghcr.io/kyverno/test:v1"}, obj.Spec.Images)
+	assert.Len(t, obj.Spec.Verifications, 1)
+	assert.Equal(t, "1h0m0s", obj.Labels[kyverno.LabelCleanupTtl])
+	assert.Equal(t, kyverno.ValueTtlAnchorLastUpdate, obj.Annotations[kyverno.AnnotationCleanupTtlAnchor])
+	// nothing is pending anymore
+	assert.NoError(t, c.reconcile(context.Background(), logr.Discard(), name, "", ""))
+}
+
+func TestRecordMerges(t *testing.T) {
+	now := time.Now()
+	name := kyvernov2alpha1.ImageVerificationResultName(testDigest)
+	existing := &kyvernov2alpha1.ImageVerificationResult{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: kyvernov2alpha1.ImageVerificationResultSpec{
+			Digest: testDigest,
+			Images: []string{"ghcr.io/kyverno/test:v1"},
+			Verifications: []kyvernov2alpha1.ImageVerificationRecord{signed(kyvernov2alpha1.ImageVerificationRecord{
+				Policy: "check-images",
+				Rule:   "verify",
+				Status: kyvernov2alpha1.ImageVerificationPass,
+				Time:   metav1.NewTime(now.Add(-time.Minute)),
+			}), signed(kyvernov2alpha1.ImageVerificationRecord{
+				Policy: "old-policy",
+				Rule:   "verify",
+				Status: kyvernov2alpha1.ImageVerificationPass,
+				Time:   metav1.NewTime(now.Add(-2 * time.Hour)),
+			})},
+		},
+	}
+	c, client := newTestController(t, existing)
+
+	record, found := c.Lookup(testDigest, "check-images", "verify")
+	assert.True(t, found)
+	assert.Equal(t, kyvernov2alpha1.ImageVerificationPass, record.Status)
+	// expired verifications are not returned
+	_, found = c.Lookup(testDigest, "old-policy", "verify")
+	assert.False(t, found)
+
+	c.Record(results.Result{Image: "ghcr.io/kyverno/test:latest", Digest: testDigest, Policy: "check-images", Rule: "verify", Status: kyvernov2alpha1.ImageVerificationFail, Message: "no signature", Time: now})
+	c.Record(results.Result{Digest: testDigest, Policy: "other", Rule: "verify", Status: kyvernov2alpha1.ImageVerificationPass, Time: now})
+	assert.NoError(t, c.reconcile(context.Background(), logr.Discard(), name, "", ""))
+
+	obj, err := client.KyvernoV2alpha1().ImageVerificationResults().Get(context.Background(), name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ghcr.io/kyverno/test:v1", "ghcr.io/kyverno/test:latest"}, obj.Spec.Images)
+	assert.Len(t, obj.Spec.Verifications, 2)
+	record = obj.Spec.FindVerification("check-images", "verify")
+	assert.Equal(t, kyvernov2alpha1.ImageVerificationFail, record.Status)
+	assert.Equal(t, "no signature", record.Message)
+	assert.NotNil(t, obj.Spec.FindVerification("other", "verify"))
+	assert.Nil(t, obj.Spec.FindVerification("old-policy", "verify"))
+}
+
+func TestLookupSignature(t *testing.T) {
+	now := time.Now()
+	name := kyvernov2alpha1.ImageVerificationResultName(testDigest)
+	forged := kyvernov2alpha1.ImageVerificationRecord{
+		Policy: "forged",
+		Rule:   "verify",
+		Status: kyvernov2alpha1.ImageVerificationPass,
+		Time:   metav1.NewTime(now),
+	}
+	tampered := signed(kyvernov2alpha1.ImageVerificationRecord{
+		Policy: "tampered",
+		Rule:   "verify",
+		Status: kyvernov2alpha1.ImageVerificationFail,
+		Time:   metav1.NewTime(now),
+	})
+	tampered.Status = kyvernov2alpha1.ImageVerificationPass
+	c, _ := newTestController(t, &kyvernov2alpha1.ImageVerificationResult{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: kyvernov2alpha1.ImageVerificationResultSpec{
+			Digest:        testDigest,
+			Verifications: []kyvernov2alpha1.ImageVerificationRecord{forged, tampered},
+		},
+	})
+	// unsigned and tampered records are ignored
+	_, found := c.Lookup(testDigest, "forged", "verify")
+	assert.False(t, found)
+	_, found = c.Lookup(testDigest, "tampered", "verify")
+	assert.False(t, found)
+
+	// the published records are signed
+	c.Record(results.Result{Digest: testDigest, Policy: "check-images", Rule: "verify", Status: kyvernov2alpha1.ImageVerificationPass, Time: now})
+	assert.NoError(t, c.reconcile(context.Background(), logr.Discard(), name, "", ""))
+	obj, err := c.client.KyvernoV2alpha1().ImageVerificationResults().Get(context.Background(), name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	record := obj.Spec.FindVerification("check-images", "verify")
+	assert.NotEmpty(t, record.Signature)
+	assert.Equal(t, c.sign(testDigest, record), record.Signature)
+}
@@ -79,6 +79,13 @@ type controller struct {
 	ephrLister     cache.GenericLister
 	cephrLister    cache.GenericLister
 
+	// enricher adds resource metadata to the aggregated results, it is nil when disabled
+	enricher utils.Enricher
+
+	// rollup attributes the results of controller-owned resources to their top-level owner
+	owners       utils.OwnerResolver
+	rollupConfig RollupConfig
+
 	// reportUUIDToPolicyCache maps report UUIDs to policies that affect them for targeted reconciliation.
 	// This avoids processing all reports when a single policy changes.
 	cacheMu                 *sync.Mutex
@@ -112,6 +119,9 @@ func NewController(
 	vapInformer admissionregistrationv1informers.ValidatingAdmissionPolicyInformer,
 	mapInformer admissionregistrationv1beta1informers.MutatingAdmissionPolicyInformer,
 	mapAlphaInformer admissionregistrationv1alpha1informers.MutatingAdmissionPolicyInformer,
+	enricher utils.Enricher,
+	owners utils.OwnerResolver,
+	rollupConfig RollupConfig,
 ) controllers.Controller {
 	ephrInformer := metadataFactory.ForResource(reportsv1.SchemeGroupVersion.WithResource("ephemeralreports"))
 	cephrInformer := metadataFactory.ForResource(reportsv1.SchemeGroupVersion.WithResource("clusterephemeralreports"))
@@ -175,6 +185,9 @@ func NewController(
 	c := controller{
 		client:                  client,
 		dclient:                 dclient,
+		enricher:                enricher,
+		owners:                  owners,
+		rollupConfig:            rollupConfig,
 		orClient:                orClient,
 		polLister:               polInformer.Lister(),
 		cpolLister:              cpolInformer.Lister(),
@@ -770,6 +783,13 @@ func (c *controller) frontReconcile(ctx context.Context, logger logr.Logger, _,
 	}
 	// check if it is owned already
 	if len(reportMeta.OwnerReferences) != 0 {
+		if c.rollupConfig.Enabled && c.owners != nil {
+			if handled, err := c.rollup(ctx, logger, reportMeta); err != nil {
+				return err
+			} else if handled {
+				return nil
+			}
+		}
 		defer func() {
 			obj := cache.ObjectName{Namespace: namespace, Name: string(reportMeta.OwnerReferences[0].UID)}
 			c.backQueue.Add(obj.String())
@@ -887,6 +907,18 @@ func (c *controller) backReconcile(ctx context.Context, logger logr.Logger, _, n
 			report = reportutils.NewPolicyReport(namespace, name, scope, c.orClient != nil)
 			controllerutils.SetOwner(report, owner.APIVersion, owner.Kind, owner.Name, owner.UID)
 		}
+		if c.enricher != nil {
+			if owners := report.GetOwnerReferences(); len(owners) != 0 {
+				resource := corev1.ObjectReference{
+					APIVersion: owners[0].APIVersion,
+					Kind:       owners[0].Kind,
+					Namespace:  namespace,
+					Name:       owners[0].Name,
+					UID:        owners[0].UID,
+				}
+				results = c.enricher.Enrich(ctx, logger, resource, results)
+			}
+		}
 		reportutils.SetResults(report, results...)
 		if report.GetResourceVersion() == "" {
 			r, err := reportutils.CreatePermanentReport(ctx, report, c.client, c.orClient)
@@ -143,7 +143,7 @@ func TestController(t *testing.T) {
 	metaClient.CreateFake(&metav1.PartialObjectMetadata{ObjectMeta: kyvernoPolr.ObjectMeta}, metav1.CreateOptions{})
 	metaClient.CreateFake(&metav1.PartialObjectMetadata{ObjectMeta: notKyvernoPolr.ObjectMeta}, metav1.CreateOptions{})
 
-	controller := aggregate.NewController(client, nil, nil, metaFactory, polInformer, cpolInformer, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	controller := aggregate.NewController(client, nil, nil, metaFactory, polInformer, cpolInformer, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, aggregate.RollupConfig{})
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -206,7 +206,7 @@ func TestControllerWithOpenreports(t *testing.T) {
 	dClient, _ := dclient.NewFakeClient(s, map[schema.GroupVersionResource]string{}, pod)
 	dClient.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
 
-	controller := aggregate.NewController(client, orClient.OpenreportsV1alpha1(), dClient, metaFactory, polInformer, cpolInformer, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	controller := aggregate.NewController(client, orClient.OpenreportsV1alpha1(), dClient, metaFactory, polInformer, cpolInformer, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, aggregate.RollupConfig{})
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
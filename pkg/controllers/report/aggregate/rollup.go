@@ -0,0 +1,66 @@
+package aggregate
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RollupConfig configures the attribution of report results to top-level workloads
+type RollupConfig struct {
+	// Enabled attributes the results of controller-owned resources (pods, replica sets, jobs...) to their
+	// top-level owner (deployment, stateful set, cron job, rollout...), results are deduplicated per rule
+	Enabled bool
+	// Pods controls whether the results of controller-owned pods are reported, when false they are dropped
+	// and only the results of the workloads themselves are kept
+	Pods bool
+}
+
+// rollup attributes an ephemeral report owned by a controller-owned resource to the top-level owner
+// of the resource, or leaves it out of the aggregation when it belongs to a controller-owned pod and
+// pods are not reported. The pod reports are kept rather than deleted, the background scan would
+// recreate them otherwise, they are garbage collected with the pod.
+// It returns true when the report was handed over or left out, the update will be processed again.
+func (c *controller) rollup(ctx context.Context, logger logr.Logger, reportMeta *metav1.PartialObjectMetadata) (bool, error) {
+	owner := reportMeta.OwnerReferences[0]
+	resource := corev1.ObjectReference{
+		APIVersion: owner.APIVersion,
+		Kind:       owner.Kind,
+		Namespace:  reportMeta.GetNamespace(),
+		Name:       owner.Name,
+		UID:        owner.UID,
+	}
+	top := c.owners.TopLevelOwner(ctx, logger, resource)
+	if top.UID == "" || top.UID == resource.UID {
+		return false, nil
+	}
+	if !c.rollupConfig.Pods && resource.APIVersion == "v1" && resource.Kind == "Pod" {
+		logger.V(3).Info("skipping report of a controller-owned pod", "owner", top.Kind+"/"+top.Name)
+		// drop the aggregated report of the pod, it may exist from before the rollup was enabled
+		report, err := c.getReport(ctx, reportMeta.GetNamespace(), string(resource.UID))
+		if err != nil || report == nil {
+			return true, err
+		}
+		return true, deleteReport(ctx, report, c.client, c.orClient)
+	}
+	report, err := c.getEphemeralReport(ctx, reportMeta.GetNamespace(), reportMeta.GetName())
+	if err != nil {
+		return false, err
+	}
+	results := report.GetResults()
+	for i := range results {
+		results[i].Subjects = []corev1.ObjectReference{top}
+	}
+	reportutils.SetResults(report, results...)
+	controllerutils.SetOwner(report, top.APIVersion, top.Kind, top.Name, top.UID)
+	reportutils.SetResourceUid(report, top.UID)
+	if _, err := updateReport(ctx, report, c.client, c.orClient); err != nil {
+		return false, err
+	}
+	logger.V(3).Info("attributed report to the top-level owner", "owner", top.Kind+"/"+top.Name)
+	return true, nil
+}
@@ -0,0 +1,137 @@
+package aggregate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	"github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	openreportsv1alpha1 "github.com/openreports/reports-api/apis/openreports.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeOwners map[string]corev1.ObjectReference
+
+func (o fakeOwners) TopLevelOwner(_ context.Context, _ logr.Logger, resource corev1.ObjectReference) corev1.ObjectReference {
+	if owner, ok := o[string(resource.UID)]; ok {
+		return owner
+	}
+	return resource
+}
+
+var deployment = corev1.ObjectReference{
+	APIVersion: "apps/v1",
+	Kind:       "Deployment",
+	Namespace:  "default",
+	Name:       "web",
+	UID:        "deployment-uid",
+}
+
+func newRollupEphemeralReport(kind, name, uid string) *reportsv1.EphemeralReport {
+	return &reportsv1.EphemeralReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uid,
+			Namespace: "default",
+			Labels: map[string]string{
+				reportutils.LabelResourceUid: uid,
+				kyverno.LabelAppManagedBy:    kyverno.ValueKyvernoApp,
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "v1",
+				Kind:       kind,
+				Name:       name,
+				UID:        "pod-uid",
+			}},
+		},
+		Spec: reportsv1.EphemeralReportSpec{
+			Results: []openreportsv1alpha1.ReportResult{{
+				Policy:   "require-labels",
+				Rule:     "check-team",
+				Result:   "fail",
+				Subjects: []corev1.ObjectReference{{Kind: kind, Name: name}},
+			}},
+		},
+	}
+}
+
+func newRollupController(config RollupConfig, ephr *reportsv1.EphemeralReport) *controller {
+	return &controller{
+		client:       fake.NewSimpleClientset(ephr),
+		owners:       fakeOwners{"pod-uid": deployment},
+		rollupConfig: config,
+	}
+}
+
+func reportMeta(ephr *reportsv1.EphemeralReport) *metav1.PartialObjectMetadata {
+	return &metav1.PartialObjectMetadata{ObjectMeta: ephr.ObjectMeta}
+}
+
+func TestController_rollup(t *testing.T) {
+	ctx := context.TODO()
+	ephr := newRollupEphemeralReport("Pod", "web-6d4cf56db6-x2x9z", "pod-uid")
+	c := newRollupController(RollupConfig{Enabled: true, Pods: true}, ephr)
+
+	handled, err := c.rollup(ctx, logr.Discard(), reportMeta(ephr))
+	assert.NoError(t, err)
+	assert.True(t, handled)
+
+	updated, err := c.client.ReportsV1().EphemeralReports("default").Get(ctx, ephr.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "deployment-uid", string(updated.OwnerReferences[0].UID))
+	assert.Equal(t, "Deployment", updated.OwnerReferences[0].Kind)
+	assert.Equal(t, "deployment-uid", updated.Labels[reportutils.LabelResourceUid])
+	assert.Equal(t, []corev1.ObjectReference{deployment}, updated.Spec.Results[0].Subjects)
+	assert.Equal(t, 1, updated.Spec.Summary.Fail)
+
+	// the report is now owned by the top-level owner and left as is
+	handled, err = c.rollup(ctx, logr.Discard(), reportMeta(updated))
+	assert.NoError(t, err)
+	assert.False(t, handled)
+}
+
+func TestController_rollup_withoutPods(t *testing.T) {
+	ctx := context.TODO()
+	ephr := newRollupEphemeralReport("Pod", "web-6d4cf56db6-x2x9z", "pod-uid")
+	c := newRollupController(RollupConfig{Enabled: true}, ephr)
+	polr := &v1alpha2.PolicyReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod-uid",
+			Namespace: "default",
+			Labels:    map[string]string{kyverno.LabelAppManagedBy: kyverno.ValueKyvernoApp},
+		},
+	}
+	_, err := c.client.Wgpolicyk8sV1alpha2().PolicyReports("default").Create(ctx, polr, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	handled, err := c.rollup(ctx, logr.Discard(), reportMeta(ephr))
+	assert.NoError(t, err)
+	assert.True(t, handled)
+
+	// the report is kept for the background scan, it is left out of the aggregation
+	_, err = c.client.ReportsV1().EphemeralReports("default").Get(ctx, ephr.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	// the aggregated report of the pod is deleted
+	_, err = c.client.Wgpolicyk8sV1alpha2().PolicyReports("default").Get(ctx, "pod-uid", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestController_rollup_notOwned(t *testing.T) {
+	ctx := context.TODO()
+	ephr := newRollupEphemeralReport("Pod", "debug", "pod-uid")
+	c := newRollupController(RollupConfig{Enabled: true}, ephr)
+	c.owners = fakeOwners{}
+
+	handled, err := c.rollup(ctx, logr.Discard(), reportMeta(ephr))
+	assert.NoError(t, err)
+	assert.False(t, handled)
+
+	_, err = c.client.ReportsV1().EphemeralReports("default").Get(ctx, ephr.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+}
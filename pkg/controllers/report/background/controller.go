@@ -2,6 +2,7 @@ package background
 
 import (
 	"context"
+	"maps"
 	"strings"
 	"time"
 
@@ -41,6 +42,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apiserver/pkg/admission/plugin/policy/mutating/patch"
 	admissionregistrationv1informers "k8s.io/client-go/informers/admissionregistration/v1"
 	admissionregistrationv1alpha1informers "k8s.io/client-go/informers/admissionregistration/v1alpha1"
@@ -52,7 +54,9 @@ import (
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 )
 
 const (
@@ -62,6 +66,7 @@ const (
 	maxRetries             = 10
 	annotationLastScanTime = "audit.kyverno.io/last-scan-time"
 	enqueueDelay           = 30 * time.Second
+	scanStatusInterval     = 10 * time.Second
 )
 
 type controller struct {
@@ -98,6 +103,10 @@ type controller struct {
 	metadataCache resource.MetadataCache
 	forceDelay    time.Duration
 
+	// scan
+	scanLimiter flowcontrol.RateLimiter
+	progress    *scanProgress
+
 	// config
 	config        config.Configuration
 	jp            jmespath.Interface
@@ -133,6 +142,7 @@ func NewController(
 	nsInformer corev1informers.NamespaceInformer,
 	metadataCache resource.MetadataCache,
 	forceDelay time.Duration,
+	scanLimiter flowcontrol.RateLimiter,
 	config config.Configuration,
 	jp jmespath.Interface,
 	eventGen event.Interface,
@@ -160,6 +170,8 @@ func NewController(
 		queue:          queue,
 		metadataCache:  metadataCache,
 		forceDelay:     forceDelay,
+		scanLimiter:    scanLimiter,
+		progress:       newScanProgress(clock.RealClock{}),
 		config:         config,
 		jp:             jp,
 		eventGen:       eventGen,
@@ -271,23 +283,39 @@ func NewController(
 
 func (c *controller) Run(ctx context.Context, workers int) {
 	logger.V(2).Info("background scan", "interval", c.forceDelay.Abs().String())
+	go wait.UntilWithContext(ctx, c.updateScanStatuses, scanStatusInterval)
 	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
 }
 
 func (c *controller) addPolicy(obj kyvernov1.PolicyInterface) {
+	c.trackPolicy(obj)
 	c.enqueueResources()
 }
 
 func (c *controller) updatePolicy(old, obj kyvernov1.PolicyInterface) {
-	if old.GetResourceVersion() != obj.GetResourceVersion() {
+	c.trackPolicy(obj)
+	// status updates (including the background scan progress) don't change the generation,
+	// reported annotations are checked separately as they don't change it either
+	if old.GetGeneration() != obj.GetGeneration() ||
+		!maps.Equal(reportutils.ReportedAnnotations(old.GetAnnotations()), reportutils.ReportedAnnotations(obj.GetAnnotations())) {
 		c.enqueueResources()
 	}
 }
 
 func (c *controller) deletePolicy(obj kyvernov1.PolicyInterface) {
+	c.progress.stop(cache.MetaObjectToName(obj).String())
 	c.enqueueResources()
 }
 
+func (c *controller) trackPolicy(policy kyvernov1.PolicyInterface) {
+	key := cache.MetaObjectToName(policy).String()
+	if utils.CanBackgroundProcess(policy) {
+		c.progress.start(key, policy)
+	} else {
+		c.progress.stop(key)
+	}
+}
+
 func (c *controller) addException(obj *kyvernov2.PolicyException) {
 	c.enqueueResources()
 }
@@ -508,25 +536,8 @@ func (c *controller) needsReconcile(
 		}
 	}
 	// if a policy or an exception changed, we need a partial reconcile
-	expected := map[string]string{}
-	for _, policy := range policies {
-		expected[reportutils.PolicyLabel(policy)] = policy.GetResourceVersion()
-	}
-	for _, exception := range exceptions {
-		expected[reportutils.PolicyExceptionLabel(exception)] = exception.GetResourceVersion()
-	}
-	for _, binding := range vapBindings {
-		expected[reportutils.ValidatingAdmissionPolicyBindingLabel(binding)] = binding.GetResourceVersion()
-	}
-	for _, binding := range mapBindings {
-		expected[reportutils.MutatingAdmissionPolicyBindingLabel(&binding)] = binding.GetResourceVersion()
-	}
-	actual := map[string]string{}
-	for key, value := range reportMetadata.GetLabels() {
-		if reportutils.IsPolicyLabel(key) {
-			actual[key] = value
-		}
-	}
+	expected := expectedLabels(reportMetadata, hash, exceptions, vapBindings, mapBindings, policies...)
+	actual := actualLabels(reportMetadata)
 	if !datautils.DeepEqual(expected, actual) {
 		return reportutils.GetResourceHash(reportMetadata), true, false, nil
 	}
@@ -572,25 +583,9 @@ func (c *controller) reconcileReport(
 		observed = reportutils.NewBackgroundScanReport(namespace, name, gvk, resource.Name, uid)
 	}
 	// build desired report
-	expected := map[string]string{}
-	for _, policy := range policies {
-		expected[reportutils.PolicyLabel(policy)] = policy.GetResourceVersion()
-	}
-	for _, exception := range exceptions {
-		expected[reportutils.PolicyExceptionLabel(exception)] = exception.GetResourceVersion()
-	}
-	for _, binding := range vapBindings {
-		expected[reportutils.ValidatingAdmissionPolicyBindingLabel(binding)] = binding.GetResourceVersion()
-	}
-	for _, binding := range mapBindings {
-		expected[reportutils.MutatingAdmissionPolicyBindingLabel(&binding)] = binding.GetResourceVersion()
-	}
-	actual := map[string]string{}
-	for key, value := range observed.GetLabels() {
-		if reportutils.IsPolicyLabel(key) {
-			actual[key] = value
-		}
-	}
+	resourceHash := reportutils.CalculateResourceHash(*target)
+	expected := expectedLabels(observed, resourceHash, exceptions, vapBindings, mapBindings, policies...)
+	actual := actualLabels(observed)
 	var ruleResults []openreportsv1alpha1.ReportResult
 	if !full {
 		policyNameToLabel := map[string]string{}
@@ -679,7 +674,10 @@ func (c *controller) reconcileReport(
 				}
 			}
 		}
-		if full || reevaluate || actual[reportutils.PolicyLabel(policy)] != policy.GetResourceVersion() {
+		if full || reevaluate || actual[reportutils.PolicyLabel(policy)] != expected[reportutils.PolicyLabel(policy)] {
+			if err := c.waitForScan(ctx); err != nil {
+				return err
+			}
 			scanner := utils.NewScanner(logger, c.engine, c.config, c.jp, c.client, c.gctxStore, c.mapper, c.typeConverter)
 			for _, result := range scanner.ScanResource(ctx, *target, gvr, "", ns, vapBindings, mapBindings, celexceptions, policy) {
 				if result.Error != nil {
@@ -700,6 +698,7 @@ func (c *controller) reconcileReport(
 	for _, policy := range policies {
 		reportutils.SetPolicyLabel(desired, policy)
 	}
+	reportutils.SetPolicyFingerprints(desired, resourceHash, policies...)
 	for _, exception := range exceptions {
 		reportutils.SetPolicyExceptionLabel(desired, exception)
 	}
@@ -720,6 +719,50 @@ func (c *controller) reconcileReport(
 	return nil
 }
 
+// expectedLabels returns the policy labels expected on the report, policies with an unchanged fingerprint
+// keep the observed label so that updates not affecting the evaluation don't trigger a new scan
+func expectedLabels(
+	report metav1.Object,
+	resourceHash string,
+	exceptions []kyvernov2.PolicyException,
+	vapBindings []admissionregistrationv1.ValidatingAdmissionPolicyBinding,
+	mapBindings []admissionregistrationv1beta1.MutatingAdmissionPolicyBinding,
+	policies ...engineapi.GenericPolicy,
+) map[string]string {
+	labels := report.GetLabels()
+	fingerprints := reportutils.GetPolicyFingerprints(report)
+	expected := map[string]string{}
+	for _, policy := range policies {
+		label := reportutils.PolicyLabel(policy)
+		expected[label] = policy.GetResourceVersion()
+		if fingerprint, ok := fingerprints[label]; ok && labels[label] != "" {
+			if fingerprint == reportutils.PolicyFingerprint(resourceHash, policy) {
+				expected[label] = labels[label]
+			}
+		}
+	}
+	for _, exception := range exceptions {
+		expected[reportutils.PolicyExceptionLabel(exception)] = exception.GetResourceVersion()
+	}
+	for _, binding := range vapBindings {
+		expected[reportutils.ValidatingAdmissionPolicyBindingLabel(binding)] = binding.GetResourceVersion()
+	}
+	for _, binding := range mapBindings {
+		expected[reportutils.MutatingAdmissionPolicyBindingLabel(&binding)] = binding.GetResourceVersion()
+	}
+	return expected
+}
+
+func actualLabels(report metav1.Object) map[string]string {
+	actual := map[string]string{}
+	for key, value := range report.GetLabels() {
+		if reportutils.IsPolicyLabel(key) {
+			actual[key] = value
+		}
+	}
+	return actual
+}
+
 func (c *controller) storeReport(ctx context.Context, observed, desired reportsv1.ReportInterface) error {
 	var err error
 	hasReport := observed.GetResourceVersion() != ""
@@ -757,6 +800,7 @@ func (c *controller) reconcile(ctx context.Context, log logr.Logger, key, namesp
 	// if the resource is not present it means we shouldn't have a report for it
 	// we can delete the report, we will recreate one if the resource comes back
 	if !exists {
+		c.progress.forget(key)
 		report, err := c.getMeta(namespace, name)
 		if err != nil {
 			if !apierrors.IsNotFound(err) {
@@ -786,8 +830,10 @@ func (c *controller) reconcile(ctx context.Context, log logr.Logger, key, namesp
 
 	kyvernoPolicies = utils.RemoveNonBackgroundPolicies(kyvernoPolicies...)
 	policies := make([]engineapi.GenericPolicy, 0, len(kyvernoPolicies))
+	policyKeys := make([]string, 0, len(kyvernoPolicies))
 	for _, pol := range kyvernoPolicies {
 		policies = append(policies, engineapi.NewKyvernoPolicy(pol))
+		policyKeys = append(policyKeys, cache.MetaObjectToName(pol).String())
 	}
 	if c.vpolLister != nil {
 		vpols, err := utils.FetchValidatingPolicies(c.vpolLister)
@@ -913,8 +959,70 @@ func (c *controller) reconcile(ctx context.Context, log logr.Logger, key, namesp
 			if observedHash != r.Hash {
 				c.metadataCache.UpdateResourceHash(gvr, uid, resource.Resource{Name: r.Name, Namespace: namespace, Hash: observedHash})
 			}
-			return c.reconcileReport(ctx, namespace, name, full, uid, gvk, gvr, r, exceptions, celexceptions, vapBindings, mapBindings, policies...)
+			if err := c.reconcileReport(ctx, namespace, name, full, uid, gvk, gvr, r, exceptions, celexceptions, vapBindings, mapBindings, policies...); err != nil {
+				return err
+			}
 		}
 	}
+	c.progress.done(key, policyKeys...)
 	return nil
 }
+
+// waitForScan blocks until the scan rate limiter allows a new scan
+func (c *controller) waitForScan(ctx context.Context) error {
+	if c.scanLimiter == nil {
+		return nil
+	}
+	return c.scanLimiter.Wait(ctx)
+}
+
+// updateScanStatuses reports the background scan progress in the status of kyverno policies
+func (c *controller) updateScanStatuses(ctx context.Context) {
+	resources := map[string]schema.GroupVersionKind{}
+	for _, key := range c.metadataCache.GetAllResourceKeys() {
+		_, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			continue
+		}
+		if _, gvk, _, exists := c.metadataCache.GetResourceHash(types.UID(name)); exists {
+			resources[key] = gvk
+		}
+	}
+	for key, status := range c.progress.statuses(resources) {
+		namespace, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			continue
+		}
+		build := func(policy kyvernov1.PolicyInterface) error {
+			// the policy changed since the progress was computed
+			if policy.GetGeneration() != status.ObservedGeneration {
+				return nil
+			}
+			policy.GetStatus().BackgroundScan = status.DeepCopy()
+			return nil
+		}
+		if namespace == "" {
+			policy, err := c.cpolLister.Get(name)
+			if err != nil {
+				continue
+			}
+			err = controllerutils.UpdateStatus(ctx, policy, c.kyvernoClient.KyvernoV1().ClusterPolicies(), func(policy *kyvernov1.ClusterPolicy) error {
+				return build(policy)
+			}, nil)
+			if err != nil {
+				logger.Error(err, "failed to update background scan status", "policy", key)
+			}
+		} else {
+			policy, err := c.polLister.Policies(namespace).Get(name)
+			if err != nil {
+				continue
+			}
+			err = controllerutils.UpdateStatus(ctx, policy, c.kyvernoClient.KyvernoV1().Policies(namespace), func(policy *kyvernov1.Policy) error {
+				return build(policy)
+			}, nil)
+			if err != nil {
+				logger.Error(err, "failed to update background scan status", "policy", key)
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package background
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/controllers/report/resource"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+type staticMetadataCache struct {
+	resource.MetadataCache
+	keys []string
+}
+
+func (c staticMetadataCache) GetAllResourceKeys() []string {
+	return c.keys
+}
+
+func TestUpdatePolicy(t *testing.T) {
+	c := &controller{
+		metadataCache: staticMetadataCache{keys: []string{"uid-1", "ns/uid-2"}},
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[string](),
+			workqueue.TypedRateLimitingQueueConfig[string]{Name: "test"},
+		),
+		progress: newScanProgress(clocktesting.NewFakePassiveClock(time.Now())),
+	}
+	old := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cpol", Generation: 1, ResourceVersion: "1"}}
+
+	// a status update bumps the resource version but not the generation
+	obj := old.DeepCopy()
+	obj.ResourceVersion = "2"
+	obj.Status.BackgroundScan = &kyvernov1.BackgroundScanStatus{Total: 2}
+	c.updatePolicy(old, obj)
+	assert.Equal(t, 0, c.queue.Len())
+
+	obj = obj.DeepCopy()
+	obj.ResourceVersion = "3"
+	obj.Generation = 2
+	c.updatePolicy(old, obj)
+	assert.Equal(t, 2, c.queue.Len())
+
+	c.queue = workqueue.NewTypedRateLimitingQueueWithConfig(
+		workqueue.DefaultTypedControllerRateLimiter[string](),
+		workqueue.TypedRateLimitingQueueConfig[string]{Name: "test"},
+	)
+	// unrelated annotations are not reported
+	obj = old.DeepCopy()
+	obj.ResourceVersion = "4"
+	obj.Annotations = map[string]string{"foo": "bar"}
+	c.updatePolicy(old, obj)
+	assert.Equal(t, 0, c.queue.Len())
+
+	// reported annotations don't change the generation either
+	obj = obj.DeepCopy()
+	obj.ResourceVersion = "5"
+	obj.Annotations[kyverno.AnnotationPolicySeverity] = "high"
+	c.updatePolicy(old, obj)
+	assert.Equal(t, 2, c.queue.Len())
+}
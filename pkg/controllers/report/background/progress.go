@@ -0,0 +1,136 @@
+package background
+
+import (
+	"strings"
+	"sync"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/controllers/report/utils"
+	"github.com/kyverno/kyverno/pkg/utils/match"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/clock"
+)
+
+// scanProgress tracks the resources scanned for the current generation of every kyverno policy
+type scanProgress struct {
+	lock     sync.Mutex
+	clock    clock.PassiveClock
+	policies map[string]*policyProgress
+}
+
+type policyProgress struct {
+	namespace      string
+	generation     int64
+	kinds          []string
+	scanned        sets.Set[string]
+	startTime      metav1.Time
+	completionTime *metav1.Time
+	// restored is the status persisted before a restart, it is reported
+	// until the in-memory progress catches up with it
+	restored *kyvernov1.BackgroundScanStatus
+}
+
+func newScanProgress(clock clock.PassiveClock) *scanProgress {
+	return &scanProgress{
+		clock:    clock,
+		policies: map[string]*policyProgress{},
+	}
+}
+
+// start starts tracking a policy, progress is reset only when the policy generation changes
+func (p *scanProgress) start(key string, policy kyvernov1.PolicyInterface) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if progress, ok := p.policies[key]; ok && progress.generation == policy.GetGeneration() {
+		return
+	}
+	progress := &policyProgress{
+		namespace:  policy.GetNamespace(),
+		generation: policy.GetGeneration(),
+		kinds:      sets.List(utils.BuildKindSet(logger, policy)),
+		scanned:    sets.New[string](),
+		startTime:  metav1.NewTime(p.clock.Now()),
+	}
+	// resume from the persisted status when the policy didn't change in the meantime
+	if status := policy.GetStatus().BackgroundScan; status != nil && status.ObservedGeneration == policy.GetGeneration() {
+		progress.restored = status.DeepCopy()
+		if status.StartTime != nil {
+			progress.startTime = *status.StartTime.DeepCopy()
+		}
+		progress.completionTime = status.CompletionTime.DeepCopy()
+	}
+	p.policies[key] = progress
+}
+
+// stop stops tracking a policy
+func (p *scanProgress) stop(key string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.policies, key)
+}
+
+// done marks a resource as scanned for the given policies
+func (p *scanProgress) done(resource string, keys ...string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for _, key := range keys {
+		if progress, ok := p.policies[key]; ok {
+			progress.scanned.Insert(resource)
+		}
+	}
+}
+
+// forget removes a resource that doesn't exist anymore
+func (p *scanProgress) forget(resource string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for _, progress := range p.policies {
+		progress.scanned.Delete(resource)
+	}
+}
+
+// statuses computes the scan status of every tracked policy against the known resources,
+// only the resources matching the kinds of a policy are counted
+func (p *scanProgress) statuses(resources map[string]schema.GroupVersionKind) map[string]kyvernov1.BackgroundScanStatus {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	statuses := make(map[string]kyvernov1.BackgroundScanStatus, len(p.policies))
+	for key, progress := range p.policies {
+		status := kyvernov1.BackgroundScanStatus{
+			ObservedGeneration: progress.generation,
+			StartTime:          progress.startTime.DeepCopy(),
+		}
+		for resource, gvk := range resources {
+			// namespaced policies only apply to resources in the same namespace
+			if progress.namespace != "" && !strings.HasPrefix(resource, progress.namespace+"/") {
+				continue
+			}
+			if !match.CheckKind(progress.kinds, gvk, "", false) {
+				continue
+			}
+			status.Total++
+			if progress.scanned.Has(resource) {
+				status.Scanned++
+			}
+		}
+		if progress.restored != nil {
+			if status.Scanned < progress.restored.Scanned && !status.IsCompleted() {
+				// the scan state was lost, keep reporting the persisted status
+				statuses[key] = *progress.restored.DeepCopy()
+				continue
+			}
+			progress.restored = nil
+		}
+		if !status.IsCompleted() {
+			progress.completionTime = nil
+		} else if progress.completionTime == nil {
+			now := metav1.NewTime(p.clock.Now())
+			progress.completionTime = &now
+		}
+		status.CompletionTime = progress.completionTime.DeepCopy()
+		statuses[key] = status
+	}
+	return statuses
+}
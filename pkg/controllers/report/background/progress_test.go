@@ -0,0 +1,127 @@
+package background
+
+import (
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+var podGVK = schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+
+func podSpec() kyvernov1.Spec {
+	return kyvernov1.Spec{
+		Rules: []kyvernov1.Rule{{
+			Name: "rule",
+			MatchResources: kyvernov1.MatchResources{
+				ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}},
+			},
+			Validation: &kyvernov1.Validation{Message: "message"},
+		}},
+	}
+}
+
+func TestScanProgress(t *testing.T) {
+	clock := clocktesting.NewFakePassiveClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	progress := newScanProgress(clock)
+	cpol := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cpol", Generation: 1}, Spec: podSpec()}
+	pol := &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Name: "pol", Namespace: "ns", Generation: 1}, Spec: podSpec()}
+	resources := map[string]schema.GroupVersionKind{"uid-1": podGVK, "ns/uid-2": podGVK, "other/uid-3": podGVK}
+	progress.start("cpol", cpol)
+	progress.start("ns/pol", pol)
+
+	statuses := progress.statuses(resources)
+	assert.Equal(t, 3, statuses["cpol"].Total)
+	assert.Equal(t, 0, statuses["cpol"].Scanned)
+	assert.Nil(t, statuses["cpol"].CompletionTime)
+	assert.Equal(t, 1, statuses["ns/pol"].Total)
+
+	progress.done("ns/uid-2", "cpol", "ns/pol")
+	statuses = progress.statuses(resources)
+	assert.Equal(t, 1, statuses["cpol"].Scanned)
+	assert.Equal(t, 1, statuses["ns/pol"].Scanned)
+	assert.NotNil(t, statuses["ns/pol"].CompletionTime)
+
+	progress.done("uid-1", "cpol")
+	progress.done("other/uid-3", "cpol")
+	clock.SetTime(clock.Now().Add(time.Minute))
+	statuses = progress.statuses(resources)
+	assert.Equal(t, 3, statuses["cpol"].Scanned)
+	assert.Equal(t, clock.Now(), statuses["cpol"].CompletionTime.Time)
+
+	// the same generation doesn't reset the progress
+	progress.start("cpol", cpol)
+	assert.Equal(t, 3, progress.statuses(resources)["cpol"].Scanned)
+
+	// a new generation resets the progress
+	cpol.Generation = 2
+	progress.start("cpol", cpol)
+	statuses = progress.statuses(resources)
+	assert.Equal(t, int64(2), statuses["cpol"].ObservedGeneration)
+	assert.Equal(t, 0, statuses["cpol"].Scanned)
+	assert.Nil(t, statuses["cpol"].CompletionTime)
+
+	// deleted resources are not counted anymore
+	progress.forget("ns/uid-2")
+	statuses = progress.statuses(map[string]schema.GroupVersionKind{"uid-1": podGVK, "other/uid-3": podGVK})
+	assert.Equal(t, 2, statuses["cpol"].Total)
+	assert.Equal(t, 0, statuses["ns/pol"].Total)
+
+	progress.stop("ns/pol")
+	assert.NotContains(t, progress.statuses(resources), "ns/pol")
+}
+
+func TestScanProgress_Kinds(t *testing.T) {
+	progress := newScanProgress(clocktesting.NewFakePassiveClock(time.Now()))
+	progress.start("cpol", &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cpol", Generation: 1}, Spec: podSpec()})
+	resources := map[string]schema.GroupVersionKind{
+		"ns/uid-1": podGVK,
+		"ns/uid-2": {Group: "apps", Version: "v1", Kind: "Deployment"},
+	}
+	progress.done("ns/uid-1", "cpol")
+	status := progress.statuses(resources)["cpol"]
+	// only the resources matching the policy kinds are counted
+	assert.Equal(t, 1, status.Total)
+	assert.Equal(t, 1, status.Scanned)
+	assert.NotNil(t, status.CompletionTime)
+}
+
+func TestScanProgress_Restored(t *testing.T) {
+	clock := clocktesting.NewFakePassiveClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	progress := newScanProgress(clock)
+	startTime := metav1.NewTime(clock.Now().Add(-time.Hour))
+	completionTime := metav1.NewTime(clock.Now().Add(-time.Minute))
+	cpol := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cpol", Generation: 1}, Spec: podSpec()}
+	cpol.Status.BackgroundScan = &kyvernov1.BackgroundScanStatus{
+		ObservedGeneration: 1,
+		Total:              2,
+		Scanned:            2,
+		StartTime:          &startTime,
+		CompletionTime:     &completionTime,
+	}
+	resources := map[string]schema.GroupVersionKind{"uid-1": podGVK, "uid-2": podGVK}
+	progress.start("cpol", cpol)
+
+	// the persisted status is kept until the progress catches up
+	progress.done("uid-1", "cpol")
+	status := progress.statuses(resources)["cpol"]
+	assert.Equal(t, 2, status.Scanned)
+	assert.Equal(t, completionTime.Time, status.CompletionTime.Time)
+
+	progress.done("uid-2", "cpol")
+	status = progress.statuses(resources)["cpol"]
+	assert.Equal(t, 2, status.Scanned)
+	assert.Equal(t, startTime.Time, status.StartTime.Time)
+	assert.Equal(t, completionTime.Time, status.CompletionTime.Time)
+
+	// a persisted status for another generation is ignored
+	cpol.Generation = 2
+	progress.start("cpol", cpol)
+	status = progress.statuses(resources)["cpol"]
+	assert.Equal(t, 0, status.Scanned)
+	assert.Equal(t, clock.Now(), status.StartTime.Time)
+}
@@ -0,0 +1,174 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	defaultBatchSize = 100
+	defaultTimeout   = 30 * time.Second
+)
+
+// Config configures the report export sinks
+type Config struct {
+	// BatchSize is the maximum number of events sent to a sink at once
+	BatchSize int `json:"batchSize,omitempty"`
+	// Sinks is the list of sinks receiving events
+	Sinks []SinkConfig `json:"sinks"`
+}
+
+// SinkConfig configures a sink, exactly one sink type must be set
+type SinkConfig struct {
+	// Name identifies the sink
+	Name string `json:"name"`
+	// Timeout is the maximum duration of a single delivery
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Stdout writes events as JSON lines on the standard output
+	Stdout *StdoutConfig `json:"stdout,omitempty"`
+	// Webhook posts events to an HTTP endpoint
+	Webhook *WebhookConfig `json:"webhook,omitempty"`
+	// Kafka produces events through a Kafka compatible HTTP bridge
+	Kafka *KafkaConfig `json:"kafka,omitempty"`
+	// S3 stores events in an S3 compatible object store
+	S3 *S3Config `json:"s3,omitempty"`
+}
+
+type StdoutConfig struct{}
+
+type WebhookConfig struct {
+	// URL is the endpoint events are posted to
+	URL string `json:"url"`
+	// Headers are added to every request
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+type KafkaConfig struct {
+	// URL is the HTTP bridge url
+	URL string `json:"url"`
+	// Topic is the topic events are produced to
+	Topic string `json:"topic"`
+	// Headers are added to every request
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+type S3Config struct {
+	// Endpoint is the object store url
+	Endpoint string `json:"endpoint"`
+	// Bucket is the bucket objects are stored in
+	Bucket string `json:"bucket"`
+	// Prefix is prepended to object names
+	Prefix string `json:"prefix,omitempty"`
+	// Region is the bucket region, credentials are loaded from the environment
+	Region string `json:"region,omitempty"`
+}
+
+// LoadConfig loads the export configuration from a YAML file
+func LoadConfig(path string) (*Config, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+	if err := yaml.UnmarshalStrict(content, &config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Validate checks the configuration is valid
+func (c *Config) Validate() error {
+	var errs []error
+	names := map[string]struct{}{}
+	for i, sink := range c.Sinks {
+		if sink.Name == "" {
+			errs = append(errs, fmt.Errorf("sinks[%d]: name is required", i))
+		} else if _, ok := names[sink.Name]; ok {
+			errs = append(errs, fmt.Errorf("sinks[%d]: duplicate name %s", i, sink.Name))
+		} else if msgs := validation.IsConfigMapKey(sink.Name); len(msgs) != 0 {
+			// the name identifies the sink delivery state in the state config map
+			errs = append(errs, fmt.Errorf("sinks[%d]: invalid name %s: %s", i, sink.Name, strings.Join(msgs, ", ")))
+		}
+		names[sink.Name] = struct{}{}
+		count := 0
+		if sink.Stdout != nil {
+			count++
+		}
+		if sink.Webhook != nil {
+			count++
+			if sink.Webhook.URL == "" {
+				errs = append(errs, fmt.Errorf("sinks[%d]: webhook url is required", i))
+			}
+		}
+		if sink.Kafka != nil {
+			count++
+			if sink.Kafka.URL == "" || sink.Kafka.Topic == "" {
+				errs = append(errs, fmt.Errorf("sinks[%d]: kafka url and topic are required", i))
+			}
+		}
+		if sink.S3 != nil {
+			count++
+			if sink.S3.Endpoint == "" || sink.S3.Bucket == "" {
+				errs = append(errs, fmt.Errorf("sinks[%d]: s3 endpoint and bucket are required", i))
+			}
+		}
+		if count != 1 {
+			errs = append(errs, fmt.Errorf("sinks[%d]: exactly one sink type must be set", i))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NewSinks creates the configured sinks
+func (c *Config) NewSinks(ctx context.Context) ([]Sink, error) {
+	var sinks []Sink
+	for _, config := range c.Sinks {
+		timeout := defaultTimeout
+		if config.Timeout != nil {
+			timeout = config.Timeout.Duration
+		}
+		client := &http.Client{Timeout: timeout}
+		switch {
+		case config.Stdout != nil:
+			sinks = append(sinks, NewWriterSink(config.Name, os.Stdout))
+		case config.Webhook != nil:
+			sinks = append(sinks, NewWebhookSink(config.Name, client, config.Webhook.URL, config.Webhook.Headers))
+		case config.Kafka != nil:
+			sinks = append(sinks, NewKafkaSink(config.Name, client, config.Kafka.URL, config.Kafka.Topic, config.Kafka.Headers))
+		case config.S3 != nil:
+			region := config.S3.Region
+			if region == "" {
+				region = "us-east-1"
+			}
+			awsConfig, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+			if err != nil {
+				return nil, fmt.Errorf("failed to load credentials for sink %s (%w)", config.Name, err)
+			}
+			sinks = append(sinks, NewS3Sink(config.Name, client, config.S3.Endpoint, config.S3.Bucket, config.S3.Prefix, region, awsConfig.Credentials, clock.RealClock{}))
+		}
+	}
+	return sinks, nil
+}
+
+// GetBatchSize returns the maximum number of events sent to a sink at once
+func (c *Config) GetBatchSize() int {
+	if c.BatchSize <= 0 {
+		return defaultBatchSize
+	}
+	return c.BatchSize
+}
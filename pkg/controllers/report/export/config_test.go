@@ -0,0 +1,87 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+batchSize: 50
+sinks:
+- name: stdout
+  stdout: {}
+- name: siem
+  timeout: 10s
+  webhook:
+    url: https://siem.example.com/events
+    headers:
+      Authorization: Bearer token
+- name: kafka
+  kafka:
+    url: http://bridge:8080
+    topic: policy-reports
+- name: archive
+  s3:
+    endpoint: https://minio:9000
+    bucket: reports
+`
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	config, err := LoadConfig(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 50, config.GetBatchSize())
+	assert.Len(t, config.Sinks, 4)
+	assert.Equal(t, 10*time.Second, config.Sinks[1].Timeout.Duration)
+	assert.Equal(t, "Bearer token", config.Sinks[1].Webhook.Headers["Authorization"])
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr string
+	}{{
+		name:   "empty",
+		config: Config{},
+	}, {
+		name:    "missing name",
+		config:  Config{Sinks: []SinkConfig{{Stdout: &StdoutConfig{}}}},
+		wantErr: "sinks[0]: name is required",
+	}, {
+		name:    "duplicate name",
+		config:  Config{Sinks: []SinkConfig{{Name: "a", Stdout: &StdoutConfig{}}, {Name: "a", Stdout: &StdoutConfig{}}}},
+		wantErr: "sinks[1]: duplicate name a",
+	}, {
+		name:    "invalid name",
+		config:  Config{Sinks: []SinkConfig{{Name: "my sink", Stdout: &StdoutConfig{}}}},
+		wantErr: "sinks[0]: invalid name my sink: a valid config key must consist of alphanumeric characters, '-', '_' or '.' (e.g. 'key.name',  or 'KEY_NAME',  or 'key-name', regex used for validation is '[-._a-zA-Z0-9]+')",
+	}, {
+		name:    "no type",
+		config:  Config{Sinks: []SinkConfig{{Name: "a"}}},
+		wantErr: "sinks[0]: exactly one sink type must be set",
+	}, {
+		name:    "several types",
+		config:  Config{Sinks: []SinkConfig{{Name: "a", Stdout: &StdoutConfig{}, Webhook: &WebhookConfig{URL: "http://localhost"}}}},
+		wantErr: "sinks[0]: exactly one sink type must be set",
+	}, {
+		name:    "missing kafka topic",
+		config:  Config{Sinks: []SinkConfig{{Name: "a", Kafka: &KafkaConfig{URL: "http://localhost"}}}},
+		wantErr: "sinks[0]: kafka url and topic are required",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+	assert.Equal(t, defaultBatchSize, (&Config{}).GetBatchSize())
+}
@@ -0,0 +1,289 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/openreports"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	openreportsv1alpha1 "github.com/openreports/reports-api/apis/openreports.io/v1alpha1"
+	openreportsclient "github.com/openreports/reports-api/pkg/client/clientset/versioned/typed/openreports.io/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers            = 2
+	ControllerName     = "report-export-controller"
+	maxRetries         = 10
+	redeliveryInterval = time.Minute
+	persistInterval    = 10 * time.Second
+)
+
+// controller streams policy report deltas to the configured sinks.
+//
+// Delivery is at-least-once: the failures delivered to every sink are remembered and
+// the events to send are computed against them, they are acknowledged only when the sink
+// accepted the events. A report that failed to be delivered keeps being retried.
+//
+// Back-pressure comes from the queue: while a sink is slow or unavailable report updates
+// are coalesced in the queue and the next delivery sends the delta against the last state
+// the sink acknowledged, events are never buffered in memory.
+//
+// The delivery state is periodically saved to the state store and loaded back on startup,
+// failures resolved while the controller was not running (or not leading) are still sent.
+// A state change not saved yet when the controller stops abruptly only causes events to be
+// sent again.
+type controller struct {
+	// clients
+	client   versioned.Interface
+	orClient openreportsclient.OpenreportsV1alpha1Interface
+
+	// sinks
+	sinks     []Sink
+	batchSize int
+	store     StateStore
+
+	// queue
+	queue workqueue.TypedRateLimitingInterface[any]
+
+	// state
+	clock     clock.PassiveClock
+	lock      sync.Mutex
+	delivered State
+	pending   sets.Set[string]
+	dirty     bool
+}
+
+func NewController(
+	client versioned.Interface,
+	orClient openreportsclient.OpenreportsV1alpha1Interface,
+	metadataFactory metadatainformers.SharedInformerFactory,
+	batchSize int,
+	store StateStore,
+	sinks ...Sink,
+) controllers.Controller {
+	queue := workqueue.NewTypedRateLimitingQueueWithConfig(
+		workqueue.DefaultTypedControllerRateLimiter[any](),
+		workqueue.TypedRateLimitingQueueConfig[any]{Name: ControllerName},
+	)
+	c := &controller{
+		client:    client,
+		orClient:  orClient,
+		sinks:     sinks,
+		batchSize: batchSize,
+		store:     store,
+		queue:     queue,
+		clock:     clock.RealClock{},
+		delivered: State{},
+		pending:   sets.New[string](),
+	}
+	for _, sink := range sinks {
+		c.delivered[sink.Name()] = map[string]map[string]Event{}
+	}
+	var polrInformer, cpolrInformer cache.SharedIndexInformer
+	if orClient != nil {
+		polrInformer = metadataFactory.ForResource(openreportsv1alpha1.SchemeGroupVersion.WithResource("reports")).Informer()
+		cpolrInformer = metadataFactory.ForResource(openreportsv1alpha1.SchemeGroupVersion.WithResource("clusterreports")).Informer()
+	} else {
+		polrInformer = metadataFactory.ForResource(policyreportv1alpha2.SchemeGroupVersion.WithResource("policyreports")).Informer()
+		cpolrInformer = metadataFactory.ForResource(policyreportv1alpha2.SchemeGroupVersion.WithResource("clusterpolicyreports")).Informer()
+	}
+	if _, _, err := controllerutils.AddDefaultEventHandlers(logger, polrInformer, queue); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	if _, _, err := controllerutils.AddDefaultEventHandlers(logger, cpolrInformer, queue); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	if c.store != nil {
+		if err := c.load(ctx); err != nil {
+			// saving would overwrite the stored state, delivery goes on with the state kept in memory only
+			logger.Error(err, "failed to load the delivery state, it will not be persisted")
+			c.store = nil
+		}
+	}
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile, c.redeliver, c.persist)
+	if c.store != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), persistInterval)
+		defer cancel()
+		c.save(ctx, logger)
+	}
+}
+
+// load seeds the delivery state from the store, the reports it references are enqueued
+// so that the ones deleted in the meantime get their failures resolved
+func (c *controller) load(ctx context.Context) error {
+	var state State
+	err := retry.OnError(retry.DefaultBackoff, func(error) bool { return ctx.Err() == nil }, func() error {
+		var err error
+		state, err = c.store.Load(ctx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, sink := range c.sinks {
+		for key, events := range state[sink.Name()] {
+			c.delivered[sink.Name()][key] = events
+			c.queue.Add(key)
+		}
+	}
+	return nil
+}
+
+// persist periodically saves the delivery state when it changed
+func (c *controller) persist(ctx context.Context, logger logr.Logger) {
+	if c.store == nil {
+		return
+	}
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		c.save(ctx, logger)
+	}, persistInterval)
+}
+
+func (c *controller) save(ctx context.Context, logger logr.Logger) {
+	c.lock.Lock()
+	if !c.dirty {
+		c.lock.Unlock()
+		return
+	}
+	state := make(State, len(c.delivered))
+	for sink, reports := range c.delivered {
+		state[sink] = maps.Clone(reports)
+	}
+	c.dirty = false
+	c.lock.Unlock()
+	if err := c.store.Save(ctx, state); err != nil {
+		logger.Error(err, "failed to save the delivery state")
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		c.dirty = true
+	}
+}
+
+// redeliver periodically enqueues reports that failed to be delivered,
+// the queue drops them after too many retries but delivery must go on
+func (c *controller) redeliver(ctx context.Context, _ logr.Logger) {
+	wait.UntilWithContext(ctx, func(context.Context) {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		for key := range c.pending {
+			c.queue.Add(key)
+		}
+	}, redeliveryInterval)
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
+	report, err := c.getReport(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+	current := failures(report)
+	now := metav1.NewTime(c.clock.Now())
+	var errs []error
+	for _, sink := range c.sinks {
+		delivered := c.getDelivered(sink, key)
+		events := diff(delivered, current, now)
+		if len(events) == 0 {
+			continue
+		}
+		if err := sendBatches(ctx, sink, events, c.batchSize); err != nil {
+			logger.Error(err, "failed to export report events", "sink", sink.Name(), "events", len(events))
+			errs = append(errs, err)
+			continue
+		}
+		logger.V(4).Info("exported report events", "sink", sink.Name(), "events", len(events))
+		c.setDelivered(sink, key, current)
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(errs) != 0 {
+		c.pending.Insert(key)
+	} else {
+		c.pending.Delete(key)
+	}
+	return errors.Join(errs...)
+}
+
+func (c *controller) getDelivered(sink Sink, key string) map[string]Event {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.delivered[sink.Name()][key]
+}
+
+func (c *controller) setDelivered(sink Sink, key string, events map[string]Event) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(events) == 0 {
+		delete(c.delivered[sink.Name()], key)
+	} else {
+		c.delivered[sink.Name()][key] = events
+	}
+	c.dirty = true
+}
+
+// getReport returns the report, a deleted report is returned as nil
+func (c *controller) getReport(ctx context.Context, namespace, name string) (*report, error) {
+	var scope *corev1.ObjectReference
+	var results []openreportsv1alpha1.ReportResult
+	var err error
+	if c.orClient != nil {
+		if namespace == "" {
+			var r *openreportsv1alpha1.ClusterReport
+			if r, err = c.orClient.ClusterReports().Get(ctx, name, metav1.GetOptions{}); err == nil {
+				scope, results = r.Scope, r.Results
+			}
+		} else {
+			var r *openreportsv1alpha1.Report
+			if r, err = c.orClient.Reports(namespace).Get(ctx, name, metav1.GetOptions{}); err == nil {
+				scope, results = r.Scope, r.Results
+			}
+		}
+	} else {
+		if namespace == "" {
+			var r *policyreportv1alpha2.ClusterPolicyReport
+			if r, err = c.client.Wgpolicyk8sV1alpha2().ClusterPolicyReports().Get(ctx, name, metav1.GetOptions{}); err == nil {
+				scope, results = r.Scope, openreports.NewWGCpolAdapter(r).GetResults()
+			}
+		} else {
+			var r *policyreportv1alpha2.PolicyReport
+			if r, err = c.client.Wgpolicyk8sV1alpha2().PolicyReports(namespace).Get(ctx, name, metav1.GetOptions{}); err == nil {
+				scope, results = r.Scope, openreports.NewWGPolAdapter(r).GetResults()
+			}
+		}
+	}
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &report{
+		namespace: namespace,
+		name:      name,
+		scope:     scope,
+		results:   results,
+	}, nil
+}
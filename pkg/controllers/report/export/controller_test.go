@@ -0,0 +1,129 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+)
+
+type fakeSink struct {
+	name   string
+	err    error
+	events []Event
+}
+
+func (s *fakeSink) Name() string { return s.name }
+
+func (s *fakeSink) Send(_ context.Context, events []Event) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.events = append(s.events, events...)
+	return nil
+}
+
+func newTestController(sinks ...Sink) *controller {
+	c := &controller{
+		client:    fake.NewSimpleClientset(),
+		sinks:     sinks,
+		batchSize: defaultBatchSize,
+		queue:     workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[any]()),
+		clock:     clock.RealClock{},
+		delivered: State{},
+		pending:   sets.New[string](),
+	}
+	for _, sink := range sinks {
+		c.delivered[sink.Name()] = map[string]map[string]Event{}
+	}
+	return c
+}
+
+func TestController_reconcile(t *testing.T) {
+	ctx := context.TODO()
+	healthy := &fakeSink{name: "healthy"}
+	broken := &fakeSink{name: "broken", err: errors.New("unavailable")}
+	c := newTestController(healthy, broken)
+	polr := &policyreportv1alpha2.PolicyReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "uid", Namespace: "ns"},
+		Results: []policyreportv1alpha2.PolicyReportResult{{
+			Source: "kyverno",
+			Policy: "require-labels",
+			Rule:   "check-team",
+			Result: "fail",
+		}},
+	}
+	_, err := c.client.Wgpolicyk8sV1alpha2().PolicyReports("ns").Create(ctx, polr, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	// the broken sink doesn't prevent the healthy one from being delivered
+	err = c.reconcile(ctx, logr.Discard(), "ns/uid", "ns", "uid")
+	assert.Error(t, err)
+	assert.Len(t, healthy.events, 1)
+	assert.Equal(t, EventFail, healthy.events[0].Type)
+	assert.True(t, c.pending.Has("ns/uid"))
+
+	// events are delivered again to the broken sink only
+	broken.err = nil
+	assert.NoError(t, c.reconcile(ctx, logr.Discard(), "ns/uid", "ns", "uid"))
+	assert.Len(t, healthy.events, 1)
+	assert.Len(t, broken.events, 1)
+	assert.False(t, c.pending.Has("ns/uid"))
+
+	// deleting the report resolves the failures
+	assert.NoError(t, c.client.Wgpolicyk8sV1alpha2().PolicyReports("ns").Delete(ctx, "uid", metav1.DeleteOptions{}))
+	assert.NoError(t, c.reconcile(ctx, logr.Discard(), "ns/uid", "ns", "uid"))
+	assert.Len(t, healthy.events, 2)
+	assert.Equal(t, EventResolved, healthy.events[1].Type)
+	assert.Empty(t, c.delivered["healthy"])
+}
+
+func TestController_state(t *testing.T) {
+	ctx := context.TODO()
+	sink := &fakeSink{name: "siem"}
+	store := NewConfigMapStateStore(kubefake.NewSimpleClientset().CoreV1().ConfigMaps("kyverno"), StateConfigMapName)
+	c := newTestController(sink)
+	c.store = store
+	polr := &policyreportv1alpha2.PolicyReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "uid", Namespace: "ns"},
+		Results: []policyreportv1alpha2.PolicyReportResult{{
+			Source: "kyverno",
+			Policy: "require-labels",
+			Rule:   "check-team",
+			Result: "fail",
+		}},
+	}
+	_, err := c.client.Wgpolicyk8sV1alpha2().PolicyReports("ns").Create(ctx, polr, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	assert.NoError(t, c.reconcile(ctx, logr.Discard(), "ns/uid", "ns", "uid"))
+	assert.Len(t, sink.events, 1)
+	assert.True(t, c.dirty)
+	c.save(ctx, logr.Discard())
+	assert.False(t, c.dirty)
+
+	// a new controller (restart or leader change) resolves the failures of the report deleted in the meantime
+	restarted := newTestController(sink)
+	restarted.client = c.client
+	restarted.store = store
+	assert.NoError(t, c.client.Wgpolicyk8sV1alpha2().PolicyReports("ns").Delete(ctx, "uid", metav1.DeleteOptions{}))
+	assert.NoError(t, restarted.load(ctx))
+	assert.Equal(t, 1, restarted.queue.Len())
+	assert.NoError(t, restarted.reconcile(ctx, logr.Discard(), "ns/uid", "ns", "uid"))
+	assert.Len(t, sink.events, 2)
+	assert.Equal(t, EventResolved, sink.events[1].Type)
+	assert.Equal(t, "require-labels", sink.events[1].Policy)
+
+	restarted.save(ctx, logr.Discard())
+	state, err := store.Load(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, state["siem"])
+}
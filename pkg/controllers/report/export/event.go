@@ -0,0 +1,117 @@
+package export
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kyverno/kyverno/pkg/openreports"
+	openreportsv1alpha1 "github.com/openreports/reports-api/apis/openreports.io/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EventType is the type of a report result delta
+type EventType string
+
+const (
+	// EventFail is emitted when a result starts failing
+	EventFail EventType = "fail"
+	// EventResolved is emitted when a failing result doesn't fail anymore
+	EventResolved EventType = "resolved"
+)
+
+// Event is a report result delta sent to the export sinks
+type Event struct {
+	Type      EventType                `json:"type"`
+	Timestamp metav1.Time              `json:"timestamp"`
+	Namespace string                   `json:"namespace,omitempty"`
+	Report    string                   `json:"report"`
+	Resource  *corev1.ObjectReference  `json:"resource,omitempty"`
+	Subjects  []corev1.ObjectReference `json:"subjects,omitempty"`
+	Source    string                   `json:"source,omitempty"`
+	Policy    string                   `json:"policy"`
+	Rule      string                   `json:"rule,omitempty"`
+	Category  string                   `json:"category,omitempty"`
+	Severity  string                   `json:"severity,omitempty"`
+	Message   string                   `json:"message,omitempty"`
+}
+
+// Key returns the key used to partition events, events for the same report share the same key
+func (e Event) Key() string {
+	if e.Namespace == "" {
+		return e.Report
+	}
+	return e.Namespace + "/" + e.Report
+}
+
+// report is the exported view of a policy report
+type report struct {
+	namespace string
+	name      string
+	scope     *corev1.ObjectReference
+	results   []openreportsv1alpha1.ReportResult
+}
+
+// failures returns the failing results of a report indexed by result key
+func failures(report *report) map[string]Event {
+	out := map[string]Event{}
+	if report == nil {
+		return out
+	}
+	for _, result := range report.results {
+		if result.Result != openreports.StatusFail {
+			continue
+		}
+		out[resultKey(result)] = Event{
+			Type:      EventFail,
+			Namespace: report.namespace,
+			Report:    report.name,
+			Resource:  report.scope,
+			Subjects:  result.Subjects,
+			Source:    result.Source,
+			Policy:    result.Policy,
+			Rule:      result.Rule,
+			Category:  result.Category,
+			Severity:  string(result.Severity),
+			Message:   result.Description,
+		}
+	}
+	return out
+}
+
+// resultKey identifies a result in a report, results of the same rule for different subjects are distinct
+func resultKey(result openreportsv1alpha1.ReportResult) string {
+	key := result.Source + "/" + result.Policy + "/" + result.Rule
+	if len(result.Subjects) == 0 {
+		return key
+	}
+	subjects := make([]string, 0, len(result.Subjects))
+	for _, subject := range result.Subjects {
+		if subject.UID != "" {
+			subjects = append(subjects, string(subject.UID))
+		} else {
+			subjects = append(subjects, subject.Kind+"/"+subject.Namespace+"/"+subject.Name)
+		}
+	}
+	sort.Strings(subjects)
+	return key + "/" + strings.Join(subjects, ",")
+}
+
+// diff computes the events needed to go from the delivered failures to the current ones
+func diff(delivered, current map[string]Event, now metav1.Time) []Event {
+	var events []Event
+	for key, event := range current {
+		if _, ok := delivered[key]; !ok {
+			event.Timestamp = now
+			events = append(events, event)
+		}
+	}
+	for key, event := range delivered {
+		if _, ok := current[key]; !ok {
+			event.Type = EventResolved
+			event.Timestamp = now
+			events = append(events, event)
+		}
+	}
+	return events
+}
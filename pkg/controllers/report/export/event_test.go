@@ -0,0 +1,79 @@
+package export
+
+import (
+	"testing"
+	"time"
+
+	openreportsv1alpha1 "github.com/openreports/reports-api/apis/openreports.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDiff(t *testing.T) {
+	now := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	newReport := func(results ...openreportsv1alpha1.ReportResult) *report {
+		return &report{namespace: "ns", name: "uid", results: results}
+	}
+	fail := func(policy string) openreportsv1alpha1.ReportResult {
+		return openreportsv1alpha1.ReportResult{Source: "kyverno", Policy: policy, Rule: "rule", Result: "fail", Description: "failed"}
+	}
+	pass := func(policy string) openreportsv1alpha1.ReportResult {
+		return openreportsv1alpha1.ReportResult{Source: "kyverno", Policy: policy, Rule: "rule", Result: "pass"}
+	}
+	tests := []struct {
+		name     string
+		previous *report
+		current  *report
+		want     map[string]EventType
+	}{{
+		name:    "new report",
+		current: newReport(fail("a"), pass("b")),
+		want:    map[string]EventType{"a": EventFail},
+	}, {
+		name:     "unchanged",
+		previous: newReport(fail("a")),
+		current:  newReport(fail("a")),
+		want:     map[string]EventType{},
+	}, {
+		name:     "new fail and resolved fail",
+		previous: newReport(fail("a"), pass("b")),
+		current:  newReport(pass("a"), fail("b")),
+		want:     map[string]EventType{"a": EventResolved, "b": EventFail},
+	}, {
+		name:     "deleted report",
+		previous: newReport(fail("a"), fail("b")),
+		want:     map[string]EventType{"a": EventResolved, "b": EventResolved},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := diff(failures(tt.previous), failures(tt.current), now)
+			got := map[string]EventType{}
+			for _, event := range events {
+				got[event.Policy] = event.Type
+				assert.Equal(t, now, event.Timestamp)
+				assert.Equal(t, "ns/uid", event.Key())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFailures_Subjects(t *testing.T) {
+	subject := func(name string) []corev1.ObjectReference {
+		return []corev1.ObjectReference{{Kind: "Pod", Namespace: "ns", Name: name}}
+	}
+	current := failures(&report{namespace: "ns", name: "uid", results: []openreportsv1alpha1.ReportResult{
+		{Source: "kyverno", Policy: "a", Rule: "rule", Result: "fail", Subjects: subject("first")},
+		{Source: "kyverno", Policy: "a", Rule: "rule", Result: "fail", Subjects: subject("second")},
+	}})
+	// the same rule failing for different subjects gives distinct events
+	assert.Len(t, current, 2)
+	previous := failures(&report{namespace: "ns", name: "uid", results: []openreportsv1alpha1.ReportResult{
+		{Source: "kyverno", Policy: "a", Rule: "rule", Result: "fail", Subjects: subject("first")},
+	}})
+	events := diff(previous, current, metav1.Now())
+	assert.Len(t, events, 1)
+	assert.Equal(t, EventFail, events[0].Type)
+	assert.Equal(t, subject("second"), events[0].Subjects)
+}
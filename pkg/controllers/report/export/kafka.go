@@ -0,0 +1,57 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// kafkaContentType is the content type of JSON records for Kafka REST proxies and HTTP bridges
+const kafkaContentType = "application/vnd.kafka.json.v2+json"
+
+type kafkaSink struct {
+	name    string
+	client  *http.Client
+	url     string
+	headers map[string]string
+}
+
+type kafkaRecord struct {
+	Key   string `json:"key"`
+	Value Event  `json:"value"`
+}
+
+type kafkaRecords struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+// NewKafkaSink returns a sink producing events to a topic through a Kafka compatible HTTP bridge,
+// records are keyed by report so that events for the same report land in the same partition
+func NewKafkaSink(name string, client *http.Client, bridgeURL string, topic string, headers map[string]string) Sink {
+	return &kafkaSink{
+		name:    name,
+		client:  client,
+		url:     strings.TrimSuffix(bridgeURL, "/") + "/topics/" + url.PathEscape(topic),
+		headers: headers,
+	}
+}
+
+func (s *kafkaSink) Name() string {
+	return s.name
+}
+
+func (s *kafkaSink) Send(ctx context.Context, events []Event) error {
+	records := kafkaRecords{
+		Records: make([]kafkaRecord, 0, len(events)),
+	}
+	for _, event := range events {
+		records.Records = append(records.Records, kafkaRecord{Key: event.Key(), Value: event})
+	}
+	body, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	return post(ctx, s.client, s.url, kafkaContentType, s.headers, body)
+}
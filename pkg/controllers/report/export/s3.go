@@ -0,0 +1,91 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"k8s.io/utils/clock"
+)
+
+type s3Sink struct {
+	name        string
+	client      *http.Client
+	endpoint    string
+	bucket      string
+	prefix      string
+	region      string
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	clock       clock.PassiveClock
+}
+
+// NewS3Sink returns a sink storing every batch of events as a JSON lines object in an S3 compatible bucket,
+// path style addressing is used so that it works with most S3 compatible object stores
+func NewS3Sink(name string, client *http.Client, endpoint, bucket, prefix, region string, credentials aws.CredentialsProvider, clock clock.PassiveClock) Sink {
+	return &s3Sink{
+		name:        name,
+		client:      client,
+		endpoint:    strings.TrimSuffix(endpoint, "/"),
+		bucket:      bucket,
+		prefix:      prefix,
+		region:      region,
+		credentials: credentials,
+		signer:      v4.NewSigner(),
+		clock:       clock,
+	}
+}
+
+func (s *s3Sink) Name() string {
+	return s.name
+}
+
+func (s *s3Sink) Send(ctx context.Context, events []Event) error {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+	hash := sha256.Sum256(body.Bytes())
+	payloadHash := hex.EncodeToString(hash[:])
+	now := s.clock.Now().UTC()
+	// the object name is derived from the batch content only, sending the same batch again overwrites
+	// the same object, a redelivery computed by a later reconciliation carries new event timestamps
+	// and is stored as a new object
+	created := now
+	if len(events) != 0 && !events[0].Timestamp.IsZero() {
+		created = events[0].Timestamp.UTC()
+	}
+	key := s.prefix + created.Format("2006/01/02/150405.000000000") + "-" + payloadHash[:16] + ".jsonl"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.endpoint+"/"+url.PathEscape(s.bucket)+"/"+escapeKey(key), bytes.NewReader(body.Bytes()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	credentials, err := s.credentials.Retrieve(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.signer.SignHTTP(ctx, credentials, req, payloadHash, "s3", s.region, now); err != nil {
+		return err
+	}
+	return do(s.client, req)
+}
+
+func escapeKey(key string) string {
+	parts := strings.Split(key, "/")
+	for i := range parts {
+		parts[i] = url.PathEscape(parts[i])
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,27 @@
+package export
+
+import (
+	"context"
+)
+
+// Sink delivers report events to an external system
+type Sink interface {
+	// Name returns the sink name, it identifies the sink delivery state
+	Name() string
+	// Send delivers the events, an error means events must be delivered again
+	Send(context.Context, []Event) error
+}
+
+// sendBatches sends events to the sink in batches of at most size events
+func sendBatches(ctx context.Context, sink Sink, events []Event, size int) error {
+	if size <= 0 {
+		size = len(events)
+	}
+	for start := 0; start < len(events); start += size {
+		end := min(start+size, len(events))
+		if err := sink.Send(ctx, events[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+var testEvents = []Event{{
+	Type:      EventFail,
+	Namespace: "ns",
+	Report:    "uid-1",
+	Policy:    "policy",
+	Rule:      "rule",
+}, {
+	Type:      EventResolved,
+	Namespace: "ns",
+	Report:    "uid-2",
+	Policy:    "policy",
+	Rule:      "rule",
+}}
+
+type request struct {
+	method  string
+	path    string
+	headers http.Header
+	body    []byte
+}
+
+func newServer(t *testing.T, status int) (*httptest.Server, *[]request) {
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		requests = append(requests, request{method: r.Method, path: r.URL.EscapedPath(), headers: r.Header, body: body})
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestWriterSink(t *testing.T) {
+	var out bytes.Buffer
+	sink := NewWriterSink("stdout", &out)
+	assert.NoError(t, sink.Send(context.TODO(), testEvents))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(t, lines, 2)
+	var event Event
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &event))
+	assert.Equal(t, testEvents[1], event)
+}
+
+func TestWebhookSink(t *testing.T) {
+	server, requests := newServer(t, http.StatusOK)
+	sink := NewWebhookSink("webhook", server.Client(), server.URL+"/events", map[string]string{"Authorization": "Bearer token"})
+	assert.NoError(t, sink.Send(context.TODO(), testEvents))
+	assert.Len(t, *requests, 1)
+	assert.Equal(t, http.MethodPost, (*requests)[0].method)
+	assert.Equal(t, "/events", (*requests)[0].path)
+	assert.Equal(t, "Bearer token", (*requests)[0].headers.Get("Authorization"))
+	var events []Event
+	assert.NoError(t, json.Unmarshal((*requests)[0].body, &events))
+	assert.Equal(t, testEvents, events)
+}
+
+func TestWebhookSinkError(t *testing.T) {
+	server, _ := newServer(t, http.StatusServiceUnavailable)
+	sink := NewWebhookSink("webhook", server.Client(), server.URL, nil)
+	err := sink.Send(context.TODO(), testEvents)
+	assert.ErrorContains(t, err, "failed with status 503")
+}
+
+func TestKafkaSink(t *testing.T) {
+	server, requests := newServer(t, http.StatusOK)
+	sink := NewKafkaSink("kafka", server.Client(), server.URL+"/", "policy-reports", nil)
+	assert.NoError(t, sink.Send(context.TODO(), testEvents))
+	assert.Len(t, *requests, 1)
+	assert.Equal(t, "/topics/policy-reports", (*requests)[0].path)
+	assert.Equal(t, kafkaContentType, (*requests)[0].headers.Get("Content-Type"))
+	var records kafkaRecords
+	assert.NoError(t, json.Unmarshal((*requests)[0].body, &records))
+	assert.Len(t, records.Records, 2)
+	assert.Equal(t, "ns/uid-1", records.Records[0].Key)
+	assert.Equal(t, testEvents[0], records.Records[0].Value)
+}
+
+func TestS3Sink(t *testing.T) {
+	server, requests := newServer(t, http.StatusOK)
+	clock := clocktesting.NewFakePassiveClock(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	creds := credentials.NewStaticCredentialsProvider("access", "secret", "")
+	sink := NewS3Sink("s3", server.Client(), server.URL, "reports", "kyverno/", "eu-west-1", creds, clock)
+	assert.NoError(t, sink.Send(context.TODO(), testEvents))
+	assert.Len(t, *requests, 1)
+	assert.Equal(t, http.MethodPut, (*requests)[0].method)
+	assert.True(t, strings.HasPrefix((*requests)[0].path, "/reports/kyverno/2026/01/02/030405.000000000-"))
+	assert.True(t, strings.HasSuffix((*requests)[0].path, ".jsonl"))
+	assert.Contains(t, (*requests)[0].headers.Get("Authorization"), "Credential=access/20260102/eu-west-1/s3/aws4_request")
+	assert.NotEmpty(t, (*requests)[0].headers.Get("X-Amz-Content-Sha256"))
+	assert.Len(t, strings.Split(strings.TrimSpace(string((*requests)[0].body)), "\n"), 2)
+}
+
+func TestS3SinkObjectName(t *testing.T) {
+	server, requests := newServer(t, http.StatusOK)
+	clock := clocktesting.NewFakePassiveClock(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	creds := credentials.NewStaticCredentialsProvider("access", "secret", "")
+	sink := NewS3Sink("s3", server.Client(), server.URL, "reports", "", "eu-west-1", creds, clock)
+	events := append([]Event(nil), testEvents...)
+	events[0].Timestamp = metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, sink.Send(context.TODO(), events))
+	// the same batch sent later is stored under the same name
+	clock.SetTime(clock.Now().Add(time.Minute))
+	assert.NoError(t, sink.Send(context.TODO(), events))
+	assert.Len(t, *requests, 2)
+	assert.True(t, strings.HasPrefix((*requests)[0].path, "/reports/2026/01/01/000000.000000000-"))
+	assert.Equal(t, (*requests)[0].path, (*requests)[1].path)
+	// another batch gets another name
+	assert.NoError(t, sink.Send(context.TODO(), events[:1]))
+	assert.NotEqual(t, (*requests)[0].path, (*requests)[2].path)
+}
+
+func TestS3SinkCredentialsError(t *testing.T) {
+	server, requests := newServer(t, http.StatusOK)
+	creds := aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+		return aws.Credentials{}, errors.New("no credentials")
+	})
+	sink := NewS3Sink("s3", server.Client(), server.URL, "reports", "", "us-east-1", creds, clocktesting.NewFakePassiveClock(time.Now()))
+	assert.EqualError(t, sink.Send(context.TODO(), testEvents), "no credentials")
+	assert.Empty(t, *requests)
+}
+
+type countingSink struct {
+	batches [][]Event
+}
+
+func (s *countingSink) Name() string { return "counting" }
+
+func (s *countingSink) Send(_ context.Context, events []Event) error {
+	s.batches = append(s.batches, events)
+	return nil
+}
+
+func TestSendBatches(t *testing.T) {
+	events := make([]Event, 5)
+	sink := &countingSink{}
+	assert.NoError(t, sendBatches(context.TODO(), sink, events, 2))
+	assert.Len(t, sink.batches, 3)
+	assert.Len(t, sink.batches[2], 1)
+}
@@ -0,0 +1,218 @@
+package export
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// StateConfigMapName is the name of the first config map storing the delivery state,
+	// the following shards are suffixed with their index
+	StateConfigMapName = "kyverno-report-export"
+	// MaxStateShards is the maximum number of config maps storing the delivery state
+	MaxStateShards = 8
+	// maxStateSize bounds the size of a state shard below the config map size limit
+	maxStateSize = 900 * 1024
+	// stateKey is the config map key holding a state shard
+	stateKey = "state"
+)
+
+// State is the delivery state, the failures acknowledged by every sink indexed by sink name,
+// report key and result key
+type State map[string]map[string]map[string]Event
+
+// StateStore persists the delivery state so that it survives restarts and leader changes
+type StateStore interface {
+	// Load returns the stored delivery state
+	Load(context.Context) (State, error)
+	// Save replaces the stored delivery state
+	Save(context.Context, State) error
+}
+
+// stateRecord is the stored delivery state of a report for a sink
+type stateRecord struct {
+	Sink    string           `json:"sink"`
+	Report  string           `json:"report"`
+	Results map[string]Event `json:"results"`
+}
+
+type configMapStateStore struct {
+	client  corev1client.ConfigMapInterface
+	name    string
+	maxSize int
+	// shards is the number of shards holding data in the last loaded or saved state
+	shards int
+}
+
+// NewConfigMapStateStore returns a store keeping the delivery state in up to MaxStateShards config maps.
+// Every report state is compressed separately and the records are spread across the shards so that
+// none of them exceeds the config map size limit.
+func NewConfigMapStateStore(client corev1client.ConfigMapInterface, name string) StateStore {
+	return &configMapStateStore{
+		client:  client,
+		name:    name,
+		maxSize: maxStateSize,
+	}
+}
+
+// StateShardName returns the name of the config map storing the given state shard
+func StateShardName(name string, shard int) string {
+	if shard == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s-%d", name, shard)
+}
+
+func (s *configMapStateStore) Load(ctx context.Context) (State, error) {
+	state := State{}
+	s.shards = 0
+	for shard := 0; shard < MaxStateShards; shard++ {
+		cm, err := s.client.Get(ctx, StateShardName(s.name, shard), metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		data := cm.BinaryData[stateKey]
+		if len(data) == 0 {
+			continue
+		}
+		records, err := decodeState(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the delivery state shard %d: %w", shard, err)
+		}
+		for _, record := range records {
+			if state[record.Sink] == nil {
+				state[record.Sink] = map[string]map[string]Event{}
+			}
+			state[record.Sink][record.Report] = record.Results
+		}
+		s.shards = shard + 1
+	}
+	return state, nil
+}
+
+func (s *configMapStateStore) Save(ctx context.Context, state State) error {
+	var shards [][]byte
+	var current []byte
+	for _, sink := range slices.Sorted(maps.Keys(state)) {
+		reports := state[sink]
+		for _, report := range slices.Sorted(maps.Keys(reports)) {
+			encoded, err := encodeRecord(stateRecord{Sink: sink, Report: report, Results: reports[report]})
+			if err != nil {
+				return err
+			}
+			if len(current) > 0 && len(current)+len(encoded) > s.maxSize {
+				shards = append(shards, current)
+				current = nil
+			}
+			current = append(current, encoded...)
+		}
+	}
+	if len(current) > 0 {
+		shards = append(shards, current)
+	}
+	if len(shards) > MaxStateShards {
+		return fmt.Errorf("the delivery state exceeds the size limit of %d config maps", MaxStateShards)
+	}
+	for _, data := range shards {
+		if len(data) > s.maxSize {
+			return fmt.Errorf("the delivery state of a report exceeds the config map size limit (%d bytes)", len(data))
+		}
+	}
+	for shard, data := range shards {
+		if err := s.saveShard(ctx, shard, data); err != nil {
+			return err
+		}
+	}
+	// the shards not used anymore are emptied
+	for shard := len(shards); shard < s.shards; shard++ {
+		if err := s.saveShard(ctx, shard, nil); err != nil {
+			return err
+		}
+	}
+	s.shards = len(shards)
+	return nil
+}
+
+func (s *configMapStateStore) saveShard(ctx context.Context, shard int, data []byte) error {
+	name := StateShardName(s.name, shard)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := s.client.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			if len(data) == 0 {
+				return nil
+			}
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+					Labels: map[string]string{
+						kyverno.LabelAppManagedBy: kyverno.ValueKyvernoApp,
+					},
+				},
+				BinaryData: map[string][]byte{stateKey: data},
+			}
+			_, err = s.client.Create(ctx, cm, metav1.CreateOptions{})
+			return err
+		}
+		cm = cm.DeepCopy()
+		if len(data) == 0 {
+			cm.BinaryData = nil
+		} else {
+			cm.BinaryData = map[string][]byte{stateKey: data}
+		}
+		_, err = s.client.Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// encodeRecord compresses a record in its own gzip member, a shard is the concatenation of its members
+func encodeRecord(record stateRecord) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	if err := json.NewEncoder(writer).Encode(record); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func decodeState(data []byte) ([]stateRecord, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	var records []stateRecord
+	decoder := json.NewDecoder(reader)
+	for {
+		var record stateRecord
+		if err := decoder.Decode(&record); err != nil {
+			if errors.Is(err, io.EOF) {
+				return records, nil
+			}
+			return nil, err
+		}
+		records = append(records, record)
+	}
+}
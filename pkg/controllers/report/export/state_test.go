@@ -0,0 +1,59 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestConfigMapStateStore_shards(t *testing.T) {
+	ctx := context.TODO()
+	client := kubefake.NewSimpleClientset().CoreV1().ConfigMaps("kyverno")
+	store := NewConfigMapStateStore(client, StateConfigMapName).(*configMapStateStore)
+	store.maxSize = 512
+	state := State{"siem": {}}
+	for i := 0; i < 10; i++ {
+		state["siem"][fmt.Sprintf("ns/report-%d", i)] = map[string]Event{
+			"kyverno/policy/rule": {Type: EventFail, Namespace: "ns", Report: fmt.Sprintf("report-%d", i), Policy: "policy", Rule: "rule"},
+		}
+	}
+	assert.NoError(t, store.Save(ctx, state))
+	assert.Greater(t, store.shards, 1)
+	shards := store.shards
+	for shard := 0; shard < shards; shard++ {
+		cm, err := client.Get(ctx, StateShardName(StateConfigMapName, shard), metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(cm.BinaryData[stateKey]), store.maxSize)
+	}
+
+	// a new store loads the state back from every shard
+	loaded, err := NewConfigMapStateStore(client, StateConfigMapName).Load(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, state, loaded)
+
+	// shards not needed anymore are emptied
+	restarted := NewConfigMapStateStore(client, StateConfigMapName).(*configMapStateStore)
+	restarted.maxSize = store.maxSize
+	_, err = restarted.Load(ctx)
+	assert.NoError(t, err)
+	small := State{"siem": {"ns/report-0": state["siem"]["ns/report-0"]}}
+	assert.NoError(t, restarted.Save(ctx, small))
+	assert.Equal(t, 1, restarted.shards)
+	cm, err := client.Get(ctx, StateShardName(StateConfigMapName, shards-1), metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, cm.BinaryData)
+	loaded, err = restarted.Load(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, small, loaded)
+}
+
+func TestConfigMapStateStore_tooLarge(t *testing.T) {
+	store := NewConfigMapStateStore(kubefake.NewSimpleClientset().CoreV1().ConfigMaps("kyverno"), StateConfigMapName).(*configMapStateStore)
+	store.maxSize = 64
+	state := State{"siem": {"ns/report": {"kyverno/policy/rule": {Type: EventFail, Report: "report", Policy: "policy"}}}}
+	assert.Error(t, store.Save(context.TODO(), state))
+}
@@ -0,0 +1,38 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+type writerSink struct {
+	name string
+	lock sync.Mutex
+	out  io.Writer
+}
+
+// NewWriterSink returns a sink writing events as JSON lines
+func NewWriterSink(name string, out io.Writer) Sink {
+	return &writerSink{
+		name: name,
+		out:  out,
+	}
+}
+
+func (s *writerSink) Name() string {
+	return s.name
+}
+
+func (s *writerSink) Send(_ context.Context, events []Event) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	encoder := json.NewEncoder(s.out)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+type webhookSink struct {
+	name    string
+	client  *http.Client
+	url     string
+	headers map[string]string
+}
+
+// NewWebhookSink returns a sink posting events as a JSON array to the given url
+func NewWebhookSink(name string, client *http.Client, url string, headers map[string]string) Sink {
+	return &webhookSink{
+		name:    name,
+		client:  client,
+		url:     url,
+		headers: headers,
+	}
+}
+
+func (s *webhookSink) Name() string {
+	return s.name
+}
+
+func (s *webhookSink) Send(ctx context.Context, events []Event) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	return post(ctx, s.client, s.url, "application/json", s.headers, body)
+}
+
+func post(ctx context.Context, client *http.Client, url string, contentType string, headers map[string]string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	return do(client, req)
+}
+
+func do(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// drain the body so that the connection can be reused
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s failed with status %d: %s", req.Method, req.URL.Redacted(), resp.StatusCode, string(message))
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package history
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"github.com/kyverno/kyverno/pkg/openreports"
+	openreportsclient "github.com/openreports/reports-api/pkg/client/clientset/versioned/typed/openreports.io/v1alpha1"
+	"go.opentelemetry.io/otel/metric"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/clock"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "report-history-controller"
+
+	// listPageSize is the number of reports fetched per list call
+	listPageSize = 500
+)
+
+// controller periodically snapshots the summaries of the aggregated policy reports
+// per policy, namespace and severity into the history store.
+//
+// The most recent snapshot is exposed as gauges, the store keeps the trend.
+type controller struct {
+	// clients
+	client   versioned.Interface
+	orClient openreportsclient.OpenreportsV1alpha1Interface
+
+	// history
+	store    Store
+	interval time.Duration
+	metrics  metrics.ReportHistoryMetrics
+
+	// state
+	clock  clock.Clock
+	lock   sync.Mutex
+	latest *Snapshot
+}
+
+func NewController(
+	client versioned.Interface,
+	orClient openreportsclient.OpenreportsV1alpha1Interface,
+	store Store,
+	interval time.Duration,
+) controllers.Controller {
+	c := &controller{
+		client:   client,
+		orClient: orClient,
+		store:    store,
+		interval: interval,
+		metrics:  metrics.GetReportHistoryMetrics(),
+		clock:    clock.RealClock{},
+	}
+	if c.metrics != nil {
+		if _, err := c.metrics.RegisterCallback(c.report); err != nil {
+			logger.Error(err, "failed to register callback")
+		}
+	}
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, _ int) {
+	logger.V(2).Info("starting ...", "interval", c.interval)
+	defer logger.V(2).Info("stopping ...")
+	// restore the latest snapshot so that a leader change doesn't reset the gauges
+	// nor snapshot more often than the configured interval
+	snapshots, err := c.store.List(ctx)
+	if err != nil {
+		logger.Error(err, "failed to load report history")
+	} else if len(snapshots) != 0 {
+		c.setLatest(snapshots[len(snapshots)-1])
+		if delay := c.interval - c.clock.Since(snapshots[len(snapshots)-1].Time.Time); delay > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-c.clock.After(delay):
+			}
+		}
+	}
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := c.snapshot(ctx); err != nil {
+			logger.Error(err, "failed to snapshot policy reports")
+		}
+	}, c.interval)
+}
+
+func (c *controller) snapshot(ctx context.Context) error {
+	reports, err := c.listReports(ctx)
+	if err != nil {
+		return err
+	}
+	snapshot := newSnapshot(metav1.NewTime(c.clock.Now().Truncate(time.Second)), reports...)
+	if err := c.store.Append(ctx, snapshot); err != nil {
+		return err
+	}
+	logger.V(4).Info("stored policy reports snapshot", "reports", len(reports), "entries", len(snapshot.Entries))
+	c.setLatest(snapshot)
+	return nil
+}
+
+func (c *controller) setLatest(snapshot Snapshot) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.latest = &snapshot
+}
+
+func (c *controller) getLatest() *Snapshot {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.latest
+}
+
+func (c *controller) report(ctx context.Context, observer metric.Observer) error {
+	latest := c.getLatest()
+	if latest == nil {
+		return nil
+	}
+	for _, entry := range latest.Entries {
+		c.metrics.RecordReportSummary(ctx, entry.Policy, entry.Namespace, entry.Severity, entry.Summary(), observer)
+	}
+	return nil
+}
+
+func (c *controller) listReports(ctx context.Context) ([]report, error) {
+	var reports []report
+	if c.orClient != nil {
+		if err := listPages(func(options metav1.ListOptions) (string, error) {
+			polrs, err := c.orClient.Reports(metav1.NamespaceAll).List(ctx, options)
+			if err != nil {
+				return "", err
+			}
+			for _, polr := range polrs.Items {
+				reports = append(reports, report{namespace: polr.Namespace, results: polr.Results})
+			}
+			return polrs.Continue, nil
+		}); err != nil {
+			return nil, err
+		}
+		if err := listPages(func(options metav1.ListOptions) (string, error) {
+			cpolrs, err := c.orClient.ClusterReports().List(ctx, options)
+			if err != nil {
+				return "", err
+			}
+			for _, cpolr := range cpolrs.Items {
+				reports = append(reports, report{results: cpolr.Results})
+			}
+			return cpolrs.Continue, nil
+		}); err != nil {
+			return nil, err
+		}
+		return reports, nil
+	}
+	if err := listPages(func(options metav1.ListOptions) (string, error) {
+		polrs, err := c.client.Wgpolicyk8sV1alpha2().PolicyReports(metav1.NamespaceAll).List(ctx, options)
+		if err != nil {
+			return "", err
+		}
+		for i := range polrs.Items {
+			reports = append(reports, report{namespace: polrs.Items[i].Namespace, results: openreports.NewWGPolAdapter(&polrs.Items[i]).GetResults()})
+		}
+		return polrs.Continue, nil
+	}); err != nil {
+		return nil, err
+	}
+	if err := listPages(func(options metav1.ListOptions) (string, error) {
+		cpolrs, err := c.client.Wgpolicyk8sV1alpha2().ClusterPolicyReports().List(ctx, options)
+		if err != nil {
+			return "", err
+		}
+		for i := range cpolrs.Items {
+			reports = append(reports, report{results: openreports.NewWGCpolAdapter(&cpolrs.Items[i]).GetResults()})
+		}
+		return cpolrs.Continue, nil
+	}); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// listPages calls list with the options of every page, list returns the continue token of the next page
+func listPages(list func(metav1.ListOptions) (string, error)) error {
+	options := metav1.ListOptions{Limit: listPageSize}
+	for {
+		next, err := list(options)
+		if err != nil {
+			return err
+		}
+		if next == "" {
+			return nil
+		}
+		options.Continue = next
+	}
+}
@@ -0,0 +1,79 @@
+package history
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestController_snapshot(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	client := fake.NewSimpleClientset(
+		&policyreportv1alpha2.PolicyReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "team-a"},
+			Results: []policyreportv1alpha2.PolicyReportResult{
+				{Policy: "require-labels", Severity: "medium", Result: "fail"},
+				{Policy: "require-labels", Severity: "medium", Result: "pass"},
+			},
+		},
+		&policyreportv1alpha2.ClusterPolicyReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "b"},
+			Results: []policyreportv1alpha2.PolicyReportResult{
+				{Policy: "require-labels", Severity: "medium", Result: "fail"},
+			},
+		},
+	)
+	store := NewConfigMapStore(kubefake.NewSimpleClientset().CoreV1().ConfigMaps("kyverno"), ConfigMapName, Retention{})
+	c := &controller{
+		client:   client,
+		store:    store,
+		interval: time.Hour,
+		clock:    clocktesting.NewFakeClock(now),
+	}
+	assert.NoError(t, c.snapshot(ctx))
+
+	snapshots, err := store.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+	assert.Equal(t, []Entry{
+		{Policy: "require-labels", Severity: "medium", Fail: 1},
+		{Policy: "require-labels", Namespace: "team-a", Severity: "medium", Pass: 1, Fail: 1},
+	}, snapshots[0].Entries)
+	assert.True(t, snapshots[0].Time.Equal(&c.getLatest().Time))
+	assert.Equal(t, snapshots[0].Entries, c.getLatest().Entries)
+}
+
+func TestController_listReports_pages(t *testing.T) {
+	ctx := context.TODO()
+	client := fake.NewSimpleClientset()
+	pages := map[string]*policyreportv1alpha2.PolicyReportList{
+		"": {
+			ListMeta: metav1.ListMeta{Continue: "page-2"},
+			Items:    []policyreportv1alpha2.PolicyReport{{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "team-a"}}},
+		},
+		"page-2": {
+			Items: []policyreportv1alpha2.PolicyReport{{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "team-b"}}},
+		},
+	}
+	client.PrependReactor("list", "policyreports", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		options := action.(clienttesting.ListActionImpl).ListOptions
+		assert.Equal(t, int64(listPageSize), options.Limit)
+		return true, pages[options.Continue], nil
+	})
+	c := &controller{client: client}
+	reports, err := c.listReports(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, reports, 2)
+	assert.Equal(t, "team-a", reports[0].namespace)
+	assert.Equal(t, "team-b", reports[1].namespace)
+}
@@ -0,0 +1,116 @@
+package history
+
+import (
+	"cmp"
+	"slices"
+
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	openreportsv1alpha1 "github.com/openreports/reports-api/apis/openreports.io/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Snapshot holds the aggregated report summaries at a point in time
+type Snapshot struct {
+	Time    metav1.Time `json:"time"`
+	Entries []Entry     `json:"entries,omitempty"`
+	// Truncated is true when entries were dropped to fit the store size
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Entry holds the result counts of a policy in a namespace for a given severity,
+// zero values are omitted to keep the store compact
+type Entry struct {
+	Policy    string `json:"policy"`
+	Namespace string `json:"namespace,omitempty"`
+	Severity  string `json:"severity,omitempty"`
+	Pass      int    `json:"pass,omitempty"`
+	Fail      int    `json:"fail,omitempty"`
+	Warn      int    `json:"warn,omitempty"`
+	Error     int    `json:"error,omitempty"`
+	Skip      int    `json:"skip,omitempty"`
+}
+
+// Summary returns the entry counts as a report summary
+func (e Entry) Summary() openreportsv1alpha1.ReportSummary {
+	return openreportsv1alpha1.ReportSummary{
+		Pass:  e.Pass,
+		Fail:  e.Fail,
+		Warn:  e.Warn,
+		Error: e.Error,
+		Skip:  e.Skip,
+	}
+}
+
+// Filter returns the snapshot entries matching the given policy, namespace and severity,
+// an empty value matches everything
+func (s Snapshot) Filter(policy, namespace, severity string) []Entry {
+	var out []Entry
+	for _, entry := range s.Entries {
+		if policy != "" && entry.Policy != policy {
+			continue
+		}
+		if namespace != "" && entry.Namespace != namespace {
+			continue
+		}
+		if severity != "" && entry.Severity != severity {
+			continue
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+// report is the view of an aggregated policy report used to build snapshots
+type report struct {
+	namespace string
+	results   []openreportsv1alpha1.ReportResult
+}
+
+type entryKey struct {
+	policy    string
+	namespace string
+	severity  string
+}
+
+// newSnapshot groups the results of the given reports per policy, namespace and severity
+// and summarizes every group
+func newSnapshot(time metav1.Time, reports ...report) Snapshot {
+	groups := map[entryKey][]openreportsv1alpha1.ReportResult{}
+	for _, report := range reports {
+		for _, result := range report.results {
+			key := entryKey{
+				policy:    result.Policy,
+				namespace: report.namespace,
+				severity:  string(result.Severity),
+			}
+			groups[key] = append(groups[key], result)
+		}
+	}
+	snapshot := Snapshot{
+		Time: time,
+	}
+	for key, results := range groups {
+		summary := reportutils.CalculateSummary(results)
+		snapshot.Entries = append(snapshot.Entries, Entry{
+			Policy:    key.policy,
+			Namespace: key.namespace,
+			Severity:  key.severity,
+			Pass:      summary.Pass,
+			Fail:      summary.Fail,
+			Warn:      summary.Warn,
+			Error:     summary.Error,
+			Skip:      summary.Skip,
+		})
+	}
+	slices.SortFunc(snapshot.Entries, compareEntries)
+	return snapshot
+}
+
+// compareEntries orders entries by policy, namespace and severity
+func compareEntries(a, b Entry) int {
+	return cmp.Or(
+		cmp.Compare(a.Policy, b.Policy),
+		cmp.Compare(a.Namespace, b.Namespace),
+		cmp.Compare(a.Severity, b.Severity),
+	)
+}
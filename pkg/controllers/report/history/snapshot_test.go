@@ -0,0 +1,47 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	openreportsv1alpha1 "github.com/openreports/reports-api/apis/openreports.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_newSnapshot(t *testing.T) {
+	now := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	snapshot := newSnapshot(now,
+		report{
+			namespace: "team-a",
+			results: []openreportsv1alpha1.ReportResult{
+				{Policy: "require-labels", Severity: "medium", Result: "fail"},
+				{Policy: "require-labels", Severity: "medium", Result: "pass"},
+				{Policy: "require-labels", Severity: "medium", Result: "fail"},
+				{Policy: "disallow-latest", Result: "warn"},
+			},
+		},
+		report{
+			namespace: "team-a",
+			results: []openreportsv1alpha1.ReportResult{
+				{Policy: "require-labels", Severity: "medium", Result: "pass"},
+			},
+		},
+		report{
+			results: []openreportsv1alpha1.ReportResult{
+				{Policy: "require-labels", Severity: "high", Result: "error"},
+			},
+		},
+	)
+	assert.Equal(t, now, snapshot.Time)
+	assert.Equal(t, []Entry{
+		{Policy: "disallow-latest", Namespace: "team-a", Warn: 1},
+		{Policy: "require-labels", Severity: "high", Error: 1},
+		{Policy: "require-labels", Namespace: "team-a", Severity: "medium", Pass: 2, Fail: 2},
+	}, snapshot.Entries)
+	assert.Equal(t, []Entry{
+		{Policy: "require-labels", Namespace: "team-a", Severity: "medium", Pass: 2, Fail: 2},
+	}, snapshot.Filter("require-labels", "team-a", ""))
+	assert.Len(t, snapshot.Filter("", "", "high"), 1)
+	assert.Equal(t, openreportsv1alpha1.ReportSummary{Pass: 2, Fail: 2}, snapshot.Entries[2].Summary())
+}
@@ -0,0 +1,206 @@
+package history
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// ConfigMapName is the name of the config map storing the report history
+	ConfigMapName = "kyverno-report-history"
+	// snapshotKeyFormat is the time layout of the config map keys, keys sort chronologically
+	snapshotKeyFormat = "20060102T150405Z"
+	// maxStoreSize bounds the size of the stored snapshots below the config map size limit
+	maxStoreSize = 900 * 1024
+)
+
+// Retention controls how long snapshots are kept in the store
+type Retention struct {
+	// MaxAge is the maximum age of a snapshot, zero means no age limit
+	MaxAge time.Duration
+	// MaxSnapshots is the maximum number of snapshots, zero means no count limit
+	MaxSnapshots int
+}
+
+// Store persists report history snapshots
+type Store interface {
+	// List returns the stored snapshots, oldest first
+	List(context.Context) ([]Snapshot, error)
+	// Append stores a snapshot and applies the retention policy
+	Append(context.Context, Snapshot) error
+}
+
+type configMapStore struct {
+	client    corev1client.ConfigMapInterface
+	name      string
+	retention Retention
+}
+
+// NewConfigMapStore returns a store keeping snapshots in a config map, one key per snapshot
+func NewConfigMapStore(client corev1client.ConfigMapInterface, name string, retention Retention) Store {
+	return &configMapStore{
+		client:    client,
+		name:      name,
+		retention: retention,
+	}
+}
+
+func (s *configMapStore) List(ctx context.Context) ([]Snapshot, error) {
+	cm, err := s.client.Get(ctx, s.name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return Decode(cm)
+}
+
+func (s *configMapStore) Append(ctx context.Context, snapshot Snapshot) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := s.client.Get(ctx, s.name, metav1.GetOptions{})
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: s.name,
+					Labels: map[string]string{
+						kyverno.LabelAppManagedBy: kyverno.ValueKyvernoApp,
+					},
+				},
+			}
+			data, err := encode(s.retention.apply(snapshot.Time.Time, []Snapshot{snapshot}))
+			if err != nil {
+				return err
+			}
+			cm.Data = data
+			_, err = s.client.Create(ctx, cm, metav1.CreateOptions{})
+			return err
+		}
+		snapshots, err := Decode(cm)
+		if err != nil {
+			return err
+		}
+		snapshots = slices.DeleteFunc(snapshots, func(existing Snapshot) bool {
+			return snapshotKey(existing) == snapshotKey(snapshot)
+		})
+		snapshots = append(snapshots, snapshot)
+		data, err := encode(s.retention.apply(snapshot.Time.Time, snapshots))
+		if err != nil {
+			return err
+		}
+		cm = cm.DeepCopy()
+		cm.Data = data
+		_, err = s.client.Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// Decode returns the snapshots stored in a config map, oldest first
+func Decode(cm *corev1.ConfigMap) ([]Snapshot, error) {
+	snapshots := make([]Snapshot, 0, len(cm.Data))
+	for key, value := range cm.Data {
+		var snapshot Snapshot
+		if err := json.Unmarshal([]byte(value), &snapshot); err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot %s: %w", key, err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	slices.SortFunc(snapshots, func(a, b Snapshot) int {
+		return a.Time.Compare(b.Time.Time)
+	})
+	return snapshots, nil
+}
+
+func encode(snapshots []Snapshot) (map[string]string, error) {
+	data := make(map[string]string, len(snapshots))
+	for _, snapshot := range snapshots {
+		bytes, err := json.Marshal(snapshot)
+		if err != nil {
+			return nil, err
+		}
+		data[snapshotKey(snapshot)] = string(bytes)
+	}
+	return data, nil
+}
+
+func snapshotKey(snapshot Snapshot) string {
+	return snapshot.Time.UTC().Format(snapshotKeyFormat)
+}
+
+// apply sorts the snapshots oldest first and drops the ones exceeding the retention policy
+// or the store size, the most recent snapshot is always kept and truncated to the store size
+func (r Retention) apply(now time.Time, snapshots []Snapshot) []Snapshot {
+	slices.SortFunc(snapshots, func(a, b Snapshot) int {
+		return a.Time.Compare(b.Time.Time)
+	})
+	if r.MaxAge > 0 {
+		snapshots = slices.DeleteFunc(snapshots, func(s Snapshot) bool {
+			return now.Sub(s.Time.Time) > r.MaxAge
+		})
+	}
+	if r.MaxSnapshots > 0 && len(snapshots) > r.MaxSnapshots {
+		snapshots = snapshots[len(snapshots)-r.MaxSnapshots:]
+	}
+	if len(snapshots) != 0 {
+		snapshots[len(snapshots)-1] = truncate(snapshots[len(snapshots)-1], maxStoreSize)
+	}
+	size := 0
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		size += snapshotSize(snapshots[i])
+		if size > maxStoreSize && i != len(snapshots)-1 {
+			return snapshots[i+1:]
+		}
+	}
+	return snapshots
+}
+
+// truncate drops the entries of a snapshot until it fits the given size, the entries with the
+// fewest fail, error and warn results are dropped first
+func truncate(snapshot Snapshot, size int) Snapshot {
+	if snapshotSize(snapshot) <= size {
+		return snapshot
+	}
+	entries := slices.Clone(snapshot.Entries)
+	slices.SortStableFunc(entries, func(a, b Entry) int {
+		return cmp.Compare(b.Fail+b.Error+b.Warn, a.Fail+a.Error+a.Warn)
+	})
+	truncated := Snapshot{Time: snapshot.Time, Truncated: true}
+	total := snapshotSize(truncated)
+	for _, entry := range entries {
+		bytes, err := json.Marshal(entry)
+		if err != nil {
+			continue
+		}
+		// account for the separator between entries
+		if total+len(bytes)+1 > size {
+			break
+		}
+		total += len(bytes) + 1
+		truncated.Entries = append(truncated.Entries, entry)
+	}
+	slices.SortFunc(truncated.Entries, compareEntries)
+	return truncated
+}
+
+// snapshotSize returns the size of a snapshot in the store
+func snapshotSize(snapshot Snapshot) int {
+	bytes, err := json.Marshal(snapshot)
+	if err != nil {
+		return 0
+	}
+	return len(bytes) + len(snapshotKeyFormat)
+}
@@ -0,0 +1,114 @@
+package history
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func snapshotAt(t time.Time, entries ...Entry) Snapshot {
+	return Snapshot{Time: metav1.NewTime(t), Entries: entries}
+}
+
+func TestRetention_apply(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	var snapshots []Snapshot
+	for i := 9; i >= 0; i-- {
+		snapshots = append(snapshots, snapshotAt(now.Add(-time.Duration(i)*24*time.Hour)))
+	}
+	tests := []struct {
+		name      string
+		retention Retention
+		want      int
+	}{{
+		name: "no limit",
+		want: 10,
+	}, {
+		name:      "max age",
+		retention: Retention{MaxAge: 72 * time.Hour},
+		want:      4,
+	}, {
+		name:      "max snapshots",
+		retention: Retention{MaxSnapshots: 2},
+		want:      2,
+	}, {
+		name:      "both",
+		retention: Retention{MaxAge: 72 * time.Hour, MaxSnapshots: 3},
+		want:      3,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.retention.apply(now, append([]Snapshot(nil), snapshots...))
+			assert.Len(t, got, tt.want)
+			// the most recent snapshots are kept
+			assert.Equal(t, now, got[len(got)-1].Time.Time)
+		})
+	}
+}
+
+func TestRetention_apply_size(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	large := Entry{Policy: strings.Repeat("p", maxStoreSize/3)}
+	got := Retention{}.apply(now, []Snapshot{
+		snapshotAt(now.Add(-3*time.Hour), large),
+		snapshotAt(now.Add(-2*time.Hour), large),
+		snapshotAt(now.Add(-time.Hour), large),
+		snapshotAt(now, large),
+	})
+	assert.Len(t, got, 2)
+	assert.Equal(t, now, got[1].Time.Time)
+}
+
+func TestRetention_apply_truncate(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	var entries []Entry
+	for i := range 4 {
+		entries = append(entries, Entry{Policy: strings.Repeat(string(rune('a'+i)), maxStoreSize/3), Fail: i})
+	}
+	got := Retention{}.apply(now, []Snapshot{
+		snapshotAt(now.Add(-time.Hour), Entry{Policy: "require-labels"}),
+		snapshotAt(now, entries...),
+	})
+	// the newest snapshot alone exceeds the store size, it is truncated
+	assert.Len(t, got, 2)
+	assert.False(t, got[0].Truncated)
+	assert.True(t, got[1].Truncated)
+	assert.LessOrEqual(t, snapshotSize(got[0])+snapshotSize(got[1]), maxStoreSize)
+	// the entries with the most failures are kept, in order
+	assert.Equal(t, []Entry{entries[2], entries[3]}, got[1].Entries)
+}
+
+func TestConfigMapStore(t *testing.T) {
+	ctx := context.TODO()
+	client := fake.NewSimpleClientset().CoreV1().ConfigMaps("kyverno")
+	store := NewConfigMapStore(client, ConfigMapName, Retention{MaxSnapshots: 2})
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	snapshots, err := store.List(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, snapshots)
+
+	entry := Entry{Policy: "require-labels", Namespace: "team-a", Fail: 1}
+	for i := 2; i >= 0; i-- {
+		assert.NoError(t, store.Append(ctx, snapshotAt(now.Add(-time.Duration(i)*time.Hour), entry)))
+	}
+	// appending a snapshot with the same timestamp replaces it
+	entry.Fail = 3
+	assert.NoError(t, store.Append(ctx, snapshotAt(now, entry)))
+
+	snapshots, err = store.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 2)
+	assert.Equal(t, now.Add(-time.Hour), snapshots[0].Time.UTC())
+	assert.Equal(t, now, snapshots[1].Time.UTC())
+	assert.Equal(t, []Entry{entry}, snapshots[1].Entries)
+
+	cm, err := client.Get(ctx, ConfigMapName, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, cm.Data, "20250110T000000Z")
+}
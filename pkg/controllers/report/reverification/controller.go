@@ -0,0 +1,395 @@
+package reverification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/breaker"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	policiesv1beta1listers "github.com/kyverno/kyverno/pkg/client/listers/policies.kyverno.io/v1beta1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/controllers/report/utils"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "image-reverification-controller"
+	maxRetries     = 3
+)
+
+// Action is what the controller does with the pods running images that fail verification
+type Action string
+
+const (
+	// ActionReport only reports the failures in the policy reports
+	ActionReport Action = "report"
+	// ActionAnnotate reports the failures and annotates the pods with the failed enforcing policy rules
+	ActionAnnotate Action = "annotate"
+	// ActionEvict reports the failures and evicts the pods failing enforcing policy rules, the failures
+	// of audit rules are only reported
+	ActionEvict Action = "evict"
+)
+
+// ParseAction parses an action name
+func ParseAction(action string) (Action, error) {
+	switch Action(action) {
+	case ActionReport, ActionAnnotate, ActionEvict:
+		return Action(action), nil
+	default:
+		return "", fmt.Errorf("invalid image reverification action %q, must be one of %s, %s or %s", action, ActionReport, ActionAnnotate, ActionEvict)
+	}
+}
+
+// Config configures the periodic re-verification of the images of running pods
+type Config struct {
+	// Interval is the interval between two re-verifications, 0 disables the re-verification
+	Interval time.Duration
+	// Action is what is done with the pods running images that fail verification
+	Action Action
+}
+
+// controller periodically re-verifies the images of running pods against the image verification
+// policies, bypassing the verification caches, so that revoked signatures or rotated keys are caught
+// for workloads admitted before the change.
+type controller struct {
+	// clients
+	kubeClient    kubernetes.Interface
+	kyvernoClient versioned.Interface
+	scanner       utils.Scanner
+
+	// listers
+	podLister      corev1listers.PodLister
+	nsLister       corev1listers.NamespaceLister
+	cpolLister     kyvernov1listers.ClusterPolicyLister
+	polLister      kyvernov1listers.PolicyLister
+	ivpolLister    policiesv1beta1listers.ImageValidatingPolicyLister
+	celpolexLister policiesv1beta1listers.PolicyExceptionLister
+
+	// queue
+	queue workqueue.TypedRateLimitingInterface[any]
+
+	// config
+	config Config
+
+	// cycle dedupes the verifications of the current re-verification cycle
+	cycle atomic.Pointer[imageverifycache.Cycle]
+}
+
+func NewController(
+	kubeClient kubernetes.Interface,
+	kyvernoClient versioned.Interface,
+	scanner utils.Scanner,
+	podInformer corev1informers.PodInformer,
+	nsLister corev1listers.NamespaceLister,
+	cpolLister kyvernov1listers.ClusterPolicyLister,
+	polLister kyvernov1listers.PolicyLister,
+	ivpolLister policiesv1beta1listers.ImageValidatingPolicyLister,
+	celpolexLister policiesv1beta1listers.PolicyExceptionLister,
+	config Config,
+) controllers.Controller {
+	return &controller{
+		kubeClient:     kubeClient,
+		kyvernoClient:  kyvernoClient,
+		scanner:        scanner,
+		podLister:      podInformer.Lister(),
+		nsLister:       nsLister,
+		cpolLister:     cpolLister,
+		polLister:      polLister,
+		ivpolLister:    ivpolLister,
+		celpolexLister: celpolexLister,
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[any](),
+			workqueue.TypedRateLimitingQueueConfig[any]{Name: ControllerName},
+		),
+		config: config,
+	}
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	logger.V(2).Info("starting ...", "interval", c.config.Interval, "action", c.config.Action)
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile, c.ticker)
+}
+
+func (c *controller) ticker(ctx context.Context, logger logr.Logger) {
+	wait.UntilWithContext(ctx, func(context.Context) {
+		if err := c.enqueuePods(); err != nil {
+			logger.Error(err, "failed to enqueue pods")
+		}
+	}, c.config.Interval)
+}
+
+func (c *controller) enqueuePods() error {
+	pods, err := c.podLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	c.cycle.Store(imageverifycache.NewCycle())
+	for _, pod := range pods {
+		if isRunning(pod) {
+			c.queue.Add(cache.MetaObjectToName(pod).String())
+		}
+	}
+	return nil
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, _, namespace, name string) error {
+	pod, err := c.podLister.Pods(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !isRunning(pod) {
+		return nil
+	}
+	policies, err := c.fetchPolicies(namespace)
+	if err != nil {
+		return err
+	}
+	if len(policies) == 0 {
+		return c.act(ctx, pod, nil)
+	}
+	exceptions, err := utils.FetchCELPolicyExceptions(c.celpolexLister, namespace)
+	if err != nil {
+		return err
+	}
+	ns, err := c.nsLister.Get(namespace)
+	if err != nil {
+		return err
+	}
+	// verify the images actually running, tags may have been moved since the pod was admitted
+	resource, err := kubeutils.ObjToUnstructured(withRunningDigests(pod))
+	if err != nil {
+		return err
+	}
+	resource.SetAPIVersion("v1")
+	resource.SetKind("Pod")
+	gvr := corev1.SchemeGroupVersion.WithResource("pods")
+	// cached verification outcomes would hide revoked signatures and rotated keys, the digests
+	// shared by several pods are only verified once per cycle
+	results := c.scanner.ScanResource(imageverifycache.WithCycle(ctx, c.currentCycle()), *resource, gvr, "", ns, nil, nil, exceptions, policies...)
+	var responses []engineapi.EngineResponse
+	for _, result := range results {
+		if result.Error != nil {
+			logger.Error(result.Error, "failed to re-verify images")
+			continue
+		}
+		if result.EngineResponse == nil {
+			continue
+		}
+		response := *result.EngineResponse
+		if response.Policy().AsKyvernoPolicy() != nil {
+			// keep only the image verification outcomes
+			response.PolicyResponse.Rules = slices.DeleteFunc(slices.Clone(response.PolicyResponse.Rules), func(rule engineapi.RuleResponse) bool {
+				return rule.RuleType() != engineapi.ImageVerify
+			})
+		}
+		responses = append(responses, response)
+	}
+	report := reportutils.BuildImageReverificationReport(namespace, resource.GroupVersionKind(), name, pod.GetUID(), responses...)
+	if len(report.GetResults()) > 0 {
+		err := breaker.GetReportsBreaker().Do(ctx, func(ctx context.Context) error {
+			_, err := reportutils.CreateEphemeralReport(ctx, report, c.kyvernoClient)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	failures, audited := failedRules(responses)
+	if len(failures) != 0 || len(audited) != 0 {
+		logger.V(2).Info("running pod failed image re-verification", "failures", failures, "audited", audited)
+	}
+	return c.act(ctx, pod, failures)
+}
+
+// currentCycle returns the verification outcomes of the current re-verification cycle
+func (c *controller) currentCycle() *imageverifycache.Cycle {
+	if cycle := c.cycle.Load(); cycle != nil {
+		return cycle
+	}
+	return imageverifycache.NewCycle()
+}
+
+// act applies the configured action to a pod given its failed policy rules
+func (c *controller) act(ctx context.Context, pod *corev1.Pod, failures []string) error {
+	switch c.config.Action {
+	case ActionAnnotate:
+		return c.updateAnnotation(ctx, pod, failures)
+	case ActionEvict:
+		if len(failures) == 0 {
+			return nil
+		}
+		err := c.kubeClient.CoreV1().Pods(pod.Namespace).EvictV1(ctx, &policyv1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+		})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// fetchPolicies returns the policies verifying images that apply to the namespace,
+// kyverno policies are trimmed down to their image verification rules
+func (c *controller) fetchPolicies(namespace string) ([]engineapi.GenericPolicy, error) {
+	var policies []engineapi.GenericPolicy
+	cpols, err := utils.FetchClusterPolicies(c.cpolLister)
+	if err != nil {
+		return nil, err
+	}
+	pols, err := utils.FetchPolicies(c.polLister, namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, pol := range append(cpols, pols...) {
+		if pol.GetDeletionTimestamp() != nil || !pol.GetSpec().HasVerifyImages() {
+			continue
+		}
+		pol = pol.CreateDeepCopy()
+		spec := pol.GetSpec()
+		spec.Rules = slices.DeleteFunc(spec.Rules, func(rule kyvernov1.Rule) bool {
+			return !rule.HasVerifyImages()
+		})
+		policies = append(policies, engineapi.NewKyvernoPolicy(pol))
+	}
+	ivpols, err := utils.FetchImageVerificationPolicies(c.ivpolLister)
+	if err != nil {
+		return nil, err
+	}
+	for i := range ivpols {
+		policies = append(policies, engineapi.NewImageValidatingPolicy(&ivpols[i]))
+	}
+	return policies, nil
+}
+
+// updateAnnotation sets the failed policy rules annotation of the pod, the annotation is
+// removed once the pod images pass the verification again
+func (c *controller) updateAnnotation(ctx context.Context, pod *corev1.Pod, failures []string) error {
+	value := strings.Join(failures, ",")
+	current, annotated := pod.GetAnnotations()[kyverno.AnnotationImageVerifyFailed]
+	if current == value && (annotated || value == "") {
+		return nil
+	}
+	var annotation any
+	if value != "" {
+		annotation = value
+	}
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]any{
+				kyverno.AnnotationImageVerifyFailed: annotation,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.kubeClient.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// failedRules returns the sorted `policy/rule` keys of the failed image verifications of the enforcing
+// rules and of the audit rules, only the former would have blocked the pod admission
+func failedRules(responses []engineapi.EngineResponse) ([]string, []string) {
+	var failures, audited []string
+	for _, response := range responses {
+		policy := cache.MetaObjectToName(response.Policy()).String()
+		for _, rule := range response.PolicyResponse.Rules {
+			if rule.Status() != engineapi.RuleStatusFail {
+				continue
+			}
+			if enforced(response, rule.Name()) {
+				failures = append(failures, policy+"/"+rule.Name())
+			} else {
+				audited = append(audited, policy+"/"+rule.Name())
+			}
+		}
+	}
+	slices.Sort(failures)
+	slices.Sort(audited)
+	return slices.Compact(failures), slices.Compact(audited)
+}
+
+// enforced returns true if the failures of a rule block the admission requests, like in the admission
+// webhooks image validating policies enforce with the Deny action and kyverno policies with the Enforce
+// failure action of the rule, or of the policy
+func enforced(response engineapi.EngineResponse, ruleName string) bool {
+	if ivpol := response.Policy().AsImageValidatingPolicyLike(); ivpol != nil {
+		return slices.Contains(ivpol.GetSpec().ValidationActions(), admissionregistrationv1.Deny)
+	}
+	if kpol := response.Policy().AsKyvernoPolicy(); kpol != nil {
+		for _, rule := range kpol.GetSpec().Rules {
+			if rule.Name == ruleName && rule.HasVerifyImages() && rule.VerifyImages[0].FailureAction != nil {
+				return rule.VerifyImages[0].FailureAction.Enforce()
+			}
+		}
+	}
+	return response.GetValidationFailureAction().Enforce()
+}
+
+func isRunning(pod *corev1.Pod) bool {
+	return pod.DeletionTimestamp == nil && pod.Status.Phase == corev1.PodRunning
+}
+
+// withRunningDigests returns a copy of the pod with the images of the containers pinned to the digests
+// reported in the container statuses, the containers without a known digest are left unchanged
+func withRunningDigests(pod *corev1.Pod) *corev1.Pod {
+	pod = pod.DeepCopy()
+	// container names are unique across the init, regular and ephemeral containers
+	digests := map[string]string{}
+	for _, status := range slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses, pod.Status.EphemeralContainerStatuses) {
+		if _, digest, found := strings.Cut(status.ImageID, "@"); found && strings.HasPrefix(digest, "sha256:") {
+			digests[status.Name] = digest
+		}
+	}
+	pin := func(name string, image *string) {
+		if digest, ok := digests[name]; ok {
+			ref, _, _ := strings.Cut(*image, "@")
+			*image = ref + "@" + digest
+		}
+	}
+	for i := range pod.Spec.InitContainers {
+		pin(pod.Spec.InitContainers[i].Name, &pod.Spec.InitContainers[i].Image)
+	}
+	for i := range pod.Spec.Containers {
+		pin(pod.Spec.Containers[i].Name, &pod.Spec.Containers[i].Image)
+	}
+	for i := range pod.Spec.EphemeralContainers {
+		pin(pod.Spec.EphemeralContainers[i].Name, &pod.Spec.EphemeralContainers[i].Image)
+	}
+	return pod
+}
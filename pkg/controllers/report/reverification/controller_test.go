@@ -0,0 +1,345 @@
+package reverification
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/breaker"
+	kyvernofake "github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	policiesv1beta1listers "github.com/kyverno/kyverno/pkg/client/listers/policies.kyverno.io/v1beta1"
+	"github.com/kyverno/kyverno/pkg/controllers/report/utils"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubeinformers "k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+)
+
+type fakeScanner struct {
+	status   engineapi.RuleStatus
+	bypassed bool
+	cycle    *imageverifycache.Cycle
+	images   []string
+}
+
+func (s *fakeScanner) ScanResource(
+	ctx context.Context,
+	resource unstructured.Unstructured,
+	_ schema.GroupVersionResource,
+	_ string,
+	_ *corev1.Namespace,
+	_ []admissionregistrationv1.ValidatingAdmissionPolicyBinding,
+	_ []admissionregistrationv1beta1.MutatingAdmissionPolicyBinding,
+	_ []*policiesv1beta1.PolicyException,
+	policies ...engineapi.GenericPolicy,
+) map[*engineapi.GenericPolicy]utils.ScanResult {
+	s.bypassed = imageverifycache.IsBypassed(ctx)
+	s.cycle = imageverifycache.CycleFrom(ctx)
+	s.images = nil
+	containers, _, _ := unstructured.NestedSlice(resource.Object, "spec", "containers")
+	for _, container := range containers {
+		s.images = append(s.images, container.(map[string]any)["image"].(string))
+	}
+	results := map[*engineapi.GenericPolicy]utils.ScanResult{}
+	for i := range policies {
+		var rules []engineapi.RuleResponse
+		if policies[i].AsKyvernoPolicy() != nil {
+			rules = append(rules, *engineapi.RulePass("validate", engineapi.Validation, "", nil))
+		}
+		rule := engineapi.NewRuleResponse("check-signature", engineapi.ImageVerify, "", s.status, nil)
+		rules = append(rules, *rule)
+		response := engineapi.NewEngineResponse(resource, policies[i], nil).
+			WithPolicyResponse(engineapi.PolicyResponse{Rules: rules})
+		results[&policies[i]] = utils.ScanResult{EngineResponse: &response}
+	}
+	return results
+}
+
+type fixture struct {
+	kubeClient    *kubefake.Clientset
+	kyvernoClient *kyvernofake.Clientset
+	scanner       *fakeScanner
+	controller    *controller
+}
+
+func newFixture(t *testing.T, action Action, status engineapi.RuleStatus, pod *corev1.Pod) *fixture {
+	return newFixtureWithFailureAction(t, action, status, pod, kyvernov1.Enforce)
+}
+
+func newFixtureWithFailureAction(t *testing.T, action Action, status engineapi.RuleStatus, pod *corev1.Pod, failureAction kyvernov1.ValidationFailureAction) *fixture {
+	breaker.SetReportsBreaker(breaker.NewBreaker("test", func(context.Context) bool { return false }))
+	_ = reportutils.NewReportingConfig([]string{"pass", "fail", "warn", "error", "skip"}, "validate", "imageVerify")
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+	cpol := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "verify"},
+		Spec: kyvernov1.Spec{
+			Rules: []kyvernov1.Rule{{
+				Name:         "check-signature",
+				VerifyImages: []kyvernov1.ImageVerification{{ImageReferences: []string{"*"}, FailureAction: &failureAction}},
+			}, {
+				Name: "validate",
+			}},
+		},
+	}
+	kubeClient := kubefake.NewSimpleClientset(pod)
+	kubeClient.PrependReactor("create", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return action.GetSubresource() == "eviction", nil, nil
+	})
+	podInformer := kubeinformers.NewSharedInformerFactory(kubeClient, 0).Core().V1().Pods()
+	assert.NoError(t, podInformer.Informer().GetIndexer().Add(pod))
+	nsIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NoError(t, nsIndexer.Add(ns))
+	cpolIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NoError(t, cpolIndexer.Add(cpol))
+	polIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	ivpolIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	celpolexIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	kyvernoClient := kyvernofake.NewSimpleClientset()
+	scanner := &fakeScanner{status: status}
+	c := NewController(
+		kubeClient,
+		kyvernoClient,
+		scanner,
+		podInformer,
+		corev1listers.NewNamespaceLister(nsIndexer),
+		kyvernov1listers.NewClusterPolicyLister(cpolIndexer),
+		kyvernov1listers.NewPolicyLister(polIndexer),
+		policiesv1beta1listers.NewImageValidatingPolicyLister(ivpolIndexer),
+		policiesv1beta1listers.NewPolicyExceptionLister(celpolexIndexer),
+		Config{Action: action},
+	)
+	return &fixture{
+		kubeClient:    kubeClient,
+		kyvernoClient: kyvernoClient,
+		scanner:       scanner,
+		controller:    c.(*controller),
+	}
+}
+
+func newPod(annotations map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default", UID: "uid", Annotations: annotations},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}}},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func TestParseAction(t *testing.T) {
+	for _, action := range []string{"report", "annotate", "evict"} {
+		parsed, err := ParseAction(action)
+		assert.NoError(t, err)
+		assert.Equal(t, Action(action), parsed)
+	}
+	_, err := ParseAction("delete")
+	assert.Error(t, err)
+}
+
+func TestReconcile_Report(t *testing.T) {
+	f := newFixture(t, ActionReport, engineapi.RuleStatusFail, newPod(nil))
+	assert.NoError(t, f.controller.reconcile(context.TODO(), logr.Discard(), "default/nginx", "default", "nginx"))
+	assert.True(t, f.scanner.bypassed)
+	assert.Equal(t, []string{"nginx"}, f.scanner.images)
+
+	reports, err := f.kyvernoClient.ReportsV1().EphemeralReports("default").List(context.TODO(), metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, reports.Items, 1)
+	report := reports.Items[0]
+	assert.Equal(t, "image-reverification", report.Labels["audit.kyverno.io/source"])
+	assert.Equal(t, "uid", report.Labels["audit.kyverno.io/resource.uid"])
+	// only the image verification outcomes are reported
+	assert.Len(t, report.Spec.Results, 1)
+	assert.Equal(t, "check-signature", report.Spec.Results[0].Rule)
+
+	pod, err := f.kubeClient.CoreV1().Pods("default").Get(context.TODO(), "nginx", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, pod.Annotations)
+}
+
+func TestReconcile_Annotate(t *testing.T) {
+	f := newFixture(t, ActionAnnotate, engineapi.RuleStatusFail, newPod(nil))
+	assert.NoError(t, f.controller.reconcile(context.TODO(), logr.Discard(), "default/nginx", "default", "nginx"))
+	pod, err := f.kubeClient.CoreV1().Pods("default").Get(context.TODO(), "nginx", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "verify/check-signature", pod.Annotations[kyverno.AnnotationImageVerifyFailed])
+
+	// the annotation is removed once the images pass the verification again
+	f = newFixture(t, ActionAnnotate, engineapi.RuleStatusPass, newPod(map[string]string{kyverno.AnnotationImageVerifyFailed: "verify/check-signature"}))
+	assert.NoError(t, f.controller.reconcile(context.TODO(), logr.Discard(), "default/nginx", "default", "nginx"))
+	pod, err = f.kubeClient.CoreV1().Pods("default").Get(context.TODO(), "nginx", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.NotContains(t, pod.Annotations, kyverno.AnnotationImageVerifyFailed)
+}
+
+func TestReconcile_Evict(t *testing.T) {
+	evictions := func(f *fixture) int {
+		count := 0
+		for _, action := range f.kubeClient.Actions() {
+			if action.GetVerb() == "create" && action.GetSubresource() == "eviction" {
+				count++
+			}
+		}
+		return count
+	}
+	f := newFixture(t, ActionEvict, engineapi.RuleStatusPass, newPod(nil))
+	assert.NoError(t, f.controller.reconcile(context.TODO(), logr.Discard(), "default/nginx", "default", "nginx"))
+	assert.Equal(t, 0, evictions(f))
+
+	f = newFixture(t, ActionEvict, engineapi.RuleStatusFail, newPod(nil))
+	assert.NoError(t, f.controller.reconcile(context.TODO(), logr.Discard(), "default/nginx", "default", "nginx"))
+	assert.Equal(t, 1, evictions(f))
+}
+
+func TestReconcile_Audit(t *testing.T) {
+	// the failures of audit rules are reported, the pods are neither annotated nor evicted
+	f := newFixtureWithFailureAction(t, ActionAnnotate, engineapi.RuleStatusFail, newPod(nil), kyvernov1.Audit)
+	assert.NoError(t, f.controller.reconcile(context.TODO(), logr.Discard(), "default/nginx", "default", "nginx"))
+	reports, err := f.kyvernoClient.ReportsV1().EphemeralReports("default").List(context.TODO(), metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, reports.Items, 1)
+	pod, err := f.kubeClient.CoreV1().Pods("default").Get(context.TODO(), "nginx", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.NotContains(t, pod.Annotations, kyverno.AnnotationImageVerifyFailed)
+
+	f = newFixtureWithFailureAction(t, ActionEvict, engineapi.RuleStatusFail, newPod(nil), kyvernov1.Audit)
+	assert.NoError(t, f.controller.reconcile(context.TODO(), logr.Discard(), "default/nginx", "default", "nginx"))
+	for _, action := range f.kubeClient.Actions() {
+		assert.NotEqual(t, "eviction", action.GetSubresource())
+	}
+}
+
+func Test_failedRules(t *testing.T) {
+	resource := unstructured.Unstructured{}
+	newResponse := func(policy engineapi.GenericPolicy) engineapi.EngineResponse {
+		return engineapi.NewEngineResponse(resource, policy, nil).WithPolicyResponse(engineapi.PolicyResponse{Rules: []engineapi.RuleResponse{
+			*engineapi.RuleFail("check-signature", engineapi.ImageVerify, "", nil),
+			*engineapi.RulePass("check-attestation", engineapi.ImageVerify, "", nil),
+		}})
+	}
+	newIvpol := func(name string, actions ...admissionregistrationv1.ValidationAction) engineapi.GenericPolicy {
+		return engineapi.NewImageValidatingPolicy(&policiesv1beta1.ImageValidatingPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       policiesv1beta1.ImageValidatingPolicySpec{ValidationAction: actions},
+		})
+	}
+	newCpol := func(name string, policyAction kyvernov1.ValidationFailureAction, ruleAction *kyvernov1.ValidationFailureAction) engineapi.GenericPolicy {
+		return engineapi.NewKyvernoPolicy(&kyvernov1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: kyvernov1.Spec{
+				ValidationFailureAction: policyAction,
+				Rules: []kyvernov1.Rule{{
+					Name:         "check-signature",
+					VerifyImages: []kyvernov1.ImageVerification{{ImageReferences: []string{"*"}, FailureAction: ruleAction}},
+				}},
+			},
+		})
+	}
+	failures, audited := failedRules([]engineapi.EngineResponse{
+		newResponse(newIvpol("ivpol-deny", admissionregistrationv1.Deny)),
+		newResponse(newIvpol("ivpol-audit", admissionregistrationv1.Audit, admissionregistrationv1.Warn)),
+		newResponse(newCpol("cpol-enforce", kyvernov1.Audit, ptr.To(kyvernov1.Enforce))),
+		newResponse(newCpol("cpol-audit", kyvernov1.Enforce, ptr.To(kyvernov1.Audit))),
+		newResponse(newCpol("cpol-policy-enforce", kyvernov1.Enforce, nil)),
+		newResponse(newCpol("cpol-policy-audit", kyvernov1.Audit, nil)),
+	})
+	assert.Equal(t, []string{"cpol-enforce/check-signature", "cpol-policy-enforce/check-signature", "ivpol-deny/check-signature"}, failures)
+	assert.Equal(t, []string{"cpol-audit/check-signature", "cpol-policy-audit/check-signature", "ivpol-audit/check-signature"}, audited)
+}
+
+func TestReconcile_NotRunning(t *testing.T) {
+	pod := newPod(nil)
+	pod.Status.Phase = corev1.PodSucceeded
+	f := newFixture(t, ActionEvict, engineapi.RuleStatusFail, pod)
+	assert.NoError(t, f.controller.reconcile(context.TODO(), logr.Discard(), "default/nginx", "default", "nginx"))
+	assert.False(t, f.scanner.bypassed)
+	assert.Equal(t, 0, len(f.kyvernoClient.Actions()))
+}
+
+func TestReconcile_RunningDigests(t *testing.T) {
+	pod := newPod(nil)
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "nginx", ImageID: "docker.io/library/nginx@sha256:abc"}}
+	f := newFixture(t, ActionReport, engineapi.RuleStatusPass, pod)
+	assert.NoError(t, f.controller.reconcile(context.TODO(), logr.Discard(), "default/nginx", "default", "nginx"))
+	assert.Equal(t, []string{"nginx@sha256:abc"}, f.scanner.images)
+}
+
+func TestReconcile_Cycle(t *testing.T) {
+	f := newFixture(t, ActionReport, engineapi.RuleStatusPass, newPod(nil))
+	assert.NoError(t, f.controller.enqueuePods())
+	cycle := f.controller.currentCycle()
+	assert.NoError(t, f.controller.reconcile(context.TODO(), logr.Discard(), "default/nginx", "default", "nginx"))
+	// the outcomes are shared within a cycle
+	assert.Same(t, cycle, f.scanner.cycle)
+	assert.Same(t, cycle, f.controller.currentCycle())
+	// and reset for the next one
+	assert.NoError(t, f.controller.enqueuePods())
+	assert.NotSame(t, cycle, f.controller.currentCycle())
+}
+
+func Test_withRunningDigests(t *testing.T) {
+	tests := []struct {
+		name     string
+		image    string
+		imageID  string
+		expected string
+	}{{
+		name:     "tag",
+		image:    "ghcr.io/org/app:v1",
+		imageID:  "ghcr.io/org/app@sha256:abc",
+		expected: "ghcr.io/org/app:v1@sha256:abc",
+	}, {
+		name:     "digest",
+		image:    "ghcr.io/org/app@sha256:old",
+		imageID:  "docker-pullable://ghcr.io/org/app@sha256:abc",
+		expected: "ghcr.io/org/app@sha256:abc",
+	}, {
+		name:     "image id without digest",
+		image:    "ghcr.io/org/app:v1",
+		imageID:  "sha256:abc",
+		expected: "ghcr.io/org/app:v1",
+	}, {
+		name:     "no status",
+		image:    "ghcr.io/org/app:v1",
+		expected: "ghcr.io/org/app:v1",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				Spec: corev1.PodSpec{
+					InitContainers:      []corev1.Container{{Name: "init", Image: tt.image}},
+					Containers:          []corev1.Container{{Name: "app", Image: tt.image}},
+					EphemeralContainers: []corev1.EphemeralContainer{{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debug", Image: tt.image}}},
+				},
+			}
+			if tt.imageID != "" {
+				pod.Status = corev1.PodStatus{
+					InitContainerStatuses:      []corev1.ContainerStatus{{Name: "init", ImageID: tt.imageID}},
+					ContainerStatuses:          []corev1.ContainerStatus{{Name: "app", ImageID: tt.imageID}},
+					EphemeralContainerStatuses: []corev1.ContainerStatus{{Name: "debug", ImageID: tt.imageID}},
+				}
+			}
+			pinned := withRunningDigests(pod)
+			assert.Equal(t, tt.expected, pinned.Spec.InitContainers[0].Image)
+			assert.Equal(t, tt.expected, pinned.Spec.Containers[0].Image)
+			assert.Equal(t, tt.expected, pinned.Spec.EphemeralContainers[0].Image)
+			// the pod from the informer cache is not modified
+			assert.Equal(t, tt.image, pod.Spec.Containers[0].Image)
+		})
+	}
+}
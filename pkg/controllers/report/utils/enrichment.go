@@ -0,0 +1,161 @@
+package utils
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	openreportsv1alpha1 "github.com/openreports/reports-api/apis/openreports.io/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+const (
+	// PropertyOwnerAPIVersion is the result property holding the api version of the top-level owner
+	PropertyOwnerAPIVersion = "owner.apiVersion"
+	// PropertyOwnerKind is the result property holding the kind of the top-level owner
+	PropertyOwnerKind = "owner.kind"
+	// PropertyOwnerName is the result property holding the name of the top-level owner
+	PropertyOwnerName = "owner.name"
+	// PropertyNamespaceLabelPrefix prefixes the result properties holding namespace labels
+	PropertyNamespaceLabelPrefix = "namespace."
+
+	maxOwnerDepth  = 10
+	ownerCacheSize = 10000
+	ownerCacheTTL  = 10 * time.Minute
+)
+
+// EnrichmentConfig configures the metadata added to the report results
+type EnrichmentConfig struct {
+	// NamespaceLabels are the labels of the resource namespace copied to the results
+	NamespaceLabels []string
+	// TopLevelOwner adds the top-level owner of the resource, resolved via owner references, to the results
+	TopLevelOwner bool
+}
+
+// Enabled returns true if the config adds metadata to the results
+func (c EnrichmentConfig) Enabled() bool {
+	return len(c.NamespaceLabels) != 0 || c.TopLevelOwner
+}
+
+// Enricher adds resource metadata to the report results of a resource
+type Enricher interface {
+	Enrich(ctx context.Context, logger logr.Logger, resource corev1.ObjectReference, results []openreportsv1alpha1.ReportResult) []openreportsv1alpha1.ReportResult
+}
+
+type enricher struct {
+	config   EnrichmentConfig
+	client   dclient.Interface
+	nsLister corev1listers.NamespaceLister
+	owners   *cache.LRUExpireCache
+}
+
+// NewEnricher returns an enricher for the given config, nil is returned when the config doesn't enrich results
+func NewEnricher(config EnrichmentConfig, client dclient.Interface, nsLister corev1listers.NamespaceLister) Enricher {
+	if !config.Enabled() {
+		return nil
+	}
+	return &enricher{
+		config:   config,
+		client:   client,
+		nsLister: nsLister,
+		owners:   cache.NewLRUExpireCache(ownerCacheSize),
+	}
+}
+
+func (e *enricher) Enrich(ctx context.Context, logger logr.Logger, resource corev1.ObjectReference, results []openreportsv1alpha1.ReportResult) []openreportsv1alpha1.ReportResult {
+	properties := e.properties(ctx, logger, resource)
+	if len(properties) == 0 {
+		return results
+	}
+	enriched := make([]openreportsv1alpha1.ReportResult, 0, len(results))
+	for _, result := range results {
+		// results may share their properties with the reports they come from
+		result.Properties = maps.Clone(result.Properties)
+		if result.Properties == nil {
+			result.Properties = map[string]string{}
+		}
+		maps.Copy(result.Properties, properties)
+		enriched = append(enriched, result)
+	}
+	return enriched
+}
+
+func (e *enricher) properties(ctx context.Context, logger logr.Logger, resource corev1.ObjectReference) map[string]string {
+	properties := map[string]string{}
+	if len(e.config.NamespaceLabels) != 0 && resource.Namespace != "" && e.nsLister != nil {
+		namespace, err := e.nsLister.Get(resource.Namespace)
+		if err != nil {
+			logger.V(4).Info("failed to get namespace", "namespace", resource.Namespace, "error", err)
+		} else {
+			for _, label := range e.config.NamespaceLabels {
+				if value, ok := namespace.Labels[label]; ok {
+					properties[PropertyNamespaceLabelPrefix+label] = value
+				}
+			}
+		}
+	}
+	if e.config.TopLevelOwner && e.client != nil {
+		owner := e.topLevelOwner(ctx, logger, resource)
+		properties[PropertyOwnerAPIVersion] = owner.APIVersion
+		properties[PropertyOwnerKind] = owner.Kind
+		properties[PropertyOwnerName] = owner.Name
+	}
+	return properties
+}
+
+// topLevelOwner walks up the controller owner references of a resource and returns the top-level owner,
+// the resource itself is returned when it has no owner. The walk stops at the last owner that could be fetched.
+func (e *enricher) topLevelOwner(ctx context.Context, logger logr.Logger, resource corev1.ObjectReference) corev1.ObjectReference {
+	if resource.UID != "" {
+		if owner, ok := e.owners.Get(resource.UID); ok {
+			return owner.(corev1.ObjectReference)
+		}
+	}
+	current := resource
+	// don't cache owners resolved after an unexpected error, they may be incomplete
+	cacheable := true
+	for range maxOwnerDepth {
+		obj, err := e.client.GetResource(ctx, current.APIVersion, current.Kind, current.Namespace, current.Name)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				logger.V(4).Info("failed to get owner", "kind", current.Kind, "namespace", current.Namespace, "name", current.Name, "error", err)
+				cacheable = false
+			}
+			break
+		}
+		ref := controllerOf(obj.GetOwnerReferences())
+		if ref == nil {
+			break
+		}
+		current = corev1.ObjectReference{
+			APIVersion: ref.APIVersion,
+			Kind:       ref.Kind,
+			Namespace:  resource.Namespace,
+			Name:       ref.Name,
+			UID:        ref.UID,
+		}
+	}
+	if cacheable && resource.UID != "" {
+		e.owners.Add(resource.UID, current, ownerCacheTTL)
+	}
+	return current
+}
+
+// controllerOf returns the controller owner reference, or the only owner reference if none is marked as controller
+func controllerOf(refs []metav1.OwnerReference) *metav1.OwnerReference {
+	for i := range refs {
+		if refs[i].Controller != nil && *refs[i].Controller {
+			return &refs[i]
+		}
+	}
+	if len(refs) == 1 {
+		return &refs[0]
+	}
+	return nil
+}
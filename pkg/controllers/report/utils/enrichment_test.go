@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	openreportsv1alpha1 "github.com/openreports/reports-api/apis/openreports.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+)
+
+func newEnrichmentClient(t *testing.T) dclient.Interface {
+	deployment := &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a", UID: "deployment-uid"},
+	}
+	replicaSet := &appsv1.ReplicaSet{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "ReplicaSet"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-6d4cf56db6",
+			Namespace: "team-a",
+			UID:       "replicaset-uid",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "web",
+				UID:        "deployment-uid",
+				Controller: ptr.To(true),
+			}},
+		},
+	}
+	pod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-6d4cf56db6-x2x9z",
+			Namespace: "team-a",
+			UID:       "pod-uid",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "ReplicaSet",
+				Name:       "web-6d4cf56db6",
+				UID:        "replicaset-uid",
+				Controller: ptr.To(true),
+			}},
+		},
+	}
+	orphan := &corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "team-a", UID: "orphan-uid"},
+	}
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{}, deployment, replicaSet, pod, orphan)
+	assert.NoError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient([]schema.GroupVersionResource{
+		{Version: "v1", Resource: "pods"},
+		{Group: "apps", Version: "v1", Resource: "replicasets"},
+	}))
+	return client
+}
+
+func newNamespaceLister(namespaces ...*corev1.Namespace) corev1listers.NamespaceLister {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, namespace := range namespaces {
+		_ = indexer.Add(namespace)
+	}
+	return corev1listers.NewNamespaceLister(indexer)
+}
+
+func TestNewEnricher_disabled(t *testing.T) {
+	assert.Nil(t, NewEnricher(EnrichmentConfig{}, nil, nil))
+}
+
+func TestEnricher_Enrich(t *testing.T) {
+	nsLister := newNamespaceLister(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "team-a",
+			Labels: map[string]string{"team": "payments", "env": "prod"},
+		},
+	})
+	enricher := NewEnricher(EnrichmentConfig{
+		NamespaceLabels: []string{"team", "cost-center"},
+		TopLevelOwner:   true,
+	}, newEnrichmentClient(t), nsLister)
+	shared := map[string]string{"process": "background scan"}
+	results := []openreportsv1alpha1.ReportResult{{Policy: "require-labels", Properties: shared}, {Policy: "disallow-latest"}}
+
+	enriched := enricher.Enrich(context.TODO(), logr.Discard(), corev1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Namespace:  "team-a",
+		Name:       "web-6d4cf56db6-x2x9z",
+		UID:        "pod-uid",
+	}, results)
+	assert.Len(t, enriched, 2)
+	for _, result := range enriched {
+		assert.Equal(t, "payments", result.Properties["namespace.team"])
+		assert.NotContains(t, result.Properties, "namespace.cost-center")
+		assert.NotContains(t, result.Properties, "namespace.env")
+		assert.Equal(t, "apps/v1", result.Properties[PropertyOwnerAPIVersion])
+		assert.Equal(t, "Deployment", result.Properties[PropertyOwnerKind])
+		assert.Equal(t, "web", result.Properties[PropertyOwnerName])
+	}
+	assert.Equal(t, "background scan", enriched[0].Properties["process"])
+	// the original properties are left untouched
+	assert.Equal(t, map[string]string{"process": "background scan"}, shared)
+}
+
+func TestEnricher_topLevelOwner(t *testing.T) {
+	e := NewEnricher(EnrichmentConfig{TopLevelOwner: true}, newEnrichmentClient(t), nil).(*enricher)
+	tests := []struct {
+		name     string
+		resource corev1.ObjectReference
+		want     string
+	}{{
+		name:     "owned pod",
+		resource: corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "team-a", Name: "web-6d4cf56db6-x2x9z", UID: "pod-uid"},
+		want:     "web",
+	}, {
+		name:     "orphan pod",
+		resource: corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "team-a", Name: "debug", UID: "orphan-uid"},
+		want:     "debug",
+	}, {
+		name:     "missing resource",
+		resource: corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "team-a", Name: "gone", UID: "gone-uid"},
+		want:     "gone",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner := e.topLevelOwner(context.TODO(), logr.Discard(), tt.resource)
+			assert.Equal(t, tt.want, owner.Name)
+			// the owner is cached
+			cached, ok := e.owners.Get(tt.resource.UID)
+			assert.True(t, ok)
+			assert.Equal(t, owner, cached)
+		})
+	}
+}
+
+func Test_controllerOf(t *testing.T) {
+	assert.Nil(t, controllerOf(nil))
+	assert.Equal(t, "a", controllerOf([]metav1.OwnerReference{{Name: "a"}}).Name)
+	assert.Equal(t, "b", controllerOf([]metav1.OwnerReference{{Name: "a"}, {Name: "b", Controller: ptr.To(true)}}).Name)
+	assert.Nil(t, controllerOf([]metav1.OwnerReference{{Name: "a"}, {Name: "b"}}))
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+// OwnerResolver resolves the top-level owner of a resource
+type OwnerResolver interface {
+	// TopLevelOwner walks up the controller owner references of a resource and returns the top-level owner,
+	// the resource itself is returned when it has no owner. The walk stops at the last owner that could be fetched.
+	TopLevelOwner(ctx context.Context, logger logr.Logger, resource corev1.ObjectReference) corev1.ObjectReference
+}
+
+// NewOwnerResolver returns an owner resolver walking the owners the same way the enrichment does,
+// resolved owners are cached by resource uid
+func NewOwnerResolver(client dclient.Interface) OwnerResolver {
+	return &enricher{
+		client: client,
+		owners: cache.NewLRUExpireCache(ownerCacheSize),
+	}
+}
+
+func (e *enricher) TopLevelOwner(ctx context.Context, logger logr.Logger, resource corev1.ObjectReference) corev1.ObjectReference {
+	return e.topLevelOwner(ctx, logger, resource)
+}
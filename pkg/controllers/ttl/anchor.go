@@ -0,0 +1,226 @@
+package ttl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/metadata"
+)
+
+// idleRecheckInterval is the interval at which the pods selected by an idle resource are checked
+const idleRecheckInterval = time.Minute
+
+var (
+	jobsResource = schema.GroupResource{Group: "batch", Resource: "jobs"}
+	podsResource = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+)
+
+// Anchor computes the time a TTL duration is relative to
+type Anchor interface {
+	// Time returns the anchor time of an object, reached is false while the event the anchor waits for did not happen
+	Time(ctx context.Context, obj metav1.Object) (anchor time.Time, reached bool, err error)
+	// RecheckAfter returns the interval after which the anchor must be evaluated again, zero when the changes
+	// of the anchor are reported by the watch on the object
+	RecheckAfter() time.Duration
+}
+
+// newAnchors returns the anchors available for a resource, selected with the `cleanup.kyverno.io/ttl-anchor` annotation
+func newAnchors(metadataClient metadata.Interface, dynamicClient dynamic.Interface, gvr schema.GroupVersionResource) map[string]Anchor {
+	return map[string]Anchor{
+		kyverno.ValueTtlAnchorCreation:      creationAnchor{},
+		kyverno.ValueTtlAnchorLastUpdate:    lastUpdateAnchor{},
+		kyverno.ValueTtlAnchorJobCompletion: jobCompletionAnchor{client: dynamicClient, gvr: gvr},
+		kyverno.ValueTtlAnchorIdle:          idleAnchor{client: dynamicClient, metadataClient: metadataClient, gvr: gvr},
+	}
+}
+
+// creationAnchor is the default anchor, the TTL is relative to the creation of the object
+type creationAnchor struct{}
+
+func (creationAnchor) Time(_ context.Context, obj metav1.Object) (time.Time, bool, error) {
+	return obj.GetCreationTimestamp().Time, true, nil
+}
+
+func (creationAnchor) RecheckAfter() time.Duration { return 0 }
+
+// lastUpdateAnchor makes the TTL relative to the last update of the object, taken from the
+// `cleanup.kyverno.io/last-update` annotation when set or from the managed fields of the object
+// itself (not of its subresources) otherwise
+type lastUpdateAnchor struct{}
+
+func (lastUpdateAnchor) Time(_ context.Context, obj metav1.Object) (time.Time, bool, error) {
+	if value, ok := obj.GetAnnotations()[kyverno.AnnotationCleanupLastUpdate]; ok {
+		lastUpdate, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("failed to parse %s annotation: %w", kyverno.AnnotationCleanupLastUpdate, err)
+		}
+		return lastUpdate, true, nil
+	}
+	lastUpdate := obj.GetCreationTimestamp().Time
+	for _, entry := range obj.GetManagedFields() {
+		// status and other subresource updates are made by controllers, not by the users of the object
+		if entry.Subresource != "" {
+			continue
+		}
+		if entry.Time != nil && entry.Time.After(lastUpdate) {
+			lastUpdate = entry.Time.Time
+		}
+	}
+	return lastUpdate, true, nil
+}
+
+func (lastUpdateAnchor) RecheckAfter() time.Duration { return 0 }
+
+// jobCompletionAnchor makes the TTL relative to the completion time of a job, or to the time it
+// failed as failed jobs have no completion time, the status updates of the job are reported by the watch
+type jobCompletionAnchor struct {
+	client dynamic.Interface
+	gvr    schema.GroupVersionResource
+}
+
+func (a jobCompletionAnchor) Time(ctx context.Context, obj metav1.Object) (time.Time, bool, error) {
+	if a.gvr.GroupResource() != jobsResource {
+		return time.Time{}, false, fmt.Errorf("the %s anchor only applies to jobs", kyverno.ValueTtlAnchorJobCompletion)
+	}
+	job, err := a.client.Resource(a.gvr).Namespace(obj.GetNamespace()).Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	value, found, err := unstructured.NestedString(job.Object, "status", "completionTime")
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if !found {
+		value, found, err = failedTime(job)
+		if err != nil || !found {
+			return time.Time{}, false, err
+		}
+	}
+	completionTime, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return completionTime, true, nil
+}
+
+func (jobCompletionAnchor) RecheckAfter() time.Duration { return 0 }
+
+// failedTime returns the last transition time of the true Failed condition of a job
+func failedTime(job *unstructured.Unstructured) (string, bool, error) {
+	conditions, _, err := unstructured.NestedSlice(job.Object, "status", "conditions")
+	if err != nil {
+		return "", false, err
+	}
+	for _, condition := range conditions {
+		condition, ok := condition.(map[string]any)
+		if !ok || condition["type"] != "Failed" || condition["status"] != "True" {
+			continue
+		}
+		value, ok := condition["lastTransitionTime"].(string)
+		return value, ok, nil
+	}
+	return "", false, nil
+}
+
+// idleAnchor makes the TTL relative to the time since which the pod selector of the object (a service,
+// a deployment, ...) matches no pod, the start of the idle period is recorded in the
+// `cleanup.kyverno.io/idle-since` annotation and removed as soon as a pod is selected again
+type idleAnchor struct {
+	client         dynamic.Interface
+	metadataClient metadata.Interface
+	gvr            schema.GroupVersionResource
+}
+
+func (a idleAnchor) Time(ctx context.Context, obj metav1.Object) (time.Time, bool, error) {
+	resource, err := a.client.Resource(a.gvr).Namespace(obj.GetNamespace()).Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	selector, err := podSelector(resource)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	pods, err := a.metadataClient.Resource(podsResource).Namespace(obj.GetNamespace()).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+		Limit:         1,
+	})
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	idleSince, idle := obj.GetAnnotations()[kyverno.AnnotationCleanupIdleSince]
+	if len(pods.Items) != 0 {
+		if idle {
+			return time.Time{}, false, a.setIdleSince(ctx, obj, nil)
+		}
+		return time.Time{}, false, nil
+	}
+	if idle {
+		if since, err := time.Parse(time.RFC3339, idleSince); err == nil {
+			return since, true, nil
+		}
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	value := now.Format(time.RFC3339)
+	if err := a.setIdleSince(ctx, obj, &value); err != nil {
+		return time.Time{}, false, err
+	}
+	return now, true, nil
+}
+
+func (idleAnchor) RecheckAfter() time.Duration { return idleRecheckInterval }
+
+// setIdleSince sets the idle annotation of an object, the annotation is removed when value is nil
+func (a idleAnchor) setIdleSince(ctx context.Context, obj metav1.Object, value *string) error {
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]any{
+				kyverno.AnnotationCleanupIdleSince: value,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = a.metadataClient.Resource(a.gvr).Namespace(obj.GetNamespace()).Patch(ctx, obj.GetName(), types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// podSelector returns the pod selector of a resource, from its `spec.selector` field which is either a label
+// selector (deployments, jobs, ...) or a map of labels (services, replication controllers)
+func podSelector(resource *unstructured.Unstructured) (labels.Selector, error) {
+	selector, found, err := unstructured.NestedMap(resource.Object, "spec", "selector")
+	if err != nil {
+		return nil, err
+	}
+	if !found || len(selector) == 0 {
+		return nil, fmt.Errorf("the %s anchor requires a resource with a pod selector", kyverno.ValueTtlAnchorIdle)
+	}
+	_, hasLabels := selector["matchLabels"]
+	_, hasExpressions := selector["matchExpressions"]
+	if hasLabels || hasExpressions {
+		var labelSelector metav1.LabelSelector
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(selector, &labelSelector); err != nil {
+			return nil, err
+		}
+		return metav1.LabelSelectorAsSelector(&labelSelector)
+	}
+	set := labels.Set{}
+	for key, value := range selector {
+		value, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid pod selector value for key %s", key)
+		}
+		set[key] = value
+	}
+	return labels.SelectorFromSet(set), nil
+}
@@ -0,0 +1,175 @@
+package ttl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	metafake "k8s.io/client-go/metadata/fake"
+)
+
+var (
+	jobs     = schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}
+	services = schema.GroupVersionResource{Version: "v1", Resource: "services"}
+)
+
+func newUnstructured(apiVersion, kind, name string, fields map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: fields}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetName(name)
+	obj.SetNamespace("default")
+	return obj
+}
+
+func newPodMetadata(name string, labels map[string]string) *metav1.PartialObjectMetadata {
+	return &metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+	}
+}
+
+func TestLastUpdateAnchor(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	updated := created.Add(time.Hour)
+	obj := &metav1.ObjectMeta{
+		CreationTimestamp: metav1.NewTime(created),
+		ManagedFields: []metav1.ManagedFieldsEntry{
+			{Manager: "kubectl", Time: &metav1.Time{Time: updated}},
+			{Manager: "kube-controller-manager", Time: &metav1.Time{Time: created}},
+			// status updates are ignored
+			{Manager: "kube-controller-manager", Subresource: "status", Time: &metav1.Time{Time: updated.Add(time.Hour)}},
+		},
+	}
+	anchor, reached, err := lastUpdateAnchor{}.Time(context.Background(), obj)
+	assert.NoError(t, err)
+	assert.True(t, reached)
+	assert.Equal(t, updated, anchor)
+
+	obj.SetAnnotations(map[string]string{kyverno.AnnotationCleanupLastUpdate: "2026-02-01T00:00:00Z"})
+	anchor, reached, err = lastUpdateAnchor{}.Time(context.Background(), obj)
+	assert.NoError(t, err)
+	assert.True(t, reached)
+	assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), anchor)
+}
+
+func TestJobCompletionAnchor(t *testing.T) {
+	running := newUnstructured("batch/v1", "Job", "running", map[string]any{})
+	completed := newUnstructured("batch/v1", "Job", "completed", map[string]any{
+		"status": map[string]any{"completionTime": "2026-01-01T10:00:00Z"},
+	})
+	failed := newUnstructured("batch/v1", "Job", "failed", map[string]any{
+		"status": map[string]any{"conditions": []any{
+			map[string]any{"type": "FailureTarget", "status": "True", "lastTransitionTime": "2026-01-01T10:00:00Z"},
+			map[string]any{"type": "Failed", "status": "True", "lastTransitionTime": "2026-01-01T11:00:00Z"},
+		}},
+	})
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), running, completed, failed)
+	anchor := jobCompletionAnchor{client: client, gvr: jobs}
+
+	_, reached, err := anchor.Time(context.Background(), running)
+	assert.NoError(t, err)
+	assert.False(t, reached)
+
+	completionTime, reached, err := anchor.Time(context.Background(), completed)
+	assert.NoError(t, err)
+	assert.True(t, reached)
+	assert.Equal(t, time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC), completionTime)
+
+	// failed jobs have no completion time
+	failedTime, reached, err := anchor.Time(context.Background(), failed)
+	assert.NoError(t, err)
+	assert.True(t, reached)
+	assert.Equal(t, time.Date(2026, 1, 1, 11, 0, 0, 0, time.UTC), failedTime)
+
+	_, _, err = jobCompletionAnchor{client: client, gvr: services}.Time(context.Background(), running)
+	assert.Error(t, err)
+}
+
+func TestIdleAnchor(t *testing.T) {
+	service := newUnstructured("v1", "Service", "web", map[string]any{
+		"spec": map[string]any{"selector": map[string]any{"app": "web"}},
+	})
+	serviceMetadata := &metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+	}
+	scheme := metafake.NewTestScheme()
+	assert.NoError(t, metav1.AddMetaToScheme(scheme))
+	metadataClient := metafake.NewSimpleMetadataClient(scheme, serviceMetadata, newPodMetadata("web", map[string]string{"app": "web"}))
+	anchor := idleAnchor{
+		client:         dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), service),
+		metadataClient: metadataClient,
+		gvr:            services,
+	}
+	assert.Equal(t, idleRecheckInterval, anchor.RecheckAfter())
+
+	// a pod is selected, the service is not idle
+	_, reached, err := anchor.Time(context.Background(), service)
+	assert.NoError(t, err)
+	assert.False(t, reached)
+
+	// no pod is selected, the idle period starts
+	assert.NoError(t, metadataClient.Resource(podsResource).Namespace("default").Delete(context.Background(), "web", metav1.DeleteOptions{}))
+	idleSince, reached, err := anchor.Time(context.Background(), service)
+	assert.NoError(t, err)
+	assert.True(t, reached)
+	patched, err := metadataClient.Resource(services).Namespace("default").Get(context.Background(), "web", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, idleSince.Format(time.RFC3339), patched.GetAnnotations()[kyverno.AnnotationCleanupIdleSince])
+
+	// the recorded idle period is reused
+	since, reached, err := anchor.Time(context.Background(), patched)
+	assert.NoError(t, err)
+	assert.True(t, reached)
+	assert.Equal(t, idleSince, since)
+}
+
+func TestPodSelector(t *testing.T) {
+	deployment := newUnstructured("apps/v1", "Deployment", "web", map[string]any{
+		"spec": map[string]any{"selector": map[string]any{"matchLabels": map[string]any{"app": "web"}}},
+	})
+	selector, err := podSelector(deployment)
+	assert.NoError(t, err)
+	assert.Equal(t, "app=web", selector.String())
+
+	service := newUnstructured("v1", "Service", "web", map[string]any{
+		"spec": map[string]any{"selector": map[string]any{"app": "web"}},
+	})
+	selector, err = podSelector(service)
+	assert.NoError(t, err)
+	assert.Equal(t, "app=web", selector.String())
+
+	_, err = podSelector(newUnstructured("v1", "ConfigMap", "data", map[string]any{}))
+	assert.Error(t, err)
+}
+
+func TestGetDeletionTime(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := &controller{anchors: newAnchors(nil, nil, jobs)}
+	obj := &metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)}
+
+	deletionTime, reached, recheckAfter, err := c.getDeletionTime(context.Background(), obj, "1h")
+	assert.NoError(t, err)
+	assert.True(t, reached)
+	assert.Zero(t, recheckAfter)
+	assert.Equal(t, created.Add(time.Hour), deletionTime)
+
+	// absolute deletion times ignore the anchor
+	obj.SetAnnotations(map[string]string{kyverno.AnnotationCleanupTtlAnchor: kyverno.ValueTtlAnchorJobCompletion})
+	deletionTime, reached, _, err = c.getDeletionTime(context.Background(), obj, "2026-03-01")
+	assert.NoError(t, err)
+	assert.True(t, reached)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), deletionTime)
+
+	obj.SetAnnotations(map[string]string{kyverno.AnnotationCleanupTtlAnchor: "unknown"})
+	_, _, _, err = c.getDeletionTime(context.Background(), obj, "1h")
+	assert.Error(t, err)
+}
@@ -2,12 +2,16 @@ package ttl
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/api/kyverno"
+	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -16,6 +20,7 @@ import (
 	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
 	"k8s.io/utils/ptr"
 )
 
@@ -34,13 +39,32 @@ type controller struct {
 	logger       logr.Logger
 	metrics      metrics.TTLInfoMetrics
 	gvr          schema.GroupVersionResource
+	kind         string
+	anchors      map[string]Anchor
+	eventGen     event.Interface
+
+	// last status reported for each object, events are only emitted when it changes
+	lock     sync.Mutex
+	reported map[string]string
 }
 
-func newController(client metadata.Getter, metainformer informers.GenericInformer, logger logr.Logger, gvr schema.GroupVersionResource) (*controller, error) {
+func newController(
+	client metadata.Getter,
+	metainformer informers.GenericInformer,
+	logger logr.Logger,
+	gvr schema.GroupVersionResource,
+	kind string,
+	namespace string,
+	anchors map[string]Anchor,
+	eventGen event.Interface,
+) (*controller, error) {
 	name := gvr.Version + "/" + gvr.Resource
 	if gvr.Group != "" {
 		name = gvr.Group + "/" + name
 	}
+	if namespace != metav1.NamespaceAll {
+		name = name + "/" + namespace
+	}
 	queue := workqueue.NewTypedRateLimitingQueueWithConfig(workqueue.DefaultTypedControllerRateLimiter[any](), workqueue.TypedRateLimitingQueueConfig[any]{Name: name})
 	c := &controller{
 		name:     name,
@@ -51,6 +75,10 @@ func newController(client metadata.Getter, metainformer informers.GenericInforme
 		logger:   logger,
 		metrics:  metrics.GetTTLInfoMetrics(),
 		gvr:      gvr,
+		kind:     kind,
+		anchors:  anchors,
+		eventGen: eventGen,
+		reported: map[string]string{},
 	}
 	enqueue := controllerutils.LogError(logger, controllerutils.Parse(controllerutils.MetaNamespaceKey, controllerutils.Queue(queue)))
 	registration, err := controllerutils.AddEventHandlers(
@@ -123,6 +151,7 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, itemKey
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			// resource doesn't exist anymore, nothing much to do at this point
+			c.forget(itemKey)
 			return nil
 		}
 		// there was an error, return it to requeue the key
@@ -141,12 +170,21 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, itemKey
 	ttlValue, ok := labels[kyverno.LabelCleanupTtl]
 	if !ok {
 		// No 'ttl' label present, no further action needed
+		c.forget(itemKey)
+		return nil
+	}
+	deletionTime, reached, recheckAfter, err := c.getDeletionTime(ctx, metaObj, ttlValue)
+	if err != nil {
+		logger.Error(err, "failed to compute deletion time", "value", ttlValue)
+		c.report(itemKey, metaObj, event.TTLInvalid, err.Error())
 		return nil
 	}
-	var deletionTime time.Time
-	// Try parsing ttlValue as duration
-	if err := parseDeletionTime(metaObj, &deletionTime, ttlValue); err != nil {
-		logger.Error(err, "failed to parse label", "value", ttlValue)
+	if !reached {
+		anchor := metaObj.GetAnnotations()[kyverno.AnnotationCleanupTtlAnchor]
+		c.report(itemKey, metaObj, event.TTLPending, fmt.Sprintf("the TTL of %s is waiting for its %s anchor", ttlValue, anchor))
+		if recheckAfter > 0 {
+			c.queue.AddAfter(itemKey, recheckAfter)
+		}
 		return nil
 	}
 	if time.Now().After(deletionTime) {
@@ -162,14 +200,72 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, itemKey
 			return err
 		}
 		logger.V(2).Info("resource has been deleted")
+		c.forget(itemKey)
 	} else {
+		c.report(itemKey, metaObj, event.TTLScheduled, fmt.Sprintf("the resource will be deleted at %s", deletionTime.UTC().Format(time.RFC3339)))
 		if c.metrics != nil {
 			c.metrics.RecordDeletedObject(context.Background(), c.gvr, metaObj.GetNamespace())
 		}
 		// Calculate the remaining time until deletion
 		timeRemaining := time.Until(deletionTime)
+		if recheckAfter > 0 && recheckAfter < timeRemaining {
+			timeRemaining = recheckAfter
+		}
 		// Add the item back to the queue after the remaining time
 		c.queue.AddAfter(itemKey, timeRemaining)
 	}
 	return nil
 }
+
+// getDeletionTime computes the deletion time of an object, TTL durations are relative to the anchor selected by the
+// `cleanup.kyverno.io/ttl-anchor` annotation (the creation of the object by default), reached is false while the
+// anchor is not known yet and recheckAfter is the interval after which the anchor must be evaluated again
+func (c *controller) getDeletionTime(ctx context.Context, metaObj metav1.Object, ttlValue string) (deletionTime time.Time, reached bool, recheckAfter time.Duration, err error) {
+	ttl, err := strfmt.ParseDuration(ttlValue)
+	if err != nil {
+		// absolute deletion times don't depend on an anchor
+		if err := parseDeletionTime(metaObj, &deletionTime, ttlValue); err != nil {
+			return deletionTime, false, 0, err
+		}
+		return deletionTime, true, 0, nil
+	}
+	name := metaObj.GetAnnotations()[kyverno.AnnotationCleanupTtlAnchor]
+	if name == "" {
+		name = kyverno.ValueTtlAnchorCreation
+	}
+	anchor, ok := c.anchors[name]
+	if !ok {
+		return deletionTime, false, 0, fmt.Errorf("unknown TTL anchor %s", name)
+	}
+	anchorTime, reached, err := anchor.Time(ctx, metaObj)
+	if err != nil || !reached {
+		return deletionTime, false, anchor.RecheckAfter(), err
+	}
+	return anchorTime.Add(ttl), true, anchor.RecheckAfter(), nil
+}
+
+// report emits an event when the TTL status of an object changes
+func (c *controller) report(itemKey string, metaObj metav1.Object, reason event.Reason, message string) {
+	status := string(reason) + ":" + message
+	c.lock.Lock()
+	changed := c.reported[itemKey] != status
+	c.reported[itemKey] = status
+	c.lock.Unlock()
+	if !changed || c.eventGen == nil {
+		return
+	}
+	regarding := corev1.ObjectReference{
+		APIVersion: c.gvr.GroupVersion().String(),
+		Kind:       c.kind,
+		Name:       metaObj.GetName(),
+		Namespace:  metaObj.GetNamespace(),
+		UID:        metaObj.GetUID(),
+	}
+	c.eventGen.Add(event.NewTTLEvent(regarding, reason, message))
+}
+
+func (c *controller) forget(itemKey string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.reported, itemKey)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/kyverno/kyverno/api/kyverno"
 	"github.com/kyverno/kyverno/pkg/auth/checker"
 	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"go.opentelemetry.io/otel/metric"
@@ -19,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/metadata/metadatainformer"
 	"k8s.io/client-go/tools/cache"
@@ -34,33 +36,55 @@ const (
 type manager struct {
 	metadataClient  metadata.Interface
 	discoveryClient discovery.DiscoveryInterface
+	dynamicClient   dynamic.Interface
 	checker         checker.AuthChecker
+	eventGen        event.Interface
 	resController   map[schema.GroupVersionResource]stopFunc
 	logger          logr.Logger
 	interval        time.Duration
 	lock            sync.Mutex
 	infoMetric      metrics.TTLInfoMetrics
 	resyncPeriod    time.Duration
+	scope           Scope
+	tracker         *Tracker
+	// isLeader returns true while the instance holds the leadership, probing only happens on the leader
+	isLeader func() bool
+	// namespaced contains the namespaced resources found by the last discovery
+	namespaced sets.Set[schema.GroupVersionResource]
+	// kinds contains the kinds of the resources found by the last discovery
+	kinds map[schema.GroupVersionResource]string
 }
 
 func NewManager(
 	metadataInterface metadata.Interface,
 	discoveryInterface discovery.DiscoveryInterface,
+	dynamicInterface dynamic.Interface,
 	checker checker.AuthChecker,
+	eventGen event.Interface,
 	timeInterval time.Duration,
 	resyncPeriod time.Duration,
+	scope Scope,
+	tracker *Tracker,
+	isLeader func() bool,
 ) controllers.Controller {
 	logger := logging.WithName(ControllerName)
 
 	mgr := &manager{
 		metadataClient:  metadataInterface,
 		discoveryClient: discoveryInterface,
+		dynamicClient:   dynamicInterface,
 		checker:         checker,
+		eventGen:        eventGen,
 		resController:   map[schema.GroupVersionResource]stopFunc{},
 		logger:          logger,
 		interval:        timeInterval,
 		infoMetric:      metrics.GetTTLInfoMetrics(),
 		resyncPeriod:    resyncPeriod,
+		scope:           scope,
+		tracker:         tracker,
+		isLeader:        isLeader,
+		namespaced:      sets.New[schema.GroupVersionResource](),
+		kinds:           map[schema.GroupVersionResource]string{},
 	}
 	if mgr.infoMetric != nil {
 		if _, err := mgr.infoMetric.RegisterCallback(mgr.report); err != nil {
@@ -82,29 +106,94 @@ func (m *manager) Run(ctx context.Context, worker int) {
 	}()
 	ticker := time.NewTicker(m.interval)
 	defer ticker.Stop()
+	// a nil channel never receives, the manager only reconciles periodically without a tracker
+	var changes <-chan struct{}
+	if m.tracker != nil {
+		changes = m.tracker.Changes()
+	}
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			if err := m.reconcile(ctx, worker); err != nil {
-				m.logger.Error(err, "reconciliation failed")
-				return
-			}
+		case <-changes:
+			m.logger.V(3).Info("new labelled resource observed")
+		}
+		// the leadership can be lost before the context is cancelled, discovery and probes are skipped meanwhile
+		if m.isLeader != nil && !m.isLeader() {
+			m.logger.V(3).Info("not leader, skipping reconciliation")
+			continue
+		}
+		if err := m.reconcile(ctx, worker); err != nil {
+			m.logger.Error(err, "reconciliation failed")
+			return
 		}
 	}
 }
 
-func (m *manager) getDesiredState() (sets.Set[schema.GroupVersionResource], error) {
+func (m *manager) getDesiredState(ctx context.Context) (sets.Set[schema.GroupVersionResource], error) {
 	// Get the list of resources currently present in the cluster
 	newresources, err := discoverResources(m.logger, m.discoveryClient)
 	if err != nil {
 		return nil, err
 	}
-	validResources := m.filterPermissionsResource(newresources)
+	namespaced := sets.New[schema.GroupVersionResource]()
+	kinds := map[schema.GroupVersionResource]string{}
+	var scoped []schema.GroupVersionResource
+	for _, resource := range newresources {
+		kinds[resource.gvr] = resource.kind
+		if resource.namespaced {
+			namespaced.Insert(resource.gvr)
+		}
+		if !m.scope.AllowsResource(resource.gvr) || len(m.scope.Namespaces(resource.namespaced)) == 0 {
+			continue
+		}
+		scoped = append(scoped, resource.gvr)
+	}
+	m.namespaced = namespaced
+	m.kinds = kinds
+	validResources := m.filterPermissionsResource(scoped)
+	if m.scope.Lazy {
+		validResources = m.filterLabelledResources(ctx, validResources)
+	}
 	return sets.New(validResources...), nil
 }
 
+// filterLabelledResources keeps the resources already watched and the resources for which objects carrying
+// the TTL label exist, the tracker reports the objects admitted by this instance, the other ones are probed
+func (m *manager) filterLabelledResources(ctx context.Context, resources []schema.GroupVersionResource) []schema.GroupVersionResource {
+	var labelled []schema.GroupVersionResource
+	for _, gvr := range resources {
+		if _, ok := m.resController[gvr]; ok {
+			labelled = append(labelled, gvr)
+		} else if m.tracker != nil && m.tracker.Seen(gvr) {
+			labelled = append(labelled, gvr)
+		} else if m.hasLabelledObjects(ctx, gvr) {
+			labelled = append(labelled, gvr)
+		}
+	}
+	return labelled
+}
+
+// hasLabelledObjects lists at most one object carrying the TTL label in the namespaces of the scope
+func (m *manager) hasLabelledObjects(ctx context.Context, gvr schema.GroupVersionResource) bool {
+	opts := metav1.ListOptions{
+		LabelSelector: kyverno.LabelCleanupTtl,
+		Limit:         1,
+	}
+	for _, namespace := range m.scope.Namespaces(m.namespaced.Has(gvr)) {
+		list, err := m.metadataClient.Resource(gvr).Namespace(namespace).List(ctx, opts)
+		if err != nil {
+			m.logger.V(3).Info("failed to look for labelled objects", "gvr", gvr, "namespace", namespace, "error", err.Error())
+			continue
+		}
+		if len(list.Items) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *manager) getObservedState() (sets.Set[schema.GroupVersionResource], error) {
 	observedState := sets.New[schema.GroupVersionResource]()
 	for resource := range m.resController {
@@ -130,12 +219,12 @@ func (m *manager) stop(ctx context.Context, gvr schema.GroupVersionResource) err
 // This prevents the informer from failing repeatedly if the service account lacks
 // permission to list/watch the resource (403 Forbidden), which can cause cascading
 // failures similar to those described in https://github.com/projectcalico/calico/issues/9527
-func (m *manager) preflightCheck(ctx context.Context, gvr schema.GroupVersionResource, logger logr.Logger) error {
+func (m *manager) preflightCheck(ctx context.Context, gvr schema.GroupVersionResource, namespace string, logger logr.Logger) error {
 	opts := metav1.ListOptions{
 		LabelSelector: kyverno.LabelCleanupTtl,
 		Limit:         1,
 	}
-	_, err := m.metadataClient.Resource(gvr).List(ctx, opts)
+	_, err := m.metadataClient.Resource(gvr).Namespace(namespace).List(ctx, opts)
 	if err != nil {
 		// Check if it's a 403 Forbidden - don't start informer for forbidden resources
 		if apierrors.IsForbidden(err) {
@@ -150,12 +239,42 @@ func (m *manager) preflightCheck(ctx context.Context, gvr schema.GroupVersionRes
 }
 
 func (m *manager) start(ctx context.Context, gvr schema.GroupVersionResource, workers int) error {
+	var stopFuncs []stopFunc
+	for _, namespace := range m.scope.Namespaces(m.namespaced.Has(gvr)) {
+		stop, err := m.startNamespace(ctx, gvr, namespace, workers)
+		if err != nil {
+			for _, stop := range stopFuncs {
+				stop()
+			}
+			return err
+		}
+		if stop != nil {
+			stopFuncs = append(stopFuncs, stop)
+		}
+	}
+	if len(stopFuncs) == 0 {
+		return nil
+	}
+	m.resController[gvr] = func() {
+		for _, stop := range stopFuncs {
+			stop()
+		}
+	}
+	return nil
+}
+
+// startNamespace starts the informer and controller handling a resource in a namespace, it returns a nil
+// stop function if the preflight check failed
+func (m *manager) startNamespace(ctx context.Context, gvr schema.GroupVersionResource, namespace string, workers int) (stopFunc, error) {
 	logger := m.logger.WithValues("gvr", gvr)
+	if namespace != metav1.NamespaceAll {
+		logger = logger.WithValues("namespace", namespace)
+	}
 
 	// Perform preflight check before starting the informer
-	if err := m.preflightCheck(ctx, gvr, logger); err != nil {
+	if err := m.preflightCheck(ctx, gvr, namespace, logger); err != nil {
 		logger.Error(err, "preflight check failed, skipping resource")
-		return nil
+		return nil, nil
 	}
 
 	indexers := cache.Indexers{
@@ -166,7 +285,7 @@ func (m *manager) start(ctx context.Context, gvr schema.GroupVersionResource, wo
 	}
 	informer := metadatainformer.NewFilteredMetadataInformer(m.metadataClient,
 		gvr,
-		metav1.NamespaceAll,
+		namespace,
 		m.resyncPeriod,
 		indexers,
 		options,
@@ -186,12 +305,21 @@ func (m *manager) start(ctx context.Context, gvr schema.GroupVersionResource, wo
 	}
 	if !cache.WaitForCacheSync(ctx.Done(), informer.Informer().HasSynced) {
 		stopInformer()
-		return fmt.Errorf("failed to wait for cache sync: %s", gvr.Resource)
+		return nil, fmt.Errorf("failed to wait for cache sync: %s", gvr.Resource)
 	}
-	controller, err := newController(m.metadataClient.Resource(gvr), informer, logger, gvr)
+	controller, err := newController(
+		m.metadataClient.Resource(gvr),
+		informer,
+		logger,
+		gvr,
+		m.kinds[gvr],
+		namespace,
+		newAnchors(m.metadataClient, m.dynamicClient, gvr),
+		m.eventGen,
+	)
 	if err != nil {
 		stopInformer()
-		return err
+		return nil, err
 	}
 	var controllerWaitGroup wait.Group
 	controllerWaitGroup.StartWithContext(cont, func(ctx context.Context) {
@@ -199,19 +327,25 @@ func (m *manager) start(ctx context.Context, gvr schema.GroupVersionResource, wo
 		defer logger.V(3).Info("controller stopping...")
 		controller.Start(ctx, workers)
 	})
-	m.resController[gvr] = func() {
+	return func() {
 		stopInformer()
 		controller.Stop()
 		controllerWaitGroup.Wait()
-	}
-	return nil
+	}, nil
 }
 
 func (m *manager) filterPermissionsResource(resources []schema.GroupVersionResource) []schema.GroupVersionResource {
 	validResources := []schema.GroupVersionResource{}
 	for _, resource := range resources {
-		// Check if the service account has the necessary permissions
-		if HasResourcePermissions(m.logger, resource, m.checker) {
+		// Check if the service account has the necessary permissions in every namespace of the scope
+		allowed := true
+		for _, namespace := range m.scope.Namespaces(m.namespaced.Has(resource)) {
+			if !hasNamespacedPermissions(m.logger, resource, namespace, m.checker) {
+				allowed = false
+				break
+			}
+		}
+		if allowed {
 			validResources = append(validResources, resource)
 		}
 	}
@@ -230,7 +364,7 @@ func (m *manager) report(ctx context.Context, observer metric.Observer) error {
 func (m *manager) reconcile(ctx context.Context, workers int) error {
 	defer m.logger.V(3).Info("manager reconciliation done")
 	m.logger.V(3).Info("beginning reconciliation", "interval", m.interval)
-	desiredState, err := m.getDesiredState()
+	desiredState, err := m.getDesiredState(ctx)
 	if err != nil {
 		return err
 	}
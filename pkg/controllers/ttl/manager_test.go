@@ -0,0 +1,42 @@
+package ttl
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+type countingDiscovery struct {
+	discovery.DiscoveryInterface
+	calls atomic.Int32
+}
+
+func (d *countingDiscovery) ServerPreferredResources() ([]*metav1.APIResourceList, error) {
+	d.calls.Add(1)
+	return nil, nil
+}
+
+func TestManager_RunNotLeader(t *testing.T) {
+	discoveryClient := &countingDiscovery{DiscoveryInterface: &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}}
+	var leader atomic.Bool
+	m := NewManager(nil, discoveryClient, nil, nil, nil, 10*time.Millisecond, time.Minute, NewScope(nil, nil, true), NewTracker(), leader.Load)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.Run(ctx, 1)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(0), discoveryClient.calls.Load())
+	leader.Store(true)
+	assert.Eventually(t, func() bool { return discoveryClient.calls.Load() != 0 }, time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+}
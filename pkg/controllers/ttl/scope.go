@@ -0,0 +1,132 @@
+package ttl
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// coreVersion matches the versions of the core group, `pods.v1` is a core resource and not the `v1` group
+var coreVersion = regexp.MustCompile(`^v[1-9][0-9]*((alpha|beta)[1-9][0-9]*)?$`)
+
+// Scope restricts the resources handled by the TTL manager
+type Scope struct {
+	// resources and groups are the allow-lists of resources with and without a version,
+	// every resource is allowed when both are empty
+	resources []schema.GroupVersionResource
+	groups    sets.Set[schema.GroupResource]
+	// namespaces is the allow-list of namespaces, every namespace is allowed when empty
+	namespaces sets.Set[string]
+	// Lazy only starts watching a resource once an object carrying the TTL label was seen for it
+	Lazy bool
+}
+
+// NewScope creates a scope from an allow-list of resources and namespaces, resources are given in the
+// `resource.version.group`, `resource.group` or `resource.version` formats (`pods`, `pods.v1`, `deployments.apps`, `jobs.v1.batch`)
+func NewScope(resources []string, namespaces []string, lazy bool) Scope {
+	scope := Scope{
+		groups:     sets.New[schema.GroupResource](),
+		namespaces: sets.New[string](),
+		Lazy:       lazy,
+	}
+	for _, resource := range resources {
+		resource = strings.TrimSpace(resource)
+		if resource == "" {
+			continue
+		}
+		gvr, gr := schema.ParseResourceArg(resource)
+		if gvr == nil && coreVersion.MatchString(gr.Group) {
+			gvr = &schema.GroupVersionResource{Version: gr.Group, Resource: gr.Resource}
+		}
+		if gvr != nil {
+			scope.resources = append(scope.resources, *gvr)
+		} else {
+			scope.groups.Insert(gr)
+		}
+	}
+	for _, namespace := range namespaces {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			scope.namespaces.Insert(namespace)
+		}
+	}
+	return scope
+}
+
+// AllowsResource returns true if the resource belongs to the scope
+func (s Scope) AllowsResource(gvr schema.GroupVersionResource) bool {
+	if len(s.groups) == 0 && len(s.resources) == 0 {
+		return true
+	}
+	if s.groups.Has(gvr.GroupResource()) {
+		return true
+	}
+	for _, resource := range s.resources {
+		if resource == gvr {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsNamespace returns true if objects in the namespace belong to the scope
+func (s Scope) AllowsNamespace(namespace string) bool {
+	return len(s.namespaces) == 0 || s.namespaces.Has(namespace)
+}
+
+// Namespaces returns the namespaces watched for a resource, cluster scoped resources are not watched
+// when the scope is restricted to a set of namespaces
+func (s Scope) Namespaces(namespaced bool) []string {
+	if len(s.namespaces) == 0 {
+		return []string{metav1.NamespaceAll}
+	}
+	if !namespaced {
+		return nil
+	}
+	return sets.List(s.namespaces)
+}
+
+// Tracker records the resources for which objects carrying the TTL label were seen, it lets the
+// admission webhook tell the manager to start watching a new kind without waiting for its next reconciliation
+type Tracker struct {
+	lock    sync.Mutex
+	seen    sets.Set[schema.GroupResource]
+	changes chan struct{}
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{
+		seen:    sets.New[schema.GroupResource](),
+		changes: make(chan struct{}, 1),
+	}
+}
+
+// Observe records a resource, it returns true if the resource was not seen before
+func (t *Tracker) Observe(gvr schema.GroupVersionResource) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.seen.Has(gvr.GroupResource()) {
+		return false
+	}
+	t.seen.Insert(gvr.GroupResource())
+	select {
+	case t.changes <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// Seen returns true if an object of the resource was observed
+func (t *Tracker) Seen(gvr schema.GroupVersionResource) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.seen.Has(gvr.GroupResource())
+}
+
+// Changes returns a channel receiving a value when new resources are observed
+func (t *Tracker) Changes() <-chan struct{} {
+	return t.changes
+}
@@ -0,0 +1,65 @@
+package ttl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestScope(t *testing.T) {
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	jobs := schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	secrets := schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+
+	all := NewScope([]string{""}, []string{""}, false)
+	assert.True(t, all.AllowsResource(secrets))
+	assert.True(t, all.AllowsNamespace("default"))
+	assert.Equal(t, []string{metav1.NamespaceAll}, all.Namespaces(true))
+	assert.Equal(t, []string{metav1.NamespaceAll}, all.Namespaces(false))
+
+	scope := NewScope([]string{"pods", "jobs.batch", "deployments.v1.apps"}, []string{"team-b", " team-a"}, true)
+	assert.True(t, scope.Lazy)
+	assert.True(t, scope.AllowsResource(pods))
+	assert.True(t, scope.AllowsResource(jobs))
+	assert.True(t, scope.AllowsResource(deployments))
+	assert.False(t, scope.AllowsResource(secrets))
+	assert.False(t, scope.AllowsResource(schema.GroupVersionResource{Group: "apps", Version: "v1beta1", Resource: "deployments"}))
+	assert.True(t, scope.AllowsNamespace("team-a"))
+	assert.False(t, scope.AllowsNamespace("default"))
+	assert.Equal(t, []string{"team-a", "team-b"}, scope.Namespaces(true))
+	assert.Empty(t, scope.Namespaces(false))
+
+	// core resources with a version
+	core := NewScope([]string{"pods.v1", "configmaps.v1beta1"}, nil, false)
+	assert.True(t, core.AllowsResource(pods))
+	assert.False(t, core.AllowsResource(secrets))
+	assert.False(t, core.AllowsResource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}))
+	assert.False(t, core.AllowsResource(schema.GroupVersionResource{Group: "v1", Resource: "pods"}))
+	// resources with a version only
+	versioned := NewScope([]string{"deployments.v1.apps"}, nil, false)
+	assert.True(t, versioned.AllowsResource(deployments))
+	assert.False(t, versioned.AllowsResource(pods))
+}
+
+func TestTracker(t *testing.T) {
+	jobs := schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}
+	tracker := NewTracker()
+	assert.False(t, tracker.Seen(jobs))
+	assert.True(t, tracker.Observe(jobs))
+	// other versions of the same resource are the same kind
+	assert.False(t, tracker.Observe(schema.GroupVersionResource{Group: "batch", Version: "v1beta1", Resource: "jobs"}))
+	assert.True(t, tracker.Seen(jobs))
+	select {
+	case <-tracker.Changes():
+	default:
+		t.Fatal("expected a change notification")
+	}
+	select {
+	case <-tracker.Changes():
+		t.Fatal("expected a single change notification")
+	default:
+	}
+}
@@ -14,8 +14,15 @@ import (
 	"k8s.io/kube-openapi/pkg/validation/strfmt"
 )
 
-func discoverResources(logger logr.Logger, discoveryClient discovery.DiscoveryInterface) ([]schema.GroupVersionResource, error) {
-	var resources []schema.GroupVersionResource
+// discoveredResource is a resource supporting the verbs required by the TTL manager
+type discoveredResource struct {
+	gvr        schema.GroupVersionResource
+	kind       string
+	namespaced bool
+}
+
+func discoverResources(logger logr.Logger, discoveryClient discovery.DiscoveryInterface) ([]discoveredResource, error) {
+	var resources []discoveredResource
 	apiResourceList, err := discoveryClient.ServerPreferredResources()
 	if err != nil {
 		if !discovery.IsGroupDiscoveryFailedError(err) {
@@ -35,7 +42,11 @@ func discoverResources(logger logr.Logger, discoveryClient discovery.DiscoveryIn
 				if err != nil {
 					return resources, err
 				}
-				resources = append(resources, groupVersion.WithResource(apiResource.Name))
+				resources = append(resources, discoveredResource{
+					gvr:        groupVersion.WithResource(apiResource.Name),
+					kind:       apiResource.Kind,
+					namespaced: apiResource.Namespaced,
+				})
 			}
 		}
 	}
@@ -43,7 +54,11 @@ func discoverResources(logger logr.Logger, discoveryClient discovery.DiscoveryIn
 }
 
 func HasResourcePermissions(logger logr.Logger, resource schema.GroupVersionResource, s checker.AuthChecker) bool {
-	can, err := checker.Check(context.TODO(), s, resource.Group, resource.Version, resource.Resource, "", "", "watch", "list", "delete")
+	return hasNamespacedPermissions(logger, resource, metav1.NamespaceAll, s)
+}
+
+func hasNamespacedPermissions(logger logr.Logger, resource schema.GroupVersionResource, namespace string, s checker.AuthChecker) bool {
+	can, err := checker.Check(context.TODO(), s, resource.Group, resource.Version, resource.Resource, "", namespace, "watch", "list", "delete")
 	if err != nil {
 		logger.Error(err, "failed to check permissions")
 		return false
@@ -47,6 +47,17 @@ func NewVerifier() images.ImageVerifier {
 type cosignVerifier struct{}
 
 func (v *cosignVerifier) VerifySignature(ctx context.Context, opts images.Options) (*images.Response, error) {
+	opts, err := images.ExpandIdentityTemplates(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if material, err := loadOffline(ctx, opts); err != nil {
+		return nil, err
+	} else if material != nil {
+		return verifyOffline(ctx, opts, material, false)
+	}
+
 	if opts.SigstoreBundle {
 		results, err := verifyBundleAndFetchAttestations(ctx, opts)
 		if err != nil {
@@ -75,7 +86,11 @@ func (v *cosignVerifier) VerifySignature(ctx context.Context, opts images.Option
 			if err != nil {
 				return nil, false, err
 			}
-			return client.VerifyImageSignatures(ctx, ref, cosignOpts)
+			verified, err := images.FromReferences(opts.Client, ref, func(ref name.Reference) (verifiedSignatures, error) {
+				signatures, bundleVerified, err := client.VerifyImageSignatures(ctx, ref, cosignOpts)
+				return verifiedSignatures{signatures, bundleVerified}, err
+			})
+			return verified.signatures, verified.bundleVerified, err
 		},
 	)
 	if err != nil {
@@ -109,6 +124,12 @@ func (v *cosignVerifier) VerifySignature(ctx context.Context, opts images.Option
 	return &images.Response{Digest: digest}, nil
 }
 
+// verifiedSignatures holds the signatures verified on one of the references of an image
+type verifiedSignatures struct {
+	signatures     []oci.Signature
+	bundleVerified bool
+}
+
 func buildCosignOptions(ctx context.Context, opts images.Options) (*cosign.CheckOpts, error) {
 	var err error
 
@@ -282,6 +303,17 @@ func loadCertChain(pem []byte) ([]*x509.Certificate, error) {
 }
 
 func (v *cosignVerifier) FetchAttestations(ctx context.Context, opts images.Options) (*images.Response, error) {
+	opts, err := images.ExpandIdentityTemplates(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if material, err := loadOffline(ctx, opts); err != nil {
+		return nil, err
+	} else if material != nil {
+		return verifyOffline(ctx, opts, material, true)
+	}
+
 	if opts.SigstoreBundle {
 		results, err := verifyBundleAndFetchAttestations(ctx, opts)
 		if err != nil {
@@ -313,7 +345,11 @@ func (v *cosignVerifier) FetchAttestations(ctx context.Context, opts images.Opti
 			if err != nil {
 				return nil, false, fmt.Errorf("failed to parse image: %w", err)
 			}
-			return client.VerifyImageAttestations(ctx, ref, cosignOpts)
+			verified, err := images.FromReferences(opts.Client, ref, func(ref name.Reference) (verifiedSignatures, error) {
+				attestations, bundleVerified, err := client.VerifyImageAttestations(ctx, ref, cosignOpts)
+				return verifiedSignatures{attestations, bundleVerified}, err
+			})
+			return verified.signatures, verified.bundleVerified, err
 		},
 	)
 	if err != nil {
@@ -0,0 +1,130 @@
+package cosign
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/kyverno/kyverno/pkg/images"
+	"github.com/kyverno/kyverno/pkg/imageverification/offline"
+)
+
+// defaultRekorURL is the Rekor URL of the rules not configuring one
+const defaultRekorURL = "https://rekor.sigstore.dev"
+
+// loadOffline returns the offline verification material when a rule can be verified against it, nil
+// when no material is configured or when the rule has settings the material can't honour, these
+// rules are verified online
+func loadOffline(ctx context.Context, opts images.Options) (*offline.Material, error) {
+	material, err := offline.Load(ctx)
+	if err != nil || material == nil {
+		return nil, err
+	}
+	if reason := offlineConflict(opts); reason != "" {
+		logger.V(2).Info("verifying image online, the rule is not supported with offline verification", "image", opts.ImageRef, "reason", reason)
+		return nil, nil
+	}
+	return material, nil
+}
+
+// offlineConflict returns why the settings of a rule conflict with offline verification, empty when
+// they don't: the trusted root of the material replaces the Rekor, CT log and Fulcio roots, and the
+// bundles are checked against keyless identities or PEM public keys only
+func offlineConflict(opts images.Options) string {
+	switch {
+	case opts.Cert != "" || opts.CertChain != "":
+		return "certificate attestors are not supported"
+	case opts.Key != "" && !strings.HasPrefix(strings.TrimSpace(opts.Key), "-----BEGIN"):
+		return "only PEM encoded public keys are supported"
+	case len(opts.Annotations) != 0:
+		return "annotations are not supported"
+	case opts.RekorURL != "" && opts.RekorURL != defaultRekorURL, opts.RekorPubKey != "":
+		return "custom Rekor settings are not supported"
+	case opts.CTLogsPubKey != "" || opts.TSACertChain != "":
+		return "custom CT log settings are not supported"
+	case opts.Roots != "":
+		return "custom roots are not supported"
+	}
+	return ""
+}
+
+// verifyOffline verifies an image against the pre-distributed verification material instead of
+// the registry signatures, Rekor, Fulcio and the TUF root. The bundles are keyed by image digest,
+// references pinned to a digest are verified without any registry call while tags are still
+// resolved against the registry
+func verifyOffline(ctx context.Context, opts images.Options, material *offline.Material, attestations bool) (*images.Response, error) {
+	desc, err := resolveOffline(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	verifyOpts := offline.Options{
+		PublicKey:     opts.Key,
+		HashAlgorithm: signatureAlgorithmMap[opts.SignatureAlgorithm],
+		IgnoreTlog:    opts.IgnoreTlog,
+		IgnoreSCT:     opts.IgnoreSCT,
+	}
+	if opts.Key == "" {
+		verifyOpts.Identities = []offline.Identity{{
+			Issuer:        opts.Issuer,
+			IssuerRegExp:  opts.IssuerRegExp,
+			Subject:       opts.Subject,
+			SubjectRegExp: opts.SubjectRegExp,
+		}}
+	}
+	if attestations {
+		verifyOpts.PredicateType = opts.Type
+	}
+	results, err := material.Verify(desc.Digest.String(), verifyOpts)
+	if err != nil {
+		logger.Info("offline image verification failed", "error", err.Error())
+		return nil, err
+	}
+	logger.V(3).Info("verified image with offline bundles", "count", len(results))
+	response := &images.Response{Digest: desc.Digest.String()}
+	if attestations {
+		verified := make([]*VerificationResult, 0, len(results))
+		for _, result := range results {
+			if result.Statement == nil {
+				continue
+			}
+			verified = append(verified, &VerificationResult{
+				Bundle: &Bundle{ProtoBundle: result.Bundle, DSSE_Envelope: result.Statement},
+				Result: result.Result,
+				Desc:   desc,
+			})
+		}
+		if response.Statements, err = decodeStatementsFromBundles(verified); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+// resolveOffline returns the descriptor of an image, the digest of the reference when it has one
+func resolveOffline(ctx context.Context, opts images.Options) (*v1.Descriptor, error) {
+	ref, err := name.ParseReference(opts.ImageRef, opts.Client.NameOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse image %s", opts.ImageRef)
+	}
+	if digest, ok := ref.(name.Digest); ok {
+		hash, err := v1.NewHash(digest.DigestStr())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse digest of image %s: %w", opts.ImageRef, err)
+		}
+		return &v1.Descriptor{Digest: hash}, nil
+	}
+	remoteOpts, err := opts.Client.Options(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("constructing remote options: %w", err)
+	}
+	desc, err := images.FromReferences(opts.Client, ref, func(ref name.Reference) (*v1.Descriptor, error) {
+		return remote.Head(ref, remoteOpts...)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve image %s: %w", opts.ImageRef, err)
+	}
+	return desc, nil
+}
@@ -0,0 +1,63 @@
+package cosign
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/images"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	"gotest.tools/assert"
+)
+
+func Test_offlineConflict(t *testing.T) {
+	tests := []struct {
+		name string
+		opts images.Options
+		want string
+	}{{
+		name: "keyless",
+		opts: images.Options{Issuer: "https://github.com/", Subject: "jim", RekorURL: defaultRekorURL},
+	}, {
+		name: "pem key",
+		opts: images.Options{Key: globalRekorPubKey},
+	}, {
+		name: "kms key",
+		opts: images.Options{Key: "k8s://kyverno/keys"},
+		want: "only PEM encoded public keys are supported",
+	}, {
+		name: "certificate",
+		opts: images.Options{Cert: "cert"},
+		want: "certificate attestors are not supported",
+	}, {
+		name: "annotations",
+		opts: images.Options{Key: globalRekorPubKey, Annotations: map[string]string{"foo": "bar"}},
+		want: "annotations are not supported",
+	}, {
+		name: "custom rekor",
+		opts: images.Options{Key: globalRekorPubKey, RekorURL: "https://rekor.example.com"},
+		want: "custom Rekor settings are not supported",
+	}, {
+		name: "custom ctlog",
+		opts: images.Options{Key: globalRekorPubKey, CTLogsPubKey: "key"},
+		want: "custom CT log settings are not supported",
+	}, {
+		name: "custom roots",
+		opts: images.Options{Issuer: "https://github.com/", Subject: "jim", Roots: "roots"},
+		want: "custom roots are not supported",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, offlineConflict(tt.opts), tt.want)
+		})
+	}
+}
+
+func Test_resolveOffline_digest(t *testing.T) {
+	rc, err := registryclient.New()
+	assert.NilError(t, err)
+	digest := "sha256:4a1c4b21597c1b4415bdbecb28a3296c6b5e23ca4f9feeb599860a1dac6a0108"
+	// the registry doesn't exist, the digest of the reference is used without any registry call
+	desc, err := resolveOffline(context.TODO(), images.Options{ImageRef: "registry.invalid/test@" + digest, Client: rc})
+	assert.NilError(t, err)
+	assert.Equal(t, desc.Digest.String(), digest)
+}
@@ -49,7 +49,11 @@ func verifyBundleAndFetchAttestations(ctx context.Context, opts images.Options)
 		return nil, errors.Wrapf(err, "failed to create remote opts: %v", opts.ImageRef)
 	}
 
-	bundles, desc, err := fetchBundles(ref, attestationlimit, opts.Type, remoteOpts)
+	fetched, err := images.FromReferences(opts.Client, ref, func(ref name.Reference) (fetchedBundles, error) {
+		bundles, desc, err := fetchBundles(ref, attestationlimit, opts.Type, remoteOpts)
+		return fetchedBundles{bundles, desc}, err
+	})
+	bundles, desc := fetched.bundles, fetched.desc
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to fetch bundles: %v", opts.ImageRef)
 	}
@@ -92,6 +96,12 @@ func verifyBundles(bundles []*Bundle, desc *v1.Descriptor, trustedRoot *root.Tru
 	return verificationResults, nil
 }
 
+// fetchedBundles holds the bundles fetched from one of the references of an image
+type fetchedBundles struct {
+	bundles []*Bundle
+	desc    *v1.Descriptor
+}
+
 func fetchBundles(ref name.Reference, limit int, predicateType string, remoteOpts []remote.Option) ([]*Bundle, *v1.Descriptor, error) {
 	bundles := make([]*Bundle, 0)
 
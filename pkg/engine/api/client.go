@@ -65,6 +65,7 @@ type KeychainClient interface {
 type RemoteClient interface {
 	Options(context.Context) ([]gcrremote.Option, error)
 	NameOptions() []name.Option
+	References(name.Reference) []name.Reference
 }
 
 type RegistryClient interface {
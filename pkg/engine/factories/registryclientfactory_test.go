@@ -328,6 +328,10 @@ func (m *mockRegistryClient) NameOptions() []name.Option {
 	return []name.Option{}
 }
 
+func (m *mockRegistryClient) References(ref name.Reference) []name.Reference {
+	return []name.Reference{ref}
+}
+
 func (m *mockRegistryClient) RawAbsPath(ctx context.Context, path string, method string, dataReader io.Reader) ([]byte, error) {
 	return nil, nil
 }
@@ -128,8 +128,8 @@ func (h validateAssertHandler) Process(
 			action = policyContext.Policy().GetSpec().ValidationFailureAction
 		}
 
-		// process the old object for UPDATE admission requests in case of enforce policies
-		if action.Enforce() {
+		// process the old object for UPDATE admission requests in case of enforce or shadow policies
+		if action.Enforce() || action.Shadow() {
 			allowExisitingViolations := rule.HasValidateAllowExistingViolations()
 			if engineutils.IsUpdateRequest(policyContext) && allowExisitingViolations {
 				errs, err := validateOldObject(ctx, logger, policyContext, rule, payload, bindings)
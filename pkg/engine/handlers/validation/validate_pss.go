@@ -149,8 +149,8 @@ func (h validatePssHandler) validate(
 			action = policyContext.Policy().GetSpec().ValidationFailureAction
 		}
 
-		// process the old object for UPDATE admission requests in case of enforce policies
-		if action.Enforce() {
+		// process the old object for UPDATE admission requests in case of enforce or shadow policies
+		if action.Enforce() || action.Shadow() {
 			allowExisitingViolations := rule.HasValidateAllowExistingViolations()
 			if engineutils.IsUpdateRequest(policyContext) && allowExisitingViolations {
 				priorResp, err := h.validateOldObject(ctx, logger, policyContext, resource, rule, engineLoader, exceptions)
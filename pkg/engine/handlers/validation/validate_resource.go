@@ -161,8 +161,8 @@ func (v *validator) validate(ctx context.Context) *engineapi.RuleResponse {
 		action = v.policyContext.Policy().GetSpec().ValidationFailureAction
 	}
 
-	// process the old object for UPDATE admission requests in case of enforce policies
-	if action.Enforce() {
+	// process the old object for UPDATE admission requests in case of enforce or shadow policies
+	if action.Enforce() || action.Shadow() {
 		allowExisitingViolations := v.rule.HasValidateAllowExistingViolations()
 		if engineutils.IsUpdateRequest(v.policyContext) && allowExisitingViolations && v.nesting == 0 { // is update request and is the root level validate
 			priorResp, err := v.validateOldObject(ctx)
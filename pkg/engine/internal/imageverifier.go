@@ -10,6 +10,7 @@ import (
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/ext/wildcard"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/cosign"
@@ -17,6 +18,7 @@ import (
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/images"
+	"github.com/kyverno/kyverno/pkg/imageverification/results"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/notary"
 	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
@@ -140,6 +142,16 @@ func makeAddDigestPatch(imageInfo apiutils.ImageInfo, digest string) jsonpatch.J
 	}
 }
 
+// pinnedReference returns the reference of an image pinned to a digest, it is empty when the digest is unknown
+func pinnedReference(imageInfo apiutils.ImageInfo, digest string) string {
+	if digest == "" {
+		return ""
+	}
+	info := imageInfo.ImageInfo
+	info.Digest = digest
+	return info.String()
+}
+
 func EvaluateConditions(
 	conditions []kyvernov1.AnyAllConditions,
 	ctx enginecontext.Interface,
@@ -201,7 +213,7 @@ func (iv *ImageVerifier) Verify(
 
 		isInCache := false
 		if iv.ivCache != nil {
-			found, err := iv.ivCache.Get(ctx, iv.policyContext.Policy(), iv.rule.Name, image, imageVerify.UseCache)
+			found, err := iv.ivCache.Get(ctx, iv.policyContext.Policy(), iv.rule.Name, image, pinnedReference(imageInfo, imageInfo.Digest), imageVerify.UseCache)
 			if err != nil {
 				iv.logger.Error(err, "error occurred during cache get", "image", image)
 			} else {
@@ -209,18 +221,29 @@ func (iv *ImageVerifier) Verify(
 			}
 		}
 
+		if !isInCache && imageVerify.UseCache && !imageverifycache.IsBypassed(ctx) && iv.reuseResult(imageInfo.Digest) {
+			iv.logger.V(2).Info("published verification result found", "policy", iv.policyContext.Policy().GetName(), "ruleName", iv.rule.Name, "imageRef", image)
+			isInCache = true
+		}
+
 		var ruleResp *engineapi.RuleResponse
 		var digest string
-		if isInCache {
+		if outcome := iv.cycleOutcome(ctx, imageInfo.Digest); outcome != nil {
+			iv.logger.V(2).Info("image already verified in the cycle", "policy", iv.policyContext.Policy().GetName(), "ruleName", iv.rule.Name, "imageRef", image)
+			ruleResp = outcome
+			digest = imageInfo.Digest
+		} else if isInCache {
 			iv.logger.V(2).Info("cache entry found", "namespace", iv.policyContext.Policy().GetNamespace(), "policy", iv.policyContext.Policy().GetName(), "ruleName", iv.rule.Name, "imageRef", image)
 			ruleResp = engineapi.RulePass(iv.rule.Name, engineapi.ImageVerify, "verified from cache", iv.rule.ReportProperties)
 			digest = imageInfo.Digest
 		} else {
 			iv.logger.V(2).Info("cache entry not found", "namespace", iv.policyContext.Policy().GetNamespace(), "policy", iv.policyContext.Policy().GetName(), "ruleName", iv.rule.Name, "imageRef", image)
 			ruleResp, digest = iv.verifyImage(ctx, imageVerify, imageInfo, cfg)
+			iv.recordResult(imageVerify, imageInfo, digest, ruleResp)
+			iv.storeCycleOutcome(ctx, imageInfo, digest, ruleResp)
 			if ruleResp != nil && ruleResp.Status() == engineapi.RuleStatusPass {
 				if iv.ivCache != nil {
-					setted, err := iv.ivCache.Set(ctx, iv.policyContext.Policy(), iv.rule.Name, image, imageVerify.UseCache)
+					setted, err := iv.ivCache.Set(ctx, iv.policyContext.Policy(), iv.rule.Name, image, pinnedReference(imageInfo, digest), imageVerify.UseCache)
 					if err != nil {
 						iv.logger.Error(err, "error occurred during cache set", "image", image)
 					} else {
@@ -256,6 +279,84 @@ func (iv *ImageVerifier) Verify(
 	return patches, responses
 }
 
+// cycleOutcome returns the outcome of the verification of the digest by the current rule earlier in the
+// re-verification cycle of the context, if any
+func (iv *ImageVerifier) cycleOutcome(ctx context.Context, digest string) *engineapi.RuleResponse {
+	cycle := imageverifycache.CycleFrom(ctx)
+	if cycle == nil || digest == "" {
+		return nil
+	}
+	outcome, found := cycle.Load(iv.policyContext.Policy(), iv.rule.Name, digest)
+	if !found {
+		return nil
+	}
+	ruleResp, ok := outcome.(engineapi.RuleResponse)
+	if !ok {
+		return nil
+	}
+	return &ruleResp
+}
+
+// storeCycleOutcome records the outcome of the verification of an image digest in the re-verification cycle
+// of the context, if any
+func (iv *ImageVerifier) storeCycleOutcome(ctx context.Context, imageInfo apiutils.ImageInfo, digest string, ruleResp *engineapi.RuleResponse) {
+	cycle := imageverifycache.CycleFrom(ctx)
+	if cycle == nil || ruleResp == nil {
+		return
+	}
+	if digest == "" {
+		digest = imageInfo.Digest
+	}
+	if digest != "" {
+		cycle.Store(iv.policyContext.Policy(), iv.rule.Name, digest, *ruleResp)
+	}
+}
+
+// reuseResult returns true when the digest passed the verification of the current version of the rule, as published
+// by the image verification results controller
+func (iv *ImageVerifier) reuseResult(digest string) bool {
+	policy := iv.policyContext.Policy()
+	return results.LookupPass(digest, policyKey(policy), policy.GetResourceVersion(), iv.rule.Name)
+}
+
+// recordResult publishes the outcome of the verification of an image digest
+func (iv *ImageVerifier) recordResult(imageVerify kyvernov1.ImageVerification, imageInfo apiutils.ImageInfo, digest string, ruleResp *engineapi.RuleResponse) {
+	if ruleResp == nil {
+		return
+	}
+	if digest == "" {
+		digest = imageInfo.Digest
+	}
+	result := results.Result{
+		Image:                 imageInfo.String(),
+		Digest:                digest,
+		Policy:                policyKey(iv.policyContext.Policy()),
+		PolicyResourceVersion: iv.policyContext.Policy().GetResourceVersion(),
+		Rule:                  iv.rule.Name,
+		Signers:               results.SignersFromAttestorSets(imageVerify.Type, imageVerify.Attestors),
+	}
+	switch ruleResp.Status() {
+	case engineapi.RuleStatusPass:
+		result.Status = kyvernov2alpha1.ImageVerificationPass
+		for _, attestation := range imageVerify.Attestations {
+			result.Attestations = append(result.Attestations, attestation.Type)
+		}
+	case engineapi.RuleStatusFail:
+		result.Status = kyvernov2alpha1.ImageVerificationFail
+		result.Message = ruleResp.Message()
+	default:
+		return
+	}
+	results.Record(result)
+}
+
+func policyKey(policy kyvernov1.PolicyInterface) string {
+	if policy.GetNamespace() != "" {
+		return policy.GetNamespace() + "/" + policy.GetName()
+	}
+	return policy.GetName()
+}
+
 func (iv *ImageVerifier) verifyImage(
 	ctx context.Context,
 	imageVerify kyvernov1.ImageVerification,
@@ -637,10 +738,11 @@ func (iv *ImageVerifier) buildNotaryVerifier(
 ) (images.ImageVerifier, *images.Options, string) {
 	path := ""
 	opts := &images.Options{
-		ImageRef:  image,
-		Cert:      attestor.Certificates.Certificate,
-		CertChain: attestor.Certificates.CertificateChain,
-		Client:    iv.rclient,
+		ImageRef:    image,
+		Cert:        attestor.Certificates.Certificate,
+		CertChain:   attestor.Certificates.CertificateChain,
+		TrustPolicy: attestor.Certificates.TrustPolicy,
+		Client:      iv.rclient,
 	}
 
 	if attestation != nil {
@@ -4,10 +4,11 @@ package event
 type Action string
 
 const (
-	ResourceBlocked   Action = "Resource Blocked"
-	ResourcePassed    Action = "Resource Passed"
-	ResourceGenerated Action = "Resource Generated"
-	ResourceMutated   Action = "Resource Mutated"
-	ResourceCleanedUp Action = "Resource Cleaned Up"
-	None              Action = "None"
+	ResourceBlocked        Action = "Resource Blocked"
+	ResourcePassed         Action = "Resource Passed"
+	ResourceGenerated      Action = "Resource Generated"
+	ResourceMutated        Action = "Resource Mutated"
+	ResourceCleanedUp      Action = "Resource Cleaned Up"
+	ResourceWouldBeBlocked Action = "Resource Would Be Blocked"
+	None                   Action = "None"
 )
@@ -3,6 +3,7 @@ package event
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
@@ -164,6 +165,65 @@ func NewResourceGenerationEvent(policy, rule string, source Source, resource kyv
 	}
 }
 
+func NewGenerateDriftEvent(policy kyvernov1.PolicyInterface, rule, drift string, resource kyvernov1.ResourceSpec) Info {
+	return Info{
+		Regarding: corev1.ObjectReference{
+			APIVersion: "kyverno.io/v1",
+			Kind:       policy.GetKind(),
+			Name:       policy.GetName(),
+			Namespace:  policy.GetNamespace(),
+			UID:        policy.GetUID(),
+		},
+		Related: &corev1.ObjectReference{
+			APIVersion: resource.APIVersion,
+			Kind:       resource.Kind,
+			Name:       resource.Name,
+			Namespace:  resource.Namespace,
+			UID:        resource.UID,
+		},
+		Source:  GeneratePolicyController,
+		Reason:  GenerateDrift,
+		Message: fmt.Sprintf("downstream resource %s of rule %s is %s", resource.String(), rule, drift),
+		Action:  None,
+	}
+}
+
+func NewGenerateOrphanEvent(resource unstructured.Unstructured, reason string, deleted bool, err error) Info {
+	regarding := corev1.ObjectReference{
+		APIVersion: resource.GetAPIVersion(),
+		Kind:       resource.GetKind(),
+		Name:       resource.GetName(),
+		Namespace:  resource.GetNamespace(),
+		UID:        resource.GetUID(),
+	}
+	if err != nil {
+		return Info{
+			Regarding: regarding,
+			Source:    GeneratePolicyController,
+			Reason:    GenerateOrphan,
+			Message:   fmt.Sprintf("failed to delete orphaned generated resource (%s): %v", reason, err),
+			Action:    None,
+		}
+	}
+	if deleted {
+		return Info{
+			Regarding: regarding,
+			Source:    GeneratePolicyController,
+			Reason:    GenerateOrphan,
+			Message:   fmt.Sprintf("deleted orphaned generated resource (%s)", reason),
+			Action:    ResourceCleanedUp,
+			Type:      corev1.EventTypeNormal,
+		}
+	}
+	return Info{
+		Regarding: regarding,
+		Source:    GeneratePolicyController,
+		Reason:    GenerateOrphan,
+		Message:   fmt.Sprintf("generated resource is orphaned (%s)", reason),
+		Action:    None,
+	}
+}
+
 func NewBackgroundFailedEvent(err error, policy engineapi.GenericPolicy, rule string, source Source, resource kyvernov1.ResourceSpec) []Info {
 	var events []Info
 	regarding := corev1.ObjectReference{
@@ -344,6 +404,69 @@ func NewCleanupPolicyEvent(policy kyvernov2.CleanupPolicyInterface, resource uns
 	}
 }
 
+// NewCleanupScheduledEvent returns the event emitted before a resource is deleted by a cleanup policy,
+// regarding is the controller owner of the resource or the resource itself
+func NewCleanupScheduledEvent(policy kyvernov2.CleanupPolicyInterface, regarding corev1.ObjectReference, resource unstructured.Unstructured, message string) Info {
+	related := &corev1.ObjectReference{
+		APIVersion: resource.GetAPIVersion(),
+		Kind:       resource.GetKind(),
+		Namespace:  resource.GetNamespace(),
+		Name:       resource.GetName(),
+		UID:        resource.GetUID(),
+	}
+	if message == "" {
+		message = fmt.Sprintf("the resource %v/%v/%v is scheduled for deletion by %v %v", resource.GetKind(), resource.GetNamespace(), resource.GetName(), policy.GetKind(), policy.GetName())
+	}
+	return Info{
+		Regarding: regarding,
+		Related:   related,
+		Source:    CleanupController,
+		Action:    None,
+		Reason:    CleanupScheduled,
+		Message:   message,
+		Type:      corev1.EventTypeNormal,
+	}
+}
+
+// NewCleanupSummaryEvent returns the event summarizing a run of a cleanup policy
+func NewCleanupSummaryEvent(policy kyvernov2.CleanupPolicyInterface, message string, failed bool) Info {
+	eventType := corev1.EventTypeNormal
+	if failed {
+		eventType = corev1.EventTypeWarning
+	}
+	return Info{
+		Regarding: corev1.ObjectReference{
+			// TODO: iirc it's not safe to assume api version is set
+			APIVersion: "kyverno.io/v2",
+			Kind:       policy.GetKind(),
+			Name:       policy.GetName(),
+			Namespace:  policy.GetNamespace(),
+			UID:        policy.GetUID(),
+		},
+		Source:  CleanupController,
+		Action:  ResourceCleanedUp,
+		Reason:  CleanupSummary,
+		Message: message,
+		Type:    eventType,
+	}
+}
+
+// NewTTLEvent returns an event reporting the TTL status of a resource carrying the cleanup label
+func NewTTLEvent(regarding corev1.ObjectReference, reason Reason, message string) Info {
+	eventType := corev1.EventTypeNormal
+	if reason == TTLInvalid {
+		eventType = corev1.EventTypeWarning
+	}
+	return Info{
+		Regarding: regarding,
+		Source:    CleanupController,
+		Action:    None,
+		Reason:    reason,
+		Message:   message,
+		Type:      eventType,
+	}
+}
+
 func NewValidatingAdmissionPolicyEvent(policy engineapi.GenericPolicy, vapName, vapBindingName string) []Info {
 	regarding := corev1.ObjectReference{
 		// TODO: iirc it's not safe to assume api version is set
@@ -402,6 +525,41 @@ func NewFailedEvent(err error, policy, rule string, source Source, resource kyve
 	}
 }
 
+func NewPolicyLatencyBudgetEvent(engineResponse engineapi.EngineResponse, budget time.Duration, degradeFor time.Duration) Info {
+	pol := engineResponse.Policy()
+	resource := engineResponse.GetResourceSpec()
+	var b strings.Builder
+	if resource.Namespace != "" {
+		fmt.Fprintf(&b, "%s %s/%s", resource.Kind, resource.Namespace, resource.Name)
+	} else {
+		fmt.Fprintf(&b, "%s %s", resource.Kind, resource.Name)
+	}
+	fmt.Fprintf(&b, ": policy evaluation exceeded its latency budget of %s", budget)
+	if degradeFor > 0 {
+		fmt.Fprintf(&b, ", policy degraded for %s", degradeFor)
+	}
+	return Info{
+		Regarding: corev1.ObjectReference{
+			APIVersion: pol.GetAPIVersion(),
+			Kind:       pol.GetKind(),
+			Name:       pol.GetName(),
+			Namespace:  pol.GetNamespace(),
+			UID:        pol.GetUID(),
+		},
+		Related: &corev1.ObjectReference{
+			APIVersion: resource.APIVersion,
+			Kind:       resource.Kind,
+			Name:       resource.Name,
+			Namespace:  resource.Namespace,
+			UID:        types.UID(resource.UID),
+		},
+		Source:  AdmissionController,
+		Reason:  PolicyError,
+		Message: b.String(),
+		Action:  None,
+	}
+}
+
 func NewDeletingPolicyEvent(policy v1beta1.DeletingPolicyLike, resource unstructured.Unstructured, err error) Info {
 	regarding := corev1.ObjectReference{
 		APIVersion: schema.GroupVersion(v1beta1.GroupVersion).String(),
@@ -4,8 +4,16 @@ package event
 type Reason string
 
 const (
-	PolicyViolation Reason = "PolicyViolation"
-	PolicyApplied   Reason = "PolicyApplied"
-	PolicyError     Reason = "PolicyError"
-	PolicySkipped   Reason = "PolicySkipped"
+	PolicyViolation       Reason = "PolicyViolation"
+	PolicyApplied         Reason = "PolicyApplied"
+	PolicyError           Reason = "PolicyError"
+	PolicySkipped         Reason = "PolicySkipped"
+	PolicyShadowViolation Reason = "PolicyShadowViolation"
+	GenerateDrift         Reason = "GenerateDrift"
+	GenerateOrphan        Reason = "GenerateOrphan"
+	CleanupScheduled      Reason = "CleanupScheduled"
+	CleanupSummary        Reason = "CleanupSummary"
+	TTLScheduled          Reason = "TTLScheduled"
+	TTLPending            Reason = "TTLPending"
+	TTLInvalid            Reason = "TTLInvalid"
 )
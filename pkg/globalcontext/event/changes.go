@@ -0,0 +1,59 @@
+package event
+
+import (
+	"sync"
+)
+
+// DataChange is published when the data of a global context entry changes
+type DataChange struct {
+	// Name of the global context entry
+	Name string
+}
+
+// Subscriber is called for every data change, it must not block
+type Subscriber func(DataChange)
+
+// Broadcaster dispatches the data changes of the global context entries to its subscribers
+type Broadcaster interface {
+	// Subscribe registers a subscriber and returns the function unregistering it
+	Subscribe(Subscriber) func()
+	Publish(DataChange)
+}
+
+type broadcaster struct {
+	lock        sync.RWMutex
+	next        int
+	subscribers map[int]Subscriber
+}
+
+func NewBroadcaster() Broadcaster {
+	return &broadcaster{
+		subscribers: map[int]Subscriber{},
+	}
+}
+
+func (b *broadcaster) Subscribe(subscriber Subscriber) func() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	id := b.next
+	b.next++
+	b.subscribers[id] = subscriber
+	return func() {
+		b.lock.Lock()
+		defer b.lock.Unlock()
+		delete(b.subscribers, id)
+	}
+}
+
+func (b *broadcaster) Publish(change DataChange) {
+	// subscribers are called without holding the lock so that they can (un)subscribe
+	b.lock.RLock()
+	subscribers := make([]Subscriber, 0, len(b.subscribers))
+	for _, subscriber := range b.subscribers {
+		subscribers = append(subscribers, subscriber)
+	}
+	b.lock.RUnlock()
+	for _, subscriber := range subscribers {
+		subscriber(change)
+	}
+}
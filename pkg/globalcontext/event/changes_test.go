@@ -0,0 +1,36 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroadcaster(t *testing.T) {
+	b := NewBroadcaster()
+	// publishing without subscribers is a no-op
+	b.Publish(DataChange{Name: "ignored"})
+
+	var first, second []string
+	b.Subscribe(func(change DataChange) { first = append(first, change.Name) })
+	unsubscribe := b.Subscribe(func(change DataChange) { second = append(second, change.Name) })
+	b.Publish(DataChange{Name: "allowed-cidrs"})
+	unsubscribe()
+	b.Publish(DataChange{Name: "deployments"})
+
+	assert.Equal(t, []string{"allowed-cidrs", "deployments"}, first)
+	assert.Equal(t, []string{"allowed-cidrs"}, second)
+}
+
+func TestBroadcaster_UnsubscribeWhilePublishing(t *testing.T) {
+	b := NewBroadcaster()
+	var calls int
+	var unsubscribe func()
+	unsubscribe = b.Subscribe(func(DataChange) {
+		calls++
+		unsubscribe()
+	})
+	b.Publish(DataChange{Name: "allowed-cidrs"})
+	b.Publish(DataChange{Name: "allowed-cidrs"})
+	assert.Equal(t, 1, calls)
+}
@@ -18,6 +18,7 @@ import (
 	entryevent "github.com/kyverno/kyverno/pkg/globalcontext/event"
 	"github.com/kyverno/kyverno/pkg/globalcontext/store"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -36,6 +37,7 @@ func New(
 	ctx context.Context,
 	gce *kyvernov2beta1.GlobalContextEntry,
 	eventGen event.Interface,
+	changes entryevent.Broadcaster,
 	kyvernoClient versioned.Interface,
 	gceLister kyvernov2beta1listers.GlobalContextEntryLister,
 	logger logr.Logger,
@@ -92,10 +94,14 @@ func New(
 					UID:        gce.UID,
 				}, err))
 			} else {
-				e.setData(data, nil)
+				changed := e.setData(data, nil)
 
 				logger.V(4).Info("api call success", "data", data)
 
+				if changed && changes != nil {
+					changes.Publish(entryevent.DataChange{Name: gce.Name})
+				}
+
 				if shouldUpdateStatus {
 					if updateErr := updateStatus(ctx, gce, kyvernoClient); updateErr != nil {
 						logger.Error(updateErr, "failed to update status")
@@ -130,7 +136,8 @@ func (e *entry) Stop() {
 	e.stop()
 }
 
-func (e *entry) setData(data any, err error) {
+// setData stores the data returned by the api call, it returns true if the data changed
+func (e *entry) setData(data any, err error) bool {
 	e.Lock()
 	defer e.Unlock()
 
@@ -142,23 +149,26 @@ func (e *entry) setData(data any, err error) {
 			err = json.Unmarshal(bytes, &jsonData)
 			if err != nil {
 				e.err = err
-				return
+				return false
 			}
 		} else {
 			e.err = fmt.Errorf("data is not a byte array")
-			return
+			return false
 		}
+		previous, loaded := e.dataMap[""]
 		e.dataMap[""] = jsonData
 		for _, projection := range e.projections {
 			result, err := projection.JP.Search(jsonData)
 			if err != nil {
 				e.err = err
-				return
+				return false
 			}
 			e.dataMap[projection.Name] = result
 		}
 		e.err = nil
+		return !loaded || !datautils.DeepEqual(previous, jsonData)
 	}
+	return false
 }
 
 func doCall(ctx context.Context, caller apicall.Executor, call kyvernov1.APICall, retryLimit int) (any, error) {
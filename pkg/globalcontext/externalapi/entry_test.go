@@ -130,6 +130,18 @@ func TestEntry_Stop_WithNilStopFunction(t *testing.T) {
 	}, "calling Stop with nil stop function should panic")
 }
 
+func TestEntry_SetData_ReportsChanges(t *testing.T) {
+	e := &entry{
+		dataMap:     make(map[string]any),
+		projections: []store.Projection{},
+	}
+
+	assert.True(t, e.setData([]byte(`{"cidrs": ["10.0.0.0/8"]}`), nil), "first data should be reported as a change")
+	assert.False(t, e.setData([]byte(`{"cidrs": ["10.0.0.0/8"]}`), nil), "same data should not be reported as a change")
+	assert.False(t, e.setData(nil, fmt.Errorf("call failed")), "errors should not be reported as a change")
+	assert.True(t, e.setData([]byte(`{"cidrs": ["10.0.0.0/8", "192.168.0.0/16"]}`), nil), "new data should be reported as a change")
+}
+
 func TestEntry_SetData_WithValidJSON(t *testing.T) {
 	e := &entry{
 		dataMap:     make(map[string]any),
@@ -3,7 +3,9 @@ package k8sresource
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
@@ -22,11 +24,16 @@ import (
 	"k8s.io/client-go/tools/cache"
 )
 
+// changeDelay is the delay coalescing the bursts of changes of the watched resources into a single data change
+const changeDelay = 5 * time.Second
+
 type entry struct {
 	lister      cache.GenericLister
 	stop        func()
 	gce         *kyvernov2beta1.GlobalContextEntry
 	eventGen    event.Interface
+	changes     entryevent.Broadcaster
+	synced      cache.InformerSynced
 	projections []store.Projection
 	jp          jmespath.Interface
 
@@ -35,12 +42,19 @@ type entry struct {
 	// Raw data is read directly from the lister to avoid memory duplication
 	projectedMu sync.RWMutex
 	projected   map[string]interface{}
+
+	// pending data change publication
+	changeDelay  time.Duration
+	changesMu    sync.Mutex
+	changesTimer *time.Timer
+	stopped      bool
 }
 
 func New(
 	ctx context.Context,
 	gce *kyvernov2beta1.GlobalContextEntry,
 	eventGen event.Interface,
+	changes entryevent.Broadcaster,
 	dClient dynamic.Interface,
 	logger logr.Logger,
 	gvr schema.GroupVersionResource,
@@ -100,18 +114,25 @@ func New(
 		stop:        stop,
 		gce:         gce,
 		eventGen:    eventGen,
+		changes:     changes,
+		synced:      informer.Informer().HasSynced,
 		projections: projections,
 		jp:          jp,
 		projected:   make(map[string]interface{}),
+		changeDelay: changeDelay,
 	}
 
-	// Only add event handlers if projections are defined
-	// This avoids unnecessary processing when projections are not used
-	if len(projections) > 0 {
+	// Only add event handlers if projections are defined or data changes are published
+	// This avoids unnecessary processing when neither is used
+	if len(projections) > 0 || changes != nil {
 		_, err := informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-			AddFunc:    func(obj interface{}) { e.recomputeProjections() },
-			UpdateFunc: func(oldObj, newObj interface{}) { e.recomputeProjections() },
-			DeleteFunc: func(obj interface{}) { e.recomputeProjections() },
+			AddFunc: func(obj interface{}) { e.onChange() },
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				if dataChanged(oldObj, newObj) {
+					e.onChange()
+				}
+			},
+			DeleteFunc: func(obj interface{}) { e.onChange() },
 		})
 		if err != nil {
 			return nil, err
@@ -162,6 +183,55 @@ func (e *entry) listObjects() ([]interface{}, error) {
 	return list, nil
 }
 
+func (e *entry) onChange() {
+	if len(e.projections) > 0 {
+		e.recomputeProjections()
+	}
+	// the objects listed before the cache is synced are not changes
+	if e.changes != nil && e.synced() {
+		e.schedulePublish()
+	}
+}
+
+// schedulePublish publishes a data change once the changes received during changeDelay are coalesced
+func (e *entry) schedulePublish() {
+	e.changesMu.Lock()
+	defer e.changesMu.Unlock()
+	if e.stopped || e.changesTimer != nil {
+		return
+	}
+	e.changesTimer = time.AfterFunc(e.changeDelay, func() {
+		e.changesMu.Lock()
+		e.changesTimer = nil
+		stopped := e.stopped
+		e.changesMu.Unlock()
+		if !stopped {
+			e.changes.Publish(entryevent.DataChange{Name: e.gce.Name})
+		}
+	})
+}
+
+// dataChanged returns true if an update changed the object beyond its resource version and managed fields,
+// the updates received when the informer relists the same objects are not data changes
+func dataChanged(oldObj, newObj interface{}) bool {
+	oldU, ok := oldObj.(*unstructured.Unstructured)
+	if !ok {
+		return true
+	}
+	newU, ok := newObj.(*unstructured.Unstructured)
+	if !ok {
+		return true
+	}
+	return !reflect.DeepEqual(withoutVolatileFields(oldU), withoutVolatileFields(newU))
+}
+
+func withoutVolatileFields(obj *unstructured.Unstructured) map[string]interface{} {
+	obj = obj.DeepCopy()
+	obj.SetResourceVersion("")
+	obj.SetManagedFields(nil)
+	return obj.Object
+}
+
 func (e *entry) recomputeProjections() {
 	list, err := e.listObjects()
 	if err != nil {
@@ -210,5 +280,12 @@ func (e *entry) Get(projection string) (any, error) {
 }
 
 func (e *entry) Stop() {
+	e.changesMu.Lock()
+	e.stopped = true
+	if e.changesTimer != nil {
+		e.changesTimer.Stop()
+		e.changesTimer = nil
+	}
+	e.changesMu.Unlock()
 	e.stop()
 }
@@ -3,9 +3,11 @@ package k8sresource
 import (
 	"sync"
 	"testing"
+	"time"
 
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
 	"github.com/kyverno/kyverno/pkg/event"
+	entryevent "github.com/kyverno/kyverno/pkg/globalcontext/event"
 	"github.com/kyverno/kyverno/pkg/globalcontext/store"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -474,3 +476,53 @@ func TestEntry_ConcurrentAccess(t *testing.T) {
 	// Should not panic or race
 	assert.Equal(t, "initial", e.projected["test"])
 }
+
+func TestDataChanged(t *testing.T) {
+	newObj := func(resourceVersion, value string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": "cidrs"},
+			"data":       map[string]interface{}{"allowed": value},
+		}}
+		obj.SetResourceVersion(resourceVersion)
+		obj.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kubectl-" + resourceVersion}})
+		return obj
+	}
+	// relisted object
+	assert.False(t, dataChanged(newObj("1", "10.0.0.0/8"), newObj("1", "10.0.0.0/8")))
+	// only the resource version and managed fields changed
+	assert.False(t, dataChanged(newObj("1", "10.0.0.0/8"), newObj("2", "10.0.0.0/8")))
+	assert.True(t, dataChanged(newObj("1", "10.0.0.0/8"), newObj("2", "192.168.0.0/16")))
+}
+
+func TestEntry_ChangesCoalesced(t *testing.T) {
+	changes := entryevent.NewBroadcaster()
+	published := make(chan entryevent.DataChange, 10)
+	changes.Subscribe(func(change entryevent.DataChange) { published <- change })
+	e := &entry{
+		gce:         &kyvernov2beta1.GlobalContextEntry{ObjectMeta: metav1.ObjectMeta{Name: "cidrs"}},
+		changes:     changes,
+		synced:      func() bool { return true },
+		stop:        func() {},
+		changeDelay: 50 * time.Millisecond,
+	}
+
+	for i := 0; i < 5; i++ {
+		e.onChange()
+	}
+	select {
+	case change := <-published:
+		assert.Equal(t, "cidrs", change.Name)
+	case <-time.After(5 * time.Second):
+		t.Fatal("data change not published")
+	}
+	time.Sleep(100 * time.Millisecond)
+	assert.Empty(t, published)
+
+	// no change is published once the entry is stopped
+	e.onChange()
+	e.Stop()
+	time.Sleep(100 * time.Millisecond)
+	assert.Empty(t, published)
+}
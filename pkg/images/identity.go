@@ -0,0 +1,101 @@
+package images
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+// identityTemplate matches the `{{placeholder}}` of a keyless identity template
+var identityTemplate = regexp.MustCompile(`\{\{\s*([A-Za-z]+)\s*\}\}`)
+
+// IsIdentityTemplate returns true if a keyless subject or issuer references the image with placeholders
+func IsIdentityTemplate(value string) bool {
+	return identityTemplate.MatchString(value)
+}
+
+// ExpandIdentityTemplate replaces the placeholders of a keyless subject or issuer with the parts of the
+// verified image reference, for example `https://github.com/{{org}}/{{repo}}/.github/workflows/*` for the
+// image `ghcr.io/kyverno/kyverno:latest` gives `https://github.com/kyverno/kyverno/.github/workflows/*`.
+// The supported placeholders are:
+//   - `{{registry}}`: the registry, e.g. `ghcr.io`
+//   - `{{repository}}`: the repository path in the registry, e.g. `kyverno/kyverno`
+//   - `{{org}}`: the first segment of the repository path, e.g. `kyverno`
+//   - `{{repo}}`: the second segment of the repository path, e.g. `kyverno`
+//   - `{{tag}}`: the tag of the image, empty for digest references
+//
+// The replaced values are quoted when the template is a regular expression.
+func ExpandIdentityTemplate(template string, ref name.Reference, regExp bool) (string, error) {
+	if !IsIdentityTemplate(template) {
+		return template, nil
+	}
+	repository := ref.Context().RepositoryStr()
+	segments := strings.Split(repository, "/")
+	var errs []string
+	expanded := identityTemplate.ReplaceAllStringFunc(template, func(match string) string {
+		placeholder := identityTemplate.FindStringSubmatch(match)[1]
+		var value string
+		switch placeholder {
+		case "registry":
+			value = ref.Context().RegistryStr()
+		case "repository":
+			value = repository
+		case "org":
+			value = segments[0]
+		case "repo":
+			if len(segments) < 2 {
+				errs = append(errs, fmt.Sprintf("repository %s has no second path segment for {{repo}}", repository))
+				return match
+			}
+			value = segments[1]
+		case "tag":
+			if tag, ok := ref.(name.Tag); ok {
+				value = tag.TagStr()
+			}
+		default:
+			errs = append(errs, fmt.Sprintf("unknown placeholder %s", match))
+			return match
+		}
+		if regExp {
+			return regexp.QuoteMeta(value)
+		}
+		return value
+	})
+	if len(errs) != 0 {
+		return "", fmt.Errorf("failed to expand identity template %s for image %s: %s", template, ref.Name(), strings.Join(errs, ", "))
+	}
+	return expanded, nil
+}
+
+// ExpandIdentityTemplates returns the options with the keyless subject and issuer templates expanded
+// for the verified image
+func ExpandIdentityTemplates(opts Options) (Options, error) {
+	if !IsIdentityTemplate(opts.Subject) && !IsIdentityTemplate(opts.SubjectRegExp) &&
+		!IsIdentityTemplate(opts.Issuer) && !IsIdentityTemplate(opts.IssuerRegExp) {
+		return opts, nil
+	}
+	var nameOpts []name.Option
+	if opts.Client != nil {
+		nameOpts = opts.Client.NameOptions()
+	}
+	ref, err := name.ParseReference(opts.ImageRef, nameOpts...)
+	if err != nil {
+		return opts, fmt.Errorf("failed to parse image %s: %w", opts.ImageRef, err)
+	}
+	for _, field := range []struct {
+		value  *string
+		regExp bool
+	}{
+		{&opts.Subject, false},
+		{&opts.SubjectRegExp, true},
+		{&opts.Issuer, false},
+		{&opts.IssuerRegExp, true},
+	} {
+		if *field.value, err = ExpandIdentityTemplate(*field.value, ref, field.regExp); err != nil {
+			return opts, err
+		}
+	}
+	return opts, nil
+}
@@ -0,0 +1,117 @@
+package images
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandIdentityTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		image    string
+		regExp   bool
+		want     string
+		wantErr  bool
+	}{{
+		name:     "no template",
+		template: "https://github.com/kyverno/kyverno/.github/workflows/release.yaml@refs/tags/v1.0.0",
+		image:    "ghcr.io/kyverno/kyverno:latest",
+		want:     "https://github.com/kyverno/kyverno/.github/workflows/release.yaml@refs/tags/v1.0.0",
+	}, {
+		name:     "org and repo",
+		template: "https://github.com/{{org}}/{{repo}}/.github/workflows/*",
+		image:    "ghcr.io/kyverno/kyverno:latest",
+		want:     "https://github.com/kyverno/kyverno/.github/workflows/*",
+	}, {
+		name:     "spaces in placeholders",
+		template: "https://github.com/{{ org }}/{{ repo }}/.github/workflows/*",
+		image:    "ghcr.io/acme/payments/api:v1",
+		want:     "https://github.com/acme/payments/.github/workflows/*",
+	}, {
+		name:     "registry repository and tag",
+		template: "{{registry}}/{{repository}}@{{tag}}",
+		image:    "ghcr.io/acme/payments/api:v1",
+		want:     "ghcr.io/acme/payments/api@v1",
+	}, {
+		name:     "docker hub image",
+		template: "{{registry}}/{{org}}/{{repo}}",
+		image:    "nginx",
+		want:     "index.docker.io/library/nginx",
+	}, {
+		name:     "digest reference has no tag",
+		template: "{{org}}/{{repo}}:{{tag}}",
+		image:    "ghcr.io/kyverno/kyverno@sha256:b31bfb4d0213f254d361e0079deaaebefa4f82ba7aa76ef82e90b4935ad5b105",
+		want:     "kyverno/kyverno:",
+	}, {
+		name:     "regular expression values are quoted",
+		template: `^https://github\.com/{{org}}/{{repo}}/\.github/workflows/.+$`,
+		image:    "registry.acme.io/acme.corp/web.app:v1",
+		regExp:   true,
+		want:     `^https://github\.com/acme\.corp/web\.app/\.github/workflows/.+$`,
+	}, {
+		name:     "missing repo segment",
+		template: "https://github.com/{{org}}/{{repo}}",
+		image:    "localhost:5000/app:v1",
+		wantErr:  true,
+	}, {
+		name:     "unknown placeholder",
+		template: "https://github.com/{{owner}}/{{repo}}",
+		image:    "ghcr.io/kyverno/kyverno:latest",
+		wantErr:  true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, err := name.ParseReference(tt.image)
+			assert.NoError(t, err)
+			got, err := ExpandIdentityTemplate(tt.template, ref, tt.regExp)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExpandIdentityTemplate_RegExpMatch(t *testing.T) {
+	ref, err := name.ParseReference("ghcr.io/acme.corp/web.app:v1")
+	assert.NoError(t, err)
+	expanded, err := ExpandIdentityTemplate(`^https://github\.com/{{org}}/{{repo}}/`, ref, true)
+	assert.NoError(t, err)
+	regex := regexp.MustCompile(expanded)
+	assert.True(t, regex.MatchString("https://github.com/acme.corp/web.app/.github/workflows/release.yaml@refs/heads/main"))
+	// the dots of the image path are not wildcards
+	assert.False(t, regex.MatchString("https://github.com/acmeXcorp/web.app/.github/workflows/release.yaml@refs/heads/main"))
+}
+
+func TestExpandIdentityTemplates(t *testing.T) {
+	opts := Options{
+		ImageRef:      "ghcr.io/kyverno/kyverno:latest",
+		Subject:       "https://github.com/{{org}}/{{repo}}/.github/workflows/*",
+		SubjectRegExp: `^https://github\.com/{{org}}/`,
+		Issuer:        "https://token.actions.githubusercontent.com",
+		IssuerRegExp:  "",
+	}
+	expanded, err := ExpandIdentityTemplates(opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.com/kyverno/kyverno/.github/workflows/*", expanded.Subject)
+	assert.Equal(t, `^https://github\.com/kyverno/`, expanded.SubjectRegExp)
+	assert.Equal(t, "https://token.actions.githubusercontent.com", expanded.Issuer)
+	assert.Empty(t, expanded.IssuerRegExp)
+	// the original options are left untouched
+	assert.Equal(t, "https://github.com/{{org}}/{{repo}}/.github/workflows/*", opts.Subject)
+
+	opts.ImageRef = "localhost:5000/app:v1"
+	_, err = ExpandIdentityTemplates(opts)
+	assert.Error(t, err)
+
+	opts = Options{ImageRef: "not a valid image reference", Subject: "kyverno@acme.io"}
+	expanded, err = ExpandIdentityTemplates(opts)
+	assert.NoError(t, err)
+	assert.Equal(t, opts, expanded)
+}
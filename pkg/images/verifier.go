@@ -2,6 +2,7 @@ package images
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
@@ -20,6 +21,7 @@ type Client interface {
 	Keychain() authn.Keychain
 	Options(context.Context) ([]gcrremote.Option, error)
 	NameOptions() []name.Option
+	References(name.Reference) []name.Reference
 }
 
 type Options struct {
@@ -49,9 +51,25 @@ type Options struct {
 	PredicateType        string
 	Type                 string
 	Identities           string
+	TrustPolicy          string
 }
 
 type Response struct {
 	Digest     string
 	Statements []map[string]interface{}
 }
+
+// FromReferences calls fetch with the references an image is fetched from, the registry mirrors
+// first, until it succeeds. The errors of all the attempts are returned when none succeeded.
+func FromReferences[T any](client Client, ref name.Reference, fetch func(name.Reference) (T, error)) (T, error) {
+	var errs []error
+	for _, ref := range client.References(ref) {
+		result, err := fetch(ref)
+		if err == nil {
+			return result, nil
+		}
+		errs = append(errs, err)
+	}
+	var zero T
+	return zero, errors.Join(errs...)
+}
@@ -0,0 +1,82 @@
+package cosign
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	"github.com/kyverno/kyverno/pkg/imageverification/imagedataloader"
+	"github.com/kyverno/kyverno/pkg/imageverification/offline"
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
+)
+
+// loadOffline returns the offline verification material when an attestor can be verified against it,
+// nil when no material is configured or when the attestor has settings the material can't honour,
+// these attestors are verified online
+func loadOffline(ctx context.Context, logger logr.Logger, attestor *v1beta1.Cosign) (*offline.Material, error) {
+	material, err := offline.Load(ctx)
+	if err != nil || material == nil {
+		return nil, err
+	}
+	if reason := offlineConflict(attestor); reason != "" {
+		logger.V(2).Info("verifying image online, the attestor is not supported with offline verification", "reason", reason)
+		return nil, nil
+	}
+	return material, nil
+}
+
+// offlineConflict returns why the settings of an attestor conflict with offline verification, empty
+// when they don't: the trusted root of the material replaces the CT log, TUF and Fulcio roots, and the
+// bundles are checked against keyless identities or public keys only
+func offlineConflict(attestor *v1beta1.Cosign) string {
+	switch {
+	case attestor.Certificate != nil:
+		return "certificate attestors are not supported"
+	case attestor.Key != nil && attestor.Key.Data == "":
+		return "only public key data is supported"
+	case attestor.Keyless == nil && attestor.Key == nil:
+		return "only keyless and public key attestors are supported"
+	case len(attestor.Annotations) != 0:
+		return "annotations are not supported"
+	case attestor.CTLog != nil && (attestor.CTLog.URL != "" || attestor.CTLog.RekorPubKey != "" || attestor.CTLog.CTLogPubKey != "" || attestor.CTLog.TSACertChain != ""):
+		return "custom CT log settings are not supported"
+	case attestor.TUF != nil || attestor.Keyless != nil && attestor.Keyless.Roots != "":
+		return "custom roots are not supported"
+	}
+	return ""
+}
+
+// verifyOffline verifies an image against the pre-distributed verification material, predicateType
+// restricts the verification to the attestations of the given type
+func verifyOffline(material *offline.Material, image *imagedataloader.ImageData, attestor *v1beta1.Cosign, predicateType string) ([]offline.Result, error) {
+	opts := offline.Options{}
+	if attestor.CTLog != nil {
+		opts.IgnoreTlog = attestor.CTLog.InsecureIgnoreTlog
+		opts.IgnoreSCT = attestor.CTLog.InsecureIgnoreSCT
+	}
+	if predicateType != "" {
+		// resolve the short predicate type names the same way cosign does
+		if uri, ok := options.PredicateTypeMap[predicateType]; ok {
+			predicateType = uri
+		}
+		opts.PredicateType = predicateType
+	}
+	switch {
+	case attestor.Keyless != nil:
+		for _, id := range attestor.Keyless.Identities {
+			opts.Identities = append(opts.Identities, offline.Identity{
+				Issuer:        id.Issuer,
+				IssuerRegExp:  id.IssuerRegExp,
+				Subject:       id.Subject,
+				SubjectRegExp: id.SubjectRegExp,
+			})
+		}
+	case attestor.Key != nil && attestor.Key.Data != "":
+		opts.PublicKey = attestor.Key.Data
+		opts.HashAlgorithm = signatureAlgorithmMap[attestor.Key.HashAlgorithm]
+	default:
+		return nil, fmt.Errorf("only keyless and public key attestors are supported with offline verification")
+	}
+	return material.Verify(image.Digest, opts)
+}
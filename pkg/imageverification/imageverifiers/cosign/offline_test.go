@@ -0,0 +1,51 @@
+package cosign
+
+import (
+	"testing"
+
+	"github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_offlineConflict(t *testing.T) {
+	keyless := &v1beta1.Keyless{Identities: []v1beta1.Identity{{Issuer: "https://github.com/", Subject: "jim"}}}
+	tests := []struct {
+		name     string
+		attestor *v1beta1.Cosign
+		want     string
+	}{{
+		name:     "keyless",
+		attestor: &v1beta1.Cosign{Keyless: keyless},
+	}, {
+		name:     "key",
+		attestor: &v1beta1.Cosign{Key: &v1beta1.Key{Data: testPublicKey}},
+	}, {
+		name:     "ignore tlog",
+		attestor: &v1beta1.Cosign{Key: &v1beta1.Key{Data: testPublicKey}, CTLog: &v1beta1.CTLog{InsecureIgnoreTlog: true}},
+	}, {
+		name:     "kms key",
+		attestor: &v1beta1.Cosign{Key: &v1beta1.Key{KMS: "awskms:///key"}},
+		want:     "only public key data is supported",
+	}, {
+		name:     "certificate",
+		attestor: &v1beta1.Cosign{Certificate: &v1beta1.Certificate{}},
+		want:     "certificate attestors are not supported",
+	}, {
+		name:     "annotations",
+		attestor: &v1beta1.Cosign{Keyless: keyless, Annotations: map[string]string{"foo": "bar"}},
+		want:     "annotations are not supported",
+	}, {
+		name:     "custom ctlog",
+		attestor: &v1beta1.Cosign{Keyless: keyless, CTLog: &v1beta1.CTLog{URL: "https://rekor.example.com"}},
+		want:     "custom CT log settings are not supported",
+	}, {
+		name:     "custom tuf",
+		attestor: &v1beta1.Cosign{Keyless: keyless, TUF: &v1beta1.TUF{Mirror: "https://tuf.example.com"}},
+		want:     "custom roots are not supported",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, offlineConflict(tt.attestor))
+		})
+	}
+}
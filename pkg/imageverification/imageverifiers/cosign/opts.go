@@ -10,6 +10,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	"github.com/kyverno/kyverno/pkg/images"
 	"github.com/kyverno/kyverno/pkg/imageverification/imagedataloader"
 	"github.com/sigstore/cosign/v3/pkg/blob"
 	"github.com/sigstore/cosign/v3/pkg/cosign"
@@ -170,6 +171,41 @@ func checkOptions(ctx context.Context, att *v1beta1.Cosign, baseROpts []remote.O
 	return opts, nil
 }
 
+// expandIdentities returns the attestor with the keyless subject and issuer templates of its identities
+// expanded for the verified image, the attestor is returned as is when it has no template
+func expandIdentities(att *v1beta1.Cosign, ref name.Reference) (*v1beta1.Cosign, error) {
+	if att.Keyless == nil {
+		return att, nil
+	}
+	expanded := att
+	for i, id := range att.Keyless.Identities {
+		if !images.IsIdentityTemplate(id.Subject) && !images.IsIdentityTemplate(id.SubjectRegExp) &&
+			!images.IsIdentityTemplate(id.Issuer) && !images.IsIdentityTemplate(id.IssuerRegExp) {
+			continue
+		}
+		if expanded == att {
+			expanded = att.DeepCopy()
+		}
+		id := &expanded.Keyless.Identities[i]
+		for _, field := range []struct {
+			value  *string
+			regExp bool
+		}{
+			{&id.Subject, false},
+			{&id.SubjectRegExp, true},
+			{&id.Issuer, false},
+			{&id.IssuerRegExp, true},
+		} {
+			value, err := images.ExpandIdentityTemplate(*field.value, ref, field.regExp)
+			if err != nil {
+				return nil, err
+			}
+			*field.value = value
+		}
+	}
+	return expanded, nil
+}
+
 func initializeTuf(ctx context.Context, t *v1beta1.TUF) error {
 	if t != nil {
 		var root []byte
@@ -543,3 +543,43 @@ func TestCheckOptions_TSACertChain_UseSignedTimestamps(t *testing.T) {
 		})
 	}
 }
+
+func TestExpandIdentities(t *testing.T) {
+	ref, err := name.ParseReference("ghcr.io/acme/payments:v1")
+	require.NoError(t, err)
+
+	cosignCfg := &v1beta1.Cosign{
+		Keyless: &v1beta1.Keyless{
+			Identities: []v1beta1.Identity{
+				{
+					Issuer:  testIssuer,
+					Subject: testSubject,
+				},
+				{
+					Issuer:        testIssuer,
+					Subject:       "https://github.com/{{org}}/{{repo}}/.github/workflows/*",
+					SubjectRegExp: `^https://github\.com/{{org}}/{{repo}}/`,
+				},
+			},
+		},
+	}
+
+	expanded, err := expandIdentities(cosignCfg, ref)
+	require.NoError(t, err)
+	assert.Equal(t, testSubject, expanded.Keyless.Identities[0].Subject)
+	assert.Equal(t, testIssuer, expanded.Keyless.Identities[1].Issuer)
+	assert.Equal(t, "https://github.com/acme/payments/.github/workflows/*", expanded.Keyless.Identities[1].Subject)
+	assert.Equal(t, `^https://github\.com/acme/payments/`, expanded.Keyless.Identities[1].SubjectRegExp)
+	// the attestor of the policy is left untouched
+	assert.Equal(t, "https://github.com/{{org}}/{{repo}}/.github/workflows/*", cosignCfg.Keyless.Identities[1].Subject)
+
+	// attestors without templates are not copied
+	cosignCfg.Keyless.Identities = cosignCfg.Keyless.Identities[:1]
+	expanded, err = expandIdentities(cosignCfg, ref)
+	require.NoError(t, err)
+	assert.Same(t, cosignCfg, expanded)
+
+	cosignCfg.Keyless.Identities[0].Subject = "https://github.com/{{owner}}/*"
+	_, err = expandIdentities(cosignCfg, ref)
+	assert.Error(t, err)
+}
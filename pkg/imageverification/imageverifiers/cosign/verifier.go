@@ -45,6 +45,20 @@ func (v *Verifier) buildCheckOptsWithBundleDetection(ctx context.Context, attest
 	return cOpts, nil
 }
 
+// withExpandedIdentities returns the attestor with its keyless identity templates expanded for the image
+func withExpandedIdentities(attestor *policiesv1beta1.Attestor, image *imagedataloader.ImageData) (*policiesv1beta1.Attestor, error) {
+	att, err := expandIdentities(attestor.Cosign, image.NameRef())
+	if err != nil {
+		return nil, err
+	}
+	if att == attestor.Cosign {
+		return attestor, nil
+	}
+	expanded := *attestor
+	expanded.Cosign = att
+	return &expanded, nil
+}
+
 func (v *Verifier) VerifyImageSignature(ctx context.Context, image *imagedataloader.ImageData, attestor *policiesv1beta1.Attestor) error {
 	if attestor.Cosign == nil {
 		return fmt.Errorf("cosign verifier only supports cosign attestor")
@@ -53,6 +67,23 @@ func (v *Verifier) VerifyImageSignature(ctx context.Context, image *imagedataloa
 	logger := v.log.WithValues("image", image.Image, "digest", image.Digest, "attestor", attestor.Name)
 	logger.V(2).Info("verifying cosign image signature", "image", image.Image)
 
+	attestor, err := withExpandedIdentities(attestor, image)
+	if err != nil {
+		logger.Error(err, "image verification failed")
+		return err
+	}
+
+	if material, err := loadOffline(ctx, logger, attestor.Cosign); err != nil {
+		logger.Error(err, "image verification failed")
+		return err
+	} else if material != nil {
+		if _, err := verifyOffline(material, image, attestor.Cosign, ""); err != nil {
+			logger.Error(err, "image verification failed")
+			return err
+		}
+		return nil
+	}
+
 	cOpts, err := v.buildCheckOptsWithBundleDetection(ctx, attestor.Cosign, image)
 	if err != nil {
 		err := errors.Wrapf(err, "failed to build cosign verification opts")
@@ -119,6 +150,25 @@ func (v *Verifier) VerifyAttestationSignature(ctx context.Context, image *imaged
 	logger := v.log.WithValues("image", image.Image, "digest", image.Digest, "attestation", attestation.Name, "attestor", attestor.Name)
 	logger.V(2).Info("verifying cosign attestation signature", "image", image.Image)
 
+	attestor, err := withExpandedIdentities(attestor, image)
+	if err != nil {
+		logger.Error(err, "image verification failed")
+		return err
+	}
+
+	if material, err := loadOffline(ctx, logger, attestor.Cosign); err != nil {
+		logger.Error(err, "image verification failed")
+		return err
+	} else if material != nil {
+		results, err := verifyOffline(material, image, attestor.Cosign, attestation.InToto.Type)
+		if err != nil {
+			logger.Error(err, "image verification failed")
+			return err
+		}
+		image.AddVerifiedIntotoPayloads(attestation.InToto.Type, results[0].Payload)
+		return nil
+	}
+
 	cOpts, err := v.buildCheckOptsWithBundleDetection(ctx, attestor.Cosign, image)
 	if err != nil {
 		err := errors.Wrapf(err, "failed to build cosign verification opts")
@@ -0,0 +1,82 @@
+package predicates
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sigstore/cosign/v3/pkg/cosign/attestation"
+)
+
+const (
+	// SLSAProvenanceV1 is the predicate type of SLSA provenance v1 attestations
+	SLSAProvenanceV1 = "https://slsa.dev/provenance/v1"
+	// CycloneDX is the predicate type of CycloneDX SBOM attestations
+	CycloneDX = "https://cyclonedx.org/bom"
+	// SPDX is the predicate type of SPDX SBOM attestations
+	SPDX = "https://spdx.dev/Document"
+	// CosignVulnerabilityV1 is the predicate type of cosign vulnerability scan attestations
+	CosignVulnerabilityV1 = attestation.CosignVulnProvenanceV01
+)
+
+// statement holds the fields of an in-toto statement needed to extract its predicate
+type statement struct {
+	Type          string          `json:"_type"`
+	PredicateType string          `json:"predicateType"`
+	Predicate     json.RawMessage `json:"predicate"`
+}
+
+// predicate returns the predicate type and the raw predicate of a payload, the payload is either
+// an in-toto statement (as returned for in-toto attestations) or the predicate itself (as returned
+// for OCI referrers), in which case the predicate type is empty
+func predicate(payload any) (string, []byte, error) {
+	var data []byte
+	switch p := payload.(type) {
+	case nil:
+		return "", nil, fmt.Errorf("payload is empty")
+	case []byte:
+		data = p
+	case string:
+		data = []byte(p)
+	default:
+		var err error
+		if data, err = json.Marshal(payload); err != nil {
+			return "", nil, fmt.Errorf("failed to encode payload: %w", err)
+		}
+	}
+	var s statement
+	if err := json.Unmarshal(data, &s); err != nil {
+		return "", nil, fmt.Errorf("failed to decode payload: %w", err)
+	}
+	if s.PredicateType == "" || len(s.Predicate) == 0 {
+		return "", data, nil
+	}
+	return s.PredicateType, s.Predicate, nil
+}
+
+// checkPredicateType fails when the predicate type is set and doesn't match any of the expected types
+func checkPredicateType(predicateType string, expected ...string) error {
+	if predicateType == "" {
+		return nil
+	}
+	for _, e := range expected {
+		// versions are commonly appended to the predicate type, e.g. https://spdx.dev/Document/v2.3
+		if predicateType == e || strings.HasPrefix(predicateType, e+"/") {
+			return nil
+		}
+	}
+	return fmt.Errorf("unexpected predicate type %s, expected one of %s", predicateType, strings.Join(expected, ", "))
+}
+
+// ToMap converts a parsed predicate to its generic JSON representation
+func ToMap(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
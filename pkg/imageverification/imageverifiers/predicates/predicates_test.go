@@ -0,0 +1,153 @@
+package predicates
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const provenanceStatement = `{
+	"_type": "https://in-toto.io/Statement/v1",
+	"predicateType": "https://slsa.dev/provenance/v1",
+	"subject": [{"name": "ghcr.io/kyverno/kyverno", "digest": {"sha256": "abc"}}],
+	"predicate": {
+		"buildDefinition": {
+			"buildType": "https://slsa-framework.github.io/github-actions-buildtypes/workflow/v1",
+			"externalParameters": {"workflow": {"ref": "refs/tags/v1.15.0", "repository": "https://github.com/kyverno/kyverno"}}
+		},
+		"runDetails": {
+			"builder": {"id": "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_container_slsa3.yml@refs/tags/v2.1.0"},
+			"metadata": {"invocationId": "https://github.com/kyverno/kyverno/actions/runs/1/attempts/1"}
+		}
+	}
+}`
+
+const cycloneDX = `{
+	"bomFormat": "CycloneDX",
+	"specVersion": "1.5",
+	"components": [{
+		"name": "golang.org/x/net",
+		"version": "v0.38.0",
+		"purl": "pkg:golang/golang.org/x/net@v0.38.0",
+		"licenses": [{"license": {"id": "BSD-3-Clause"}}],
+		"components": [{"name": "golang.org/x/net/http2", "version": "v0.38.0", "licenses": [{"expression": "MIT OR Apache-2.0"}]}]
+	}]
+}`
+
+const spdx = `{
+	"spdxVersion": "SPDX-2.3",
+	"packages": [{
+		"name": "openssl",
+		"versionInfo": "3.3.0",
+		"licenseConcluded": "NOASSERTION",
+		"licenseDeclared": "Apache-2.0",
+		"externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:apk/alpine/openssl@3.3.0"}]
+	}]
+}`
+
+const trivyVulnStatement = `{
+	"_type": "https://in-toto.io/Statement/v0.1",
+	"predicateType": "https://cosign.sigstore.dev/attestation/vuln/v1",
+	"predicate": {
+		"scanner": {
+			"uri": "pkg:github/aquasecurity/trivy@0.58.0",
+			"version": "0.58.0",
+			"result": {
+				"SchemaVersion": 2,
+				"Results": [{
+					"Target": "alpine",
+					"Vulnerabilities": [
+						{"VulnerabilityID": "CVE-2024-0001", "PkgName": "openssl", "InstalledVersion": "3.3.0", "FixedVersion": "3.3.1", "Severity": "CRITICAL", "PublishedDate": "2024-01-01T00:00:00Z"},
+						{"VulnerabilityID": "CVE-2025-0002", "PkgName": "busybox", "InstalledVersion": "1.36", "Severity": "CRITICAL", "PublishedDate": "2025-06-25T00:00:00Z"},
+						{"VulnerabilityID": "CVE-2024-0003", "PkgName": "zlib", "InstalledVersion": "1.3", "Severity": "LOW", "PublishedDate": "2024-01-01T00:00:00Z"}
+					]
+				}]
+			}
+		},
+		"metadata": {"scanStartedOn": "2025-07-01T00:00:00Z", "scanFinishedOn": "2025-07-01T00:01:00Z"}
+	}
+}`
+
+const grypeResult = `{
+	"matches": [{
+		"vulnerability": {"id": "GHSA-xxxx", "severity": "Critical", "fix": {"versions": ["1.2.3"]}},
+		"artifact": {"name": "lodash", "version": "1.0.0"}
+	}]
+}`
+
+func decode(t *testing.T, data string) any {
+	var out any
+	assert.NoError(t, json.Unmarshal([]byte(data), &out))
+	return out
+}
+
+func TestParseProvenance(t *testing.T) {
+	provenance, err := ParseProvenance(decode(t, provenanceStatement))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_container_slsa3.yml@refs/tags/v2.1.0", provenance.BuilderID())
+	assert.Equal(t, "https://github.com/kyverno/kyverno/actions/runs/1/attempts/1", provenance.RunDetails.BuildMetadata.InvocationID)
+	assert.True(t, provenance.BuilderAllowed("https://github.com/slsa-framework/slsa-github-generator/*"))
+	assert.False(t, provenance.BuilderAllowed("https://github.com/kyverno/*"))
+
+	_, err = ParseProvenance(decode(t, trivyVulnStatement))
+	assert.ErrorContains(t, err, "unexpected predicate type")
+	_, err = ParseProvenance(`{"buildDefinition": {}}`)
+	assert.ErrorContains(t, err, "no builder id")
+}
+
+func TestParseSBOM(t *testing.T) {
+	sbom, err := ParseSBOM(decode(t, cycloneDX))
+	assert.NoError(t, err)
+	assert.Equal(t, &SBOM{
+		Format:      FormatCycloneDX,
+		SpecVersion: "1.5",
+		Components: []Component{
+			{Name: "golang.org/x/net", Version: "v0.38.0", PURL: "pkg:golang/golang.org/x/net@v0.38.0", Licenses: []string{"BSD-3-Clause"}},
+			{Name: "golang.org/x/net/http2", Version: "v0.38.0", Licenses: []string{"MIT OR Apache-2.0"}},
+		},
+	}, sbom)
+	assert.True(t, sbom.HasComponent("golang.org/x/net/http2", ""))
+	assert.False(t, sbom.HasComponent("golang.org/x/net", "v0.1.0"))
+
+	statement := `{"_type": "https://in-toto.io/Statement/v0.1", "predicateType": "https://spdx.dev/Document/v2.3", "predicate": ` + spdx + `}`
+	sbom, err = ParseSBOM(statement)
+	assert.NoError(t, err)
+	assert.Equal(t, &SBOM{
+		Format:      FormatSPDX,
+		SpecVersion: "SPDX-2.3",
+		Components: []Component{
+			{Name: "openssl", Version: "3.3.0", PURL: "pkg:apk/alpine/openssl@3.3.0", Licenses: []string{"Apache-2.0"}},
+		},
+	}, sbom)
+
+	_, err = ParseSBOM(`{"foo": "bar"}`)
+	assert.ErrorContains(t, err, "unsupported SBOM format")
+	_, err = ParseSBOM(nil)
+	assert.Error(t, err)
+}
+
+func TestParseVulnerabilityScan(t *testing.T) {
+	scan, err := ParseVulnerabilityScan(decode(t, trivyVulnStatement))
+	assert.NoError(t, err)
+	assert.Equal(t, "0.58.0", scan.ScannerVersion)
+	assert.Len(t, scan.Vulnerabilities, 3)
+	assert.Equal(t, time.Date(2025, 7, 1, 0, 1, 0, 0, time.UTC), *scan.ScanFinishedOn)
+
+	now := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	assert.Len(t, scan.Filter("critical", 0, now), 2)
+	critical := scan.Filter("CRITICAL", 30*24*time.Hour, now)
+	assert.Len(t, critical, 1)
+	assert.Equal(t, "CVE-2024-0001", critical[0].ID)
+	assert.Len(t, scan.Filter("", 0, now), 3)
+
+	scan, err = ParseVulnerabilityScan(grypeResult)
+	assert.NoError(t, err)
+	assert.Equal(t, []Vulnerability{{ID: "GHSA-xxxx", Severity: "CRITICAL", Package: "lodash", InstalledVersion: "1.0.0", FixedVersion: "1.2.3"}}, scan.Vulnerabilities)
+	// the publication date is unknown, the vulnerability is considered old enough
+	assert.Len(t, scan.Filter("CRITICAL", 30*24*time.Hour, now), 1)
+
+	_, err = ParseVulnerabilityScan(`{"scanner": {"result": {"foo": "bar"}}}`)
+	assert.ErrorContains(t, err, "unsupported scan result")
+}
@@ -0,0 +1,48 @@
+package predicates
+
+import (
+	"encoding/json"
+	"fmt"
+
+	slsav1 "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v1"
+	"github.com/kyverno/kyverno/ext/wildcard"
+)
+
+// Provenance is a SLSA provenance v1 predicate
+type Provenance struct {
+	slsav1.ProvenancePredicate
+}
+
+// ParseProvenance parses a SLSA provenance v1 payload
+func ParseProvenance(payload any) (*Provenance, error) {
+	predicateType, data, err := predicate(payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPredicateType(predicateType, SLSAProvenanceV1); err != nil {
+		return nil, err
+	}
+	var provenance Provenance
+	if err := json.Unmarshal(data, &provenance); err != nil {
+		return nil, fmt.Errorf("failed to decode SLSA provenance: %w", err)
+	}
+	if provenance.RunDetails.Builder.ID == "" {
+		return nil, fmt.Errorf("SLSA provenance has no builder id")
+	}
+	return &provenance, nil
+}
+
+// BuilderID returns the id of the builder that produced the artifact
+func (p *Provenance) BuilderID() string {
+	return p.RunDetails.Builder.ID
+}
+
+// BuilderAllowed returns true when the builder id matches one of the allowed patterns, patterns support wildcards
+func (p *Provenance) BuilderAllowed(allowed ...string) bool {
+	for _, pattern := range allowed {
+		if wildcard.Match(pattern, p.BuilderID()) {
+			return true
+		}
+	}
+	return false
+}
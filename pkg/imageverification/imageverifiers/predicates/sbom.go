@@ -0,0 +1,166 @@
+package predicates
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	FormatCycloneDX = "CycloneDX"
+	FormatSPDX      = "SPDX"
+)
+
+// SBOM is the format agnostic representation of a CycloneDX or SPDX document
+type SBOM struct {
+	Format      string      `json:"format"`
+	SpecVersion string      `json:"specVersion"`
+	Components  []Component `json:"components"`
+}
+
+// Component is a software component listed in an SBOM
+type Component struct {
+	Name     string   `json:"name"`
+	Version  string   `json:"version,omitempty"`
+	PURL     string   `json:"purl,omitempty"`
+	Licenses []string `json:"licenses,omitempty"`
+}
+
+type cycloneDXDocument struct {
+	BOMFormat   string               `json:"bomFormat"`
+	SpecVersion string               `json:"specVersion"`
+	Components  []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXComponent struct {
+	Name       string               `json:"name"`
+	Version    string               `json:"version"`
+	PURL       string               `json:"purl"`
+	Licenses   []cycloneDXLicense   `json:"licenses"`
+	Components []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXLicense struct {
+	License *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"license"`
+	Expression string `json:"expression"`
+}
+
+type spdxDocument struct {
+	SPDXVersion string        `json:"spdxVersion"`
+	Packages    []spdxPackage `json:"packages"`
+}
+
+type spdxPackage struct {
+	Name             string `json:"name"`
+	VersionInfo      string `json:"versionInfo"`
+	LicenseConcluded string `json:"licenseConcluded"`
+	LicenseDeclared  string `json:"licenseDeclared"`
+	ExternalRefs     []struct {
+		ReferenceType    string `json:"referenceType"`
+		ReferenceLocator string `json:"referenceLocator"`
+	} `json:"externalRefs"`
+}
+
+// ParseSBOM parses a CycloneDX or SPDX JSON payload
+func ParseSBOM(payload any) (*SBOM, error) {
+	predicateType, data, err := predicate(payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPredicateType(predicateType, CycloneDX, SPDX); err != nil {
+		return nil, err
+	}
+	var header struct {
+		BOMFormat   string `json:"bomFormat"`
+		SPDXVersion string `json:"spdxVersion"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("failed to decode SBOM: %w", err)
+	}
+	switch {
+	case header.BOMFormat == FormatCycloneDX:
+		return parseCycloneDX(data)
+	case header.SPDXVersion != "":
+		return parseSPDX(data)
+	default:
+		return nil, fmt.Errorf("unsupported SBOM format, only CycloneDX and SPDX JSON documents are supported")
+	}
+}
+
+func parseCycloneDX(data []byte) (*SBOM, error) {
+	var doc cycloneDXDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode CycloneDX document: %w", err)
+	}
+	sbom := &SBOM{
+		Format:      FormatCycloneDX,
+		SpecVersion: doc.SpecVersion,
+	}
+	var walk func([]cycloneDXComponent)
+	walk = func(components []cycloneDXComponent) {
+		for _, c := range components {
+			component := Component{
+				Name:    c.Name,
+				Version: c.Version,
+				PURL:    c.PURL,
+			}
+			for _, l := range c.Licenses {
+				switch {
+				case l.Expression != "":
+					component.Licenses = append(component.Licenses, l.Expression)
+				case l.License != nil && l.License.ID != "":
+					component.Licenses = append(component.Licenses, l.License.ID)
+				case l.License != nil && l.License.Name != "":
+					component.Licenses = append(component.Licenses, l.License.Name)
+				}
+			}
+			sbom.Components = append(sbom.Components, component)
+			walk(c.Components)
+		}
+	}
+	walk(doc.Components)
+	return sbom, nil
+}
+
+func parseSPDX(data []byte) (*SBOM, error) {
+	var doc spdxDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode SPDX document: %w", err)
+	}
+	sbom := &SBOM{
+		Format:      FormatSPDX,
+		SpecVersion: doc.SPDXVersion,
+	}
+	for _, p := range doc.Packages {
+		component := Component{
+			Name:    p.Name,
+			Version: p.VersionInfo,
+		}
+		for _, ref := range p.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				component.PURL = ref.ReferenceLocator
+				break
+			}
+		}
+		for _, license := range []string{p.LicenseConcluded, p.LicenseDeclared} {
+			if license != "" && license != "NOASSERTION" && license != "NONE" {
+				component.Licenses = append(component.Licenses, license)
+				break
+			}
+		}
+		sbom.Components = append(sbom.Components, component)
+	}
+	return sbom, nil
+}
+
+// HasComponent returns true when the SBOM lists a component with the given name, and version when not empty
+func (s *SBOM) HasComponent(name, version string) bool {
+	for _, c := range s.Components {
+		if c.Name == name && (version == "" || c.Version == version) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package predicates
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sigstore/cosign/v3/pkg/cosign/attestation"
+)
+
+// VulnerabilityScan is the scanner agnostic representation of a vulnerability scan
+type VulnerabilityScan struct {
+	Scanner         string          `json:"scanner,omitempty"`
+	ScannerVersion  string          `json:"scannerVersion,omitempty"`
+	ScanFinishedOn  *time.Time      `json:"scanFinishedOn,omitempty"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+}
+
+// Vulnerability is a vulnerability reported by a scanner, the severity is upper cased
+type Vulnerability struct {
+	ID               string     `json:"id"`
+	Severity         string     `json:"severity"`
+	Package          string     `json:"package,omitempty"`
+	InstalledVersion string     `json:"installedVersion,omitempty"`
+	FixedVersion     string     `json:"fixedVersion,omitempty"`
+	PublishedOn      *time.Time `json:"publishedOn,omitempty"`
+}
+
+type trivyReport struct {
+	Results []struct {
+		Vulnerabilities []struct {
+			VulnerabilityID  string     `json:"VulnerabilityID"`
+			PkgName          string     `json:"PkgName"`
+			InstalledVersion string     `json:"InstalledVersion"`
+			FixedVersion     string     `json:"FixedVersion"`
+			Severity         string     `json:"Severity"`
+			PublishedDate    *time.Time `json:"PublishedDate"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+type grypeReport struct {
+	Matches []struct {
+		Vulnerability struct {
+			ID       string `json:"id"`
+			Severity string `json:"severity"`
+			Fix      struct {
+				Versions []string `json:"versions"`
+			} `json:"fix"`
+		} `json:"vulnerability"`
+		Artifact struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"artifact"`
+	} `json:"matches"`
+}
+
+// ParseVulnerabilityScan parses a cosign vulnerability scan payload, the scan result can be a Trivy
+// or a Grype JSON report, a raw report is accepted as well
+func ParseVulnerabilityScan(payload any) (*VulnerabilityScan, error) {
+	predicateType, data, err := predicate(payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPredicateType(predicateType, CosignVulnerabilityV1); err != nil {
+		return nil, err
+	}
+	var vuln attestation.CosignVulnPredicate
+	if err := json.Unmarshal(data, &vuln); err != nil {
+		return nil, fmt.Errorf("failed to decode vulnerability scan: %w", err)
+	}
+	scan := &VulnerabilityScan{
+		Scanner:        vuln.Scanner.URI,
+		ScannerVersion: vuln.Scanner.Version,
+	}
+	if !vuln.Metadata.ScanFinishedOn.IsZero() {
+		scan.ScanFinishedOn = &vuln.Metadata.ScanFinishedOn
+	}
+	report := data
+	if vuln.Scanner.Result != nil {
+		if report, err = json.Marshal(vuln.Scanner.Result); err != nil {
+			return nil, err
+		}
+	}
+	if scan.Vulnerabilities, err = parseReport(report); err != nil {
+		return nil, err
+	}
+	return scan, nil
+}
+
+func parseReport(data []byte) ([]Vulnerability, error) {
+	var header map[string]json.RawMessage
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("failed to decode scan result: %w", err)
+	}
+	var vulnerabilities []Vulnerability
+	switch {
+	case header["Results"] != nil || header["SchemaVersion"] != nil:
+		var report trivyReport
+		if err := json.Unmarshal(data, &report); err != nil {
+			return nil, fmt.Errorf("failed to decode Trivy report: %w", err)
+		}
+		for _, result := range report.Results {
+			for _, v := range result.Vulnerabilities {
+				vulnerabilities = append(vulnerabilities, Vulnerability{
+					ID:               v.VulnerabilityID,
+					Severity:         strings.ToUpper(v.Severity),
+					Package:          v.PkgName,
+					InstalledVersion: v.InstalledVersion,
+					FixedVersion:     v.FixedVersion,
+					PublishedOn:      v.PublishedDate,
+				})
+			}
+		}
+	case header["matches"] != nil:
+		var report grypeReport
+		if err := json.Unmarshal(data, &report); err != nil {
+			return nil, fmt.Errorf("failed to decode Grype report: %w", err)
+		}
+		for _, m := range report.Matches {
+			vulnerabilities = append(vulnerabilities, Vulnerability{
+				ID:               m.Vulnerability.ID,
+				Severity:         strings.ToUpper(m.Vulnerability.Severity),
+				Package:          m.Artifact.Name,
+				InstalledVersion: m.Artifact.Version,
+				FixedVersion:     strings.Join(m.Vulnerability.Fix.Versions, ","),
+			})
+		}
+	default:
+		return nil, fmt.Errorf("unsupported scan result, only Trivy and Grype JSON reports are supported")
+	}
+	return vulnerabilities, nil
+}
+
+// Filter returns the vulnerabilities with the given severity published for longer than minAge, the
+// publication date is unknown for some scanners, such vulnerabilities are always considered old enough
+func (s *VulnerabilityScan) Filter(severity string, minAge time.Duration, now time.Time) []Vulnerability {
+	var out []Vulnerability
+	for _, v := range s.Vulnerabilities {
+		if severity != "" && !strings.EqualFold(v.Severity, severity) {
+			continue
+		}
+		if minAge > 0 && v.PublishedOn != nil && now.Sub(*v.PublishedOn) < minAge {
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
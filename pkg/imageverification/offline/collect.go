@@ -0,0 +1,71 @@
+package offline
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sigstore/sigstore-go/pkg/bundle"
+)
+
+const (
+	bundleArtifactTypePrefix = "application/vnd.dev.sigstore.bundle"
+	maxReferrers             = 50
+)
+
+// FetchBundles resolves the digest of an image and fetches the Sigstore bundles attached to it as OCI referrers
+func FetchBundles(ref name.Reference, opts ...remote.Option) (v1.Hash, []*bundle.Bundle, error) {
+	desc, err := remote.Head(ref, opts...)
+	if err != nil {
+		return v1.Hash{}, nil, fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	index, err := remote.Referrers(ref.Context().Digest(desc.Digest.String()), opts...)
+	if err != nil {
+		return v1.Hash{}, nil, fmt.Errorf("failed to fetch the referrers of %s: %w", ref, err)
+	}
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return v1.Hash{}, nil, err
+	}
+	if len(manifest.Manifests) > maxReferrers {
+		return v1.Hash{}, nil, fmt.Errorf("too many referrers found for %s, max limit is %d", ref, maxReferrers)
+	}
+	var bundles []*bundle.Bundle
+	for _, referrer := range manifest.Manifests {
+		if !strings.HasPrefix(referrer.ArtifactType, bundleArtifactTypePrefix) {
+			continue
+		}
+		img, err := remote.Image(ref.Context().Digest(referrer.Digest.String()), opts...)
+		if err != nil {
+			return v1.Hash{}, nil, fmt.Errorf("failed to fetch referrer %s: %w", referrer.Digest, err)
+		}
+		layers, err := img.Layers()
+		if err != nil {
+			return v1.Hash{}, nil, err
+		}
+		if len(layers) == 0 {
+			return v1.Hash{}, nil, fmt.Errorf("referrer %s has no layer", referrer.Digest)
+		}
+		reader, err := layers[0].Uncompressed()
+		if err != nil {
+			return v1.Hash{}, nil, err
+		}
+		data, err := io.ReadAll(io.LimitReader(reader, maxFileSize+1))
+		reader.Close()
+		if err != nil {
+			return v1.Hash{}, nil, err
+		}
+		if int64(len(data)) > maxFileSize {
+			return v1.Hash{}, nil, fmt.Errorf("referrer %s exceeds %d", referrer.Digest, maxFileSize)
+		}
+		var b bundle.Bundle
+		if err := b.UnmarshalJSON(data); err != nil {
+			return v1.Hash{}, nil, fmt.Errorf("failed to parse bundle %s: %w", referrer.Digest, err)
+		}
+		bundles = append(bundles, &b)
+	}
+	return desc.Digest, bundles, nil
+}
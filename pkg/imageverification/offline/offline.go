@@ -0,0 +1,98 @@
+package offline
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/sigstore/sigstore-go/pkg/bundle"
+	"github.com/sigstore/sigstore-go/pkg/root"
+)
+
+const (
+	// TrustedRootFile is the name of the file holding the Sigstore trusted root
+	TrustedRootFile = "trusted_root.json"
+	// BundleFileSuffix is the suffix of the files holding a Sigstore bundle, the file name starts
+	// with the digest of the image the bundle applies to, e.g. `sha256-<hex>.0.sigstore.json`
+	BundleFileSuffix = ".sigstore.json"
+)
+
+// Material is a set of pre-distributed verification material, the trusted root replaces the Sigstore
+// TUF root and the bundles, including their transparency log inclusion proofs, replace the registry
+// signatures, Rekor and Fulcio lookups
+type Material struct {
+	TrustedRoot *root.TrustedRoot
+	bundles     map[string][]*bundle.Bundle
+}
+
+// Bundles returns the bundles of an image digest
+func (m *Material) Bundles(digest string) []*bundle.Bundle {
+	return m.bundles[digest]
+}
+
+// Digests returns the image digests having bundles
+func (m *Material) Digests() []string {
+	digests := make([]string, 0, len(m.bundles))
+	for digest := range m.bundles {
+		digests = append(digests, digest)
+	}
+	sort.Strings(digests)
+	return digests
+}
+
+// BundleFileName returns the name of the file holding the bundle of an image digest
+func BundleFileName(digest v1.Hash, index int) string {
+	return fmt.Sprintf("%s-%s.%d%s", digest.Algorithm, digest.Hex, index, BundleFileSuffix)
+}
+
+// Parse parses the verification material files, the trusted root and the bundles, other files are ignored
+func Parse(files map[string][]byte) (*Material, error) {
+	data, ok := files[TrustedRootFile]
+	if !ok {
+		return nil, fmt.Errorf("%s is missing", TrustedRootFile)
+	}
+	trustedRoot, err := root.NewTrustedRootFromJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", TrustedRootFile, err)
+	}
+	material := &Material{
+		TrustedRoot: trustedRoot,
+		bundles:     map[string][]*bundle.Bundle{},
+	}
+	// sort the files to keep the bundles order stable
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !strings.HasSuffix(name, BundleFileSuffix) {
+			continue
+		}
+		digest, err := bundleDigest(name)
+		if err != nil {
+			return nil, err
+		}
+		var b bundle.Bundle
+		if err := b.UnmarshalJSON(files[name]); err != nil {
+			return nil, fmt.Errorf("failed to parse bundle %s: %w", name, err)
+		}
+		material.bundles[digest.String()] = append(material.bundles[digest.String()], &b)
+	}
+	return material, nil
+}
+
+// bundleDigest returns the image digest from the name of a bundle file
+func bundleDigest(name string) (v1.Hash, error) {
+	prefix, _, _ := strings.Cut(strings.TrimSuffix(name, BundleFileSuffix), ".")
+	algorithm, hex, ok := strings.Cut(prefix, "-")
+	if !ok {
+		return v1.Hash{}, fmt.Errorf("invalid bundle file name %s, expected <algorithm>-<hex>[.<index>]%s", name, BundleFileSuffix)
+	}
+	digest, err := v1.NewHash(algorithm + ":" + hex)
+	if err != nil {
+		return v1.Hash{}, fmt.Errorf("invalid bundle file name %s: %w", name, err)
+	}
+	return digest, nil
+}
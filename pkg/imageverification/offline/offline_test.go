@@ -0,0 +1,160 @@
+package offline
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// the test bundle is a message signature from a scaffolding Sigstore deployment
+const testDigest = "sha256:bc103b4a84971ef6459b294a2b98568a2bfb72cded09d4acd1e16366a401f95b"
+
+func loadTestFiles(t *testing.T) map[string][]byte {
+	entries, err := os.ReadDir("testdata")
+	assert.NoError(t, err)
+	files := map[string][]byte{}
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join("testdata", entry.Name()))
+		assert.NoError(t, err)
+		files[entry.Name()] = data
+	}
+	return files
+}
+
+func TestParse(t *testing.T) {
+	files := loadTestFiles(t)
+	files["README.md"] = []byte("ignored")
+	material, err := Parse(files)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{testDigest}, material.Digests())
+	assert.Len(t, material.Bundles(testDigest), 1)
+
+	_, err = Parse(map[string][]byte{})
+	assert.ErrorContains(t, err, TrustedRootFile)
+
+	files = loadTestFiles(t)
+	files["invalid.sigstore.json"] = files["sha256-bc103b4a84971ef6459b294a2b98568a2bfb72cded09d4acd1e16366a401f95b.0.sigstore.json"]
+	_, err = Parse(files)
+	assert.ErrorContains(t, err, "invalid bundle file name")
+}
+
+func TestBundleFileName(t *testing.T) {
+	digest, err := bundleDigest("sha256-bc103b4a84971ef6459b294a2b98568a2bfb72cded09d4acd1e16366a401f95b.3.sigstore.json")
+	assert.NoError(t, err)
+	assert.Equal(t, testDigest, digest.String())
+	assert.Equal(t, "sha256-bc103b4a84971ef6459b294a2b98568a2bfb72cded09d4acd1e16366a401f95b.3.sigstore.json", BundleFileName(digest, 3))
+
+	_, err = bundleDigest("sha256-1234.sigstore.json")
+	assert.Error(t, err)
+}
+
+func TestVerify(t *testing.T) {
+	material, err := Parse(loadTestFiles(t))
+	assert.NoError(t, err)
+
+	results, err := material.Verify(testDigest, Options{
+		Identities: []Identity{{Issuer: "http://oidc.local:8080", Subject: "foo!oidc.local"}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Nil(t, results[0].Statement)
+
+	tests := []struct {
+		name   string
+		digest string
+		opts   Options
+		err    string
+	}{{
+		name:   "identity mismatch",
+		digest: testDigest,
+		opts:   Options{Identities: []Identity{{Issuer: "http://oidc.local:8080", Subject: "foo@oidc.local"}}},
+		err:    "offline verification failed",
+	}, {
+		name:   "no identity",
+		digest: testDigest,
+		err:    "requires at least one identity",
+	}, {
+		name:   "unknown digest",
+		digest: "sha256:0000000000000000000000000000000000000000000000000000000000000000",
+		opts:   Options{Identities: []Identity{{Issuer: "http://oidc.local:8080", Subject: "foo!oidc.local"}}},
+		err:    "no offline verification bundle found",
+	}, {
+		name:   "attestation type",
+		digest: testDigest,
+		opts:   Options{Identities: []Identity{{Issuer: "http://oidc.local:8080", Subject: "foo!oidc.local"}}, PredicateType: "https://slsa.dev/provenance/v1"},
+		err:    "no offline verification bundle of type",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := material.Verify(tt.digest, tt.opts)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestConfigMapSource(t *testing.T) {
+	files := loadTestFiles(t)
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "bundles", Namespace: "kyverno", ResourceVersion: "1"},
+		Data:       map[string]string{},
+	}
+	for name, data := range files {
+		cm.Data[name] = string(data)
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NoError(t, indexer.Add(cm))
+	source := NewConfigMapSource(corev1listers.NewConfigMapLister(indexer).ConfigMaps("kyverno"), "bundles")
+
+	material, err := source.Load(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{testDigest}, material.Digests())
+	// the material is parsed again only when the configmap changes
+	cached, err := source.Load(context.Background())
+	assert.NoError(t, err)
+	assert.Same(t, material, cached)
+
+	SetSource(source)
+	defer SetSource(nil)
+	loaded, err := Load(context.Background())
+	assert.NoError(t, err)
+	assert.Same(t, material, loaded)
+}
+
+func TestImageSource(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	ref, err := name.ParseReference(strings.TrimPrefix(server.URL, "http://")+"/kyverno/bundles:v1", name.Insecure)
+	assert.NoError(t, err)
+
+	img, err := NewImage(loadTestFiles(t))
+	assert.NoError(t, err)
+	assert.NoError(t, remote.Write(ref, img))
+
+	options := func(ctx context.Context) ([]remote.Option, error) {
+		return []remote.Option{remote.WithContext(ctx)}, nil
+	}
+	source := NewImageSource(ref, options, time.Hour)
+	material, err := source.Load(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{testDigest}, material.Digests())
+
+	// the previous material is kept when the artifact can't be pulled
+	server.Close()
+	source.(*imageSource).fetched = time.Time{}
+	cached, err := source.Load(context.Background())
+	assert.NoError(t, err)
+	assert.Same(t, material, cached)
+}
@@ -0,0 +1,221 @@
+package offline
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/kyverno/kyverno/pkg/logging"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+const (
+	// ConfigMediaType is the config media type of the verification material OCI artifacts
+	ConfigMediaType types.MediaType = "application/vnd.kyverno.verification-material.config.v1+json"
+	// FileMediaType is the media type of the layers of the verification material OCI artifacts,
+	// the file name is stored in the `org.opencontainers.image.title` annotation
+	FileMediaType types.MediaType = "application/json"
+
+	maxFileSize = int64(10 * 1000 * 1000) // 10 MB
+)
+
+var logger = logging.WithName("offline-verification")
+
+// Source provides the verification material
+type Source interface {
+	Load(ctx context.Context) (*Material, error)
+}
+
+var (
+	sourceLock sync.RWMutex
+	source     Source
+)
+
+// SetSource configures the verification material used by the image verifiers, once a source is set
+// the rules it can honour are verified against the material only
+func SetSource(s Source) {
+	sourceLock.Lock()
+	defer sourceLock.Unlock()
+	source = s
+}
+
+// Load returns the configured verification material, nil when no source is configured
+func Load(ctx context.Context) (*Material, error) {
+	sourceLock.RLock()
+	s := source
+	sourceLock.RUnlock()
+	if s == nil {
+		return nil, nil
+	}
+	material, err := s.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load offline verification material: %w", err)
+	}
+	return material, nil
+}
+
+type configMapSource struct {
+	lister corev1listers.ConfigMapNamespaceLister
+	name   string
+
+	lock            sync.Mutex
+	resourceVersion string
+	material        *Material
+}
+
+// NewConfigMapSource returns a source reading the verification material from a ConfigMap, each
+// data (or binary data) key is a file
+func NewConfigMapSource(lister corev1listers.ConfigMapNamespaceLister, name string) Source {
+	return &configMapSource{
+		lister: lister,
+		name:   name,
+	}
+}
+
+func (s *configMapSource) Load(context.Context) (*Material, error) {
+	cm, err := s.lister.Get(s.name)
+	if err != nil {
+		return nil, err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.material != nil && s.resourceVersion == cm.ResourceVersion {
+		return s.material, nil
+	}
+	files := make(map[string][]byte, len(cm.Data)+len(cm.BinaryData))
+	for key, value := range cm.Data {
+		files[key] = []byte(value)
+	}
+	for key, value := range cm.BinaryData {
+		files[key] = value
+	}
+	material, err := Parse(files)
+	if err != nil {
+		return nil, fmt.Errorf("configmap %s: %w", s.name, err)
+	}
+	s.resourceVersion, s.material = cm.ResourceVersion, material
+	return material, nil
+}
+
+type imageSource struct {
+	ref     name.Reference
+	options func(context.Context) ([]remote.Option, error)
+	refresh time.Duration
+
+	lock     sync.Mutex
+	fetched  time.Time
+	material *Material
+}
+
+// NewImageSource returns a source pulling the verification material from an OCI artifact, the
+// artifact is pulled again after the refresh interval and the previous material is kept when it fails
+func NewImageSource(ref name.Reference, options func(context.Context) ([]remote.Option, error), refresh time.Duration) Source {
+	return &imageSource{
+		ref:     ref,
+		options: options,
+		refresh: refresh,
+	}
+}
+
+func (s *imageSource) Load(ctx context.Context) (*Material, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.material != nil && time.Since(s.fetched) < s.refresh {
+		return s.material, nil
+	}
+	material, err := s.pull(ctx)
+	if err != nil {
+		if s.material != nil {
+			logger.Error(err, "failed to refresh verification material, using the previous one", "image", s.ref.String())
+			return s.material, nil
+		}
+		return nil, err
+	}
+	s.fetched, s.material = time.Now(), material
+	return material, nil
+}
+
+func (s *imageSource) pull(ctx context.Context) (*Material, error) {
+	opts, err := s.options(ctx)
+	if err != nil {
+		return nil, err
+	}
+	img, err := remote.Image(s.ref, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull %s: %w", s.ref, err)
+	}
+	files, err := ImageFiles(img)
+	if err != nil {
+		return nil, fmt.Errorf("image %s: %w", s.ref, err)
+	}
+	return Parse(files)
+}
+
+// NewImage returns an OCI artifact holding verification material files
+func NewImage(files map[string][]byte) (v1.Image, error) {
+	img := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	img = mutate.ConfigMediaType(img, ConfigMediaType)
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var err error
+		img, err = mutate.Append(img, mutate.Addendum{
+			Layer:       static.NewLayer(files[name], FileMediaType),
+			Annotations: map[string]string{ocispec.AnnotationTitle: name},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return img, nil
+}
+
+// ImageFiles returns the verification material files of an OCI artifact
+func ImageFiles(img v1.Image) (map[string][]byte, error) {
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, err
+	}
+	files := map[string][]byte{}
+	for _, desc := range manifest.Layers {
+		name := desc.Annotations[ocispec.AnnotationTitle]
+		if name == "" {
+			continue
+		}
+		if desc.Size > maxFileSize {
+			return nil, fmt.Errorf("file %s size %d exceeds %d", name, desc.Size, maxFileSize)
+		}
+		layer, err := img.LayerByDigest(desc.Digest)
+		if err != nil {
+			return nil, err
+		}
+		reader, err := layer.Uncompressed()
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(io.LimitReader(reader, maxFileSize+1))
+		reader.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %w", name, err)
+		}
+		if int64(len(data)) > maxFileSize {
+			return nil, fmt.Errorf("file %s exceeds %d", name, maxFileSize)
+		}
+		files[name] = data
+	}
+	return files, nil
+}
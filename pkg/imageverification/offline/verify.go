@@ -0,0 +1,179 @@
+package offline
+
+import (
+	"crypto"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/sigstore/sigstore-go/pkg/bundle"
+	"github.com/sigstore/sigstore-go/pkg/root"
+	"github.com/sigstore/sigstore-go/pkg/verify"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+)
+
+const intotoPayloadType = "application/vnd.in-toto+json"
+
+// Identity is a keyless signer identity
+type Identity struct {
+	Issuer        string
+	IssuerRegExp  string
+	Subject       string
+	SubjectRegExp string
+}
+
+// Options configures the verification of the bundles of an image
+type Options struct {
+	// Identities are the accepted keyless identities, any of them must match
+	Identities []Identity
+	// PublicKey is the PEM encoded public key of key based signatures
+	PublicKey string
+	// HashAlgorithm is the hash algorithm of the public key
+	HashAlgorithm crypto.Hash
+	// PredicateType restricts the verification to the attestations of the given type
+	PredicateType string
+	// IgnoreTlog skips the transparency log inclusion checks
+	IgnoreTlog bool
+	// IgnoreSCT skips the timestamp checks
+	IgnoreSCT bool
+}
+
+// Result is a verified bundle
+type Result struct {
+	Bundle *bundle.Bundle
+	// Statement is the in-toto statement of the bundle, nil for message signatures
+	Statement *in_toto.Statement //nolint:staticcheck
+	// Payload is the raw in-toto statement
+	Payload []byte
+	Result  *verify.VerificationResult
+}
+
+// Verify verifies the bundles of an image digest against the trusted root, it returns the verified
+// bundles and fails when none of them could be verified
+func (m *Material) Verify(digest string, opts Options) ([]Result, error) {
+	hash, err := v1.NewHash(digest)
+	if err != nil {
+		return nil, err
+	}
+	bundles := m.Bundles(hash.String())
+	if len(bundles) == 0 {
+		return nil, fmt.Errorf("no offline verification bundle found for %s", digest)
+	}
+	policy, err := buildPolicy(hash, opts)
+	if err != nil {
+		return nil, err
+	}
+	trustedMaterial, err := m.trustedMaterial(opts)
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := verify.NewSignedEntityVerifier(trustedMaterial, verifierOptions(opts)...)
+	if err != nil {
+		return nil, err
+	}
+	var results []Result
+	var errs []error
+	for _, b := range bundles {
+		statement, payload, err := bundleStatement(b)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if opts.PredicateType != "" && (statement == nil || statement.PredicateType != opts.PredicateType) {
+			continue
+		}
+		result, err := verifier.Verify(b, policy)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		results = append(results, Result{Bundle: b, Statement: statement, Payload: payload, Result: result})
+	}
+	if len(results) == 0 {
+		if len(errs) == 0 {
+			return nil, fmt.Errorf("no offline verification bundle of type %s found for %s", opts.PredicateType, digest)
+		}
+		return nil, fmt.Errorf("offline verification failed for %s: %w", digest, errors.Join(errs...))
+	}
+	return results, nil
+}
+
+// trustedMaterial returns the trusted root, extended with the public key for key based signatures
+func (m *Material) trustedMaterial(opts Options) (root.TrustedMaterial, error) {
+	if opts.PublicKey == "" {
+		return m.TrustedRoot, nil
+	}
+	publicKey, err := cryptoutils.UnmarshalPEMToPublicKey([]byte(opts.PublicKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	hashAlgorithm := opts.HashAlgorithm
+	if hashAlgorithm == 0 {
+		hashAlgorithm = crypto.SHA256
+	}
+	verifier, err := signature.LoadVerifier(publicKey, hashAlgorithm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load public key: %w", err)
+	}
+	key := root.NewExpiringKey(verifier, time.Time{}, time.Time{})
+	publicKeyMaterial := root.NewTrustedPublicKeyMaterial(func(string) (root.TimeConstrainedVerifier, error) {
+		return key, nil
+	})
+	return root.TrustedMaterialCollection{m.TrustedRoot, publicKeyMaterial}, nil
+}
+
+func buildPolicy(digest v1.Hash, opts Options) (verify.PolicyBuilder, error) {
+	digestBytes, err := hex.DecodeString(digest.Hex)
+	if err != nil {
+		return verify.PolicyBuilder{}, err
+	}
+	artifact := verify.WithArtifactDigest(digest.Algorithm, digestBytes)
+	if opts.PublicKey != "" {
+		return verify.NewPolicy(artifact, verify.WithKey()), nil
+	}
+	if len(opts.Identities) == 0 {
+		return verify.PolicyBuilder{}, fmt.Errorf("offline keyless verification requires at least one identity")
+	}
+	var policyOptions []verify.PolicyOption
+	for _, identity := range opts.Identities {
+		id, err := verify.NewShortCertificateIdentity(identity.Issuer, identity.IssuerRegExp, identity.Subject, identity.SubjectRegExp)
+		if err != nil {
+			return verify.PolicyBuilder{}, err
+		}
+		policyOptions = append(policyOptions, verify.WithCertificateIdentity(id))
+	}
+	return verify.NewPolicy(artifact, policyOptions...), nil
+}
+
+func verifierOptions(opts Options) []verify.VerifierOption {
+	var verifierOptions []verify.VerifierOption
+	if !opts.IgnoreTlog {
+		verifierOptions = append(verifierOptions, verify.WithTransparencyLog(1))
+	}
+	if !opts.IgnoreSCT {
+		verifierOptions = append(verifierOptions, verify.WithObserverTimestamps(1))
+	}
+	if opts.IgnoreTlog && opts.IgnoreSCT {
+		verifierOptions = append(verifierOptions, verify.WithCurrentTime())
+	}
+	return verifierOptions
+}
+
+// bundleStatement returns the in-toto statement of a DSSE bundle
+func bundleStatement(b *bundle.Bundle) (*in_toto.Statement, []byte, error) { //nolint:staticcheck
+	envelope := b.GetDsseEnvelope()
+	if envelope == nil || !strings.EqualFold(envelope.PayloadType, intotoPayloadType) {
+		return nil, nil, nil
+	}
+	var statement in_toto.Statement //nolint:staticcheck
+	if err := json.Unmarshal(envelope.Payload, &statement); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode in-toto statement: %w", err)
+	}
+	return &statement, envelope.Payload, nil
+}
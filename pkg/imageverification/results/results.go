@@ -0,0 +1,87 @@
+package results
+
+import (
+	"sync"
+	"time"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+)
+
+// Result is the outcome of the verification of an image digest by a policy rule
+type Result struct {
+	Image                 string
+	Digest                string
+	Policy                string
+	PolicyResourceVersion string
+	Rule                  string
+	Status                kyvernov2alpha1.ImageVerificationStatus
+	Message               string
+	Signers               []kyvernov2alpha1.ImageSigner
+	Attestations          []string
+	Time                  time.Time
+}
+
+// Record returns the result as it is stored in an ImageVerificationResult
+func (r Result) Record() kyvernov2alpha1.ImageVerificationRecord {
+	return kyvernov2alpha1.ImageVerificationRecord{
+		Policy:                r.Policy,
+		PolicyResourceVersion: r.PolicyResourceVersion,
+		Rule:                  r.Rule,
+		Status:                r.Status,
+		Message:               r.Message,
+		Signers:               r.Signers,
+		Attestations:          r.Attestations,
+		Time:                  metav1Time(r.Time),
+	}
+}
+
+// Store records the verification results and looks up the recorded ones
+type Store interface {
+	// Record records a verification result, it must not block
+	Record(result Result)
+	// Lookup returns the recorded verification of a digest by a policy rule, expired verifications are not returned
+	Lookup(digest, policy, rule string) (*kyvernov2alpha1.ImageVerificationRecord, bool)
+}
+
+var (
+	storeLock sync.RWMutex
+	store     Store
+)
+
+// SetStore configures the store verification results are recorded to, nothing is recorded until a store is set
+func SetStore(s Store) {
+	storeLock.Lock()
+	defer storeLock.Unlock()
+	store = s
+}
+
+func getStore() Store {
+	storeLock.RLock()
+	defer storeLock.RUnlock()
+	return store
+}
+
+// Record records a verification result, results without digest are ignored
+func Record(result Result) {
+	s := getStore()
+	if s == nil || result.Digest == "" {
+		return
+	}
+	if result.Time.IsZero() {
+		result.Time = time.Now()
+	}
+	s.Record(result)
+}
+
+// LookupPass returns true when a recorded verification of the digest by the given version of a policy rule passed
+func LookupPass(digest, policy, policyResourceVersion, rule string) bool {
+	s := getStore()
+	if s == nil || digest == "" {
+		return false
+	}
+	record, found := s.Lookup(digest, policy, rule)
+	if !found {
+		return false
+	}
+	return record.Status == kyvernov2alpha1.ImageVerificationPass && record.PolicyResourceVersion == policyResourceVersion
+}
@@ -0,0 +1,89 @@
+package results
+
+import (
+	"testing"
+
+	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+type testStore struct {
+	records map[string]kyvernov2alpha1.ImageVerificationRecord
+}
+
+func (s *testStore) Record(result Result) {
+	s.records[result.Digest+"/"+result.Policy+"/"+result.Rule] = result.Record()
+}
+
+func (s *testStore) Lookup(digest, policy, rule string) (*kyvernov2alpha1.ImageVerificationRecord, bool) {
+	record, ok := s.records[digest+"/"+policy+"/"+rule]
+	return &record, ok
+}
+
+func TestRecordAndLookup(t *testing.T) {
+	// nothing is recorded without store
+	Record(Result{Digest: "sha256:1234", Policy: "pol", Rule: "rule", Status: kyvernov2alpha1.ImageVerificationPass})
+	assert.False(t, LookupPass("sha256:1234", "pol", "1", "rule"))
+
+	store := &testStore{records: map[string]kyvernov2alpha1.ImageVerificationRecord{}}
+	SetStore(store)
+	defer SetStore(nil)
+	Record(Result{Digest: "sha256:1234", Policy: "pol", PolicyResourceVersion: "1", Rule: "rule", Status: kyvernov2alpha1.ImageVerificationPass})
+	Record(Result{Digest: "sha256:5678", Policy: "pol", PolicyResourceVersion: "1", Rule: "rule", Status: kyvernov2alpha1.ImageVerificationFail})
+	Record(Result{Policy: "pol", Rule: "rule", Status: kyvernov2alpha1.ImageVerificationPass})
+	assert.Len(t, store.records, 2)
+	assert.False(t, store.records["sha256:1234/pol/rule"].Time.Time.IsZero())
+
+	assert.True(t, LookupPass("sha256:1234", "pol", "1", "rule"))
+	// the policy changed since the verification
+	assert.False(t, LookupPass("sha256:1234", "pol", "2", "rule"))
+	assert.False(t, LookupPass("sha256:5678", "pol", "1", "rule"))
+	assert.False(t, LookupPass("sha256:0000", "pol", "1", "rule"))
+}
+
+func TestSignersFromAttestorSets(t *testing.T) {
+	sets := []kyvernov1.AttestorSet{{
+		Entries: []kyvernov1.Attestor{{
+			Keyless: &kyvernov1.KeylessAttestor{Issuer: "https://token.actions.githubusercontent.com", SubjectRegExp: "https://github.com/kyverno/.*"},
+		}, {
+			Keys: &kyvernov1.StaticKeyAttestor{KMS: "gcpkms://projects/p/locations/l/keyRings/r/cryptoKeys/k"},
+		}, {
+			Keys: &kyvernov1.StaticKeyAttestor{Secret: &kyvernov1.SecretReference{Name: "key", Namespace: "kyverno"}},
+		}, {
+			Keys: &kyvernov1.StaticKeyAttestor{PublicKeys: "-----BEGIN PUBLIC KEY-----"},
+		}},
+	}}
+	signers := SignersFromAttestorSets(kyvernov1.Cosign, sets)
+	assert.Equal(t, []kyvernov2alpha1.ImageSigner{
+		{Type: "cosign/keyless", Issuer: "https://token.actions.githubusercontent.com", Subject: "https://github.com/kyverno/.*"},
+		{Type: "cosign/key", Key: "gcpkms://projects/p/locations/l/keyRings/r/cryptoKeys/k"},
+		{Type: "cosign/key", Key: "secret:kyverno/key"},
+		{Type: "cosign/key", Key: fingerprint("-----BEGIN PUBLIC KEY-----")},
+	}, signers)
+
+	signers = SignersFromAttestorSets(kyvernov1.Notary, []kyvernov1.AttestorSet{{
+		Entries: []kyvernov1.Attestor{{Certificates: &kyvernov1.CertificateAttestor{Certificate: "-----BEGIN CERTIFICATE-----"}}},
+	}})
+	assert.Equal(t, []kyvernov2alpha1.ImageSigner{{Type: "notary", Key: fingerprint("-----BEGIN CERTIFICATE-----")}}, signers)
+}
+
+func TestSignersFromAttestor(t *testing.T) {
+	signers := SignersFromAttestor(policiesv1beta1.Attestor{
+		Name: "github",
+		Cosign: &policiesv1beta1.Cosign{
+			Keyless: &policiesv1beta1.Keyless{
+				Identities: []policiesv1beta1.Identity{{Issuer: "https://token.actions.githubusercontent.com", Subject: "https://github.com/kyverno/kyverno"}},
+			},
+		},
+	})
+	assert.Equal(t, []kyvernov2alpha1.ImageSigner{{Type: "cosign/keyless", Issuer: "https://token.actions.githubusercontent.com", Subject: "https://github.com/kyverno/kyverno"}}, signers)
+
+	signers = SignersFromAttestor(policiesv1beta1.Attestor{
+		Name:   "notary",
+		Notary: &policiesv1beta1.Notary{Certs: &policiesv1beta1.StringOrExpression{Value: "-----BEGIN CERTIFICATE-----"}},
+	})
+	assert.Equal(t, []kyvernov2alpha1.ImageSigner{{Type: "notary", Key: fingerprint("-----BEGIN CERTIFICATE-----")}}, signers)
+	assert.Empty(t, fingerprint(" "))
+}
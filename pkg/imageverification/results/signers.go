@@ -0,0 +1,116 @@
+package results
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SignersFromAttestorSets returns the signer identities trusted by the attestors of an image verification rule
+func SignersFromAttestorSets(verifierType kyvernov1.ImageVerificationType, sets []kyvernov1.AttestorSet) []kyvernov2alpha1.ImageSigner {
+	prefix := "cosign"
+	switch verifierType {
+	case kyvernov1.Notary:
+		prefix = "notary"
+	case kyvernov1.SigstoreBundle:
+		prefix = "sigstorebundle"
+	}
+	var signers []kyvernov2alpha1.ImageSigner
+	for _, set := range sets {
+		for _, entry := range set.Entries {
+			switch {
+			case entry.Keyless != nil:
+				signers = append(signers, kyvernov2alpha1.ImageSigner{
+					Type:    prefix + "/keyless",
+					Issuer:  firstNonEmpty(entry.Keyless.Issuer, entry.Keyless.IssuerRegExp),
+					Subject: firstNonEmpty(entry.Keyless.Subject, entry.Keyless.SubjectRegExp),
+				})
+			case entry.Keys != nil:
+				key := fingerprint(entry.Keys.PublicKeys)
+				if entry.Keys.KMS != "" {
+					key = entry.Keys.KMS
+				} else if entry.Keys.Secret != nil {
+					key = "secret:" + entry.Keys.Secret.Namespace + "/" + entry.Keys.Secret.Name
+				}
+				signers = append(signers, kyvernov2alpha1.ImageSigner{Type: prefix + "/key", Key: key})
+			case entry.Certificates != nil:
+				signerType := prefix + "/certificate"
+				if verifierType == kyvernov1.Notary {
+					signerType = prefix
+				}
+				key := fingerprint(firstNonEmpty(entry.Certificates.Certificate, entry.Certificates.CertificateChain))
+				if entry.Certificates.TrustPolicy != "" {
+					key = "trustpolicy:" + entry.Certificates.TrustPolicy
+				}
+				signers = append(signers, kyvernov2alpha1.ImageSigner{Type: signerType, Key: key})
+			case entry.Attestor != nil:
+				signers = append(signers, kyvernov2alpha1.ImageSigner{Type: prefix + "/nested"})
+			}
+		}
+	}
+	return signers
+}
+
+// SignersFromAttestor returns the signer identities trusted by an image validating policy attestor
+func SignersFromAttestor(attestor policiesv1beta1.Attestor) []kyvernov2alpha1.ImageSigner {
+	switch {
+	case attestor.Notary != nil:
+		signer := kyvernov2alpha1.ImageSigner{Type: "notary"}
+		if attestor.Notary.Certs != nil {
+			signer.Key = fingerprint(attestor.Notary.Certs.Value)
+		}
+		return []kyvernov2alpha1.ImageSigner{signer}
+	case attestor.Cosign == nil:
+		return nil
+	case attestor.Cosign.Keyless != nil:
+		signers := make([]kyvernov2alpha1.ImageSigner, 0, len(attestor.Cosign.Keyless.Identities))
+		for _, id := range attestor.Cosign.Keyless.Identities {
+			signers = append(signers, kyvernov2alpha1.ImageSigner{
+				Type:    "cosign/keyless",
+				Issuer:  firstNonEmpty(id.Issuer, id.IssuerRegExp),
+				Subject: firstNonEmpty(id.Subject, id.SubjectRegExp),
+			})
+		}
+		return signers
+	case attestor.Cosign.Key != nil:
+		return []kyvernov2alpha1.ImageSigner{{
+			Type: "cosign/key",
+			Key:  firstNonEmpty(attestor.Cosign.Key.KMS, fingerprint(attestor.Cosign.Key.Data)),
+		}}
+	case attestor.Cosign.Certificate != nil && attestor.Cosign.Certificate.Certificate != nil:
+		return []kyvernov2alpha1.ImageSigner{{
+			Type: "cosign/certificate",
+			Key:  fingerprint(attestor.Cosign.Certificate.Certificate.Value),
+		}}
+	}
+	return []kyvernov2alpha1.ImageSigner{{Type: "cosign"}}
+}
+
+// fingerprint returns the sha256 fingerprint of PEM encoded material, empty when there is no material
+func fingerprint(pem string) string {
+	pem = strings.TrimSpace(pem)
+	if pem == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(pem))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func metav1Time(t time.Time) metav1.Time {
+	return metav1.NewTime(t.UTC().Truncate(time.Second))
+}
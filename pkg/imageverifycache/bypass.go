@@ -0,0 +1,63 @@
+package imageverifycache
+
+import (
+	"context"
+	"sync"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+)
+
+type bypassKey struct{}
+
+type cycleKey struct{}
+
+// WithBypass returns a context in which the cache lookups miss, images are verified again and the
+// successful verifications are still cached
+func WithBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassKey{}, true)
+}
+
+// IsBypassed returns true when the cache lookups must miss in the given context
+func IsBypassed(ctx context.Context) bool {
+	bypassed, _ := ctx.Value(bypassKey{}).(bool)
+	return bypassed
+}
+
+// Cycle records the verification outcomes of a re-verification cycle so that every image digest
+// is verified only once per policy rule and cycle, whatever its outcome
+type Cycle struct {
+	lock     sync.Mutex
+	outcomes map[string]any
+}
+
+func NewCycle() *Cycle {
+	return &Cycle{
+		outcomes: map[string]any{},
+	}
+}
+
+// WithCycle returns a bypassing context sharing the outcomes of the given cycle
+func WithCycle(ctx context.Context, cycle *Cycle) context.Context {
+	return context.WithValue(WithBypass(ctx), cycleKey{}, cycle)
+}
+
+// CycleFrom returns the cycle of the given context, if any
+func CycleFrom(ctx context.Context) *Cycle {
+	cycle, _ := ctx.Value(cycleKey{}).(*Cycle)
+	return cycle
+}
+
+// Load returns the outcome of the verification of a digest for a policy rule in the cycle
+func (c *Cycle) Load(policy kyvernov1.PolicyInterface, ruleName string, digest string) (any, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	outcome, found := c.outcomes[generateKey(policy, ruleName, digest)]
+	return outcome, found
+}
+
+// Store records the outcome of the verification of a digest for a policy rule in the cycle
+func (c *Cycle) Store(policy kyvernov1.PolicyInterface, ruleName string, digest string, outcome any) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.outcomes[generateKey(policy, ruleName, digest)] = outcome
+}
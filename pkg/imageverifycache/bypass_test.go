@@ -0,0 +1,31 @@
+package imageverifycache
+
+import (
+	"context"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCycle(t *testing.T) {
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy", UID: "uid"}}
+	assert.Nil(t, CycleFrom(context.TODO()))
+	cycle := NewCycle()
+	ctx := WithCycle(context.TODO(), cycle)
+	assert.True(t, IsBypassed(ctx))
+	assert.Same(t, cycle, CycleFrom(ctx))
+
+	_, found := cycle.Load(policy, "rule", "sha256:abc")
+	assert.False(t, found)
+	cycle.Store(policy, "rule", "sha256:abc", "fail")
+	outcome, found := cycle.Load(policy, "rule", "sha256:abc")
+	assert.True(t, found)
+	assert.Equal(t, "fail", outcome)
+	// outcomes are recorded per rule and digest
+	_, found = cycle.Load(policy, "other", "sha256:abc")
+	assert.False(t, found)
+	_, found = cycle.Load(policy, "rule", "sha256:def")
+	assert.False(t, found)
+}
@@ -2,11 +2,15 @@ package imageverifycache
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"time"
 
 	"github.com/dgraph-io/ristretto"
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/metrics"
 )
 
 const (
@@ -20,12 +24,16 @@ type cache struct {
 	maxSize        int64
 	ttl            time.Duration
 	cache          *ristretto.Cache
+	shared         Store
+	metrics        metrics.ImageVerifyCacheMetrics
 }
 
 type Option = func(*cache) error
 
 func New(options ...Option) (Client, error) {
-	cache := &cache{}
+	cache := &cache{
+		metrics: metrics.GetImageVerifyCacheMetrics(),
+	}
 	for _, opt := range options {
 		if err := opt(cache); err != nil {
 			return nil, err
@@ -35,6 +43,11 @@ func New(options ...Option) (Client, error) {
 		MaxCost:     cache.maxSize,
 		NumCounters: 10 * cache.maxSize,
 		BufferItems: 64,
+		OnEvict: func(*ristretto.Item) {
+			if cache.metrics != nil {
+				cache.metrics.RecordEviction(context.Background(), TierMemory, 1)
+			}
+		},
 	}
 	rcache, err := ristretto.NewCache(&config)
 	if err != nil {
@@ -87,11 +100,48 @@ func WithTTLDuration(t time.Duration) Option {
 	}
 }
 
+// WithSharedStore adds a second cache tier shared between the replicas, it is looked up on memory cache misses
+func WithSharedStore(store Store) Option {
+	return func(c *cache) error {
+		c.shared = store
+		return nil
+	}
+}
+
+// generateKey returns a digest of the policy, the verification config of the rule and the image, entries
+// are invalidated when the verification config changes but survive unrelated policy changes
 func generateKey(policy kyvernov1.PolicyInterface, ruleName string, imageRef string) string {
-	return string(policy.GetUID()) + ";" + policy.GetResourceVersion() + ";" + ruleName + ";" + imageRef
+	hash := sha256.New()
+	for _, part := range [][]byte{[]byte(policy.GetUID()), []byte(ruleName), verificationConfig(policy, ruleName), []byte(imageRef)} {
+		hash.Write(part)
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
 }
 
-func (c *cache) Set(ctx context.Context, policy kyvernov1.PolicyInterface, ruleName string, imageRef string, useCache bool) (bool, error) {
+// verificationConfig returns the serialized context and image verification config of a rule, rules not found in
+// the policy spec (autogen rules) are computed from the policy rules so the config of every rule is used
+func verificationConfig(policy kyvernov1.PolicyInterface, ruleName string) []byte {
+	type config struct {
+		Context      []kyvernov1.ContextEntry      `json:"context,omitempty"`
+		VerifyImages []kyvernov1.ImageVerification `json:"verifyImages,omitempty"`
+	}
+	var configs []config
+	for _, rule := range policy.GetSpec().Rules {
+		if rule.Name == ruleName {
+			configs = []config{{rule.Context, rule.VerifyImages}}
+			break
+		}
+		configs = append(configs, config{rule.Context, rule.VerifyImages})
+	}
+	data, err := json.Marshal(configs)
+	if err != nil {
+		return []byte(policy.GetResourceVersion())
+	}
+	return data
+}
+
+func (c *cache) Set(ctx context.Context, policy kyvernov1.PolicyInterface, ruleName string, imageRef string, pinnedRef string, useCache bool) (bool, error) {
 	if !c.isCacheEnabled {
 		// If cache is globally disabled just return
 		return false, nil
@@ -103,24 +153,59 @@ func (c *cache) Set(ctx context.Context, policy kyvernov1.PolicyInterface, ruleN
 
 	stored := c.cache.SetWithTTL(key, nil, 1, c.ttl)
 	c.cache.Wait()
+	// tags can be moved to another image, the shared tier only trusts the resolved digest
+	if c.shared != nil && pinnedRef != "" {
+		if err := c.shared.Set(ctx, generateKey(policy, ruleName, pinnedRef), c.ttl); err != nil {
+			return stored, err
+		}
+		return true, nil
+	}
 	if stored {
 		return true, nil
 	}
 	return false, nil
 }
 
-func (c *cache) Get(ctx context.Context, policy kyvernov1.PolicyInterface, ruleName string, imageRef string, useCache bool) (bool, error) {
+func (c *cache) Get(ctx context.Context, policy kyvernov1.PolicyInterface, ruleName string, imageRef string, pinnedRef string, useCache bool) (bool, error) {
 	if !c.isCacheEnabled {
 		// If cache is globally disabled just return
 		return false, nil
 	} else if !useCache {
 		// Else If enabled globally then return if locally disabled
 		return false, nil
+	} else if IsBypassed(ctx) {
+		return false, nil
 	}
 	key := generateKey(policy, ruleName, imageRef)
 	_, found := c.cache.Get(key)
+	c.recordLookup(ctx, TierMemory, found)
 	if found {
 		return true, nil
 	}
-	return false, nil
+	if c.shared == nil || pinnedRef == "" {
+		return false, nil
+	}
+	expires, found, err := c.shared.Get(ctx, generateKey(policy, ruleName, pinnedRef))
+	if err != nil {
+		return false, err
+	}
+	c.recordLookup(ctx, TierShared, found)
+	if !found {
+		return false, nil
+	}
+	// promote the entry to the memory tier for the remainder of its lifetime
+	ttl := time.Until(expires)
+	if ttl > c.ttl {
+		ttl = c.ttl
+	}
+	if ttl > 0 {
+		c.cache.SetWithTTL(key, nil, 1, ttl)
+	}
+	return true, nil
+}
+
+func (c *cache) recordLookup(ctx context.Context, tier string, hit bool) {
+	if c.metrics != nil {
+		c.metrics.RecordLookup(ctx, tier, hit)
+	}
 }
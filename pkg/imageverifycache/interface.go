@@ -9,10 +9,13 @@ import (
 type Client interface {
 	// Set Adds an image to the cache. The image is considered to be verified for the given rule in the policy
 	// The entry outomatically expires after sometime
+	// The shared tier is keyed on pinnedRef, the image reference pinned to its resolved digest, it is skipped
+	// when pinnedRef is empty
 	// Returns true when the cache entry is added
-	Set(ctx context.Context, policy kyvernov1.PolicyInterface, ruleName string, imageRef string, useCache bool) (bool, error)
+	Set(ctx context.Context, policy kyvernov1.PolicyInterface, ruleName string, imageRef string, pinnedRef string, useCache bool) (bool, error)
 
 	// Get Searches for the image verified using the rule in the policy in the cache
+	// The shared tier is only searched when the digest of the image is known
 	// Returns true when the cache entry is found
-	Get(ctx context.Context, policy kyvernov1.PolicyInterface, ruleName string, imagerRef string, useCache bool) (bool, error)
+	Get(ctx context.Context, policy kyvernov1.PolicyInterface, ruleName string, imagerRef string, pinnedRef string, useCache bool) (bool, error)
 }
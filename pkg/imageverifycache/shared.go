@@ -0,0 +1,269 @@
+package imageverifycache
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// TierMemory is the in-process cache tier
+	TierMemory = "memory"
+	// TierShared is the cache tier shared between the replicas
+	TierShared = "shared"
+
+	signingKeyName = "key"
+	signingKeySize = 32
+	// flushDelay is the delay batching the entries written to the shared tier in a single update
+	flushDelay   = time.Second
+	flushTimeout = 10 * time.Second
+)
+
+// Store is a cache tier shared between the replicas, entries are keyed on a digest of the policy
+// verification config and the image reference
+type Store interface {
+	// Get returns the expiration time of an entry, found is false if the entry doesn't exist, expired
+	// or its signature is not valid
+	Get(ctx context.Context, key string) (expires time.Time, found bool, err error)
+	// Set adds an entry expiring after ttl, the entries may be written asynchronously
+	Set(ctx context.Context, key string, ttl time.Duration) error
+}
+
+type configMapStore struct {
+	logger     logr.Logger
+	client     corev1client.ConfigMapInterface
+	lister     corev1listers.ConfigMapNamespaceLister
+	name       string
+	signingKey []byte
+	maxSize    int
+	metrics    metrics.ImageVerifyCacheMetrics
+	flushDelay time.Duration
+
+	// entries waiting for the next flush
+	lock    sync.Mutex
+	pending map[string]string
+	timer   *time.Timer
+}
+
+// NewConfigMapStore returns a store keeping its entries in a ConfigMap, every entry is signed with
+// the signing key so that entries written by anything else than a replica are ignored. The entries
+// are written in batches so that the ConfigMap is updated at most once per flushDelay by every replica
+func NewConfigMapStore(
+	logger logr.Logger,
+	client corev1client.ConfigMapInterface,
+	lister corev1listers.ConfigMapNamespaceLister,
+	name string,
+	signingKey []byte,
+	maxSize int,
+) Store {
+	if maxSize <= 0 {
+		maxSize = defaultMaxSize
+	}
+	return &configMapStore{
+		logger:     logger,
+		client:     client,
+		lister:     lister,
+		name:       name,
+		signingKey: signingKey,
+		maxSize:    maxSize,
+		metrics:    metrics.GetImageVerifyCacheMetrics(),
+		flushDelay: flushDelay,
+		pending:    map[string]string{},
+	}
+}
+
+func (s *configMapStore) Get(ctx context.Context, key string) (time.Time, bool, error) {
+	cm, err := s.lister.Get(s.name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return time.Time{}, false, nil
+		}
+		return time.Time{}, false, err
+	}
+	value, ok := cm.Data[key]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	expires, err := s.decode(key, value)
+	if err != nil {
+		s.logger.V(2).Info("ignoring invalid shared cache entry", "key", key, "error", err.Error())
+		return time.Time{}, false, nil
+	}
+	if !time.Now().Before(expires) {
+		return time.Time{}, false, nil
+	}
+	return expires, true, nil
+}
+
+func (s *configMapStore) Set(ctx context.Context, key string, ttl time.Duration) error {
+	value := s.encode(key, time.Now().Add(ttl))
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.pending[key] = value
+	if s.timer == nil {
+		s.timer = time.AfterFunc(s.flushDelay, func() {
+			ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+			defer cancel()
+			if err := s.flush(ctx); err != nil {
+				s.logger.Error(err, "failed to write shared cache entries")
+			}
+		})
+	}
+	return nil
+}
+
+// flush writes the pending entries with a single update of the ConfigMap
+func (s *configMapStore) flush(ctx context.Context) error {
+	s.lock.Lock()
+	entries := s.pending
+	s.pending = map[string]string{}
+	s.timer = nil
+	s.lock.Unlock()
+	if len(entries) == 0 {
+		return nil
+	}
+	var evicted int64
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		evicted = 0
+		cm, err := s.client.Get(ctx, s.name, metav1.GetOptions{})
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: s.name},
+				Data:       make(map[string]string, len(entries)),
+			}
+			for key, value := range entries {
+				cm.Data[key] = value
+			}
+			_, err := s.client.Create(ctx, cm, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				// another replica created it, retry as a conflict
+				return apierrors.NewConflict(corev1.Resource("configmaps"), s.name, err)
+			}
+			return err
+		}
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		evicted = s.evict(cm.Data, entries)
+		for key, value := range entries {
+			cm.Data[key] = value
+		}
+		_, err = s.client.Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+	if err == nil && evicted != 0 && s.metrics != nil {
+		s.metrics.RecordEviction(ctx, TierShared, evicted)
+	}
+	return err
+}
+
+// evict removes the expired and invalid entries and, if the store is still full, the entries expiring first
+func (s *configMapStore) evict(data map[string]string, incoming map[string]string) int64 {
+	type item struct {
+		key     string
+		expires time.Time
+	}
+	var evicted int64
+	var items []item
+	now := time.Now()
+	for key, value := range data {
+		if _, ok := incoming[key]; ok {
+			continue
+		}
+		expires, err := s.decode(key, value)
+		if err != nil || !now.Before(expires) {
+			delete(data, key)
+			evicted++
+			continue
+		}
+		items = append(items, item{key, expires})
+	}
+	if overflow := min(len(items)+len(incoming)-s.maxSize, len(items)); overflow > 0 {
+		sort.Slice(items, func(i, j int) bool { return items[i].expires.Before(items[j].expires) })
+		for _, item := range items[:overflow] {
+			delete(data, item.key)
+			evicted++
+		}
+	}
+	return evicted
+}
+
+// encode returns the value of an entry, the expiration time followed by its signature
+func (s *configMapStore) encode(key string, expires time.Time) string {
+	timestamp := strconv.FormatInt(expires.Unix(), 10)
+	return timestamp + "." + s.sign(key, timestamp)
+}
+
+func (s *configMapStore) decode(key string, value string) (time.Time, error) {
+	timestamp, signature, ok := strings.Cut(value, ".")
+	if !ok {
+		return time.Time{}, fmt.Errorf("malformed entry")
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.sign(key, timestamp))) {
+		return time.Time{}, fmt.Errorf("invalid signature")
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(seconds, 0), nil
+}
+
+func (s *configMapStore) sign(key string, timestamp string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(key))
+	mac.Write([]byte{0})
+	mac.Write([]byte(timestamp))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// LoadOrCreateSigningKey returns the key used to sign the shared cache entries, the key is stored
+// in a Secret created with a random key when it doesn't exist
+func LoadOrCreateSigningKey(ctx context.Context, client corev1client.SecretInterface, name string) ([]byte, error) {
+	secret, err := client.Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		if key := secret.Data[signingKeyName]; len(key) != 0 {
+			return key, nil
+		}
+		return nil, fmt.Errorf("secret %s has no %s entry", name, signingKeyName)
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	key := make([]byte, signingKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Data:       map[string][]byte{signingKeyName: key},
+	}
+	if _, err := client.Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			// another replica created it first
+			return LoadOrCreateSigningKey(ctx, client, name)
+		}
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,215 @@
+package imageverifycache
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+)
+
+const (
+	testNamespace = "kyverno"
+	testConfigMap = "kyverno-image-verify-cache"
+)
+
+// newTestStore returns a store and a function flushing its entries and syncing its lister with the fake client
+func newTestStore(t *testing.T, signingKey string, maxSize int) (Store, func()) {
+	return newTestStoreWithClient(t, fake.NewSimpleClientset(), signingKey, maxSize)
+}
+
+func newTestStoreWithClient(t *testing.T, client *fake.Clientset, signingKey string, maxSize int) (Store, func()) {
+	indexer := toolscache.NewIndexer(toolscache.MetaNamespaceKeyFunc, toolscache.Indexers{})
+	store := NewConfigMapStore(
+		logr.Discard(),
+		client.CoreV1().ConfigMaps(testNamespace),
+		corev1listers.NewConfigMapLister(indexer).ConfigMaps(testNamespace),
+		testConfigMap,
+		[]byte(signingKey),
+		maxSize,
+	)
+	// entries are only written by the explicit flushes of the tests
+	store.(*configMapStore).flushDelay = time.Hour
+	sync := func() {
+		assert.NoError(t, store.(*configMapStore).flush(context.Background()))
+		cm, err := client.CoreV1().ConfigMaps(testNamespace).Get(context.Background(), testConfigMap, metav1.GetOptions{})
+		assert.NoError(t, err)
+		cm.Namespace = testNamespace
+		assert.NoError(t, indexer.Update(cm))
+	}
+	return store, sync
+}
+
+func TestConfigMapStore(t *testing.T) {
+	ctx := context.Background()
+	store, sync := newTestStore(t, "secret", 2)
+
+	_, found, err := store.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	assert.NoError(t, store.Set(ctx, "a", time.Hour))
+	assert.NoError(t, store.Set(ctx, "b", 2*time.Hour))
+	sync()
+	expires, found, err := store.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expires, 2*time.Second)
+
+	// the store is full, the entry expiring first is evicted
+	assert.NoError(t, store.Set(ctx, "c", 3*time.Hour))
+	sync()
+	_, found, _ = store.Get(ctx, "a")
+	assert.False(t, found)
+	_, found, _ = store.Get(ctx, "b")
+	assert.True(t, found)
+	_, found, _ = store.Get(ctx, "c")
+	assert.True(t, found)
+}
+
+func TestConfigMapStore_Batch(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset()
+	store, sync := newTestStoreWithClient(t, client, "secret", 10)
+	writes := func() int {
+		count := 0
+		for _, action := range client.Actions() {
+			if action.GetVerb() == "create" || action.GetVerb() == "update" {
+				count++
+			}
+		}
+		return count
+	}
+
+	for _, key := range []string{"a", "b", "c"} {
+		assert.NoError(t, store.Set(ctx, key, time.Hour))
+	}
+	assert.Equal(t, 0, writes())
+	sync()
+	assert.Equal(t, 1, writes())
+	for _, key := range []string{"d", "e"} {
+		assert.NoError(t, store.Set(ctx, key, time.Hour))
+	}
+	sync()
+	assert.Equal(t, 2, writes())
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		_, found, err := store.Get(ctx, key)
+		assert.NoError(t, err)
+		assert.True(t, found, key)
+	}
+	// nothing is written without pending entries
+	sync()
+	assert.Equal(t, 2, writes())
+}
+
+func TestConfigMapStore_Signature(t *testing.T) {
+	store := &configMapStore{signingKey: []byte("secret")}
+	value := store.encode("a", time.Now().Add(time.Hour))
+
+	_, err := store.decode("a", value)
+	assert.NoError(t, err)
+	// entries can't be moved to another key
+	_, err = store.decode("b", value)
+	assert.Error(t, err)
+	// entries signed with another key are rejected
+	other := &configMapStore{signingKey: []byte("other")}
+	_, err = other.decode("a", value)
+	assert.Error(t, err)
+	// expiration times can't be extended
+	_, signature, _ := strings.Cut(value, ".")
+	_, err = store.decode("a", "99999999999."+signature)
+	assert.Error(t, err)
+}
+
+func TestGenerateKey(t *testing.T) {
+	newPolicy := func(resourceVersion string, attestor string) *kyvernov1.ClusterPolicy {
+		return &kyvernov1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "verify", UID: "uid", ResourceVersion: resourceVersion},
+			Spec: kyvernov1.Spec{
+				Rules: []kyvernov1.Rule{{
+					Name: "check-signature",
+					VerifyImages: []kyvernov1.ImageVerification{{
+						ImageReferences: []string{"ghcr.io/kyverno/*"},
+						Attestors:       []kyvernov1.AttestorSet{{Entries: []kyvernov1.Attestor{{Keys: &kyvernov1.StaticKeyAttestor{PublicKeys: attestor}}}}},
+					}},
+				}},
+			},
+		}
+	}
+	image := "ghcr.io/kyverno/kyverno@sha256:0123"
+	key := generateKey(newPolicy("1", "key-a"), "check-signature", image)
+	// unrelated policy changes keep the entries
+	assert.Equal(t, key, generateKey(newPolicy("2", "key-a"), "check-signature", image))
+	// verification config changes invalidate the entries
+	assert.NotEqual(t, key, generateKey(newPolicy("3", "key-b"), "check-signature", image))
+	assert.NotEqual(t, key, generateKey(newPolicy("1", "key-a"), "autogen-check-signature", image))
+	assert.NotEqual(t, key, generateKey(newPolicy("1", "key-a"), "check-signature", "ghcr.io/kyverno/kyverno@sha256:4567"))
+}
+
+func TestCache_SharedTier(t *testing.T) {
+	ctx := context.Background()
+	store, sync := newTestStore(t, "secret", 10)
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "verify", UID: "uid"}}
+	newCache := func() Client {
+		client, err := New(WithCacheEnableFlag(true), WithMaxSize(10), WithTTLDuration(time.Hour), WithSharedStore(store))
+		assert.NoError(t, err)
+		return client
+	}
+	const (
+		image  = "ghcr.io/kyverno/kyverno:v1"
+		pinned = "ghcr.io/kyverno/kyverno@sha256:0123"
+	)
+
+	first := newCache()
+	set, err := first.Set(ctx, policy, "rule", image, pinned, true)
+	assert.NoError(t, err)
+	assert.True(t, set)
+	// the digest of an image verified from its tag isn't known, it isn't shared
+	set, err = first.Set(ctx, policy, "rule", "ghcr.io/kyverno/kyverno:v2", "", true)
+	assert.NoError(t, err)
+	assert.True(t, set)
+	sync()
+
+	// another replica finds the entry in the shared tier when the digest of the image is known
+	second := newCache()
+	found, err := second.Get(ctx, policy, "rule", image, pinned, true)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	found, err = newCache().Get(ctx, policy, "rule", image, "", true)
+	assert.NoError(t, err)
+	assert.False(t, found)
+	// the tag was moved to another image
+	found, err = newCache().Get(ctx, policy, "rule", image, "ghcr.io/kyverno/kyverno@sha256:4567", true)
+	assert.NoError(t, err)
+	assert.False(t, found)
+	found, err = newCache().Get(ctx, policy, "rule", "ghcr.io/kyverno/kyverno:v2", "ghcr.io/kyverno/kyverno@sha256:89ab", true)
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestCache_Bypass(t *testing.T) {
+	ctx := context.Background()
+	store, sync := newTestStore(t, "secret", 10)
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "verify", UID: "uid"}}
+	client, err := New(WithCacheEnableFlag(true), WithMaxSize(10), WithTTLDuration(time.Hour), WithSharedStore(store))
+	assert.NoError(t, err)
+
+	// successful verifications are cached even when the lookups are bypassed
+	set, err := client.Set(WithBypass(ctx), policy, "rule", "image", "image@sha256:0123", true)
+	assert.NoError(t, err)
+	assert.True(t, set)
+	sync()
+	found, err := client.Get(WithBypass(ctx), policy, "rule", "image", "image@sha256:0123", true)
+	assert.NoError(t, err)
+	assert.False(t, found)
+	found, err = client.Get(ctx, policy, "rule", "image", "image@sha256:0123", true)
+	assert.NoError(t, err)
+	assert.True(t, found)
+}
@@ -5,6 +5,7 @@ type PolicyValidationMode string
 const (
 	Enforce PolicyValidationMode = "enforce"
 	Audit   PolicyValidationMode = "audit"
+	Shadow  PolicyValidationMode = "shadow"
 )
 
 type PolicyType string
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func GetGenerateDriftMetrics() GenerateDriftMetrics {
+	if metricsConfig == nil {
+		return nil
+	}
+
+	return metricsConfig.GenerateDriftMetrics()
+}
+
+type GenerateDriftMetrics interface {
+	RecordDrift(ctx context.Context, policyNamespace, policyName, ruleName, drift string, count int64, observer metric.Observer)
+	RegisterCallback(f metric.Callback) (metric.Registration, error)
+}
+
+type generateDriftMetrics struct {
+	driftMetric metric.Int64ObservableGauge
+	meter       metric.Meter
+	callback    metric.Callback
+
+	logger logr.Logger
+}
+
+func (m *generateDriftMetrics) init(meter metric.Meter) {
+	var err error
+
+	m.driftMetric, err = meter.Int64ObservableGauge(
+		"kyverno_generate_drift_resources",
+		metric.WithDescription("can be used to track the number of downstream resources of synchronized generate rules that are missing, modified or orphaned as of the last drift scan"),
+	)
+	if err != nil {
+		m.logger.Error(err, "Failed to create instrument, kyverno_generate_drift_resources")
+	}
+
+	m.meter = meter
+
+	if m.callback != nil {
+		if _, err := m.meter.RegisterCallback(m.callback, m.driftMetric); err != nil {
+			m.logger.Error(err, "failed to register callback for generate drift metric")
+		}
+	}
+}
+
+func (m *generateDriftMetrics) RecordDrift(ctx context.Context, policyNamespace, policyName, ruleName, drift string, count int64, observer metric.Observer) {
+	if m.driftMetric == nil {
+		return
+	}
+	observer.ObserveInt64(m.driftMetric, count, metric.WithAttributes(
+		attribute.String("policy_namespace", policyNamespace),
+		attribute.String("policy_name", policyName),
+		attribute.String("rule_name", ruleName),
+		attribute.String("drift_type", drift),
+	))
+}
+
+func (m *generateDriftMetrics) RegisterCallback(f metric.Callback) (metric.Registration, error) {
+	if m.meter == nil {
+		return nil, nil
+	}
+
+	m.callback = f
+	return m.meter.RegisterCallback(f, m.driftMetric)
+}
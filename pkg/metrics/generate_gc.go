@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func GetGenerateGCMetrics() GenerateGCMetrics {
+	if metricsConfig == nil {
+		return nil
+	}
+
+	return metricsConfig.GenerateGCMetrics()
+}
+
+type GenerateGCMetrics interface {
+	RecordOrphans(ctx context.Context, reason, kind string, count int64, observer metric.Observer)
+	RecordDeletion(ctx context.Context, reason, kind string, dryRun bool)
+	RegisterCallback(f metric.Callback) (metric.Registration, error)
+}
+
+type generateGCMetrics struct {
+	orphansMetric   metric.Int64ObservableGauge
+	deletionsMetric metric.Int64Counter
+	meter           metric.Meter
+	callback        metric.Callback
+
+	logger logr.Logger
+}
+
+func (m *generateGCMetrics) init(meter metric.Meter) {
+	var err error
+
+	m.orphansMetric, err = meter.Int64ObservableGauge(
+		"kyverno_generate_orphaned_resources",
+		metric.WithDescription("can be used to track the number of generated resources whose policy, rule, target or trigger no longer exists as of the last garbage collection scan"),
+	)
+	if err != nil {
+		m.logger.Error(err, "Failed to create instrument, kyverno_generate_orphaned_resources")
+	}
+	m.deletionsMetric, err = meter.Int64Counter(
+		"kyverno_generate_orphaned_resources_deleted",
+		metric.WithDescription("can be used to track the number of orphaned generated resources deleted by the garbage collection"),
+	)
+	if err != nil {
+		m.logger.Error(err, "Failed to create instrument, kyverno_generate_orphaned_resources_deleted")
+	}
+
+	m.meter = meter
+
+	if m.callback != nil {
+		if _, err := m.meter.RegisterCallback(m.callback, m.orphansMetric); err != nil {
+			m.logger.Error(err, "failed to register callback for generate orphans metric")
+		}
+	}
+}
+
+func (m *generateGCMetrics) RecordOrphans(ctx context.Context, reason, kind string, count int64, observer metric.Observer) {
+	if m.orphansMetric == nil {
+		return
+	}
+	observer.ObserveInt64(m.orphansMetric, count, metric.WithAttributes(
+		attribute.String("reason", reason),
+		attribute.String("resource_kind", kind),
+	))
+}
+
+func (m *generateGCMetrics) RecordDeletion(ctx context.Context, reason, kind string, dryRun bool) {
+	if m.deletionsMetric == nil {
+		return
+	}
+	m.deletionsMetric.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("reason", reason),
+		attribute.String("resource_kind", kind),
+		attribute.Bool("dry_run", dryRun),
+	))
+}
+
+func (m *generateGCMetrics) RegisterCallback(f metric.Callback) (metric.Registration, error) {
+	if m.meter == nil {
+		return nil, nil
+	}
+
+	m.callback = f
+	return m.meter.RegisterCallback(f, m.orphansMetric)
+}
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func GetImageVerifyCacheMetrics() ImageVerifyCacheMetrics {
+	if metricsConfig == nil {
+		return nil
+	}
+
+	return metricsConfig.ImageVerifyCacheMetrics()
+}
+
+type ImageVerifyCacheMetrics interface {
+	RecordLookup(ctx context.Context, tier string, hit bool)
+	RecordEviction(ctx context.Context, tier string, count int64)
+}
+
+type imageVerifyCacheMetrics struct {
+	lookupsMetric   metric.Int64Counter
+	evictionsMetric metric.Int64Counter
+
+	logger logr.Logger
+}
+
+func (m *imageVerifyCacheMetrics) init(meter metric.Meter) {
+	var err error
+
+	m.lookupsMetric, err = meter.Int64Counter(
+		"kyverno_image_verify_cache_lookups",
+		metric.WithDescription("can be used to track the hits and misses of each tier of the image verification cache"),
+	)
+	if err != nil {
+		m.logger.Error(err, "Failed to create instrument, kyverno_image_verify_cache_lookups")
+	}
+	m.evictionsMetric, err = meter.Int64Counter(
+		"kyverno_image_verify_cache_evictions",
+		metric.WithDescription("can be used to track the number of entries evicted from each tier of the image verification cache"),
+	)
+	if err != nil {
+		m.logger.Error(err, "Failed to create instrument, kyverno_image_verify_cache_evictions")
+	}
+}
+
+func (m *imageVerifyCacheMetrics) RecordLookup(ctx context.Context, tier string, hit bool) {
+	if m.lookupsMetric == nil {
+		return
+	}
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.lookupsMetric.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("tier", tier),
+		attribute.String("result", result),
+	))
+}
+
+func (m *imageVerifyCacheMetrics) RecordEviction(ctx context.Context, tier string, count int64) {
+	if m.evictionsMetric == nil {
+		return
+	}
+	m.evictionsMetric.Add(ctx, count, metric.WithAttributes(
+		attribute.String("tier", tier),
+	))
+}
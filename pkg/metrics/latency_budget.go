@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-logr/logr"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func GetLatencyBudgetMetrics() LatencyBudgetMetrics {
+	if metricsConfig == nil {
+		return nil
+	}
+
+	return metricsConfig.LatencyBudgetMetrics()
+}
+
+type LatencyBudgetMetrics interface {
+	RecordExceeded(ctx context.Context, policy engineapi.GenericPolicy, operation string, degraded bool)
+	RecordDegradedSkip(ctx context.Context, policy engineapi.GenericPolicy, operation string)
+}
+
+type latencyBudgetMetrics struct {
+	exceeded     metric.Int64Counter
+	degradedSkip metric.Int64Counter
+
+	logger logr.Logger
+}
+
+func (m *latencyBudgetMetrics) init(meter metric.Meter) {
+	var err error
+
+	m.exceeded, err = meter.Int64Counter(
+		"kyverno_policy_latency_budget_exceeded",
+		metric.WithDescription("can be used to track the number of admission requests for which a policy evaluation exceeded its latency budget"),
+	)
+	if err != nil {
+		m.logger.Error(err, "failed to register metric kyverno_policy_latency_budget_exceeded")
+	}
+	m.degradedSkip, err = meter.Int64Counter(
+		"kyverno_policy_degraded_skips",
+		metric.WithDescription("can be used to track the number of admission requests that skipped a policy because it was degraded"),
+	)
+	if err != nil {
+		m.logger.Error(err, "failed to register metric kyverno_policy_degraded_skips")
+	}
+}
+
+func (m *latencyBudgetMetrics) RecordExceeded(ctx context.Context, policy engineapi.GenericPolicy, operation string, degraded bool) {
+	if m.exceeded == nil {
+		return
+	}
+	attributes, ok := latencyBudgetAttributes(policy, operation)
+	if !ok {
+		return
+	}
+	attributes = append(attributes, attribute.Bool("policy_degraded", degraded))
+	m.exceeded.Add(ctx, 1, metric.WithAttributes(attributes...))
+}
+
+func (m *latencyBudgetMetrics) RecordDegradedSkip(ctx context.Context, policy engineapi.GenericPolicy, operation string) {
+	if m.degradedSkip == nil {
+		return
+	}
+	attributes, ok := latencyBudgetAttributes(policy, operation)
+	if !ok {
+		return
+	}
+	m.degradedSkip.Add(ctx, 1, metric.WithAttributes(attributes...))
+}
+
+func latencyBudgetAttributes(policy engineapi.GenericPolicy, operation string) ([]attribute.KeyValue, bool) {
+	namespace := policy.GetNamespace()
+	if namespace == "" {
+		namespace = "-"
+	}
+	if !GetManager().Config().CheckNamespace(namespace) {
+		return nil, false
+	}
+	return []attribute.KeyValue{
+		attribute.String("policy_kind", policy.GetKind()),
+		attribute.String("policy_namespace", namespace),
+		attribute.String("policy_name", policy.GetName()),
+		attribute.String("resource_request_operation", strings.ToLower(operation)),
+	}, true
+}
@@ -52,6 +52,13 @@ type MetricsConfig struct {
 	ivpolMetrics        *imageValidatingMetrics
 	mpolMetrics         *mutatingMetrics
 	gpolMetrics         *generatingMetrics
+	shadowMetrics       *shadowMetrics
+	budgetMetrics       *latencyBudgetMetrics
+	historyMetrics      *reportHistoryMetrics
+	driftMetrics        *generateDriftMetrics
+	urMetrics           *updateRequestMetrics
+	gcMetrics           *generateGCMetrics
+	ivCacheMetrics      *imageVerifyCacheMetrics
 
 	// config
 	config kconfig.MetricsConfiguration
@@ -76,6 +83,13 @@ type MetricsConfigManager interface {
 	IVPOLMetrics() ImageValidatingMetrics
 	MPOLMetrics() MutatingMetrics
 	GPOLMetrics() GeneratingMetrics
+	ShadowMetrics() ShadowMetrics
+	LatencyBudgetMetrics() LatencyBudgetMetrics
+	ReportHistoryMetrics() ReportHistoryMetrics
+	GenerateDriftMetrics() GenerateDriftMetrics
+	UpdateRequestMetrics() UpdateRequestMetrics
+	GenerateGCMetrics() GenerateGCMetrics
+	ImageVerifyCacheMetrics() ImageVerifyCacheMetrics
 }
 
 func (m *MetricsConfig) Config() kconfig.MetricsConfiguration {
@@ -138,6 +152,34 @@ func (m *MetricsConfig) GPOLMetrics() GeneratingMetrics {
 	return m.gpolMetrics
 }
 
+func (m *MetricsConfig) ShadowMetrics() ShadowMetrics {
+	return m.shadowMetrics
+}
+
+func (m *MetricsConfig) LatencyBudgetMetrics() LatencyBudgetMetrics {
+	return m.budgetMetrics
+}
+
+func (m *MetricsConfig) ReportHistoryMetrics() ReportHistoryMetrics {
+	return m.historyMetrics
+}
+
+func (m *MetricsConfig) GenerateDriftMetrics() GenerateDriftMetrics {
+	return m.driftMetrics
+}
+
+func (m *MetricsConfig) UpdateRequestMetrics() UpdateRequestMetrics {
+	return m.urMetrics
+}
+
+func (m *MetricsConfig) GenerateGCMetrics() GenerateGCMetrics {
+	return m.gcMetrics
+}
+
+func (m *MetricsConfig) ImageVerifyCacheMetrics() ImageVerifyCacheMetrics {
+	return m.ivCacheMetrics
+}
+
 func (m *MetricsConfig) initializeMetrics(meterProvider metric.MeterProvider) error {
 	var err error
 	meter := meterProvider.Meter(MeterName)
@@ -177,6 +219,13 @@ func (m *MetricsConfig) initializeMetrics(meterProvider metric.MeterProvider) er
 	m.ivpolMetrics.init(meter)
 	m.mpolMetrics.init(meter)
 	m.gpolMetrics.init(meter)
+	m.shadowMetrics.init(meter)
+	m.budgetMetrics.init(meter)
+	m.historyMetrics.init(meter)
+	m.driftMetrics.init(meter)
+	m.urMetrics.init(meter)
+	m.gcMetrics.init(meter)
+	m.ivCacheMetrics.init(meter)
 
 	initKyvernoInfoMetric(m)
 	return nil
@@ -326,6 +375,13 @@ func NewMetricsConfigManager(logger logr.Logger, metricsConfiguration kconfig.Me
 		ivpolMetrics:        &imageValidatingMetrics{logger: logger.WithName("image-validating-policy")},
 		mpolMetrics:         &mutatingMetrics{logger: logger.WithName("mutating-policy")},
 		gpolMetrics:         &generatingMetrics{logger: logger.WithName("generating-policy")},
+		shadowMetrics:       &shadowMetrics{logger: logger.WithName("shadow")},
+		budgetMetrics:       &latencyBudgetMetrics{logger: logger.WithName("latency-budget")},
+		historyMetrics:      &reportHistoryMetrics{logger: logger.WithName("report-history")},
+		driftMetrics:        &generateDriftMetrics{logger: logger.WithName("generate-drift")},
+		urMetrics:           &updateRequestMetrics{logger: logger.WithName("update-request")},
+		gcMetrics:           &generateGCMetrics{logger: logger.WithName("generate-gc")},
+		ivCacheMetrics:      &imageVerifyCacheMetrics{logger: logger.WithName("image-verify-cache")},
 	}
 
 	return config
@@ -82,11 +82,13 @@ func GetPolicyInfos(policy kyvernov1.PolicyInterface) (string, string, PolicyTyp
 		policyType = Namespaced
 	}
 	backgroundMode := parsePolicyBackgroundMode(policy)
-	isEnforce := policy.GetSpec().HasValidateEnforce()
 	var validationMode PolicyValidationMode
-	if isEnforce {
+	switch {
+	case policy.GetSpec().HasValidateEnforce():
 		validationMode = Enforce
-	} else {
+	case policy.GetSpec().HasValidateShadow():
+		validationMode = Shadow
+	default:
 		validationMode = Audit
 	}
 	return name, namespace, policyType, backgroundMode, validationMode, nil
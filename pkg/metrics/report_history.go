@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	openreportsv1alpha1 "github.com/openreports/reports-api/apis/openreports.io/v1alpha1"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func GetReportHistoryMetrics() ReportHistoryMetrics {
+	if metricsConfig == nil {
+		return nil
+	}
+
+	return metricsConfig.ReportHistoryMetrics()
+}
+
+type ReportHistoryMetrics interface {
+	RecordReportSummary(ctx context.Context, policy, namespace, severity string, summary openreportsv1alpha1.ReportSummary, observer metric.Observer)
+	RegisterCallback(f metric.Callback) (metric.Registration, error)
+}
+
+type reportHistoryMetrics struct {
+	resultsMetric metric.Int64ObservableGauge
+	meter         metric.Meter
+	callback      metric.Callback
+
+	logger logr.Logger
+}
+
+func (m *reportHistoryMetrics) init(meter metric.Meter) {
+	var err error
+
+	m.resultsMetric, err = meter.Int64ObservableGauge(
+		"kyverno_policy_report_results",
+		metric.WithDescription("can be used to track the number of policy report results per policy, namespace, severity and result as of the last report history snapshot"),
+	)
+	if err != nil {
+		m.logger.Error(err, "Failed to create instrument, kyverno_policy_report_results")
+	}
+
+	m.meter = meter
+
+	if m.callback != nil {
+		if _, err := m.meter.RegisterCallback(m.callback, m.resultsMetric); err != nil {
+			m.logger.Error(err, "failed to register callback for report history metric")
+		}
+	}
+}
+
+func (m *reportHistoryMetrics) RecordReportSummary(ctx context.Context, policy, namespace, severity string, summary openreportsv1alpha1.ReportSummary, observer metric.Observer) {
+	if m.resultsMetric == nil {
+		return
+	}
+	if namespace == "" {
+		namespace = "-"
+	}
+	if !GetManager().Config().CheckNamespace(namespace) {
+		return
+	}
+	for result, count := range map[string]int{
+		"pass":  summary.Pass,
+		"fail":  summary.Fail,
+		"warn":  summary.Warn,
+		"error": summary.Error,
+		"skip":  summary.Skip,
+	} {
+		observer.ObserveInt64(m.resultsMetric, int64(count), metric.WithAttributes(
+			attribute.String("policy_name", policy),
+			attribute.String("resource_namespace", namespace),
+			attribute.String("policy_severity", severity),
+			attribute.String("policy_result", result),
+		))
+	}
+}
+
+func (m *reportHistoryMetrics) RegisterCallback(f metric.Callback) (metric.Registration, error) {
+	if m.meter == nil {
+		return nil, nil
+	}
+
+	m.callback = f
+	return m.meter.RegisterCallback(f, m.resultsMetric)
+}
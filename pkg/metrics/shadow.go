@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-logr/logr"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func GetShadowMetrics() ShadowMetrics {
+	if metricsConfig == nil {
+		return nil
+	}
+
+	return metricsConfig.ShadowMetrics()
+}
+
+type ShadowMetrics interface {
+	RecordResponse(ctx context.Context, operation string, response engineapi.EngineResponse)
+}
+
+type shadowMetrics struct {
+	resultCounter metric.Int64Counter
+
+	logger logr.Logger
+}
+
+func (m *shadowMetrics) init(meter metric.Meter) {
+	var err error
+
+	m.resultCounter, err = meter.Int64Counter(
+		"kyverno_policy_shadow_results",
+		metric.WithDescription("can be used to track the results of policies evaluated in shadow mode, a fail or error result is a request that would have been denied if the policy was enforced"),
+	)
+	if err != nil {
+		m.logger.Error(err, "failed to register metric kyverno_policy_shadow_results")
+	}
+}
+
+func (m *shadowMetrics) RecordResponse(ctx context.Context, operation string, response engineapi.EngineResponse) {
+	if m.resultCounter == nil {
+		return
+	}
+
+	policy := response.Policy()
+	namespace := policy.GetNamespace()
+	if namespace == "" {
+		namespace = "-"
+	}
+	if !GetManager().Config().CheckNamespace(namespace) {
+		return
+	}
+
+	for _, rule := range response.PolicyResponse.Rules {
+		m.resultCounter.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("policy_kind", policy.GetKind()),
+			attribute.String("policy_namespace", namespace),
+			attribute.String("policy_name", policy.GetName()),
+			attribute.String("rule_name", rule.Name()),
+			attribute.String("rule_result", string(rule.Status())),
+			attribute.String("resource_kind", response.Resource.GetKind()),
+			attribute.String("resource_namespace", response.Resource.GetNamespace()),
+			attribute.String("resource_request_operation", strings.ToLower(operation)),
+		))
+	}
+}
@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// UpdateRequestMode is the way an update request is handed over to the background controller
+type UpdateRequestMode string
+
+const (
+	// UpdateRequestModeCRD is used for update requests persisted as UpdateRequest resources
+	UpdateRequestModeCRD UpdateRequestMode = "crd"
+	// UpdateRequestModeMemory is used for update requests handed over in memory
+	UpdateRequestModeMemory UpdateRequestMode = "memory"
+)
+
+func GetUpdateRequestMetrics() UpdateRequestMetrics {
+	if metricsConfig == nil {
+		return nil
+	}
+
+	return metricsConfig.UpdateRequestMetrics()
+}
+
+type UpdateRequestMetrics interface {
+	RecordHandover(ctx context.Context, mode UpdateRequestMode, fallback bool)
+	RecordProcessing(ctx context.Context, mode UpdateRequestMode, requestType string, success bool, duration time.Duration)
+	RecordPersisted(ctx context.Context, reason string)
+}
+
+type updateRequestMetrics struct {
+	handovers  metric.Int64Counter
+	processing metric.Float64Histogram
+	persisted  metric.Int64Counter
+
+	logger logr.Logger
+}
+
+func (m *updateRequestMetrics) init(meter metric.Meter) {
+	var err error
+
+	m.handovers, err = meter.Int64Counter(
+		"kyverno_update_request_handovers",
+		metric.WithDescription("can be used to track the number of update requests handed over to the background controller, per mode"),
+	)
+	if err != nil {
+		m.logger.Error(err, "Failed to create instrument, kyverno_update_request_handovers")
+	}
+	m.processing, err = meter.Float64Histogram(
+		"kyverno_update_request_processing_duration_seconds",
+		metric.WithDescription("can be used to track the latencies (in seconds) of the update requests processed by the background controller, per mode"),
+	)
+	if err != nil {
+		m.logger.Error(err, "Failed to create instrument, kyverno_update_request_processing_duration_seconds")
+	}
+	m.persisted, err = meter.Int64Counter(
+		"kyverno_update_request_persisted",
+		metric.WithDescription("can be used to track the number of in-memory update requests that were persisted as UpdateRequest resources"),
+	)
+	if err != nil {
+		m.logger.Error(err, "Failed to create instrument, kyverno_update_request_persisted")
+	}
+}
+
+func (m *updateRequestMetrics) RecordHandover(ctx context.Context, mode UpdateRequestMode, fallback bool) {
+	if m.handovers == nil {
+		return
+	}
+	m.handovers.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("mode", string(mode)),
+		attribute.Bool("fallback", fallback),
+	))
+}
+
+func (m *updateRequestMetrics) RecordProcessing(ctx context.Context, mode UpdateRequestMode, requestType string, success bool, duration time.Duration) {
+	if m.processing == nil {
+		return
+	}
+	m.processing.Record(ctx, duration.Seconds(), metric.WithAttributes(
+		attribute.String("mode", string(mode)),
+		attribute.String("request_type", requestType),
+		attribute.Bool("success", success),
+	))
+}
+
+func (m *updateRequestMetrics) RecordPersisted(ctx context.Context, reason string) {
+	if m.persisted == nil {
+		return
+	}
+	m.persisted.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("reason", reason),
+	))
+}
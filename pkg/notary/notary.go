@@ -44,17 +44,9 @@ type notaryVerifier struct {
 func (v *notaryVerifier) VerifySignature(ctx context.Context, opts images.Options) (*images.Response, error) {
 	v.log.V(2).Info("verifying image", "reference", opts.ImageRef)
 
-	certsPEM := combineCerts(opts)
-	certs, err := cryptoutils.LoadCertificatesFromPEM(bytes.NewReader([]byte(certsPEM)))
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to parse certificates")
-	}
-
-	trustStore := NewTrustStore("kyverno", certs)
-	policyDoc := v.buildPolicy()
-	notationVerifier, err := verifier.New(policyDoc, trustStore, nil)
+	notationVerifier, err := v.newVerifier(ctx, opts)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to created verifier")
+		return nil, err
 	}
 
 	v.log.V(4).Info("creating notation repo", "reference", opts.ImageRef)
@@ -102,6 +94,41 @@ func combineCerts(opts images.Options) string {
 	return certs
 }
 
+// newVerifier returns a verifier using either the configured trust policy document referenced by the
+// attestor or an in-memory trust policy trusting the attestor certificates
+func (v *notaryVerifier) newVerifier(ctx context.Context, opts images.Options) (notation.Verifier, error) {
+	if opts.TrustPolicy != "" {
+		trustConfig, err := LoadTrustConfig(ctx)
+		if err != nil {
+			return nil, err
+		}
+		policyDoc, err := trustConfig.Select(opts.TrustPolicy)
+		if err != nil {
+			return nil, err
+		}
+		v.log.V(4).Info("using trust policy document", "trustPolicy", opts.TrustPolicy)
+		notationVerifier, err := verifier.New(policyDoc, trustConfig.Store, PluginManager())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to created verifier")
+		}
+		return notationVerifier, nil
+	}
+
+	certsPEM := combineCerts(opts)
+	certs, err := cryptoutils.LoadCertificatesFromPEM(bytes.NewReader([]byte(certsPEM)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse certificates")
+	}
+
+	trustStore := NewTrustStore("kyverno", certs)
+	policyDoc := v.buildPolicy()
+	notationVerifier, err := verifier.New(policyDoc, trustStore, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to created verifier")
+	}
+	return notationVerifier, nil
+}
+
 func (v *notaryVerifier) buildPolicy() *trustpolicy.Document {
 	return &trustpolicy.Document{
 		Version: "1.0",
@@ -118,12 +145,24 @@ func (v *notaryVerifier) buildPolicy() *trustpolicy.Document {
 }
 
 func (v *notaryVerifier) verifyOutcomes(outcomes []*notation.VerificationOutcome) error {
+	if len(outcomes) == 0 {
+		return errors.New("no signature verification outcome")
+	}
 	var errs []error
 	for _, outcome := range outcomes {
 		if outcome.Error != nil {
 			errs = append(errs, outcome.Error)
 			continue
 		}
+		// trust policies skipping the verification have no envelope, nothing was verified
+		if outcome.EnvelopeContent == nil {
+			if outcome.VerificationLevel != nil && outcome.VerificationLevel.Name == trustpolicy.LevelSkip.Name {
+				errs = append(errs, errors.New("signature verification skipped by the trust policy"))
+			} else {
+				errs = append(errs, errors.New("signature verification outcome without signature envelope"))
+			}
+			continue
+		}
 
 		content := outcome.EnvelopeContent.Payload.Content
 		contentType := outcome.EnvelopeContent.Payload.ContentType
@@ -149,16 +188,24 @@ func (v *notaryVerifier) FetchAttestations(ctx context.Context, opts images.Opti
 
 	v.log.V(4).Info("client setup done", "repo", ref)
 
-	repoDesc, err := gcrremote.Head(ref, remoteOpts...)
-	if err != nil {
-		return nil, err
-	}
-	v.log.V(4).Info("fetched repository", "repoDesc", repoDesc)
+	// the mirrors of the image registry are tried first
+	fetched, err := images.FromReferences(opts.Client, ref, func(ref name.Reference) (fetchedReferrers, error) {
+		repoDesc, err := gcrremote.Head(ref, remoteOpts...)
+		if err != nil {
+			return fetchedReferrers{}, err
+		}
+		v.log.V(4).Info("fetched repository", "repoDesc", repoDesc)
 
-	referrers, err := gcrremote.Referrers(ref.Context().Digest(repoDesc.Digest.String()), remoteOpts...)
+		referrers, err := gcrremote.Referrers(ref.Context().Digest(repoDesc.Digest.String()), remoteOpts...)
+		if err != nil {
+			return fetchedReferrers{}, err
+		}
+		return fetchedReferrers{ref, repoDesc, referrers}, nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	ref, repoDesc, referrers := fetched.ref, fetched.repoDesc, fetched.referrers
 
 	referrersDescs, err := referrers.IndexManifest()
 	if err != nil {
@@ -210,9 +257,16 @@ func (v *notaryVerifier) FetchAttestations(ctx context.Context, opts images.Opti
 	return nil, fmt.Errorf("no matching attestations found for image %s with type %s", opts.ImageRef, opts.Type)
 }
 
+// fetchedReferrers holds the referrers fetched from one of the references of an image
+type fetchedReferrers struct {
+	ref       name.Reference
+	repoDesc  *v1.Descriptor
+	referrers v1.ImageIndex
+}
+
 func verifyAttestators(ctx context.Context, v *notaryVerifier, ref name.Reference, opts images.Options, desc v1.Descriptor) (ocispec.Descriptor, error) {
 	v.log.V(2).Info("verifying attestations", "reference", opts.ImageRef, "opts", opts)
-	if opts.Cert == "" && opts.CertChain == "" {
+	if opts.Cert == "" && opts.CertChain == "" && opts.TrustPolicy == "" {
 		// skips the checks when no attestor is provided
 		v1Desc := ocispec.Descriptor{
 			MediaType:   string(desc.MediaType),
@@ -224,20 +278,10 @@ func verifyAttestators(ctx context.Context, v *notaryVerifier, ref name.Referenc
 		}
 		return v1Desc, nil
 	}
-	certsPEM := combineCerts(opts)
-	certs, err := cryptoutils.LoadCertificatesFromPEM(bytes.NewReader([]byte(certsPEM)))
-	if err != nil {
-		v.log.V(4).Info("failed to parse certificates", "err", err)
-		return ocispec.Descriptor{}, errors.Wrapf(err, "failed to parse certificates")
-	}
-
-	v.log.V(4).Info("parsed certificates")
-	trustStore := NewTrustStore("kyverno", certs)
-	policyDoc := v.buildPolicy()
-	notationVerifier, err := verifier.New(policyDoc, trustStore, nil)
+	notationVerifier, err := v.newVerifier(ctx, opts)
 	if err != nil {
 		v.log.V(4).Info("failed to created verifier", "err", err)
-		return ocispec.Descriptor{}, errors.Wrapf(err, "failed to created verifier")
+		return ocispec.Descriptor{}, err
 	}
 
 	v.log.V(4).Info("created verifier")
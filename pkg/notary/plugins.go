@@ -0,0 +1,33 @@
+package notary
+
+import (
+	"sync"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/plugin"
+)
+
+var (
+	pluginLock    sync.RWMutex
+	pluginManager plugin.Manager
+)
+
+// SetPluginDirectory configures the directory the Notation verification plugins are loaded from,
+// every plugin is installed as `<directory>/<name>/notation-<name>`
+func SetPluginDirectory(path string) {
+	pluginLock.Lock()
+	defer pluginLock.Unlock()
+	if path == "" {
+		pluginManager = nil
+		return
+	}
+	pluginManager = plugin.NewCLIManager(dir.NewSysFS(path))
+}
+
+// PluginManager returns the manager of the Notation verification plugins, nil when no plugin
+// directory is configured
+func PluginManager() plugin.Manager {
+	pluginLock.RLock()
+	defer pluginLock.RUnlock()
+	return pluginManager
+}
@@ -31,30 +31,30 @@ func parseReferenceCrane(ctx context.Context, ref string, registryClient images.
 		return nil, err
 	}
 
-	desc, err := gcrremote.Head(nameRef, remoteOpts...)
-	if err != nil {
-		return nil, err
-	}
-
-	if !isDigestReference(ref) {
-		nameRef, err = name.ParseReference(GetReferenceFromDescriptor(v1ToOciSpecDescriptor(*desc), nameRef), nameOpts...)
+	// the mirrors of the image registry are tried first
+	return images.FromReferences(registryClient, nameRef, func(nameRef name.Reference) (*parsedReference, error) {
+		desc, err := gcrremote.Head(nameRef, remoteOpts...)
 		if err != nil {
 			return nil, err
 		}
-	}
 
-	repository := NewRepository(remoteOpts, nameRef)
-	err = resolveDigestCrane(repository, remoteOpts, nameRef)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to resolve digest")
-	}
+		if !isDigestReference(ref) {
+			nameRef = nameRef.Context().Digest(desc.Digest.String())
+		}
+
+		repository := NewRepository(remoteOpts, nameRef)
+		err = resolveDigestCrane(repository, remoteOpts, nameRef)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to resolve digest")
+		}
 
-	return &parsedReference{
-		Repo:       repository,
-		RemoteOpts: remoteOpts,
-		Ref:        nameRef,
-		Desc:       v1ToOciSpecDescriptor(*desc),
-	}, nil
+		return &parsedReference{
+			Repo:       repository,
+			RemoteOpts: remoteOpts,
+			Ref:        nameRef,
+			Desc:       v1ToOciSpecDescriptor(*desc),
+		}, nil
+	})
 }
 
 func isDigestReference(reference string) bool {
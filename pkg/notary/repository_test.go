@@ -2,11 +2,29 @@ package notary
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/notaryproject/notation-go"
 	notationregistry "github.com/notaryproject/notation-go/registry"
+	"github.com/notaryproject/notation-go/signer"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"gotest.tools/assert"
 )
@@ -81,3 +99,89 @@ func TestFetchSignatureBlob(t *testing.T) {
 		}
 	}
 }
+
+// signedImage is an image signed with Notation in a local registry
+type signedImage struct {
+	ref  name.Reference
+	desc v1.Descriptor
+	cert *x509.Certificate
+}
+
+// newSignedImage pushes a random image to a local registry and signs it with a self-signed
+// code signing certificate
+func newSignedImage(t *testing.T) signedImage {
+	t.Helper()
+	server := httptest.NewServer(registry.New(registry.WithReferrersSupport(true)))
+	t.Cleanup(server.Close)
+	ref, err := name.ParseReference(strings.TrimPrefix(server.URL, "http://") + "/kyverno/test-verify-image:signed")
+	assert.NilError(t, err)
+
+	img, err := random.Image(1024, 1)
+	assert.NilError(t, err)
+	assert.NilError(t, remote.Write(ref, img))
+	desc, err := remote.Head(ref)
+	assert.NilError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kyverno", Organization: []string{"Notary"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NilError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NilError(t, err)
+
+	notationSigner, err := signer.NewGenericSigner(key, []*x509.Certificate{cert})
+	assert.NilError(t, err)
+	envelope, _, err := notationSigner.Sign(context.TODO(), v1ToOciSpecDescriptor(*desc), notation.SignerSignOptions{
+		SignatureMediaType: "application/jose+json",
+	})
+	assert.NilError(t, err)
+
+	sig, err := mutate.AppendLayers(
+		mutate.MediaType(empty.Image, types.OCIManifestSchema1),
+		static.NewLayer(envelope, "application/jose+json"),
+	)
+	assert.NilError(t, err)
+	sig = mutate.ConfigMediaType(sig, notationregistry.ArtifactTypeNotation)
+	sig = mutate.Subject(sig, *desc).(v1.Image)
+	sigDigest, err := sig.Digest()
+	assert.NilError(t, err)
+	assert.NilError(t, remote.Write(ref.Context().Digest(sigDigest.String()), sig))
+
+	return signedImage{ref: ref, desc: *desc, cert: cert}
+}
+
+func TestRepositoryLocalRegistry(t *testing.T) {
+	image := newSignedImage(t)
+	repositoryClient := NewRepository(nil, image.ref.Context().Digest(image.desc.Digest.String()))
+
+	desc, err := repositoryClient.Resolve(ctx, image.desc.Digest.String())
+	assert.NilError(t, err)
+	assert.Equal(t, desc.Digest.String(), image.desc.Digest.String())
+	assert.Equal(t, desc.MediaType, string(image.desc.MediaType))
+
+	var signatures []ocispec.Descriptor
+	err = repositoryClient.ListSignatures(ctx, desc, func(descs []ocispec.Descriptor) error {
+		signatures = append(signatures, descs...)
+		return nil
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(signatures), 1)
+	assert.Equal(t, signatures[0].ArtifactType, notationregistry.ArtifactTypeNotation)
+
+	blob, blobDesc, err := repositoryClient.FetchSignatureBlob(ctx, signatures[0])
+	assert.NilError(t, err)
+	assert.Equal(t, blobDesc.MediaType, "application/jose+json")
+	assert.Equal(t, blobDesc.Size, int64(len(blob)))
+
+	_, _, err = repositoryClient.PushSignature(ctx, "application/jose+json", blob, desc, nil)
+	assert.ErrorContains(t, err, "not implemented")
+}
@@ -0,0 +1,228 @@
+package notary
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation-go/verifier/truststore"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+const (
+	// TrustPolicyFile is the key of the Notation trust policy document in the trust policy ConfigMap
+	TrustPolicyFile = "trustpolicy.json"
+	// AnyTrustPolicy selects the trust policy of the document by the registry scope of the image
+	AnyTrustPolicy = "*"
+)
+
+// TrustConfig is a Notation trust policy document and the trust stores it references
+type TrustConfig struct {
+	Document *trustpolicy.Document
+	Store    truststore.X509TrustStore
+}
+
+// ParseTrustConfig parses a trust policy document and its trust stores, every file other than the
+// trust policy document is a PEM encoded trust store named `<type>.<name>`, e.g. `ca.acme-rockets.pem`
+// (the `.pem`, `.crt` and `.cer` extensions are ignored)
+func ParseTrustConfig(files map[string][]byte) (*TrustConfig, error) {
+	data, ok := files[TrustPolicyFile]
+	if !ok {
+		return nil, fmt.Errorf("trust policy document %s not found", TrustPolicyFile)
+	}
+	var document trustpolicy.Document
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse trust policy document: %w", err)
+	}
+	if err := document.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid trust policy document: %w", err)
+	}
+	store := mapTrustStore{}
+	for file, data := range files {
+		if file == TrustPolicyFile {
+			continue
+		}
+		storeType, name, err := trustStoreName(file)
+		if err != nil {
+			return nil, err
+		}
+		certs, err := cryptoutils.LoadCertificatesFromPEM(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse trust store %s: %w", file, err)
+		}
+		if err := truststore.ValidateCertificates(certs); err != nil {
+			return nil, fmt.Errorf("invalid trust store %s: %w", file, err)
+		}
+		if store[storeType] == nil {
+			store[storeType] = map[string][]*x509.Certificate{}
+		}
+		store[storeType][name] = append(store[storeType][name], certs...)
+	}
+	return &TrustConfig{Document: &document, Store: store}, nil
+}
+
+// Select returns the trust policy document verifying the images of a rule, the registry scopes of the
+// named trust policy are kept so the images of the rule outside of them fail the verification
+func (c *TrustConfig) Select(name string) (*trustpolicy.Document, error) {
+	if name == AnyTrustPolicy {
+		return c.Document, nil
+	}
+	for _, policy := range c.Document.TrustPolicies {
+		if policy.Name == name {
+			return &trustpolicy.Document{
+				Version:       c.Document.Version,
+				TrustPolicies: []trustpolicy.TrustPolicy{policy},
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("trust policy %s not found in the trust policy document", name)
+}
+
+func trustStoreName(file string) (truststore.Type, string, error) {
+	for _, ext := range []string{".pem", ".crt", ".cer"} {
+		file = strings.TrimSuffix(file, ext)
+	}
+	storeType, name, ok := strings.Cut(file, ".")
+	if !ok || name == "" {
+		return "", "", fmt.Errorf("invalid trust store file name %s, expected <type>.<name>", file)
+	}
+	for _, t := range truststore.Types {
+		if string(t) == storeType {
+			return t, name, nil
+		}
+	}
+	return "", "", fmt.Errorf("invalid trust store file name %s, unsupported trust store type %s", file, storeType)
+}
+
+type mapTrustStore map[truststore.Type]map[string][]*x509.Certificate
+
+func (s mapTrustStore) GetCertificates(_ context.Context, storeType truststore.Type, name string) ([]*x509.Certificate, error) {
+	certs := s[storeType][name]
+	if len(certs) == 0 {
+		return nil, truststore.TrustStoreError{Msg: fmt.Sprintf("the trust store %q of type %q does not exist", name, storeType)}
+	}
+	return certs, nil
+}
+
+// TrustConfigSource provides the trust policy document and trust stores
+type TrustConfigSource interface {
+	Load(ctx context.Context) (*TrustConfig, error)
+}
+
+var (
+	trustConfigLock   sync.RWMutex
+	trustConfigSource TrustConfigSource
+)
+
+// SetTrustConfigSource configures the trust policy document referenced by the `trustPolicy` of
+// notary attestors
+func SetTrustConfigSource(s TrustConfigSource) {
+	trustConfigLock.Lock()
+	defer trustConfigLock.Unlock()
+	trustConfigSource = s
+}
+
+// LoadTrustConfig returns the configured trust policy document and trust stores
+func LoadTrustConfig(ctx context.Context) (*TrustConfig, error) {
+	trustConfigLock.RLock()
+	s := trustConfigSource
+	trustConfigLock.RUnlock()
+	if s == nil {
+		return nil, fmt.Errorf("no Notation trust policy document is configured")
+	}
+	config, err := s.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Notation trust policy document: %w", err)
+	}
+	return config, nil
+}
+
+type kubernetesTrustConfigSource struct {
+	configMaps    corev1listers.ConfigMapNamespaceLister
+	configMapName string
+	secrets       corev1listers.SecretNamespaceLister
+	secretName    string
+
+	lock    sync.Mutex
+	version string
+	config  *TrustConfig
+}
+
+// NewKubernetesTrustConfigSource returns a source reading the trust policy document and trust stores
+// from a ConfigMap, the trust stores can also be read from an optional Secret
+func NewKubernetesTrustConfigSource(
+	configMaps corev1listers.ConfigMapNamespaceLister,
+	configMapName string,
+	secrets corev1listers.SecretNamespaceLister,
+	secretName string,
+) TrustConfigSource {
+	return &kubernetesTrustConfigSource{
+		configMaps:    configMaps,
+		configMapName: configMapName,
+		secrets:       secrets,
+		secretName:    secretName,
+	}
+}
+
+func (s *kubernetesTrustConfigSource) Load(_ context.Context) (*TrustConfig, error) {
+	cm, err := s.configMaps.Get(s.configMapName)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string][]byte{}
+	for key, value := range cm.Data {
+		files[key] = []byte(value)
+	}
+	for key, value := range cm.BinaryData {
+		files[key] = value
+	}
+	version := cm.ResourceVersion
+	if s.secretName != "" {
+		secret, err := s.secrets.Get(s.secretName)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		if secret != nil {
+			version += "/" + secret.ResourceVersion
+			if err := addSecretFiles(files, secret); err != nil {
+				return nil, err
+			}
+		}
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	// the trust config is parsed again only when the config map or secret changes
+	if s.config != nil && s.version == version {
+		return s.config, nil
+	}
+	config, err := ParseTrustConfig(files)
+	if err != nil {
+		return nil, err
+	}
+	s.version, s.config = version, config
+	return config, nil
+}
+
+func addSecretFiles(files map[string][]byte, secret *corev1.Secret) error {
+	keys := make([]string, 0, len(secret.Data))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, ok := files[key]; ok {
+			return fmt.Errorf("trust store %s is defined in both the config map and the secret", key)
+		}
+		files[key] = secret.Data[key]
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package notary
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/images"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func testTrustPolicyDocument(t *testing.T, scopes ...string) []byte {
+	t.Helper()
+	if len(scopes) == 0 {
+		scopes = []string{"registry.acme-rockets.io/software/net-monitor"}
+	}
+	document := trustpolicy.Document{
+		Version: "1.0",
+		TrustPolicies: []trustpolicy.TrustPolicy{{
+			Name:                  "kyverno",
+			RegistryScopes:        scopes,
+			SignatureVerification: trustpolicy.SignatureVerification{VerificationLevel: trustpolicy.LevelStrict.Name},
+			TrustStores:           []string{"ca:kyverno"},
+			TrustedIdentities:     []string{"*"},
+		}, {
+			Name:                  "skip",
+			RegistryScopes:        []string{"*"},
+			SignatureVerification: trustpolicy.SignatureVerification{VerificationLevel: trustpolicy.LevelSkip.Name},
+		}},
+	}
+	data, err := json.Marshal(document)
+	assert.NilError(t, err)
+	return data
+}
+
+func encodeCertificate(cert *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+}
+
+type staticTrustConfigSource struct {
+	config *TrustConfig
+}
+
+func (s staticTrustConfigSource) Load(context.Context) (*TrustConfig, error) {
+	return s.config, nil
+}
+
+func TestParseTrustConfig(t *testing.T) {
+	image := newSignedImage(t)
+	config, err := ParseTrustConfig(map[string][]byte{
+		TrustPolicyFile:  testTrustPolicyDocument(t),
+		"ca.kyverno.pem": encodeCertificate(image.cert),
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(config.Document.TrustPolicies), 2)
+	certs, err := config.Store.GetCertificates(context.TODO(), "ca", "kyverno")
+	assert.NilError(t, err)
+	assert.Equal(t, len(certs), 1)
+	_, err = config.Store.GetCertificates(context.TODO(), "signingAuthority", "kyverno")
+	assert.ErrorContains(t, err, "does not exist")
+
+	_, err = ParseTrustConfig(map[string][]byte{"ca.kyverno.pem": encodeCertificate(image.cert)})
+	assert.ErrorContains(t, err, "trust policy document trustpolicy.json not found")
+	_, err = ParseTrustConfig(map[string][]byte{TrustPolicyFile: []byte(`{"version":"1.0"}`)})
+	assert.ErrorContains(t, err, "invalid trust policy document")
+	_, err = ParseTrustConfig(map[string][]byte{
+		TrustPolicyFile: testTrustPolicyDocument(t),
+		"kyverno.pem":   encodeCertificate(image.cert),
+	})
+	assert.ErrorContains(t, err, "expected <type>.<name>")
+	_, err = ParseTrustConfig(map[string][]byte{
+		TrustPolicyFile:   testTrustPolicyDocument(t),
+		"foo.kyverno.pem": encodeCertificate(image.cert),
+	})
+	assert.ErrorContains(t, err, "unsupported trust store type foo")
+}
+
+func TestTrustConfig_Select(t *testing.T) {
+	config, err := ParseTrustConfig(map[string][]byte{TrustPolicyFile: testTrustPolicyDocument(t)})
+	assert.NilError(t, err)
+
+	document, err := config.Select(AnyTrustPolicy)
+	assert.NilError(t, err)
+	assert.Equal(t, document, config.Document)
+
+	document, err = config.Select("kyverno")
+	assert.NilError(t, err)
+	assert.Equal(t, len(document.TrustPolicies), 1)
+	assert.Equal(t, document.TrustPolicies[0].Name, "kyverno")
+	// the registry scopes of the named trust policy still apply
+	assert.DeepEqual(t, document.TrustPolicies[0].RegistryScopes, []string{"registry.acme-rockets.io/software/net-monitor"})
+
+	_, err = config.Select("unknown")
+	assert.ErrorContains(t, err, "trust policy unknown not found")
+}
+
+func TestKubernetesTrustConfigSource(t *testing.T) {
+	image := newSignedImage(t)
+	cmIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	secretIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	source := NewKubernetesTrustConfigSource(
+		corev1listers.NewConfigMapLister(cmIndexer).ConfigMaps("kyverno"),
+		"notation",
+		corev1listers.NewSecretLister(secretIndexer).Secrets("kyverno"),
+		"notation",
+	)
+
+	_, err := source.Load(context.TODO())
+	assert.ErrorContains(t, err, "not found")
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "notation", Namespace: "kyverno", ResourceVersion: "1"},
+		Data:       map[string]string{TrustPolicyFile: string(testTrustPolicyDocument(t))},
+	}
+	assert.NilError(t, cmIndexer.Add(cm))
+	// the secret is optional
+	config, err := source.Load(context.TODO())
+	assert.NilError(t, err)
+	_, err = config.Store.GetCertificates(context.TODO(), "ca", "kyverno")
+	assert.ErrorContains(t, err, "does not exist")
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "notation", Namespace: "kyverno", ResourceVersion: "1"},
+		Data:       map[string][]byte{"ca.kyverno.pem": encodeCertificate(image.cert)},
+	}
+	assert.NilError(t, secretIndexer.Add(secret))
+	config, err = source.Load(context.TODO())
+	assert.NilError(t, err)
+	_, err = config.Store.GetCertificates(context.TODO(), "ca", "kyverno")
+	assert.NilError(t, err)
+	cached, err := source.Load(context.TODO())
+	assert.NilError(t, err)
+	assert.Equal(t, cached, config)
+
+	cm = cm.DeepCopy()
+	cm.ResourceVersion = "2"
+	cm.Data["ca.kyverno.pem"] = string(encodeCertificate(image.cert))
+	assert.NilError(t, cmIndexer.Update(cm))
+	_, err = source.Load(context.TODO())
+	assert.ErrorContains(t, err, "defined in both the config map and the secret")
+}
+
+func TestNotaryVerifierTrustPolicy(t *testing.T) {
+	image := newSignedImage(t)
+	rc, err := registryclient.New(registryclient.WithAllowInsecureRegistry())
+	assert.NilError(t, err)
+	opts := images.Options{
+		ImageRef:    image.ref.String(),
+		TrustPolicy: "kyverno",
+		Client:      rc,
+	}
+
+	SetTrustConfigSource(nil)
+	_, err = NewVerifier().VerifySignature(context.TODO(), opts)
+	assert.ErrorContains(t, err, "no Notation trust policy document is configured")
+
+	config, err := ParseTrustConfig(map[string][]byte{
+		TrustPolicyFile:  testTrustPolicyDocument(t, image.ref.Context().Name()),
+		"ca.kyverno.pem": encodeCertificate(image.cert),
+	})
+	assert.NilError(t, err)
+	SetTrustConfigSource(staticTrustConfigSource{config: config})
+	t.Cleanup(func() { SetTrustConfigSource(nil) })
+
+	resp, err := NewVerifier().VerifySignature(context.TODO(), opts)
+	assert.NilError(t, err)
+	assert.Equal(t, resp.Digest, image.desc.Digest.String())
+
+	// the image is out of the registry scopes of the trust policy
+	outOfScope, err := ParseTrustConfig(map[string][]byte{
+		TrustPolicyFile:  testTrustPolicyDocument(t),
+		"ca.kyverno.pem": encodeCertificate(image.cert),
+	})
+	assert.NilError(t, err)
+	SetTrustConfigSource(staticTrustConfigSource{config: outOfScope})
+	_, err = NewVerifier().VerifySignature(context.TODO(), opts)
+	assert.Assert(t, err != nil)
+	SetTrustConfigSource(staticTrustConfigSource{config: config})
+
+	// a trust policy skipping the verification doesn't verify the image
+	opts.TrustPolicy = "skip"
+	_, err = NewVerifier().VerifySignature(context.TODO(), opts)
+	assert.ErrorContains(t, err, "signature verification skipped by the trust policy")
+
+	// another signing identity is not trusted
+	other := newSignedImage(t)
+	opts.TrustPolicy = "kyverno"
+	opts.ImageRef = other.ref.String()
+	_, err = NewVerifier().VerifySignature(context.TODO(), opts)
+	assert.Assert(t, err != nil)
+}
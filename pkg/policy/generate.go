@@ -3,7 +3,9 @@ package policy
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/api/kyverno"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
@@ -12,12 +14,15 @@ import (
 	generateutils "github.com/kyverno/kyverno/pkg/background/generate"
 	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	entryevent "github.com/kyverno/kyverno/pkg/globalcontext/event"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
 	"go.uber.org/multierr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
 )
 
 func (pc *policyController) handleGenerate(policyKey string, policy kyvernov1.PolicyInterface) error {
@@ -81,6 +86,63 @@ func (pc *policyController) syncDataPolicyChanges(policy kyvernov1.PolicyInterfa
 	return multierr.Combine(errs...)
 }
 
+// enqueueGlobalContextChange enqueues the policies rendering synchronized resources from the data of the changed
+// global context entry, only their data downstream resources are then updated
+func (pc *policyController) enqueueGlobalContextChange(change entryevent.DataChange) {
+	logger := pc.log.WithName("enqueueGlobalContextChange").WithValues("entry", change.Name)
+	pc.enqueueSourcePolicies(logger, func(policy kyvernov1.PolicyInterface) bool {
+		return generatesFromGlobalContextEntry(policy, change.Name)
+	})
+}
+
+// resyncAPICallSources periodically enqueues the policies rendering synchronized resources from an API call,
+// API calls don't notify changes so their data downstream resources are resynchronized at every interval
+func (pc *policyController) resyncAPICallSources(ctx context.Context) {
+	logger := pc.log.WithName("resyncAPICallSources")
+	ticker := time.NewTicker(pc.sourceResyncPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			logger.V(3).Info("resynchronizing resources generated from API calls", "interval", pc.sourceResyncPeriod.String())
+			pc.enqueueSourcePolicies(logger, generatesFromAPICall)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// enqueueSourcePolicies enqueues the data synchronization of the policies matching the given function
+func (pc *policyController) enqueueSourcePolicies(logger logr.Logger, match func(kyvernov1.PolicyInterface) bool) {
+	var policies []kyvernov1.PolicyInterface
+	if cpols, err := pc.pLister.List(labels.Everything()); err == nil {
+		for _, cpol := range cpols {
+			policies = append(policies, cpol)
+		}
+	} else {
+		logger.Error(err, "unable to list ClusterPolicies")
+	}
+	if pols, err := pc.npLister.Policies(metav1.NamespaceAll).List(labels.Everything()); err == nil {
+		for _, pol := range pols {
+			policies = append(policies, pol)
+		}
+	} else {
+		logger.Error(err, "unable to list Policies")
+	}
+	for _, policy := range policies {
+		if !match(policy) || !pc.canBackgroundProcess(policy) {
+			continue
+		}
+		key, err := cache.MetaNamespaceKeyFunc(policy)
+		if err != nil {
+			logger.Error(err, "failed to enqueue policy")
+			continue
+		}
+		logger.V(4).Info("generate source data may have changed, queuing policy", "policy", policy.GetName())
+		pc.queue.Add("gctx/" + key)
+	}
+}
+
 func (pc *policyController) handleGenerateForExisting(policy kyvernov1.PolicyInterface) error {
 	var errors []error
 	var triggers []*unstructured.Unstructured
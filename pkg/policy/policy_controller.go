@@ -26,6 +26,7 @@ import (
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
+	entryevent "github.com/kyverno/kyverno/pkg/globalcontext/event"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
@@ -106,6 +107,12 @@ type policyController struct {
 
 	watchManager *gpol.WatchManager
 
+	// gctxChanges publishes the data changes of the global context entries
+	gctxChanges entryevent.Broadcaster
+
+	// sourceResyncPeriod is the interval between two resyncs of the resources generated from API calls
+	sourceResyncPeriod time.Duration
+
 	// mapper
 	restMapper meta.RESTMapper
 }
@@ -128,6 +135,8 @@ func NewPolicyController(
 	jp jmespath.Interface,
 	urGenerator generator.UpdateRequestGenerator,
 	watchManager *gpol.WatchManager,
+	gctxChanges entryevent.Broadcaster,
+	sourceResyncPeriod time.Duration,
 ) (*policyController, error) {
 	// Event broad caster
 	eventInterface := client.GetEventsInterface()
@@ -160,6 +169,9 @@ func NewPolicyController(
 		jp:              jp,
 		urGenerator:     urGenerator,
 		watchManager:    watchManager,
+		gctxChanges:     gctxChanges,
+
+		sourceResyncPeriod: sourceResyncPeriod,
 	}
 	apiGroupResources, _ := restmapper.GetAPIGroupResources(client.GetKubeClient().Discovery())
 	pc.restMapper = restmapper.NewDiscoveryRESTMapper(apiGroupResources)
@@ -350,12 +362,20 @@ func (pc *policyController) Run(ctx context.Context, workers int) {
 		return
 	}
 
+	if pc.gctxChanges != nil {
+		defer pc.gctxChanges.Subscribe(pc.enqueueGlobalContextChange)()
+	}
+
 	for i := 0; i < workers; i++ {
 		go wait.UntilWithContext(ctx, pc.worker, time.Second)
 	}
 
 	go pc.forceReconciliation(ctx)
 
+	if pc.sourceResyncPeriod > 0 {
+		go pc.resyncAPICallSources(ctx)
+	}
+
 	<-ctx.Done()
 }
 
@@ -427,6 +447,19 @@ func (pc *policyController) syncPolicy(key string) error {
 				errs = append(errs, err)
 			}
 		}
+	case "gctx":
+		// the data of a global context entry changed, only the data downstream resources depend on it
+		policy, err := pc.getPolicy(polName)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if err := pc.syncDataPolicyChanges(policy, false); err != nil {
+			logger.Error(err, "failed to create UR on global context entry change")
+			errs = append(errs, err)
+		}
 	case "gpol":
 		gpol, err := pc.gpolLister.Get(polName)
 		if err != nil {
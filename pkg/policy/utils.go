@@ -52,6 +52,39 @@ func contains(slice []string, item string) bool {
 	return false
 }
 
+// generatesFromGlobalContextEntry returns true if a synchronized generate rule of the policy renders its data
+// from the global context entry with the given name
+func generatesFromGlobalContextEntry(policy kyvernov1.PolicyInterface, name string) bool {
+	return generatesFromSource(policy, func(source *kyvernov1.GenerateSource) bool {
+		return source.GlobalReference != nil && source.GlobalReference.Name == name
+	})
+}
+
+// generatesFromAPICall returns true if a synchronized generate rule of the policy renders its data from an API call
+func generatesFromAPICall(policy kyvernov1.PolicyInterface) bool {
+	return generatesFromSource(policy, func(source *kyvernov1.GenerateSource) bool {
+		return source.APICall != nil
+	})
+}
+
+func generatesFromSource(policy kyvernov1.PolicyInterface, match func(*kyvernov1.GenerateSource) bool) bool {
+	for _, rule := range policy.GetSpec().Rules {
+		if !rule.HasGenerate() || !rule.Generation.Synchronize {
+			continue
+		}
+		patterns := []kyvernov1.GeneratePattern{rule.Generation.GeneratePattern}
+		for _, foreach := range rule.Generation.ForEachGeneration {
+			patterns = append(patterns, foreach.GeneratePattern)
+		}
+		for _, pattern := range patterns {
+			if pattern.Source != nil && match(pattern.Source) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func castPolicy(p interface{}) engineapi.GenericPolicy {
 	var policy engineapi.GenericPolicy
 	switch obj := p.(type) {
@@ -342,3 +342,106 @@ func Test_resourceMatchesWithWildcards(t *testing.T) {
 		})
 	}
 }
+
+func Test_generatesFromGlobalContextEntry(t *testing.T) {
+	source := &kyverno.GenerateSource{
+		GlobalReference: &kyverno.GlobalContextEntryReference{Name: "allowed-cidrs"},
+	}
+	policy := func(synchronize bool, generation kyverno.Generation) *kyverno.ClusterPolicy {
+		generation.Synchronize = synchronize
+		return &kyverno.ClusterPolicy{
+			Spec: kyverno.Spec{
+				Rules: []kyverno.Rule{{Name: "generate", Generation: &generation}},
+			},
+		}
+	}
+	tests := []struct {
+		name   string
+		policy *kyverno.ClusterPolicy
+		entry  string
+		want   bool
+	}{{
+		name:   "synchronized rule with source",
+		policy: policy(true, kyverno.Generation{GeneratePattern: kyverno.GeneratePattern{Source: source}}),
+		entry:  "allowed-cidrs",
+		want:   true,
+	}, {
+		name:   "other entry",
+		policy: policy(true, kyverno.Generation{GeneratePattern: kyverno.GeneratePattern{Source: source}}),
+		entry:  "deployments",
+		want:   false,
+	}, {
+		name:   "rule not synchronized",
+		policy: policy(false, kyverno.Generation{GeneratePattern: kyverno.GeneratePattern{Source: source}}),
+		entry:  "allowed-cidrs",
+		want:   false,
+	}, {
+		name: "foreach with source",
+		policy: policy(true, kyverno.Generation{ForEachGeneration: []kyverno.ForEachGeneration{
+			{List: "request.object.spec.namespaces"},
+			{List: "request.object.spec.namespaces", GeneratePattern: kyverno.GeneratePattern{Source: source}},
+		}}),
+		entry: "allowed-cidrs",
+		want:  true,
+	}, {
+		name: "api call source",
+		policy: policy(true, kyverno.Generation{GeneratePattern: kyverno.GeneratePattern{Source: &kyverno.GenerateSource{
+			APICall: &kyverno.ContextAPICall{APICall: kyverno.APICall{URLPath: "/api/v1/namespaces"}},
+		}}}),
+		entry: "allowed-cidrs",
+		want:  false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := generatesFromGlobalContextEntry(tt.policy, tt.entry); got != tt.want {
+				t.Errorf("generatesFromGlobalContextEntry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_generatesFromAPICall(t *testing.T) {
+	policy := func(synchronize bool, source *kyverno.GenerateSource) *kyverno.ClusterPolicy {
+		return &kyverno.ClusterPolicy{
+			Spec: kyverno.Spec{
+				Rules: []kyverno.Rule{{Name: "generate", Generation: &kyverno.Generation{
+					Synchronize: synchronize,
+					ForEachGeneration: []kyverno.ForEachGeneration{{
+						List:            "request.object.spec.namespaces",
+						GeneratePattern: kyverno.GeneratePattern{Source: source},
+					}},
+				}}},
+			},
+		}
+	}
+	apiCall := &kyverno.GenerateSource{APICall: &kyverno.ContextAPICall{APICall: kyverno.APICall{URLPath: "/api/v1/namespaces"}}}
+	globalReference := &kyverno.GenerateSource{GlobalReference: &kyverno.GlobalContextEntryReference{Name: "allowed-cidrs"}}
+	tests := []struct {
+		name   string
+		policy *kyverno.ClusterPolicy
+		want   bool
+	}{{
+		name:   "synchronized rule with api call source",
+		policy: policy(true, apiCall),
+		want:   true,
+	}, {
+		name:   "rule not synchronized",
+		policy: policy(false, apiCall),
+		want:   false,
+	}, {
+		name:   "global context entry source",
+		policy: policy(true, globalReference),
+		want:   false,
+	}, {
+		name:   "no source",
+		policy: policy(true, nil),
+		want:   false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := generatesFromAPICall(tt.policy); got != tt.want {
+				t.Errorf("generatesFromAPICall() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -60,7 +60,7 @@ func (c *cache) GetPolicies(pkey PolicyType, gvr schema.GroupVersionResource, su
 			result = append(result, c.store.get(ValidateEnforce, gvr, subresource, namespace.Name)...)
 		}
 	}
-	if pkey == ValidateAudit || pkey == ValidateEnforce {
+	if pkey == ValidateAudit || pkey == ValidateEnforce || pkey == ValidateShadow {
 		result = filterPolicies(pkey, result, namespace)
 	}
 	return result
@@ -74,9 +74,11 @@ func filterPolicies(pkey PolicyType, result []kyvernov1.PolicyInterface, namespa
 		keepPolicy := true
 		switch pkey {
 		case ValidateAudit:
-			keepPolicy, filteredPolicy = checkValidationFailureActionOverrides(false, namespace, policy)
+			keepPolicy, filteredPolicy = checkValidationFailureActionOverrides(kyvernov1.ValidationFailureAction.Audit, namespace, policy)
 		case ValidateEnforce:
-			keepPolicy, filteredPolicy = checkValidationFailureActionOverrides(true, namespace, policy)
+			keepPolicy, filteredPolicy = checkValidationFailureActionOverrides(kyvernov1.ValidationFailureAction.Enforce, namespace, policy)
+		case ValidateShadow:
+			keepPolicy, filteredPolicy = checkValidationFailureActionOverrides(kyvernov1.ValidationFailureAction.Shadow, namespace, policy)
 		}
 		// add policy to result
 		if keepPolicy {
@@ -86,7 +88,7 @@ func filterPolicies(pkey PolicyType, result []kyvernov1.PolicyInterface, namespa
 	return policies
 }
 
-func checkValidationFailureActionOverrides(enforce bool, namespace *corev1.Namespace, policy kyvernov1.PolicyInterface) (bool, kyvernov1.PolicyInterface) {
+func checkValidationFailureActionOverrides(mode func(kyvernov1.ValidationFailureAction) bool, namespace *corev1.Namespace, policy kyvernov1.PolicyInterface) (bool, kyvernov1.PolicyInterface) {
 	filteredRules := make([]kyvernov1.Rule, 0, len(policy.GetSpec().Rules))
 
 	// Use pointer to avoid copying the rule in each iteration
@@ -115,14 +117,14 @@ func checkValidationFailureActionOverrides(enforce bool, namespace *corev1.Names
 			// Global override: both Namespaces and NamespaceSelector are empty/nil - applies to all namespaces
 			if len(action.Namespaces) == 0 && action.NamespaceSelector == nil {
 				overrideMatched = true
-				if action.Action.Enforce() == enforce {
+				if mode(action.Action) {
 					filteredRules = append(filteredRules, *rule)
 				}
 				break // Stop once we find a matching override
 			}
 			if namespace != nil && wildcard.CheckPatterns(action.Namespaces, namespace.Name) {
 				overrideMatched = true
-				if action.Action.Enforce() == enforce {
+				if mode(action.Action) {
 					filteredRules = append(filteredRules, *rule)
 				}
 				break // Stop once we find a matching override
@@ -135,7 +137,7 @@ func checkValidationFailureActionOverrides(enforce bool, namespace *corev1.Names
 				}
 				if selector.Matches(labels.Set(namespace.Labels)) {
 					overrideMatched = true
-					if action.Action.Enforce() == enforce {
+					if mode(action.Action) {
 						filteredRules = append(filteredRules, *rule)
 					}
 					break // Stop once we find a matching override
@@ -144,7 +146,7 @@ func checkValidationFailureActionOverrides(enforce bool, namespace *corev1.Names
 		}
 
 		// If no override matched for the namespace, apply the default validation failure action
-		if !overrideMatched && validationFailureAction.Enforce() == enforce {
+		if !overrideMatched && mode(*validationFailureAction) {
 			filteredRules = append(filteredRules, *rule)
 		}
 	}
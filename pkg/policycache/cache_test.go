@@ -1540,3 +1540,73 @@ func Test_NamespacedPolicy_MixedEnforceAudit_Rules(t *testing.T) {
 		require.Equal(t, 0, len(auditPolicies), "should not find policy in different namespace")
 	})
 }
+
+func Test_ClusterPolicy_MixedEnforceShadow_Rules(t *testing.T) {
+	cache := NewCache()
+
+	rawPolicy := []byte(`{
+		"metadata": {
+		  "name": "shadow-policy"
+		},
+		"spec": {
+		  "rules": [
+			{
+			  "name": "enforce-no-root",
+			  "match": {
+				"resources": {
+				  "kinds": ["Pod"]
+				}
+			  },
+			  "validate": {
+				"failureAction": "Enforce",
+				"deny": {}
+			  }
+			},
+			{
+			  "name": "shadow-missing-labels",
+			  "match": {
+				"resources": {
+				  "kinds": ["Pod"]
+				}
+			  },
+			  "validate": {
+				"failureAction": "Shadow",
+				"pattern": {
+				  "metadata": {
+					"labels": {
+					  "team": "?*"
+					}
+				  }
+				}
+			  }
+			}
+		  ]
+		}
+	  }`)
+
+	var policy *kyvernov1.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	require.NoError(t, err)
+
+	finder := TestResourceFinder{}
+	key, _ := kubecache.MetaNamespaceKeyFunc(policy)
+	err = cache.Set(key, policy, finder)
+	require.NoError(t, err)
+
+	enforcePolicies := cache.GetPolicies(ValidateEnforce, podsGVRS.GroupVersionResource(), "", nil)
+	require.Equal(t, 1, len(enforcePolicies))
+	require.Equal(t, 1, len(enforcePolicies[0].GetSpec().Rules))
+	require.Equal(t, "enforce-no-root", enforcePolicies[0].GetSpec().Rules[0].Name)
+
+	shadowPolicies := cache.GetPolicies(ValidateShadow, podsGVRS.GroupVersionResource(), "", nil)
+	require.Equal(t, 1, len(shadowPolicies))
+	require.Equal(t, 1, len(shadowPolicies[0].GetSpec().Rules))
+	require.Equal(t, "shadow-missing-labels", shadowPolicies[0].GetSpec().Rules[0].Name)
+
+	auditPolicies := cache.GetPolicies(ValidateAudit, podsGVRS.GroupVersionResource(), "", nil)
+	require.Equal(t, 0, len(auditPolicies), "shadow rules must not be evaluated as audit rules")
+
+	cache.Unset(key)
+	shadowPolicies = cache.GetPolicies(ValidateShadow, podsGVRS.GroupVersionResource(), "", nil)
+	require.Equal(t, 0, len(shadowPolicies))
+}
@@ -91,6 +91,18 @@ func computeEnforcePolicy(spec *kyvernov1.Spec) bool {
 	return false
 }
 
+func computeShadowPolicy(spec *kyvernov1.Spec) bool {
+	if spec.ValidationFailureAction.Shadow() {
+		return true
+	}
+	for _, k := range spec.ValidationFailureActionOverrides {
+		if k.Action.Shadow() {
+			return true
+		}
+	}
+	return false
+}
+
 func set(set sets.Set[string], item string, value bool) sets.Set[string] {
 	if value {
 		return set.Insert(item)
@@ -102,6 +114,7 @@ func set(set sets.Set[string], item string, value bool) sets.Set[string] {
 func (m *policyMap) set(key string, policy kyvernov1.PolicyInterface, client ResourceFinder) error {
 	var errs []error
 	enforcePolicy := computeEnforcePolicy(policy.GetSpec())
+	shadowPolicy := computeShadowPolicy(policy.GetSpec())
 	auditWarning := false
 	if policy.GetSpec().EmitWarning != nil && *policy.GetSpec().EmitWarning {
 		auditWarning = true
@@ -117,10 +130,16 @@ func (m *policyMap) set(key string, policy kyvernov1.PolicyInterface, client Res
 			if action != nil && action.Enforce() {
 				enforcePolicy = true
 			}
+			if action != nil && action.Shadow() {
+				shadowPolicy = true
+			}
 			for _, k := range rule.Validation.FailureActionOverrides {
 				if k.Action.Enforce() {
 					enforcePolicy = true
 				}
+				if k.Action.Shadow() {
+					shadowPolicy = true
+				}
 			}
 		}
 		entries := sets.New[policyKey]()
@@ -174,6 +193,7 @@ func (m *policyMap) set(key string, policy kyvernov1.PolicyInterface, client Res
 				ValidateEnforce:      sets.New[string](),
 				ValidateAudit:        sets.New[string](),
 				ValidateAuditWarn:    sets.New[string](),
+				ValidateShadow:       sets.New[string](),
 				Generate:             sets.New[string](),
 				VerifyImagesMutate:   sets.New[string](),
 				VerifyImagesValidate: sets.New[string](),
@@ -183,6 +203,7 @@ func (m *policyMap) set(key string, policy kyvernov1.PolicyInterface, client Res
 		m.kindType[gvrs][ValidateEnforce] = set(m.kindType[gvrs][ValidateEnforce], key, state.hasValidate && enforcePolicy)
 		m.kindType[gvrs][ValidateAudit] = set(m.kindType[gvrs][ValidateAudit], key, state.hasValidate && !enforcePolicy)
 		m.kindType[gvrs][ValidateAuditWarn] = set(m.kindType[gvrs][ValidateAuditWarn], key, state.hasValidate && !enforcePolicy && auditWarning)
+		m.kindType[gvrs][ValidateShadow] = set(m.kindType[gvrs][ValidateShadow], key, state.hasValidate && shadowPolicy)
 		m.kindType[gvrs][Generate] = set(m.kindType[gvrs][Generate], key, state.hasGenerate)
 		m.kindType[gvrs][VerifyImagesMutate] = set(m.kindType[gvrs][VerifyImagesMutate], key, state.hasVerifyImages)
 		m.kindType[gvrs][VerifyImagesValidate] = set(m.kindType[gvrs][VerifyImagesValidate], key, state.hasVerifyImages && state.hasImagesValidationChecks)
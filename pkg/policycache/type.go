@@ -9,6 +9,7 @@ const (
 	ValidateEnforce
 	ValidateAudit
 	ValidateAuditWarn
+	ValidateShadow
 	Generate
 	VerifyImagesMutate
 	VerifyImagesValidate
@@ -3,6 +3,7 @@ package registryclient
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"net/http"
 	"runtime"
@@ -40,12 +41,17 @@ type Client interface {
 
 	// NameOptions returns name.Option configuration for the client.
 	NameOptions() []name.Option
+
+	// References returns the references an image is fetched from, the configured mirrors
+	// of its registry in order followed by the image itself.
+	References(name.Reference) []name.Reference
 }
 
 type client struct {
 	keychain              authn.Keychain
 	transport             http.RoundTripper
 	allowInsecureRegistry bool
+	mirrors               map[string]Mirror
 }
 
 type config struct {
@@ -53,6 +59,8 @@ type config struct {
 	transport             *http.Transport
 	tracing               bool
 	allowInsecureRegistry bool
+	mirrors               map[string]Mirror
+	mirrorKeychains       map[string]authn.Keychain
 }
 
 // Option is an option to initialize registry client.
@@ -71,10 +79,14 @@ func New(options ...Option) (Client, error) {
 	c := &client{
 		keychain:  defaultKeychain,
 		transport: cfg.transport,
+		mirrors:   cfg.mirrors,
 	}
 	if len(cfg.keychain) > 0 {
 		c.keychain = authn.NewMultiKeychain(cfg.keychain...)
 	}
+	if len(cfg.mirrorKeychains) > 0 {
+		c.keychain = &mirrorKeychain{endpoints: cfg.mirrorKeychains, fallback: c.keychain}
+	}
 	if cfg.tracing {
 		c.transport = tracing.Transport(cfg.transport, otelhttp.WithFilter(tracing.RequestFilterIsInSpan))
 	}
@@ -176,6 +188,7 @@ func (c *client) NameOptions() []name.Option {
 
 // FetchImageDescriptor fetches Descriptor from registry with given imageRef
 // and provides access to metadata about remote artifact.
+// The mirrors of the image registry are tried first, in order.
 func (c *client) FetchImageDescriptor(ctx context.Context, imageRef string) (*gcrremote.Descriptor, error) {
 	nameOpts := c.NameOptions()
 	parsedRef, err := name.ParseReference(imageRef, nameOpts...)
@@ -186,14 +199,20 @@ func (c *client) FetchImageDescriptor(ctx context.Context, imageRef string) (*gc
 	if err != nil {
 		return nil, fmt.Errorf("failed to get gcr remote opts: %s, error: %w", imageRef, err)
 	}
-	desc, err := gcrremote.Get(parsedRef, remoteOpts...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch image reference: %s, error: %w", imageRef, err)
-	}
-	if _, ok := parsedRef.(name.Digest); ok && parsedRef.Identifier() != desc.Digest.String() {
-		return nil, fmt.Errorf("digest mismatch, expected: %s, received: %s", parsedRef.Identifier(), desc.Digest.String())
+	var errs []error
+	for _, ref := range c.References(parsedRef) {
+		desc, err := gcrremote.Get(ref, remoteOpts...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to fetch image reference: %s, error: %w", ref, err))
+			continue
+		}
+		if _, ok := parsedRef.(name.Digest); ok && parsedRef.Identifier() != desc.Digest.String() {
+			errs = append(errs, fmt.Errorf("digest mismatch, expected: %s, received: %s", parsedRef.Identifier(), desc.Digest.String()))
+			continue
+		}
+		return desc, nil
 	}
-	return desc, nil
+	return nil, errors.Join(errs...)
 }
 
 func (c *client) Keychain() authn.Keychain {
@@ -0,0 +1,155 @@
+package registryclient
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Mirror lists the endpoints serving the content of a registry, similar to a containerd hosts.toml file.
+type Mirror struct {
+	// Registry is the registry the mirror applies to, e.g. `docker.io` or `ghcr.io`.
+	Registry string `json:"registry"`
+	// Endpoints are tried in order before the registry itself.
+	Endpoints []MirrorEndpoint `json:"endpoints"`
+	// SkipRegistry disables the fallback to the registry when all the endpoints failed.
+	SkipRegistry bool `json:"skipRegistry,omitempty"`
+}
+
+// MirrorEndpoint is a registry mirror or pull-through proxy.
+type MirrorEndpoint struct {
+	// Host is the host of the endpoint, with an optional port.
+	Host string `json:"host"`
+	// Path is prepended to the image repositories, e.g. the project of a pull-through proxy.
+	Path string `json:"path,omitempty"`
+	// Insecure allows plain http connections to the endpoint.
+	Insecure bool `json:"insecure,omitempty"`
+	// ImagePullSecret is the secret holding the endpoint credentials, it supports the namespace/name notation.
+	ImagePullSecret string `json:"imagePullSecret,omitempty"`
+}
+
+// ParseMirrors parses a YAML or JSON list of mirrors.
+func ParseMirrors(data string) ([]Mirror, error) {
+	var mirrors []Mirror
+	if err := yaml.UnmarshalStrict([]byte(data), &mirrors); err != nil {
+		return nil, fmt.Errorf("failed to parse registry mirrors: %w", err)
+	}
+	for _, mirror := range mirrors {
+		if _, err := name.NewRegistry(mirror.Registry); err != nil || mirror.Registry == "" {
+			return nil, fmt.Errorf("invalid mirror registry %q", mirror.Registry)
+		}
+		if len(mirror.Endpoints) == 0 {
+			return nil, fmt.Errorf("mirror of registry %s has no endpoint", mirror.Registry)
+		}
+		for _, endpoint := range mirror.Endpoints {
+			if _, err := name.NewRegistry(endpoint.Host); err != nil || endpoint.Host == "" {
+				return nil, fmt.Errorf("invalid endpoint host %q for registry %s", endpoint.Host, mirror.Registry)
+			}
+			if endpoint.Path != "" {
+				if _, err := name.NewRepository(endpoint.Host + "/" + strings.Trim(endpoint.Path, "/")); err != nil {
+					return nil, fmt.Errorf("invalid endpoint path %q for registry %s: %w", endpoint.Path, mirror.Registry, err)
+				}
+			}
+		}
+	}
+	return mirrors, nil
+}
+
+// WithMirrors provides initialize registry client option that fetches images through registry mirrors,
+// the lister is used to fetch the endpoints pull secrets.
+func WithMirrors(lister corev1listers.SecretLister, defaultNamespace string, mirrors ...Mirror) Option {
+	return func(c *config) error {
+		for _, mirror := range mirrors {
+			registry, err := name.NewRegistry(mirror.Registry)
+			if err != nil {
+				return err
+			}
+			if c.mirrors == nil {
+				c.mirrors = map[string]Mirror{}
+			}
+			// docker.io and index.docker.io are the same registry
+			c.mirrors[registry.RegistryStr()] = mirror
+			for _, endpoint := range mirror.Endpoints {
+				if endpoint.ImagePullSecret == "" {
+					continue
+				}
+				if lister == nil {
+					return fmt.Errorf("a secret lister is required for the pull secret of endpoint %s", endpoint.Host)
+				}
+				kc, err := NewAutoRefreshSecretsKeychain(lister, defaultNamespace, endpoint.ImagePullSecret)
+				if err != nil {
+					return err
+				}
+				if c.mirrorKeychains == nil {
+					c.mirrorKeychains = map[string]authn.Keychain{}
+				}
+				c.mirrorKeychains[endpoint.Host] = kc
+			}
+		}
+		return nil
+	}
+}
+
+// mirrorKeychain resolves the credentials of the mirror endpoints with their own pull secrets,
+// other registries are resolved with the client keychain.
+type mirrorKeychain struct {
+	endpoints map[string]authn.Keychain
+	fallback  authn.Keychain
+}
+
+func (kc *mirrorKeychain) Resolve(resource authn.Resource) (authn.Authenticator, error) {
+	if endpoint, ok := kc.endpoints[resource.RegistryStr()]; ok {
+		auth, err := endpoint.Resolve(resource)
+		if err != nil || auth != authn.Anonymous {
+			return auth, err
+		}
+	}
+	return kc.fallback.Resolve(resource)
+}
+
+// References returns the references an image is fetched from, the endpoints of the mirror of its
+// registry in order followed by the image itself unless the fallback to the registry is skipped.
+func (c *client) References(ref name.Reference) []name.Reference {
+	mirror, ok := c.mirrors[ref.Context().RegistryStr()]
+	if !ok {
+		return []name.Reference{ref}
+	}
+	refs := make([]name.Reference, 0, len(mirror.Endpoints)+1)
+	for _, endpoint := range mirror.Endpoints {
+		mirrored, err := mirrorReference(ref, endpoint, c.NameOptions()...)
+		if err != nil {
+			// endpoints are validated when parsed, this only happens with invalid repositories
+			continue
+		}
+		refs = append(refs, mirrored)
+	}
+	if !mirror.SkipRegistry || len(refs) == 0 {
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// mirrorReference returns the reference of an image on a mirror endpoint.
+func mirrorReference(ref name.Reference, endpoint MirrorEndpoint, opts ...name.Option) (name.Reference, error) {
+	if endpoint.Insecure {
+		opts = append(opts, name.Insecure)
+	}
+	repository := path.Join(strings.Trim(endpoint.Path, "/"), ref.Context().RepositoryStr())
+	repo, err := name.NewRepository(endpoint.Host+"/"+repository, opts...)
+	if err != nil {
+		return nil, err
+	}
+	switch ref := ref.(type) {
+	case name.Digest:
+		return repo.Digest(ref.DigestStr()), nil
+	case name.Tag:
+		return repo.Tag(ref.TagStr()), nil
+	default:
+		return nil, fmt.Errorf("unsupported reference %s", ref)
+	}
+}
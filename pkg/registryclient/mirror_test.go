@@ -0,0 +1,158 @@
+package registryclient
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	gcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestParseMirrors(t *testing.T) {
+	mirrors, err := ParseMirrors(`
+- registry: docker.io
+  endpoints:
+  - host: mirror.example.com:5000
+    path: dockerhub
+    imagePullSecret: kyverno/mirror
+  skipRegistry: true
+`)
+	assert.NoError(t, err)
+	assert.Equal(t, []Mirror{{
+		Registry:     "docker.io",
+		Endpoints:    []MirrorEndpoint{{Host: "mirror.example.com:5000", Path: "dockerhub", ImagePullSecret: "kyverno/mirror"}},
+		SkipRegistry: true,
+	}}, mirrors)
+
+	for _, invalid := range []string{
+		`- registry: docker.io`,
+		`- endpoints: [{host: mirror.example.com}]`,
+		`- {registry: docker.io, endpoints: [{host: "mirror example"}]}`,
+		`- {registry: docker.io, endpoints: [{host: mirror.example.com, path: "UPPER"}]}`,
+		`- {registry: docker.io, endpoints: [{host: mirror.example.com}], unknown: true}`,
+	} {
+		_, err := ParseMirrors(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestReferences(t *testing.T) {
+	c, err := New(WithMirrors(nil, "kyverno",
+		Mirror{
+			Registry: "docker.io",
+			Endpoints: []MirrorEndpoint{
+				{Host: "mirror.example.com", Path: "/dockerhub/"},
+				{Host: "proxy.example.com"},
+			},
+		},
+		Mirror{
+			Registry:     "ghcr.io",
+			Endpoints:    []MirrorEndpoint{{Host: "mirror.example.com", Path: "ghcr"}},
+			SkipRegistry: true,
+		},
+	))
+	assert.NoError(t, err)
+	tests := []struct {
+		ref  string
+		want []string
+	}{{
+		ref:  "nginx:1.25",
+		want: []string{"mirror.example.com/dockerhub/library/nginx:1.25", "proxy.example.com/library/nginx:1.25", "index.docker.io/library/nginx:1.25"},
+	}, {
+		ref:  "ghcr.io/kyverno/kyverno@sha256:b31bfb4d0213f254d361e0079deaaebefa4f82ba7aa76ef82e90b4935ad5b105",
+		want: []string{"mirror.example.com/ghcr/kyverno/kyverno@sha256:b31bfb4d0213f254d361e0079deaaebefa4f82ba7aa76ef82e90b4935ad5b105"},
+	}, {
+		ref:  "quay.io/prometheus/prometheus:latest",
+		want: []string{"quay.io/prometheus/prometheus:latest"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			ref, err := name.ParseReference(tt.ref)
+			assert.NoError(t, err)
+			var got []string
+			for _, ref := range c.References(ref) {
+				got = append(got, ref.Name())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMirrorKeychain(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NoError(t, indexer.Add(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "mirror", Namespace: "kyverno"},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte(`{"auths":{"mirror.example.com":{"username":"user","password":"pass"}}}`),
+		},
+	}))
+	c, err := New(WithMirrors(corev1listers.NewSecretLister(indexer), "kyverno", Mirror{
+		Registry:  "docker.io",
+		Endpoints: []MirrorEndpoint{{Host: "mirror.example.com", ImagePullSecret: "mirror"}},
+	}))
+	assert.NoError(t, err)
+
+	mirror, err := name.NewRegistry("mirror.example.com")
+	assert.NoError(t, err)
+	auth, err := c.Keychain().Resolve(mirror)
+	assert.NoError(t, err)
+	config, err := auth.Authorization()
+	assert.NoError(t, err)
+	assert.Equal(t, "user", config.Username)
+
+	// the credentials are not sent to other registries
+	other, err := name.NewRegistry("ghcr.io")
+	assert.NoError(t, err)
+	auth, err = c.Keychain().Resolve(other)
+	assert.NoError(t, err)
+	assert.Equal(t, authn.Anonymous, auth)
+}
+
+func TestFetchImageDescriptor_Mirrors(t *testing.T) {
+	empty := httptest.NewServer(registry.New())
+	defer empty.Close()
+	mirror := httptest.NewServer(registry.New())
+	defer mirror.Close()
+
+	image, err := random.Image(64, 1)
+	assert.NoError(t, err)
+	digest, err := image.Digest()
+	assert.NoError(t, err)
+	pushed, err := name.ParseReference(strings.TrimPrefix(mirror.URL, "http://")+"/dockerhub/library/test:v1", name.Insecure)
+	assert.NoError(t, err)
+	assert.NoError(t, gcrremote.Write(pushed, image))
+
+	newClient := func(skipRegistry bool, hosts ...string) Client {
+		var endpoints []MirrorEndpoint
+		for _, host := range hosts {
+			endpoints = append(endpoints, MirrorEndpoint{Host: strings.TrimPrefix(host, "http://"), Path: "dockerhub", Insecure: true})
+		}
+		c, err := New(WithMirrors(nil, "kyverno", Mirror{Registry: "docker.io", Endpoints: endpoints, SkipRegistry: skipRegistry}))
+		assert.NoError(t, err)
+		return c
+	}
+
+	// the endpoints are tried in order
+	desc, err := newClient(true, empty.URL, mirror.URL).FetchImageDescriptor(context.Background(), "test:v1")
+	assert.NoError(t, err)
+	assert.Equal(t, digest, desc.Digest)
+
+	desc, err = newClient(true, mirror.URL).FetchImageDescriptor(context.Background(), "test@"+digest.String())
+	assert.NoError(t, err)
+	assert.Equal(t, digest, desc.Digest)
+
+	// the errors of all the endpoints are returned
+	_, err = newClient(true, empty.URL).FetchImageDescriptor(context.Background(), "test:v1")
+	assert.ErrorContains(t, err, strings.TrimPrefix(empty.URL, "http://"))
+}
@@ -80,6 +80,10 @@ func (fi FuzzInterface) ApplyResource(ctx context.Context, apiVersion string, ki
 	return nil, fmt.Errorf("Not implemented")
 }
 
+func (fi FuzzInterface) ApplyResourceWithOptions(ctx context.Context, apiVersion string, kind string, namespace string, name string, obj interface{}, options metav1.ApplyOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	return nil, fmt.Errorf("Not implemented")
+}
+
 func (fi FuzzInterface) ApplyStatusResource(ctx context.Context, apiVersion string, kind string, namespace string, name string, obj interface{}, dryRun bool, fieldManager string) (*unstructured.Unstructured, error) {
 	return nil, fmt.Errorf("Not implemented")
 }
@@ -32,6 +32,8 @@ const (
 	LabelSource                 = "audit.kyverno.io/source"
 	AnnotationResourceNamespace = "audit.kyverno.io/resource.namespace"
 	AnnotationResourceName      = "audit.kyverno.io/resource.name"
+	//	policy fingerprints annotation
+	AnnotationPolicyFingerprints = "audit.kyverno.io/policy.fingerprints"
 	//	policy labels
 	LabelDomainClusterPolicy                    = "cpol.kyverno.io"
 	LabelDomainPolicy                           = "pol.kyverno.io"
@@ -177,6 +179,86 @@ func CalculateResourceHash(resource unstructured.Unstructured) string {
 	return hex.EncodeToString(hash[:])
 }
 
+// ReportedAnnotations returns the policy annotations copied into report results
+func ReportedAnnotations(annotations map[string]string) map[string]string {
+	reported := map[string]string{}
+	for key, value := range annotations {
+		switch {
+		case key == kyverno.AnnotationPolicyCategory,
+			key == kyverno.AnnotationPolicySeverity,
+			key == kyverno.AnnotationPolicyScored,
+			key == kyverno.AnnotationPolicyRemediation,
+			key == kyverno.AnnotationPolicyRemediationLinks,
+			strings.HasPrefix(key, kyverno.AnnotationPrefixRuleRemediation),
+			strings.HasPrefix(key, kyverno.AnnotationPrefixRuleRemediationLinks):
+			reported[key] = value
+		}
+	}
+	return reported
+}
+
+// CalculatePolicyHash computes a hash of the policy content, metadata and status are ignored
+// so that updates not changing the policy rules don't produce a different hash, except for
+// the annotations copied into report results
+func CalculatePolicyHash(policy engineapi.GenericPolicy) string {
+	data, err := json.Marshal(policy.AsObject())
+	if err != nil {
+		return ""
+	}
+	var obj map[string]any
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return ""
+	}
+	delete(obj, "metadata")
+	delete(obj, "status")
+	if annotations := ReportedAnnotations(policy.GetAnnotations()); len(annotations) != 0 {
+		obj["annotations"] = annotations
+	}
+	data, err = json.Marshal(obj)
+	if err != nil {
+		return ""
+	}
+	hash := md5.Sum(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// PolicyFingerprint computes the fingerprint of a resource and policy pair,
+// the pair needs to be evaluated again only when the fingerprint changes
+func PolicyFingerprint(resourceHash string, policy engineapi.GenericPolicy) string {
+	policyHash := CalculatePolicyHash(policy)
+	if policyHash == "" {
+		policyHash = policy.GetResourceVersion()
+	}
+	hash := md5.Sum([]byte(resourceHash + "/" + policyHash))
+	return hex.EncodeToString(hash[:])
+}
+
+func GetPolicyFingerprints(report metav1.Object) map[string]string {
+	fingerprints := map[string]string{}
+	if value := controllerutils.GetAnnotation(report, AnnotationPolicyFingerprints); value != "" {
+		if err := json.Unmarshal([]byte(value), &fingerprints); err != nil {
+			return map[string]string{}
+		}
+	}
+	return fingerprints
+}
+
+func SetPolicyFingerprints(report metav1.Object, resourceHash string, policies ...engineapi.GenericPolicy) {
+	if len(policies) == 0 {
+		delete(report.GetAnnotations(), AnnotationPolicyFingerprints)
+		return
+	}
+	fingerprints := make(map[string]string, len(policies))
+	for _, policy := range policies {
+		fingerprints[PolicyLabel(policy)] = PolicyFingerprint(resourceHash, policy)
+	}
+	data, err := json.Marshal(fingerprints)
+	if err != nil {
+		return
+	}
+	controllerutils.SetAnnotation(report, AnnotationPolicyFingerprints, string(data))
+}
+
 func SetResourceVersionLabels(report reportsv1.ReportInterface, resource *unstructured.Unstructured) {
 	if resource != nil {
 		controllerutils.SetLabel(report, LabelResourceHash, CalculateResourceHash(*resource))
@@ -3,7 +3,10 @@ package report
 import (
 	"testing"
 
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -315,3 +318,70 @@ func TestCleanupKyvernoLabels_NilLabels(t *testing.T) {
 	CleanupKyvernoLabels(obj)
 	assert.Nil(t, obj.Labels)
 }
+
+func TestPolicyFingerprint(t *testing.T) {
+	newPolicy := func(resourceVersion string, background bool) engineapi.GenericPolicy {
+		return engineapi.NewKyvernoPolicy(&kyvernov1.ClusterPolicy{
+			TypeMeta: metav1.TypeMeta{APIVersion: "kyverno.io/v1", Kind: "ClusterPolicy"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "test",
+				ResourceVersion: resourceVersion,
+			},
+			Spec: kyvernov1.Spec{
+				Background: &background,
+			},
+		})
+	}
+	policy := newPolicy("1", true)
+	fingerprint := PolicyFingerprint("hash", policy)
+	assert.NotEmpty(t, fingerprint)
+	// metadata changes don't change the fingerprint
+	assert.Equal(t, fingerprint, PolicyFingerprint("hash", newPolicy("2", true)))
+	// resource changes change the fingerprint
+	assert.NotEqual(t, fingerprint, PolicyFingerprint("other", policy))
+	// policy changes change the fingerprint
+	assert.NotEqual(t, fingerprint, PolicyFingerprint("hash", newPolicy("2", false)))
+}
+
+func TestPolicyFingerprint_ReportedAnnotations(t *testing.T) {
+	newPolicy := func(annotations map[string]string) engineapi.GenericPolicy {
+		return engineapi.NewKyvernoPolicy(&kyvernov1.ClusterPolicy{
+			TypeMeta: metav1.TypeMeta{APIVersion: "kyverno.io/v1", Kind: "ClusterPolicy"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test",
+				Annotations: annotations,
+			},
+		})
+	}
+	fingerprint := PolicyFingerprint("hash", newPolicy(nil))
+	// unrelated annotations don't change the fingerprint
+	assert.Equal(t, fingerprint, PolicyFingerprint("hash", newPolicy(map[string]string{"foo": "bar"})))
+	// annotations copied into results change the fingerprint
+	for _, key := range []string{
+		kyverno.AnnotationPolicyCategory,
+		kyverno.AnnotationPolicySeverity,
+		kyverno.AnnotationPolicyScored,
+		kyverno.AnnotationPolicyRemediation,
+		kyverno.AnnotationPolicyRemediationLinks,
+		kyverno.AnnotationPrefixRuleRemediation + "rule",
+		kyverno.AnnotationPrefixRuleRemediationLinks + "rule",
+	} {
+		assert.NotEqual(t, fingerprint, PolicyFingerprint("hash", newPolicy(map[string]string{key: "value"})), key)
+	}
+}
+
+func TestSetPolicyFingerprints_GetPolicyFingerprints(t *testing.T) {
+	background := true
+	policy := engineapi.NewKyvernoPolicy(&kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec:       kyvernov1.Spec{Background: &background},
+	})
+	report := &reportsv1.EphemeralReport{}
+	assert.Empty(t, GetPolicyFingerprints(report))
+	SetPolicyFingerprints(report, "hash", policy)
+	assert.Equal(t, map[string]string{
+		PolicyLabel(policy): PolicyFingerprint("hash", policy),
+	}, GetPolicyFingerprints(report))
+	SetPolicyFingerprints(report, "hash")
+	assert.NotContains(t, report.GetAnnotations(), AnnotationPolicyFingerprints)
+}
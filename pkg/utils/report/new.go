@@ -74,6 +74,13 @@ func BuildGenerateReport(namespace string, gvk schema.GroupVersionKind, owner st
 	return report
 }
 
+func BuildImageReverificationReport(namespace string, gvk schema.GroupVersionKind, owner string, uid types.UID, responses ...engineapi.EngineResponse) reportsv1.ReportInterface {
+	report := NewBackgroundScanReport(namespace, string(uid), gvk, owner, uid)
+	SetSource(report, "image-reverification")
+	SetResponses(report, responses...)
+	return report
+}
+
 func NewPolicyReport(namespace, name string, scope *corev1.ObjectReference, useOpenreports bool, results ...openreportsv1alpha1.ReportResult) reportsv1.ReportInterface {
 	var report reportsv1.ReportInterface
 	if useOpenreports {
@@ -211,6 +211,10 @@ func ToPolicyReportResult(pol engineapi.GenericPolicy, ruleResult engineapi.Rule
 		result.Result = "warn"
 	}
 
+	if result.Result == openreports.StatusFail || result.Result == openreports.StatusWarn {
+		addRemediationProperties(annotations, result.Rule, &result)
+	}
+
 	if resource != nil {
 		result.Subjects = []corev1.ObjectReference{*resource}
 	}
@@ -237,6 +241,25 @@ func addProperty(k, v string, result *openreportsv1alpha1.ReportResult) {
 	result.Properties[k] = v
 }
 
+// addRemediationProperties adds the remediation text and links declared in the policy annotations,
+// rule annotations take precedence over policy annotations and apply to the autogen rules too
+func addRemediationProperties(annotations map[string]string, rule string, result *openreportsv1alpha1.ReportResult) {
+	lookup := func(prefix, fallback string) string {
+		for _, name := range []string{rule, strings.TrimPrefix(rule, "autogen-cronjob-"), strings.TrimPrefix(rule, "autogen-")} {
+			if value := strings.TrimSpace(annotations[prefix+name]); value != "" {
+				return value
+			}
+		}
+		return strings.TrimSpace(annotations[fallback])
+	}
+	if remediation := lookup(kyverno.AnnotationPrefixRuleRemediation, kyverno.AnnotationPolicyRemediation); remediation != "" {
+		addProperty("remediation", remediation, result)
+	}
+	if links := lookup(kyverno.AnnotationPrefixRuleRemediationLinks, kyverno.AnnotationPolicyRemediationLinks); links != "" {
+		addProperty("remediationLinks", strings.Join(strings.Fields(strings.ReplaceAll(links, ",", " ")), ","), result)
+	}
+}
+
 func selectProcess(background, admission bool) string {
 	switch {
 	case background:
@@ -156,6 +156,51 @@ func Test_addProperty_appends_to_existing(t *testing.T) {
 	assert.Equal(t, "value", result.Properties["new"])
 }
 
+func Test_addRemediationProperties(t *testing.T) {
+	t.Parallel()
+	annotations := map[string]string{
+		"policies.kyverno.io/remediation":                         "Add the required labels.",
+		"policies.kyverno.io/remediation-links":                   "https://kyverno.io/policies/",
+		"remediation.policies.kyverno.io/check-team":              "Add a team label.",
+		"remediation-links.policies.kyverno.io/check-team":        "https://example.com/team, https://example.com/labels",
+		"remediation.policies.kyverno.io/check-cost-center":       " ",
+		"remediation-links.policies.kyverno.io/check-cost-center": "",
+	}
+	tests := []struct {
+		name      string
+		rule      string
+		wantText  string
+		wantLinks string
+	}{{
+		name:      "rule annotations",
+		rule:      "check-team",
+		wantText:  "Add a team label.",
+		wantLinks: "https://example.com/team,https://example.com/labels",
+	}, {
+		name:      "autogen rule",
+		rule:      "autogen-cronjob-check-team",
+		wantText:  "Add a team label.",
+		wantLinks: "https://example.com/team,https://example.com/labels",
+	}, {
+		name:      "policy annotations",
+		rule:      "check-cost-center",
+		wantText:  "Add the required labels.",
+		wantLinks: "https://kyverno.io/policies/",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &openreportsv1alpha1.ReportResult{}
+			addRemediationProperties(annotations, tt.rule, result)
+			assert.Equal(t, tt.wantText, result.Properties["remediation"])
+			assert.Equal(t, tt.wantLinks, result.Properties["remediationLinks"])
+		})
+	}
+
+	result := &openreportsv1alpha1.ReportResult{}
+	addRemediationProperties(nil, "check-team", result)
+	assert.Nil(t, result.Properties)
+}
+
 func Test_getResourceInfo_namespaced(t *testing.T) {
 	t.Parallel()
 	gvk := schema.GroupVersionKind{
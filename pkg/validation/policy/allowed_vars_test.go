@@ -487,3 +487,44 @@ spec:
 	err = hasInvalidVariables(policy[0], false)
 	assert.NilError(t, err)
 }
+
+func TestAllowedVars_GenerateSource(t *testing.T) {
+	var policyWithGenerateSource = []byte(`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: egress-cidrs
+spec:
+  rules:
+  - name: generate-netpol
+    match:
+      any:
+      - resources:
+          kinds:
+          - Namespace
+    generate:
+      synchronize: true
+      apiVersion: networking.k8s.io/v1
+      kind: NetworkPolicy
+      name: allowed-egress
+      namespace: "{{request.object.metadata.name}}"
+      source:
+        globalReference:
+          name: allowed-cidrs
+      data:
+        spec:
+          podSelector: {}
+          policyTypes:
+          - Egress
+          egress:
+          - to:
+            - ipBlock:
+                cidr: "{{ source.cidrs[0] }}"
+`)
+
+	policy, _, _, _, _, _, _, err := yamlutils.GetPolicy(policyWithGenerateSource)
+	assert.NilError(t, err)
+
+	err = hasInvalidVariables(policy[0], true)
+	assert.NilError(t, err)
+}
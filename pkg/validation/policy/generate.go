@@ -53,6 +53,7 @@ func resetMutableFields(rule kyvernov1.Rule, oldHasSynchronizingRule bool) (*kyv
 
 	generation.Synchronize = true
 	generation.SetData(nil)
+	generation.Source = nil
 	generation.ForEachGeneration = nil
 	generation.OrphanDownstreamOnPolicyDelete = true
 	generation.GenerateExisting = nil
@@ -765,8 +765,14 @@ func buildContext(rule *kyvernov1.Rule, background bool, target bool) *enginecon
 		}
 	}
 	if rule.HasGenerate() {
+		if rule.Generation.Source != nil {
+			addContextVariables([]kyvernov1.ContextEntry{rule.Generation.Source.ContextEntry()}, ctx)
+		}
 		for _, fe := range rule.Generation.ForEachGeneration {
 			addContextVariables(fe.Context, ctx)
+			if fe.Source != nil {
+				addContextVariables([]kyvernov1.ContextEntry{fe.Source.ContextEntry()}, ctx)
+			}
 		}
 	}
 	return ctx
@@ -1640,8 +1646,10 @@ func validateNamespaces(validationFailureActionOverrides []kyvernov1.ValidationF
 	action := map[string]sets.Set[string]{
 		"enforce":  sets.New[string](),
 		"audit":    sets.New[string](),
+		"shadow":   sets.New[string](),
 		"enforceW": sets.New[string](),
 		"auditW":   sets.New[string](),
+		"shadowW":  sets.New[string](),
 	}
 
 	for i, vfa := range validationFailureActionOverrides {
@@ -1650,29 +1658,34 @@ func validateNamespaces(validationFailureActionOverrides []kyvernov1.ValidationF
 		}
 		patternList, nsList := wildcard.SeperateWildcards(vfa.Namespaces)
 
-		if vfa.Action.Audit() {
-			if action["enforce"].HasAny(nsList...) {
-				return fmt.Errorf("conflicting namespaces found in path: %s: %s", path.Index(i).Child("namespaces").String(),
-					strings.Join(sets.List(action["enforce"].Intersection(sets.New(nsList...))), ", "))
-			}
-			action["auditW"].Insert(patternList...)
-		} else if vfa.Action.Enforce() {
-			if action["audit"].HasAny(nsList...) {
+		var current string
+		switch {
+		case vfa.Action.Audit():
+			current = "audit"
+		case vfa.Action.Enforce():
+			current = "enforce"
+		default:
+			current = "shadow"
+		}
+		for _, other := range []string{"enforce", "audit", "shadow"} {
+			if other != current && action[other].HasAny(nsList...) {
 				return fmt.Errorf("conflicting namespaces found in path: %s: %s", path.Index(i).Child("namespaces").String(),
-					strings.Join(sets.List(action["audit"].Intersection(sets.New(nsList...))), ", "))
+					strings.Join(sets.List(action[other].Intersection(sets.New(nsList...))), ", "))
 			}
-			action["enforceW"].Insert(patternList...)
 		}
-		action[strings.ToLower(string(vfa.Action))].Insert(nsList...)
+		action[current+"W"].Insert(patternList...)
+		action[current].Insert(nsList...)
 
-		err := validateWildcardsWithNamespaces(
-			sets.List(action["enforce"]),
-			sets.List(action["audit"]),
-			sets.List(action["enforceW"]),
-			sets.List(action["auditW"]),
-		)
-		if err != nil {
-			return fmt.Errorf("path: %s: %s", path.Index(i).Child("namespaces").String(), err.Error())
+		for _, pair := range [][2]string{{"enforce", "audit"}, {"enforce", "shadow"}, {"shadow", "audit"}} {
+			err := validateWildcardsWithNamespaces(
+				sets.List(action[pair[0]]),
+				sets.List(action[pair[1]]),
+				sets.List(action[pair[0]+"W"]),
+				sets.List(action[pair[1]+"W"]),
+			)
+			if err != nil {
+				return fmt.Errorf("path: %s: %s", path.Index(i).Child("namespaces").String(), err.Error())
+			}
 		}
 	}
 
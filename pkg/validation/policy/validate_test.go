@@ -2049,6 +2049,56 @@ func Test_ValidateNamespace(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "tc14",
+			spec: &kyverno.Spec{
+				ValidationFailureAction: "Enforce",
+				ValidationFailureActionOverrides: []kyverno.ValidationFailureActionOverride{
+					{
+						Action:     "Shadow",
+						Namespaces: []string{"default"},
+					},
+					{
+						Action:     "Enforce",
+						Namespaces: []string{"default"},
+					},
+				},
+			},
+			expectedError: errors.New("conflicting namespaces found in path: spec.validationFailureActionOverrides[1].namespaces: default"),
+		},
+		{
+			description: "tc15",
+			spec: &kyverno.Spec{
+				ValidationFailureAction: "Enforce",
+				ValidationFailureActionOverrides: []kyverno.ValidationFailureActionOverride{
+					{
+						Action:     "Audit",
+						Namespaces: []string{"default"},
+					},
+					{
+						Action:     "Shadow",
+						Namespaces: []string{"default*"},
+					},
+				},
+			},
+			expectedError: errors.New("path: spec.validationFailureActionOverrides[1].namespaces: wildcard pattern 'default*' matches with namespace 'default'"),
+		},
+		{
+			description: "tc16",
+			spec: &kyverno.Spec{
+				ValidationFailureAction: "Enforce",
+				ValidationFailureActionOverrides: []kyverno.ValidationFailureActionOverride{
+					{
+						Action:     "Shadow",
+						Namespaces: []string{"staging", "dev-*"},
+					},
+					{
+						Action:     "Enforce",
+						Namespaces: []string{"prod"},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -2,6 +2,7 @@ package resource
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/kyverno/kyverno/api/kyverno"
@@ -31,3 +32,16 @@ func ValidateTtlLabel(_ context.Context, object metav1.Object) error {
 	}
 	return nil
 }
+
+func ValidateTtlAnchor(_ context.Context, object metav1.Object) error {
+	anchor, ok := object.GetAnnotations()[kyverno.AnnotationCleanupTtlAnchor]
+	if !ok {
+		return nil
+	}
+	switch anchor {
+	case kyverno.ValueTtlAnchorCreation, kyverno.ValueTtlAnchorLastUpdate, kyverno.ValueTtlAnchorJobCompletion, kyverno.ValueTtlAnchorIdle:
+		return nil
+	default:
+		return fmt.Errorf("unknown TTL anchor %s", anchor)
+	}
+}
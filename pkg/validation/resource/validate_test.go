@@ -53,3 +53,14 @@ func Test_ValidateTTL(t *testing.T) {
 	err = ValidateTtlLabel(ctx, metadata)
 	assert.NilError(t, err)
 }
+
+func Test_ValidateTtlAnchor(t *testing.T) {
+	object := &metav1.ObjectMeta{}
+	assert.NilError(t, ValidateTtlAnchor(ctx, object))
+
+	object.SetAnnotations(map[string]string{"cleanup.kyverno.io/ttl-anchor": "job-completion"})
+	assert.NilError(t, ValidateTtlAnchor(ctx, object))
+
+	object.SetAnnotations(map[string]string{"cleanup.kyverno.io/ttl-anchor": "last-access"})
+	assert.Error(t, ValidateTtlAnchor(ctx, object), "unknown TTL anchor last-access")
+}
@@ -0,0 +1,66 @@
+package recorder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Start serves the recorded admission requests at config.AdmissionRecordsServicePath on a dedicated
+// debug listener, separate from the webhook server as the records hold full admission payloads.
+// The listener is stopped when the context is cancelled.
+func Start(ctx context.Context, logger logr.Logger, address string, store Store) {
+	mux := http.NewServeMux()
+	mux.Handle("GET "+config.AdmissionRecordsServicePath, Handler(store))
+	server := &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ErrorLog:          logging.StdLogger(logger, ""),
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+	go func() {
+		logger.V(2).Info("serving admission records", "address", address, "path", config.AdmissionRecordsServicePath)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error(err, "failed to serve admission records")
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Error(err, "failed to shut down admission records server")
+		}
+	}()
+}
+
+// Handler serves the recorded admission requests, the optional uid query parameter
+// restricts the response to the request with the given uid
+func Handler(store Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		records := store.List()
+		if uid := r.URL.Query().Get("uid"); uid != "" {
+			var filtered []Record
+			for _, record := range records {
+				if record.Review.Request != nil && record.Review.Request.UID == types.UID(uid) {
+					filtered = append(filtered, record)
+				}
+			}
+			records = filtered
+		}
+		if records == nil {
+			records = []Record{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(records); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package recorder
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStart(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	address := listener.Addr().String()
+	assert.NoError(t, listener.Close())
+
+	store := NewStore(10)
+	store.Add(Record{Webhook: WebhookValidate})
+	ctx, cancel := context.WithCancel(context.Background())
+	Start(ctx, logr.Discard(), address, store)
+
+	var response *http.Response
+	assert.Eventually(t, func() bool {
+		response, err = http.Get("http://" + address + config.AdmissionRecordsServicePath)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	defer response.Body.Close()
+	var records []Record
+	assert.NoError(t, json.NewDecoder(response.Body).Decode(&records))
+	assert.Len(t, records, 1)
+
+	// only the admission records are served
+	other, err := http.Get("http://" + address + "/validate")
+	assert.NoError(t, err)
+	other.Body.Close()
+	assert.Equal(t, http.StatusNotFound, other.StatusCode)
+
+	cancel()
+	assert.Eventually(t, func() bool {
+		_, err := http.Get("http://" + address + config.AdmissionRecordsServicePath)
+		return err != nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
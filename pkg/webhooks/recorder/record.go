@@ -0,0 +1,68 @@
+package recorder
+
+import (
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// WebhookMutate identifies requests recorded by the resource mutating webhook
+	WebhookMutate = "mutate"
+	// WebhookValidate identifies requests recorded by the resource validating webhook
+	WebhookValidate = "validate"
+	// WebhookValidatingPolicies identifies requests recorded by the validating policies webhook
+	WebhookValidatingPolicies = "vpol"
+	// WebhookNamespacedValidatingPolicies identifies requests recorded by the namespaced validating policies webhook
+	WebhookNamespacedValidatingPolicies = "nvpol"
+	// WebhookImageValidatingPolicies identifies requests recorded by the image validating policies validating webhook
+	WebhookImageValidatingPolicies = "ivpol-validate"
+	// WebhookImageValidatingPoliciesMutation identifies requests recorded by the image validating policies mutating webhook
+	WebhookImageValidatingPoliciesMutation = "ivpol-mutate"
+	// WebhookMutatingPolicies identifies requests recorded by the mutating policies webhook
+	WebhookMutatingPolicies = "mpol"
+	// WebhookNamespacedMutatingPolicies identifies requests recorded by the namespaced mutating policies webhook
+	WebhookNamespacedMutatingPolicies = "nmpol"
+	// WebhookGeneratingPolicies identifies requests recorded by the generating policies webhook
+	WebhookGeneratingPolicies = "gpol"
+	// WebhookNamespacedGeneratingPolicies identifies requests recorded by the namespaced generating policies webhook
+	WebhookNamespacedGeneratingPolicies = "ngpol"
+)
+
+// Record holds a denied admission request along with everything needed to replay it.
+type Record struct {
+	// Timestamp is the time the request was recorded
+	Timestamp metav1.Time `json:"timestamp"`
+	// Webhook is the resource webhook that denied the request
+	Webhook string `json:"webhook"`
+	// FailurePolicy is the failure policy of the webhook endpoint that received the request
+	FailurePolicy string `json:"failurePolicy"`
+	// URLParams holds the fine grained policy path, if any
+	URLParams string `json:"urlParams,omitempty"`
+	// Policies holds the names of the policies of the webhook path, if any
+	Policies []string `json:"policies,omitempty"`
+	// Roles are the roles bound to the requesting user
+	Roles []string `json:"roles,omitempty"`
+	// ClusterRoles are the cluster roles bound to the requesting user
+	ClusterRoles []string `json:"clusterRoles,omitempty"`
+	// GroupVersionKind is the top level GVK of the requested resource
+	GroupVersionKind metav1.GroupVersionKind `json:"groupVersionKind"`
+	// Review is the redacted admission review, holding both the request and the response
+	Review admissionv1.AdmissionReview `json:"review"`
+}
+
+// AdmissionRequest rebuilds the admission request as seen by the resource handlers
+func (r Record) AdmissionRequest() handlers.AdmissionRequest {
+	var request admissionv1.AdmissionRequest
+	if r.Review.Request != nil {
+		request = *r.Review.Request
+	}
+	return handlers.AdmissionRequest{
+		AdmissionRequest: request,
+		Roles:            r.Roles,
+		ClusterRoles:     r.ClusterRoles,
+		GroupVersionKind: schema.GroupVersionKind(r.GroupVersionKind),
+		URLParams:        r.URLParams,
+	}
+}
@@ -0,0 +1,78 @@
+package recorder
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/julienschmidt/httprouter"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Recorder records denied admission requests into a bounded store
+type Recorder struct {
+	logger    logr.Logger
+	store     Store
+	redaction Redaction
+}
+
+func New(logger logr.Logger, store Store, redaction Redaction) *Recorder {
+	return &Recorder{
+		logger:    logger,
+		store:     store,
+		redaction: redaction,
+	}
+}
+
+// Record records the admission request if it was denied, the payload of redacted kinds is redacted before being stored.
+// The policies the request was evaluated against are taken from the webhook path parameters, if any.
+func (r *Recorder) Record(ctx context.Context, webhook string, failurePolicy string, request handlers.AdmissionRequest, response admissionv1.AdmissionResponse) {
+	if response.Allowed {
+		return
+	}
+	admissionRequest, err := r.redaction.redactRequest(request.AdmissionRequest)
+	if err != nil {
+		r.logger.Error(err, "failed to redact admission request, it will not be recorded", "uid", request.UID)
+		return
+	}
+	if r.redaction.Matches(request.AdmissionRequest) {
+		response.Patch = nil
+		response.PatchType = nil
+	}
+	r.store.Add(Record{
+		Timestamp:        metav1.NewTime(time.Now()),
+		Webhook:          webhook,
+		FailurePolicy:    failurePolicy,
+		URLParams:        request.URLParams,
+		Policies:         policiesFromContext(ctx),
+		Roles:            request.Roles,
+		ClusterRoles:     request.ClusterRoles,
+		GroupVersionKind: metav1.GroupVersionKind(request.GroupVersionKind),
+		Review: admissionv1.AdmissionReview{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: admissionv1.SchemeGroupVersion.String(),
+				Kind:       "AdmissionReview",
+			},
+			Request:  admissionRequest,
+			Response: &response,
+		},
+	})
+}
+
+// policiesFromContext returns the policy names of the webhook path, e.g. /vpol/<policy>/<policy>
+func policiesFromContext(ctx context.Context) []string {
+	params := httprouter.ParamsFromContext(ctx)
+	if params == nil {
+		return nil
+	}
+	var policies []string
+	for _, policy := range strings.Split(params.ByName("policies"), "/") {
+		if policy != "" {
+			policies = append(policies, policy)
+		}
+	}
+	return policies
+}
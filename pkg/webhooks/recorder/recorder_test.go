@@ -0,0 +1,112 @@
+package recorder
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/julienschmidt/httprouter"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestRecorder_Record(t *testing.T) {
+	secrets, err := ParseRedaction("Secret")
+	assert.NoError(t, err)
+	secret := []byte(`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"test","namespace":"default"},"data":{"password":"c2VjcmV0"},"stringData":{"token":"secret"}}`)
+	pod := []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"test","namespace":"default"}}`)
+	denied := admissionv1.AdmissionResponse{
+		UID:     "uid",
+		Allowed: false,
+		Result:  &metav1.Status{Message: "denied"},
+		Patch:   []byte(`[]`),
+	}
+	t.Run("allowed requests are not recorded", func(t *testing.T) {
+		store := NewStore(10)
+		r := New(logr.Discard(), store, secrets)
+		r.Record(context.TODO(), WebhookValidate, "fail", newRequest("Pod", pod), admissionv1.AdmissionResponse{Allowed: true})
+		assert.Empty(t, store.List())
+	})
+	t.Run("denied requests are recorded", func(t *testing.T) {
+		store := NewStore(10)
+		r := New(logr.Discard(), store, secrets)
+		r.Record(context.TODO(), WebhookValidate, "fail", newRequest("Pod", pod), denied)
+		records := store.List()
+		assert.Len(t, records, 1)
+		assert.Equal(t, WebhookValidate, records[0].Webhook)
+		assert.Equal(t, "fail", records[0].FailurePolicy)
+		assert.Equal(t, []string{"role"}, records[0].Roles)
+		assert.JSONEq(t, string(pod), string(records[0].Review.Request.Object.Raw))
+		assert.Equal(t, "denied", records[0].Review.Response.Result.Message)
+		assert.Equal(t, []byte(`[]`), records[0].Review.Response.Patch)
+	})
+	t.Run("secrets are redacted", func(t *testing.T) {
+		store := NewStore(10)
+		r := New(logr.Discard(), store, secrets)
+		r.Record(context.TODO(), WebhookMutate, "fail", newRequest("Secret", secret), denied)
+		records := store.List()
+		assert.Len(t, records, 1)
+		var object map[string]any
+		assert.NoError(t, json.Unmarshal(records[0].Review.Request.Object.Raw, &object))
+		assert.NotContains(t, object, "stringData")
+		assert.NotContains(t, string(records[0].Review.Request.Object.Raw), "c2VjcmV0")
+		assert.Nil(t, records[0].Review.Response.Patch)
+	})
+	t.Run("secrets are not redacted when not configured", func(t *testing.T) {
+		store := NewStore(10)
+		r := New(logr.Discard(), store, Redaction{})
+		r.Record(context.TODO(), WebhookMutate, "fail", newRequest("Secret", secret), denied)
+		records := store.List()
+		assert.Len(t, records, 1)
+		assert.JSONEq(t, string(secret), string(records[0].Review.Request.Object.Raw))
+	})
+	t.Run("configured kinds are redacted", func(t *testing.T) {
+		configMap := []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"test","namespace":"default","labels":{"app":"test"},"annotations":{"token":"secret"}},"data":{"password":"secret"},"immutable":true}`)
+		redaction, err := ParseRedaction("Secret, ConfigMap")
+		assert.NoError(t, err)
+		store := NewStore(10)
+		r := New(logr.Discard(), store, redaction)
+		r.Record(context.TODO(), WebhookValidate, "fail", newRequest("ConfigMap", configMap), denied)
+		records := store.List()
+		assert.Len(t, records, 1)
+		assert.JSONEq(t, `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"test","namespace":"default","labels":{"app":"test"},"annotations":{"token":"**REDACTED**"}},"data":{"password":"**REDACTED**"},"immutable":true}`, string(records[0].Review.Request.Object.Raw))
+		assert.Nil(t, records[0].Review.Response.Patch)
+	})
+	t.Run("policies of the webhook path are recorded", func(t *testing.T) {
+		store := NewStore(10)
+		r := New(logr.Discard(), store, secrets)
+		ctx := context.WithValue(context.TODO(), httprouter.ParamsKey, httprouter.Params{{Key: "policies", Value: "/check-labels/check-images"}})
+		r.Record(ctx, WebhookValidatingPolicies, "fail", newRequest("Pod", pod), denied)
+		records := store.List()
+		assert.Len(t, records, 1)
+		assert.Equal(t, []string{"check-labels", "check-images"}, records[0].Policies)
+	})
+}
+
+func TestParseRedaction(t *testing.T) {
+	redaction, err := ParseRedaction("Secret,external-secrets.io/ExternalSecret,")
+	assert.NoError(t, err)
+	assert.True(t, redaction.Matches(admissionv1.AdmissionRequest{Kind: metav1.GroupVersionKind{Version: "v1", Kind: "Secret"}}))
+	assert.True(t, redaction.Matches(admissionv1.AdmissionRequest{Kind: metav1.GroupVersionKind{Group: "external-secrets.io", Version: "v1", Kind: "ExternalSecret"}}))
+	assert.False(t, redaction.Matches(admissionv1.AdmissionRequest{Kind: metav1.GroupVersionKind{Group: "example.io", Version: "v1", Kind: "Secret"}}))
+	_, err = ParseRedaction("a/b/c")
+	assert.Error(t, err)
+}
+
+func newRequest(kind string, raw []byte) handlers.AdmissionRequest {
+	return handlers.AdmissionRequest{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			UID:       "uid",
+			Kind:      metav1.GroupVersionKind{Version: "v1", Kind: kind},
+			Operation: admissionv1.Create,
+			Name:      "test",
+			Namespace: "default",
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+		Roles: []string{"role"},
+	}
+}
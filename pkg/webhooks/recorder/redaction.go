@@ -0,0 +1,138 @@
+package recorder
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const redactedValue = "**REDACTED**"
+
+// Redaction holds the kinds whose payloads are redacted before being recorded
+type Redaction struct {
+	kinds []schema.GroupKind
+}
+
+// ParseRedaction parses a comma separated list of kinds, each kind is either `Kind` for the core group
+// or `group/Kind`, e.g. `Secret,ConfigMap,external-secrets.io/ExternalSecret`
+func ParseRedaction(kinds string) (Redaction, error) {
+	var redaction Redaction
+	for _, kind := range strings.Split(kinds, ",") {
+		kind = strings.TrimSpace(kind)
+		if kind == "" {
+			continue
+		}
+		group, name, ok := strings.Cut(kind, "/")
+		if !ok {
+			group, name = "", kind
+		}
+		if name == "" || strings.Contains(name, "/") {
+			return Redaction{}, fmt.Errorf("invalid kind %q, expected Kind or group/Kind", kind)
+		}
+		redaction.kinds = append(redaction.kinds, schema.GroupKind{Group: group, Kind: name})
+	}
+	return redaction, nil
+}
+
+// Matches returns true when the kind of the request is redacted
+func (r Redaction) Matches(request admissionv1.AdmissionRequest) bool {
+	for _, kind := range r.kinds {
+		if kind.Group == request.Kind.Group && strings.EqualFold(kind.Kind, request.Kind.Kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactRequest returns a copy of the request with the payload of redacted kinds redacted
+func (r Redaction) redactRequest(request admissionv1.AdmissionRequest) (*admissionv1.AdmissionRequest, error) {
+	out := request.DeepCopy()
+	if !r.Matches(request) {
+		return out, nil
+	}
+	redact := redactObject
+	if isSecret(request) {
+		redact = redactSecret
+	}
+	var err error
+	if out.Object.Raw, err = redact(out.Object); err != nil {
+		return nil, err
+	}
+	if out.OldObject.Raw, err = redact(out.OldObject); err != nil {
+		return nil, err
+	}
+	out.Object.Object = nil
+	out.OldObject.Object = nil
+	return out, nil
+}
+
+func isSecret(request admissionv1.AdmissionRequest) bool {
+	return request.Kind.Group == "" && strings.EqualFold(request.Kind.Kind, "Secret")
+}
+
+func redactSecret(raw runtime.RawExtension) ([]byte, error) {
+	if len(raw.Raw) == 0 {
+		return raw.Raw, nil
+	}
+	obj, err := kubeutils.BytesToUnstructured(raw.Raw)
+	if err != nil {
+		return nil, err
+	}
+	redacted, err := kubeutils.RedactSecret(obj)
+	if err != nil {
+		return nil, err
+	}
+	// string data is write only and never holds anything that isn't also in data
+	delete(redacted.Object, "stringData")
+	return json.Marshal(redacted.Object)
+}
+
+// redactObject redacts the string values of an object but its type and metadata, the annotation
+// values are redacted too. The structure is kept so that policies checking fields still evaluate.
+func redactObject(raw runtime.RawExtension) ([]byte, error) {
+	if len(raw.Raw) == 0 {
+		return raw.Raw, nil
+	}
+	obj, err := kubeutils.BytesToUnstructured(raw.Raw)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range obj.Object {
+		switch key {
+		case "apiVersion", "kind":
+		case "metadata":
+			if metadata, ok := value.(map[string]any); ok {
+				if annotations, ok := metadata["annotations"]; ok {
+					metadata["annotations"] = redactValue(annotations)
+				}
+			}
+		default:
+			obj.Object[key] = redactValue(value)
+		}
+	}
+	return json.Marshal(obj.Object)
+}
+
+func redactValue(value any) any {
+	switch value := value.(type) {
+	case string:
+		return redactedValue
+	case map[string]any:
+		for key := range value {
+			value[key] = redactValue(value[key])
+		}
+		return value
+	case []any:
+		for i := range value {
+			value[i] = redactValue(value[i])
+		}
+		return value
+	default:
+		return value
+	}
+}
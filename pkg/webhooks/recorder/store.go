@@ -0,0 +1,52 @@
+package recorder
+
+import (
+	"sync"
+)
+
+// Store holds recorded admission requests
+type Store interface {
+	// Add adds a record to the store
+	Add(Record)
+	// List returns the records in the store, oldest first
+	List() []Record
+}
+
+type store struct {
+	lock    sync.RWMutex
+	records []Record
+	next    int
+	full    bool
+}
+
+// NewStore returns an in memory store keeping at most size records,
+// once the store is full the oldest records are evicted first
+func NewStore(size int) Store {
+	return &store{
+		records: make([]Record, size),
+	}
+}
+
+func (s *store) Add(record Record) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if len(s.records) == 0 {
+		return
+	}
+	s.records[s.next] = record
+	s.next = (s.next + 1) % len(s.records)
+	if s.next == 0 {
+		s.full = true
+	}
+}
+
+func (s *store) List() []Record {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if !s.full {
+		return append([]Record(nil), s.records[:s.next]...)
+	}
+	out := make([]Record, 0, len(s.records))
+	out = append(out, s.records[s.next:]...)
+	return append(out, s.records[:s.next]...)
+}
@@ -0,0 +1,49 @@
+package recorder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		add  []string
+		want []string
+	}{{
+		name: "disabled",
+		size: 0,
+		add:  []string{"a", "b"},
+		want: []string{},
+	}, {
+		name: "not full",
+		size: 3,
+		add:  []string{"a", "b"},
+		want: []string{"a", "b"},
+	}, {
+		name: "full",
+		size: 3,
+		add:  []string{"a", "b", "c"},
+		want: []string{"a", "b", "c"},
+	}, {
+		name: "evicts oldest",
+		size: 3,
+		add:  []string{"a", "b", "c", "d", "e"},
+		want: []string{"c", "d", "e"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewStore(tt.size)
+			for _, webhook := range tt.add {
+				s.Add(Record{Webhook: webhook})
+			}
+			got := []string{}
+			for _, record := range s.List() {
+				got = append(got, record.Webhook)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
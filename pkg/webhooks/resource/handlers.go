@@ -141,14 +141,19 @@ func (h *resourceHandlers) Validate(ctx context.Context, logger logr.Logger, req
 	var msg string
 	var warnings []string
 	var enforceResponses []engineapi.EngineResponse
+	var shadowResponses []engineapi.EngineResponse
 	wg.Start(func() {
 		ok, msg, warnings, enforceResponses = vh.HandleValidationEnforce(ctx, request, policies, auditWarnPolicies, startTime)
 	})
+	wg.Start(func() {
+		shadowResponses = vh.HandleValidationShadow(ctx, request)
+	})
 	if !admissionutils.IsDryRun(request.AdmissionRequest) {
 		var dummy wait.Group
 		h.handleBackgroundApplies(ctx, logger, request, generatePolicies, mutatePolicies, startTime, &dummy)
 	}
 	wg.Wait()
+	h.handleShadowResponses(ctx, logger, request, shadowResponses)
 	if !ok {
 		logger.V(4).Info("admission request denied")
 		events := webhookutils.GenerateEvents(enforceResponses, true)
@@ -177,6 +182,21 @@ func (h *resourceHandlers) Validate(ctx context.Context, logger logr.Logger, req
 	return admissionutils.ResponseSuccess(request.UID, warnings...)
 }
 
+func (h *resourceHandlers) handleShadowResponses(ctx context.Context, logger logr.Logger, request handlers.AdmissionRequest, responses []engineapi.EngineResponse) {
+	if len(responses) == 0 {
+		return
+	}
+	if denials := webhookutils.GetShadowDenials(responses); len(denials) != 0 {
+		logger.V(2).Info("admission request would have been denied by shadow policies", "denials", denials)
+	}
+	if shadowMetrics := metrics.GetShadowMetrics(); shadowMetrics != nil {
+		for _, response := range responses {
+			shadowMetrics.RecordResponse(ctx, string(request.Operation), response)
+		}
+	}
+	h.eventGen.Add(webhookutils.GenerateShadowEvents(responses)...)
+}
+
 func (h *resourceHandlers) Mutate(ctx context.Context, logger logr.Logger, request handlers.AdmissionRequest, failurePolicy string, startTime time.Time) handlers.AdmissionResponse {
 	kind := request.Kind.Kind
 	logger = logger.WithValues("kind", kind).WithValues("URLParams", request.URLParams)
@@ -94,10 +94,6 @@ func (h *imageVerificationHandler) handleVerifyImages(
 			"",
 			fmt.Sprintf("POLICY %s/%s", policy.GetNamespace(), policy.GetName()),
 			func(ctx context.Context, span trace.Span) {
-				if policy.GetSpec().GetFailurePolicy(ctx) == kyvernov1.Fail {
-					failurePolicy = kyvernov1.Fail
-				}
-
 				policyContext := policyContext.WithPolicy(policy)
 				if request.Kind.Kind != "Namespace" && request.Namespace != "" {
 					namespaceLabels, err := engineutils.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, h.nsLister, []kyvernov1.PolicyInterface{policy}, h.log)
@@ -108,13 +104,35 @@ func (h *imageVerificationHandler) handleVerifyImages(
 					policyContext = policyContext.WithNamespaceLabels(namespaceLabels)
 				}
 
-				resp, ivm := h.engine.VerifyAndPatchImages(ctx, policyContext)
+				var ivm engineapi.ImageVerificationMetadata
+				resp, evaluated, exceeded := webhookutils.EvaluateWithLatencyBudget(
+					ctx,
+					logger,
+					h.eventGen,
+					string(request.Operation),
+					engineapi.ImageVerify,
+					policyContext,
+					func(ctx context.Context) engineapi.EngineResponse {
+						var resp engineapi.EngineResponse
+						resp, ivm = h.engine.VerifyAndPatchImages(ctx, policyContext)
+						return resp
+					},
+				)
+				if !evaluated {
+					return
+				}
+				if policy.GetSpec().GetFailurePolicy(ctx) == kyvernov1.Fail {
+					failurePolicy = kyvernov1.Fail
+				}
 				if !resp.IsEmpty() {
 					engineResponses = append(engineResponses, resp)
 				}
 
 				patches = append(patches, resp.GetPatches()...)
-				verifiedImageData.Merge(ivm)
+				// the verification metadata of an evaluation exceeding its budget is still being written
+				if !exceeded {
+					verifiedImageData.Merge(ivm)
+				}
 			},
 		)
 	}
@@ -177,7 +177,20 @@ func (h *mutationHandler) applyMutation(ctx context.Context, request admissionv1
 		policyContext = policyContext.WithNamespaceLabels(namespaceLabels)
 	}
 
-	engineResponse := h.engine.Mutate(ctx, policyContext)
+	engineResponse, evaluated, _ := webhookutils.EvaluateWithLatencyBudget(
+		ctx,
+		h.log,
+		h.eventGen,
+		string(request.Operation),
+		engineapi.Mutation,
+		policyContext,
+		func(ctx context.Context) engineapi.EngineResponse {
+			return h.engine.Mutate(ctx, policyContext)
+		},
+	)
+	if !evaluated {
+		return nil, nil, nil
+	}
 	policyPatches := engineResponse.GetPatches()
 
 	if !engineResponse.IsSuccessful() {
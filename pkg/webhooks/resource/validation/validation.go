@@ -33,6 +33,9 @@ type ValidationHandler interface {
 	// patchedResource is the (resource + patches) after applying mutation rules
 	HandleValidationEnforce(context.Context, handlers.AdmissionRequest, []kyvernov1.PolicyInterface, []kyvernov1.PolicyInterface, time.Time) (bool, string, []string, []engineapi.EngineResponse)
 	HandleValidationAudit(context.Context, handlers.AdmissionRequest) []engineapi.EngineResponse
+	// HandleValidationShadow evaluates policies in shadow mode with enforce semantics,
+	// the returned responses never block the admission request
+	HandleValidationShadow(context.Context, handlers.AdmissionRequest) []engineapi.EngineResponse
 }
 
 func NewValidationHandler(
@@ -100,11 +103,14 @@ func (v *validationHandler) HandleValidationEnforce(
 			fmt.Sprintf("POLICY %s/%s", policy.GetNamespace(), policy.GetName()),
 			func(ctx context.Context, span trace.Span) {
 				policyContext := policyContext.WithPolicy(policy)
+				engineResponse, evaluated := v.validateWithLatencyBudget(ctx, logger, request, policyContext)
+				if !evaluated {
+					return
+				}
 				if policy.GetSpec().GetFailurePolicy(ctx) == kyvernov1.Fail {
 					failurePolicy = kyvernov1.Fail
 				}
 
-				engineResponse := v.engine.Validate(ctx, policyContext)
 				if engineResponse.IsNil() {
 					// we get an empty response if old and new resources created the same response
 					// allow updates if resource update doesn't change the policy evaluation
@@ -133,8 +139,8 @@ func (v *validationHandler) HandleValidationEnforce(
 			func(ctx context.Context, span trace.Span) {
 				policyContext := policyContext.WithPolicy(policy)
 
-				engineResponse := v.engine.Validate(ctx, policyContext)
-				if engineResponse.IsNil() {
+				engineResponse, evaluated := v.validateWithLatencyBudget(ctx, logger, request, policyContext)
+				if !evaluated || engineResponse.IsNil() {
 					// we get an empty response if old and new resources created the same response
 					// allow updates if resource update doesn't change the policy evaluation
 					return
@@ -227,6 +233,89 @@ func (v *validationHandler) HandleValidationAudit(
 	return responses
 }
 
+func (v *validationHandler) HandleValidationShadow(
+	ctx context.Context,
+	request handlers.AdmissionRequest,
+) []engineapi.EngineResponse {
+	gvr := schema.GroupVersionResource(request.Resource)
+
+	var namespace *corev1.Namespace
+	if request.Namespace != "" {
+		var err error
+		namespace, err = v.nsLister.Get(request.Namespace)
+		if err != nil {
+			v.log.V(4).Info("failed to get namespace", "namespace", request.Namespace, "error", err)
+		}
+	}
+
+	policies := v.pCache.GetPolicies(policycache.ValidateShadow, gvr, request.SubResource, namespace)
+	if len(policies) == 0 {
+		return nil
+	}
+
+	policyContext, err := v.buildPolicyContextFromAdmissionRequest(v.log, request, policies)
+	if err != nil {
+		v.log.Error(err, "failed to build policy context")
+		return nil
+	}
+
+	var responses []engineapi.EngineResponse
+	for _, policy := range policies {
+		tracing.ChildSpan(
+			ctx,
+			"pkg/webhooks/resource/validate",
+			fmt.Sprintf("SHADOW POLICY %s/%s", policy.GetNamespace(), policy.GetName()),
+			func(ctx context.Context, span trace.Span) {
+				policyContext := policyContext.WithPolicy(policy)
+				engineResponse, evaluated := v.validateWithLatencyBudget(ctx, v.log, request, policyContext)
+				if !evaluated || engineResponse.IsNil() {
+					return
+				}
+				responses = append(responses, engineResponse)
+				if !engineResponse.IsSuccessful() {
+					v.log.V(2).Info("validation failed", "action", "Shadow", "policy", policy.GetName(), "failed rules", engineResponse.GetFailedRules())
+				}
+			},
+		)
+	}
+	responses = webhookutils.MarkShadowResponses(responses)
+
+	if NeedsReports(request, policyContext.NewResource(), v.admissionReports) && hasReportablePolicy(policies) {
+		go func() {
+			if err := v.createReports(context.TODO(), policyContext.NewResource(), request, responses...); err != nil {
+				if reportutils.IsNamespaceTerminationError(err) {
+					v.log.V(2).Info("skipping report creation due to namespace termination", "error", err.Error())
+				} else {
+					v.log.Error(err, "failed to create report")
+				}
+			}
+		}()
+	}
+	return responses
+}
+
+// validateWithLatencyBudget validates the resource against the policy within its admission latency budget,
+// the returned boolean is false if the policy is degraded and was skipped
+func (v *validationHandler) validateWithLatencyBudget(
+	ctx context.Context,
+	logger logr.Logger,
+	request handlers.AdmissionRequest,
+	policyContext *policycontext.PolicyContext,
+) (engineapi.EngineResponse, bool) {
+	response, evaluated, _ := webhookutils.EvaluateWithLatencyBudget(
+		ctx,
+		logger,
+		v.eventGen,
+		string(request.Operation),
+		engineapi.Validation,
+		policyContext,
+		func(ctx context.Context) engineapi.EngineResponse {
+			return v.engine.Validate(ctx, policyContext)
+		},
+	)
+	return response, evaluated
+}
+
 func (v *validationHandler) buildAuditResponses(
 	ctx context.Context,
 	policyContext *policycontext.PolicyContext,
@@ -11,10 +11,12 @@ import (
 	"github.com/kyverno/kyverno/pkg/breaker"
 	celengine "github.com/kyverno/kyverno/pkg/cel/engine"
 	"github.com/kyverno/kyverno/pkg/cel/libs"
+	vpolutils "github.com/kyverno/kyverno/pkg/cel/policies/vpol"
 	vpolengine "github.com/kyverno/kyverno/pkg/cel/policies/vpol/engine"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	event "github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
@@ -88,13 +90,14 @@ func (h *handler) validate(ctx context.Context, logger logr.Logger, admissionReq
 func (h *handler) audit(ctx context.Context, logger logr.Logger, admissionRequest handlers.AdmissionRequest, request vpolengine.EngineRequest, response vpolengine.EngineResponse) {
 	blocked := false
 	for _, p := range response.Policies {
-		if p.Actions.Has(admissionregistrationv1.Deny) {
+		if p.Actions.Has(admissionregistrationv1.Deny) && !vpolutils.IsShadow(p.Policy) {
 			blocked = true
 			break
 		}
 	}
 
 	allEngineResponses := make([]engineapi.EngineResponse, 0, len(response.Policies))
+	shadowEngineResponses := make([]engineapi.EngineResponse, 0, len(response.Policies))
 	reportableEngineResponses := make([]engineapi.EngineResponse, 0, len(response.Policies))
 	for _, r := range response.Policies {
 		engineResponse := engineapi.EngineResponse{
@@ -104,12 +107,19 @@ func (h *handler) audit(ctx context.Context, logger logr.Logger, admissionReques
 			},
 		}
 		engineResponse = engineResponse.WithPolicy(engineapi.NewValidatingPolicyFromLike(r.Policy))
-		allEngineResponses = append(allEngineResponses, engineResponse)
+		if vpolutils.IsShadow(r.Policy) {
+			engineResponse = webhookutils.MarkShadowResponses([]engineapi.EngineResponse{engineResponse})[0]
+			shadowEngineResponses = append(shadowEngineResponses, engineResponse)
+		} else {
+			allEngineResponses = append(allEngineResponses, engineResponse)
+		}
 		if reportutils.IsPolicyReportable(r.Policy) {
 			reportableEngineResponses = append(reportableEngineResponses, engineResponse)
 		}
 	}
 
+	h.shadowAudit(ctx, admissionRequest, shadowEngineResponses)
+
 	if !blocked && validation.NeedsReports(admissionRequest, *response.Resource, h.admissionReports) {
 		err := h.admissionReport(ctx, request, response, reportableEngineResponses)
 		if err != nil {
@@ -120,6 +130,18 @@ func (h *handler) audit(ctx context.Context, logger logr.Logger, admissionReques
 	h.admissionEvent(ctx, allEngineResponses, blocked)
 }
 
+func (h *handler) shadowAudit(ctx context.Context, admissionRequest handlers.AdmissionRequest, responses []engineapi.EngineResponse) {
+	if len(responses) == 0 {
+		return
+	}
+	if shadowMetrics := metrics.GetShadowMetrics(); shadowMetrics != nil {
+		for _, response := range responses {
+			shadowMetrics.RecordResponse(ctx, string(admissionRequest.Operation), response)
+		}
+	}
+	h.eventGen.Add(webhookutils.GenerateShadowEvents(responses)...)
+}
+
 func (h *handler) admissionReport(ctx context.Context, request vpolengine.EngineRequest, response vpolengine.EngineResponse, responses []engineapi.EngineResponse) error {
 	report := reportutils.BuildAdmissionReport(*response.Resource, request.AdmissionRequest(), responses...)
 	if len(report.GetResults()) > 0 {
@@ -145,6 +167,10 @@ func (h *handler) admissionResponse(request vpolengine.EngineRequest, response v
 	var errs []error
 	var warnings []string
 	for _, policy := range response.Policies {
+		// shadow policies never contribute to the admission response
+		if vpolutils.IsShadow(policy.Policy) {
+			continue
+		}
 		if policy.Actions.Has(admissionregistrationv1.Deny) {
 			for _, rule := range policy.Rules {
 				switch rule.Status() {
@@ -18,6 +18,7 @@ import (
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	runtimeutils "github.com/kyverno/kyverno/pkg/utils/runtime"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	"github.com/kyverno/kyverno/pkg/webhooks/recorder"
 	admissionv1 "k8s.io/api/admission/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -73,6 +74,19 @@ func NewServer(
 	vpolLogger := logger.WithName("vpol")
 	ivpolLogger := logger.WithName("ivpol")
 	mpolLogger := logger.WithName("mpol")
+	if debugModeOpts.AdmissionRecords != nil {
+		admissionRecorder := recorder.New(resourceLogger.WithName("recorder"), debugModeOpts.AdmissionRecords, debugModeOpts.AdmissionRecordsRedaction)
+		resourceHandlers.Mutation = withRecorder(resourceHandlers.Mutation, admissionRecorder, recorder.WebhookMutate)
+		resourceHandlers.Validation = withRecorder(resourceHandlers.Validation, admissionRecorder, recorder.WebhookValidate)
+		resourceHandlers.ValidatingPolicies = withRecorder(resourceHandlers.ValidatingPolicies, admissionRecorder, recorder.WebhookValidatingPolicies)
+		resourceHandlers.NamespacedValidatingPolicies = withRecorder(resourceHandlers.NamespacedValidatingPolicies, admissionRecorder, recorder.WebhookNamespacedValidatingPolicies)
+		resourceHandlers.ImageVerificationPolicies = withRecorder(resourceHandlers.ImageVerificationPolicies, admissionRecorder, recorder.WebhookImageValidatingPolicies)
+		resourceHandlers.ImageVerificationPoliciesMutation = withRecorder(resourceHandlers.ImageVerificationPoliciesMutation, admissionRecorder, recorder.WebhookImageValidatingPoliciesMutation)
+		resourceHandlers.MutatingPolicies = withRecorder(resourceHandlers.MutatingPolicies, admissionRecorder, recorder.WebhookMutatingPolicies)
+		resourceHandlers.NamespacedMutatingPolicies = withRecorder(resourceHandlers.NamespacedMutatingPolicies, admissionRecorder, recorder.WebhookNamespacedMutatingPolicies)
+		resourceHandlers.GeneratingPolicies = withRecorder(resourceHandlers.GeneratingPolicies, admissionRecorder, recorder.WebhookGeneratingPolicies)
+		resourceHandlers.NamespacedGeneratingPolicies = withRecorder(resourceHandlers.NamespacedGeneratingPolicies, admissionRecorder, recorder.WebhookNamespacedGeneratingPolicies)
+	}
 	mux.HandlerFunc(
 		"POST",
 		"/mpol/*policies",
@@ -393,6 +407,16 @@ func registerWebhookHandlersWithAll(
 	registerWebhookHandlers(mux, name, basePath, handler, builder)
 }
 
+// withRecorder records the admission requests denied by the inner handler, the request
+// is recorded as received by the handler, after it was enriched by the handler chain
+func withRecorder(inner Handler, recorder *recorder.Recorder, webhook string) Handler {
+	return HandlerFunc(func(ctx context.Context, logger logr.Logger, request handlers.AdmissionRequest, failurePolicy string, startTime time.Time) admissionv1.AdmissionResponse {
+		response := inner.Execute(ctx, logger, request, failurePolicy, startTime)
+		recorder.Record(ctx, webhook, failurePolicy, request, response)
+		return response
+	})
+}
+
 func handlerFunc(name string, handler Handler, failurePolicy string) handlers.AdmissionHandler {
 	return handlers.FromAdmissionFunc(
 		name,
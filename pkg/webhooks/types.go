@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	"github.com/kyverno/kyverno/pkg/webhooks/recorder"
 	admissionv1 "k8s.io/api/admission/v1"
 )
 
@@ -13,6 +14,10 @@ import (
 type DebugModeOptions struct {
 	// DumpPayload is used to activate/deactivate debug mode.
 	DumpPayload bool
+	// AdmissionRecords stores denied admission requests for replay, recording is disabled when nil.
+	AdmissionRecords recorder.Store
+	// AdmissionRecordsRedaction holds the kinds whose payloads are redacted before being recorded.
+	AdmissionRecordsRedaction recorder.Redaction
 }
 
 type Handler interface {
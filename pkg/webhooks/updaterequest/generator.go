@@ -11,6 +11,7 @@ import (
 	kyvernov2informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2"
 	kyvernov2listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	generatorutils "github.com/kyverno/kyverno/pkg/utils/generator"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -47,11 +48,11 @@ func (g *generator) Apply(ctx context.Context, ur kyvernov2.UpdateRequestSpec) e
 		return nil
 	}
 	logger.V(4).Info("apply Update Request", "request", ur)
-	go g.applyResource(context.TODO(), ur)
+	go g.applyResource(context.TODO(), ur, false)
 	return nil
 }
 
-func (g *generator) applyResource(ctx context.Context, urSpec kyvernov2.UpdateRequestSpec) {
+func (g *generator) applyResource(ctx context.Context, urSpec kyvernov2.UpdateRequestSpec, fallback bool) {
 	exbackoff := &backoff.ExponentialBackOff{
 		InitialInterval:     500 * time.Millisecond,
 		RandomizationFactor: 0.5,
@@ -63,6 +64,10 @@ func (g *generator) applyResource(ctx context.Context, urSpec kyvernov2.UpdateRe
 	exbackoff.Reset()
 	if err := backoff.Retry(func() error { return g.tryApplyResource(ctx, urSpec) }, exbackoff); err != nil {
 		logger.Error(err, "failed to update request CR")
+		return
+	}
+	if urMetrics := metrics.GetUpdateRequestMetrics(); urMetrics != nil {
+		urMetrics.RecordHandover(ctx, metrics.UpdateRequestModeCRD, fallback)
 	}
 }
 
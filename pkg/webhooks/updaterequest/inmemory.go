@@ -0,0 +1,49 @@
+package updaterequest
+
+import (
+	"context"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/background/inmemory"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov2informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	generatorutils "github.com/kyverno/kyverno/pkg/utils/generator"
+)
+
+// inMemoryGenerator hands the update requests over to the background controller in memory
+// and falls back to persisting them when the background controller cannot accept them
+type inMemoryGenerator struct {
+	client   inmemory.Client
+	fallback *generator
+}
+
+// NewInMemoryGenerator returns a generator sending the update requests to the background controller with client,
+// the update requests it does not accept are persisted as UpdateRequest resources
+func NewInMemoryGenerator(client inmemory.Client, kyvernoClient versioned.Interface, urInformer kyvernov2informers.UpdateRequestInformer, urGenerator generatorutils.UpdateRequestGenerator) Generator {
+	return &inMemoryGenerator{
+		client:   client,
+		fallback: NewGenerator(kyvernoClient, urInformer, urGenerator).(*generator),
+	}
+}
+
+// Apply sends the update request to the background controller
+func (g *inMemoryGenerator) Apply(ctx context.Context, ur kyvernov2.UpdateRequestSpec) error {
+	if ur.Type == kyvernov2.Generate && len(ur.RuleContext) == 0 {
+		return nil
+	}
+	logger.V(4).Info("hand over Update Request", "request", ur)
+	go g.send(context.TODO(), ur)
+	return nil
+}
+
+func (g *inMemoryGenerator) send(ctx context.Context, ur kyvernov2.UpdateRequestSpec) {
+	if err := g.client.Send(ctx, ur); err != nil {
+		logger.V(2).Info("failed to hand over update request in memory, falling back to UpdateRequest resource", "error", err.Error())
+		g.fallback.applyResource(ctx, ur, true)
+		return
+	}
+	if urMetrics := metrics.GetUpdateRequestMetrics(); urMetrics != nil {
+		urMetrics.RecordHandover(ctx, metrics.UpdateRequestModeMemory, false)
+	}
+}
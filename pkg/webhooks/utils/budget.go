@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/breaker"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+)
+
+var errLatencyBudgetExceeded = errors.New("latency budget exceeded")
+
+// EvaluateWithLatencyBudget runs the policy evaluation within the admission latency budget configured in the policy.
+// It returns as soon as the budget is exceeded, the context of the evaluation is cancelled and its results are
+// discarded even if it completes later. In this case every rule of the policy is reported as an error so that the
+// policy failure policy applies. When the policy is configured to degrade, it is skipped by subsequent requests
+// until the degradation period elapses.
+// The first returned boolean is false if the policy is degraded and was not evaluated, the second one is true
+// when the evaluation exceeded its budget, callers must then ignore anything else produced by evaluate.
+func EvaluateWithLatencyBudget(
+	ctx context.Context,
+	logger logr.Logger,
+	eventGen event.Interface,
+	operation string,
+	ruleType engineapi.RuleType,
+	policyContext engineapi.PolicyContext,
+	evaluate func(context.Context) engineapi.EngineResponse,
+) (engineapi.EngineResponse, bool, bool) {
+	policy := policyContext.Policy()
+	budget := policy.GetSpec().GetLatencyBudget()
+	if budget == nil || budget.Duration.Duration <= 0 {
+		return evaluate(ctx), true, false
+	}
+	key := cache.MetaObjectToName(policy).String()
+	policyBreaker := breaker.GetPolicyBreaker()
+	if policyBreaker != nil && policyBreaker.Degraded(key) {
+		logger.V(2).Info("skipping degraded policy", "policy", key)
+		if budgetMetrics := metrics.GetLatencyBudgetMetrics(); budgetMetrics != nil {
+			budgetMetrics.RecordDegradedSkip(ctx, engineapi.NewKyvernoPolicy(policy), operation)
+		}
+		return engineapi.EngineResponse{}, false, false
+	}
+	budgetCtx, cancel := context.WithTimeoutCause(ctx, budget.Duration.Duration, errLatencyBudgetExceeded)
+	defer cancel()
+	start := time.Now()
+	done := make(chan engineapi.EngineResponse, 1)
+	go func() {
+		done <- evaluate(budgetCtx)
+	}()
+	// an evaluation ignoring the cancellation keeps running in the background and its response is dropped
+	var aborted engineapi.EngineResponse
+	select {
+	case response := <-done:
+		if context.Cause(budgetCtx) != errLatencyBudgetExceeded && time.Since(start) <= budget.Duration.Duration {
+			if policyBreaker != nil {
+				policyBreaker.Reset(key)
+			}
+			return response, true, false
+		}
+		aborted = response
+	case <-budgetCtx.Done():
+		if context.Cause(budgetCtx) != errLatencyBudgetExceeded {
+			// the admission request itself was cancelled
+			return engineapi.NewEngineResponseFromPolicyContext(policyContext), true, false
+		}
+	}
+	var degradeFor time.Duration
+	if budget.DegradeFor != nil && policyBreaker != nil {
+		degradeFor = budget.DegradeFor.Duration
+		policyBreaker.Trip(key, degradeFor)
+	}
+	logger.V(2).Info("policy evaluation exceeded its latency budget", "policy", key, "budget", budget.Duration.Duration, "degradeFor", degradeFor)
+	response := latencyBudgetExceededResponse(policyContext, ruleType, aborted, budget.Duration.Duration)
+	if budgetMetrics := metrics.GetLatencyBudgetMetrics(); budgetMetrics != nil {
+		budgetMetrics.RecordExceeded(ctx, response.Policy(), operation, degradeFor > 0)
+	}
+	if eventGen != nil && response.Resource.GetName() != "" {
+		eventGen.Add(event.NewPolicyLatencyBudgetEvent(response, budget.Duration.Duration, degradeFor))
+	}
+	return response, true, true
+}
+
+// latencyBudgetExceededResponse discards the results of an aborted evaluation and reports its rules as errors
+func latencyBudgetExceededResponse(policyContext engineapi.PolicyContext, ruleType engineapi.RuleType, aborted engineapi.EngineResponse, budget time.Duration) engineapi.EngineResponse {
+	msg := fmt.Sprintf("policy evaluation exceeded its latency budget of %s", budget)
+	var rules []engineapi.RuleResponse
+	evaluated := sets.New[string]()
+	errored := false
+	for _, rule := range aborted.PolicyResponse.Rules {
+		evaluated.Insert(rule.Name())
+		// rules skipped before the budget was exceeded are kept as they are
+		if rule.Status() == engineapi.RuleStatusSkip {
+			rules = append(rules, rule)
+			continue
+		}
+		errored = true
+		rules = append(rules, *engineapi.RuleError(rule.Name(), rule.RuleType(), msg, nil, rule.Properties()))
+	}
+	if !errored {
+		// the evaluation was aborted before producing any result, report the remaining rules
+		for _, rule := range autogen.Default.ComputeRules(policyContext.Policy(), "") {
+			if hasRuleType(rule, ruleType) && !evaluated.Has(rule.Name) {
+				rules = append(rules, *engineapi.RuleError(rule.Name, ruleType, msg, nil, nil))
+			}
+		}
+	}
+	return engineapi.NewEngineResponseFromPolicyContext(policyContext).WithPolicyResponse(engineapi.PolicyResponse{Rules: rules})
+}
+
+func hasRuleType(rule kyvernov1.Rule, ruleType engineapi.RuleType) bool {
+	switch ruleType {
+	case engineapi.Mutation:
+		return rule.HasMutateStandard()
+	case engineapi.Validation:
+		return rule.HasValidate()
+	case engineapi.ImageVerify:
+		return rule.HasVerifyImages()
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package utils
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/breaker"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/policycontext"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/clock"
+)
+
+type recordingEventGenerator struct {
+	events []event.Info
+}
+
+func (r *recordingEventGenerator) Add(infos ...event.Info) {
+	r.events = append(r.events, infos...)
+}
+
+func newBudgetPolicyContext(t *testing.T, budget *kyvernov1.LatencyBudget) *policycontext.PolicyContext {
+	t.Helper()
+	cfg := config.NewDefaultConfiguration(false)
+	resource := unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"namespace": "default",
+				"name":      "mypod",
+			},
+		},
+	}
+	policyContext, err := policycontext.NewPolicyContext(jmespath.New(cfg), resource, kyvernov1.Create, nil, cfg)
+	require.NoError(t, err)
+	policy := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slow-policy",
+		},
+		Spec: kyvernov1.Spec{
+			WebhookConfiguration: &kyvernov1.WebhookConfiguration{
+				LatencyBudget: budget,
+			},
+			Rules: []kyvernov1.Rule{
+				{
+					Name: "validate-rule",
+					Validation: &kyvernov1.Validation{
+						Message: "ok",
+					},
+				},
+			},
+		},
+	}
+	return policyContext.WithPolicy(policy)
+}
+
+func slowEvaluation(policyContext engineapi.PolicyContext) func(context.Context) engineapi.EngineResponse {
+	return func(ctx context.Context) engineapi.EngineResponse {
+		<-ctx.Done()
+		return engineapi.NewEngineResponseFromPolicyContext(policyContext)
+	}
+}
+
+func fastEvaluation(policyContext engineapi.PolicyContext) func(context.Context) engineapi.EngineResponse {
+	return func(ctx context.Context) engineapi.EngineResponse {
+		return engineapi.NewEngineResponseFromPolicyContext(policyContext).WithPolicyResponse(engineapi.PolicyResponse{
+			Rules: []engineapi.RuleResponse{*engineapi.RulePass("validate-rule", engineapi.Validation, "ok", nil)},
+		})
+	}
+}
+
+func TestEvaluateWithLatencyBudget(t *testing.T) {
+	breaker.SetPolicyBreaker(breaker.NewPolicyBreaker(clock.RealClock{}))
+	t.Run("no budget", func(t *testing.T) {
+		policyContext := newBudgetPolicyContext(t, nil)
+		response, evaluated, _ := EvaluateWithLatencyBudget(context.TODO(), logr.Discard(), event.NewFake(), "CREATE", engineapi.Validation, policyContext, fastEvaluation(policyContext))
+		assert.True(t, evaluated)
+		assert.True(t, response.IsSuccessful())
+	})
+	t.Run("within budget", func(t *testing.T) {
+		policyContext := newBudgetPolicyContext(t, &kyvernov1.LatencyBudget{Duration: metav1.Duration{Duration: time.Minute}})
+		response, evaluated, exceeded := EvaluateWithLatencyBudget(context.TODO(), logr.Discard(), event.NewFake(), "CREATE", engineapi.Validation, policyContext, fastEvaluation(policyContext))
+		assert.True(t, evaluated)
+		assert.False(t, exceeded)
+		assert.True(t, response.IsSuccessful())
+	})
+	t.Run("budget exceeded", func(t *testing.T) {
+		policyContext := newBudgetPolicyContext(t, &kyvernov1.LatencyBudget{Duration: metav1.Duration{Duration: 10 * time.Millisecond}})
+		eventGen := &recordingEventGenerator{}
+		response, evaluated, exceeded := EvaluateWithLatencyBudget(context.TODO(), logr.Discard(), eventGen, "CREATE", engineapi.Validation, policyContext, slowEvaluation(policyContext))
+		assert.True(t, evaluated)
+		assert.True(t, exceeded)
+		assert.True(t, response.IsError())
+		require.Len(t, response.PolicyResponse.Rules, 1)
+		assert.Equal(t, "validate-rule", response.PolicyResponse.Rules[0].Name())
+		require.Len(t, eventGen.events, 1)
+		assert.Equal(t, event.PolicyError, eventGen.events[0].Reason)
+		// the policy doesn't degrade, it is evaluated again
+		_, evaluated, _ = EvaluateWithLatencyBudget(context.TODO(), logr.Discard(), event.NewFake(), "CREATE", engineapi.Validation, policyContext, fastEvaluation(policyContext))
+		assert.True(t, evaluated)
+	})
+	t.Run("evaluation ignoring the cancellation", func(t *testing.T) {
+		policyContext := newBudgetPolicyContext(t, &kyvernov1.LatencyBudget{Duration: metav1.Duration{Duration: 10 * time.Millisecond}})
+		release := make(chan struct{})
+		defer close(release)
+		start := time.Now()
+		response, evaluated, exceeded := EvaluateWithLatencyBudget(context.TODO(), logr.Discard(), event.NewFake(), "CREATE", engineapi.Validation, policyContext, func(context.Context) engineapi.EngineResponse {
+			<-release
+			return fastEvaluation(policyContext)(context.TODO())
+		})
+		assert.Less(t, time.Since(start), time.Second)
+		assert.True(t, evaluated)
+		assert.True(t, exceeded)
+		assert.True(t, response.IsError())
+		require.Len(t, response.PolicyResponse.Rules, 1)
+		assert.Equal(t, "validate-rule", response.PolicyResponse.Rules[0].Name())
+	})
+	t.Run("budget exceeded with degradation", func(t *testing.T) {
+		policyContext := newBudgetPolicyContext(t, &kyvernov1.LatencyBudget{
+			Duration:   metav1.Duration{Duration: 10 * time.Millisecond},
+			DegradeFor: &metav1.Duration{Duration: time.Minute},
+		})
+		response, evaluated, _ := EvaluateWithLatencyBudget(context.TODO(), logr.Discard(), event.NewFake(), "CREATE", engineapi.Validation, policyContext, slowEvaluation(policyContext))
+		assert.True(t, evaluated)
+		assert.True(t, response.IsError())
+		// the policy is degraded and skipped
+		_, evaluated, _ = EvaluateWithLatencyBudget(context.TODO(), logr.Discard(), event.NewFake(), "CREATE", engineapi.Validation, policyContext, fastEvaluation(policyContext))
+		assert.False(t, evaluated)
+		// the policy recovers
+		breaker.GetPolicyBreaker().Reset("slow-policy")
+		response, evaluated, _ = EvaluateWithLatencyBudget(context.TODO(), logr.Discard(), event.NewFake(), "CREATE", engineapi.Validation, policyContext, fastEvaluation(policyContext))
+		assert.True(t, evaluated)
+		assert.True(t, response.IsSuccessful())
+	})
+}
@@ -43,3 +43,25 @@ func GenerateEvents(engineResponses []engineapi.EngineResponse, blocked bool) []
 	}
 	return events
 }
+
+// GenerateShadowEvents generates event info for the engine responses of policies evaluated in shadow mode,
+// failures are reported as would-be denials and never as blocked requests
+func GenerateShadowEvents(engineResponses []engineapi.EngineResponse) []event.Info {
+	var events []event.Info
+	for _, er := range engineResponses {
+		if er.IsEmpty() || er.Resource.GetName() == "" {
+			continue
+		}
+		for _, ruleResp := range er.PolicyResponse.Rules {
+			if ruleResp.Status() == engineapi.RuleStatusFail || ruleResp.Status() == engineapi.RuleStatusError {
+				e := event.NewPolicyFailEvent(event.AdmissionController, event.PolicyShadowViolation, er, ruleResp, false)
+				e.Action = event.ResourceWouldBeBlocked
+				events = append(events, e)
+				e = event.NewResourceViolationEvent(event.AdmissionController, event.PolicyShadowViolation, er, ruleResp)
+				e.Action = event.ResourceWouldBeBlocked
+				events = append(events, e)
+			}
+		}
+	}
+	return events
+}
@@ -245,3 +245,63 @@ func TestGenerateEvents(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateShadowEvents(t *testing.T) {
+	policy := engineapi.NewKyvernoPolicy(&kyvernov1.ClusterPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "test-policy",
+		},
+	})
+	resource := unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"namespace": "default",
+				"name":      "mypod",
+			},
+		},
+	}
+	responses := []engineapi.EngineResponse{
+		engineapi.NewEngineResponse(resource, policy, nil).
+			WithPolicyResponse(engineapi.PolicyResponse{
+				Rules: []engineapi.RuleResponse{
+					*engineapi.RuleFail("require-labels", engineapi.Validation, "label team is required", nil),
+					*engineapi.RulePass("allow", engineapi.Validation, "allowed", nil),
+				},
+			}),
+	}
+
+	events := GenerateShadowEvents(MarkShadowResponses(responses))
+	require.Len(t, events, 2)
+	for _, e := range events {
+		assert.Equal(t, event.PolicyShadowViolation, e.Reason)
+		assert.Equal(t, event.ResourceWouldBeBlocked, e.Action)
+		assert.NotContains(t, e.Message, "(blocked)")
+	}
+}
+
+func TestMarkShadowResponses(t *testing.T) {
+	policy := engineapi.NewKyvernoPolicy(&kyvernov1.ClusterPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "test-policy",
+		},
+	})
+	properties := map[string]string{"foo": "bar"}
+	responses := []engineapi.EngineResponse{
+		engineapi.NewEngineResponse(unstructured.Unstructured{}, policy, nil).
+			WithPolicyResponse(engineapi.PolicyResponse{
+				Rules: []engineapi.RuleResponse{
+					*engineapi.RuleFail("require-labels", engineapi.Validation, "label team is required", properties),
+				},
+			}),
+	}
+
+	marked := MarkShadowResponses(responses)
+	require.Len(t, marked, 1)
+	rule := marked[0].PolicyResponse.Rules[0]
+	assert.Equal(t, "true", rule.Properties()[ShadowProperty])
+	assert.Equal(t, "bar", rule.Properties()["foo"])
+	assert.NotContains(t, properties, ShadowProperty, "original properties must not be modified")
+	assert.Equal(t, []string{"test-policy/require-labels: label team is required"}, GetShadowDenials(marked))
+}
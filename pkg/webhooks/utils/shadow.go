@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"fmt"
+	"maps"
+
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+)
+
+// ShadowProperty is the rule property set on results of rules evaluated in shadow mode,
+// it is propagated to report results so that would-be denials can be told apart from audit results
+const ShadowProperty = "shadow"
+
+// MarkShadowResponses flags every rule response as a shadow evaluation result
+func MarkShadowResponses(engineResponses []engineapi.EngineResponse) []engineapi.EngineResponse {
+	responses := make([]engineapi.EngineResponse, 0, len(engineResponses))
+	for _, er := range engineResponses {
+		rules := make([]engineapi.RuleResponse, 0, len(er.PolicyResponse.Rules))
+		for _, rule := range er.PolicyResponse.Rules {
+			properties := maps.Clone(rule.Properties())
+			if properties == nil {
+				properties = map[string]string{}
+			}
+			properties[ShadowProperty] = "true"
+			rules = append(rules, *rule.WithProperties(properties))
+		}
+		er.PolicyResponse.Rules = rules
+		responses = append(responses, er)
+	}
+	return responses
+}
+
+// GetShadowDenials returns the messages of the requests that would have been denied by shadow policies
+func GetShadowDenials(engineResponses []engineapi.EngineResponse) []string {
+	var denials []string
+	for _, er := range engineResponses {
+		for _, rule := range er.PolicyResponse.Rules {
+			if rule.Status() == engineapi.RuleStatusFail || rule.Status() == engineapi.RuleStatusError {
+				denials = append(denials, fmt.Sprintf("%s/%s: %s", er.Policy().GetName(), rule.Name(), rule.Message()))
+			}
+		}
+	}
+	return denials
+}